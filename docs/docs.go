@@ -15,6 +15,69 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/api/v1/audit": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns recorded create/update/delete/restore changes, newest first, with actor, request ID and a per-field before/after diff",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Audit"
+                ],
+                "summary": "List audit log entries",
+                "parameters": [
+                    {
+                        "enum": [
+                            "customers",
+                            "employees",
+                            "shippers",
+                            "products",
+                            "categories",
+                            "suppliers",
+                            "orders"
+                        ],
+                        "type": "string",
+                        "description": "Resource name",
+                        "name": "entity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resource ID; requires entity",
+                        "name": "id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of entries (default 100, max 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.AuditEntry"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/categories": {
             "get": {
                 "security": [
@@ -30,6 +93,26 @@ const docTemplate = `{
                     "Categories"
                 ],
                 "summary": "Get all categories",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Also return soft-deleted rows",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Related resources to embed: products",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sparse fieldset, e.g. category_id,category_name",
+                        "name": "fields[category]",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -43,7 +126,7 @@ const docTemplate = `{
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
@@ -74,6 +157,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.Category"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client-generated key; retries with the same key replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -83,10 +172,22 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.SuccessResponse"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
@@ -114,6 +215,24 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also return soft-deleted rows",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Related resources to embed: products",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sparse fieldset, e.g. category_id,category_name",
+                        "name": "fields[category]",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -121,12 +240,18 @@ const docTemplate = `{
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/models.Category"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Resource version for If-Match / If-None-Match"
+                            }
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
@@ -156,6 +281,12 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous GET",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
                     {
                         "description": "Category to update",
                         "name": "category",
@@ -173,10 +304,28 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.SuccessResponse"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
@@ -187,7 +336,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Deletes a category by ID",
+                "description": "Soft-deletes a category by ID; it stays referenced by history and can be restored",
                 "produces": [
                     "application/json"
                 ],
@@ -202,6 +351,24 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous GET",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also delete products in the category",
+                        "name": "cascade",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Move products in the category to this category instead",
+                        "name": "reassign_to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -211,127 +378,214 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.SuccessResponse"
                         }
                     },
+                    "409": {
+                        "description": "Still referenced; blockers lists the dependents",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/customers": {
-            "get": {
+            },
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns a list of all customers",
+                "description": "Applies a JSON Merge Patch (RFC 7396) or JSON Patch (RFC 6902) and updates only the supplied fields",
+                "consumes": [
+                    "application/merge-patch+json",
+                    "application/json-patch+json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Customers"
+                    "Categories"
+                ],
+                "summary": "Partially update a category",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous GET",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Merge patch object or JSON Patch operations",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
                 ],
-                "summary": "Get all customers",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Customer"
-                            }
+                            "$ref": "#/definitions/models.Category"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/api/v1/categories/{id}/history": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates a new customer",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns the audit log entries of one resource, newest first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Customers"
+                    "Audit"
                 ],
-                "summary": "Create a new customer",
+                "summary": "Change history of a resource",
                 "parameters": [
                     {
-                        "description": "Customer to create",
-                        "name": "customer",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Customer"
-                        }
+                        "type": "string",
+                        "description": "Resource ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of entries (default 100, max 1000)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Customer"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.AuditEntry"
+                            }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/v1/customers/{id}": {
+        "/api/v1/categories/{id}/picture": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns a single customer by ID",
+                "description": "Returns the picture bytes with the detected content type (JPEG, PNG, GIF, BMP or WebP). The OLE header of the original Northwind category pictures is stripped. With ?size= returns a thumbnail whose longest side is at most that many pixels (JPEG for JPEG sources, PNG otherwise).",
                 "produces": [
-                    "application/json"
+                    "image/jpeg",
+                    "image/png",
+                    "image/gif",
+                    "image/bmp",
+                    "image/webp"
                 ],
                 "tags": [
-                    "Customers"
+                    "Categories"
                 ],
-                "summary": "Get customer by ID",
+                "summary": "Get a category's picture",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Customer ID",
+                        "type": "integer",
+                        "description": "Category ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Thumbnail size in pixels (16-512)",
+                        "name": "size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Customer"
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Category not found or has no picture",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
@@ -342,46 +596,68 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates an existing customer by ID",
+                "description": "Replaces the picture with a multipart upload (field \"file\"). Accepts JPEG, PNG, GIF, BMP and WebP up to 5 MB and 4096x4096 pixels; the content type is detected from the bytes.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Customers"
+                    "Categories"
                 ],
-                "summary": "Update an existing customer",
+                "summary": "Upload a category's picture",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Customer ID",
+                        "type": "integer",
+                        "description": "Category ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Customer to update",
-                        "name": "customer",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Customer"
-                        }
+                        "type": "string",
+                        "description": "ETag from a previous GET of the image",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Image file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Customer"
+                            "$ref": "#/definitions/models.ImageInfo"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
@@ -392,284 +668,419 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Deletes a customer by ID",
+                "description": "Removes the picture; the category itself is kept",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Customers"
+                    "Categories"
                 ],
-                "summary": "Delete a customer",
+                "summary": "Delete a category's picture",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Customer ID",
+                        "type": "integer",
+                        "description": "Category ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous GET of the image",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Customer"
+                            "$ref": "#/definitions/models.SuccessResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Category not found or has no picture",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/v1/employees": {
-            "get": {
+        "/api/v1/categories/{id}/restore": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns a list of all employees",
+                "description": "Restores a soft-deleted category",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Employees"
+                    "Categories"
+                ],
+                "summary": "Restore a deleted category",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get all employees",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Employee"
-                            }
+                            "$ref": "#/definitions/models.Category"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/api/v1/customers": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates a new employee",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns a list of all customers",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Employees"
+                    "Customers"
                 ],
-                "summary": "Create a new employee",
+                "summary": "Get all customers",
                 "parameters": [
                     {
-                        "description": "Employee to create",
-                        "name": "employee",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Employee"
-                        }
+                        "type": "boolean",
+                        "description": "Also return soft-deleted rows",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Full-text search; returns up to 100 best matches, most relevant first",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Related resources to embed: orders",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sparse fieldset, e.g. customer_id,company_name",
+                        "name": "fields[customer]",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Employee"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Customer"
+                            }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/employees/{id}": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns a single employee by ID",
+                "description": "Creates a new customer. How customer_id is assigned depends on CUSTOMER_ID_STRATEGY: classic derives a 5-letter code from company_name, uuid generates a UUID (customer_id in the body is ignored for both), client requires a unique 5-character uppercase customer_id.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Employees"
+                    "Customers"
                 ],
-                "summary": "Get employee by ID",
+                "summary": "Create a new customer",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Employee ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Customer to create",
+                        "name": "customer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Customer"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client-generated key; retries with the same key replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.Employee"
+                            "$ref": "#/definitions/models.Customer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/v1/customers/bulk": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates an existing employee",
+                "description": "Runs create, update, patch (merge patch) and delete operations from a JSON array or NDJSON stream. mode=atomic (default) applies all or nothing; mode=best_effort applies each operation on its own and answers 207 when some fail.",
                 "consumes": [
-                    "application/json"
+                    "application/json",
+                    "application/x-ndjson"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Employees"
+                    "Customers"
                 ],
-                "summary": "Update an employee",
+                "summary": "Bulk create/update/delete customers",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Employee ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "enum": [
+                            "atomic",
+                            "best_effort"
+                        ],
+                        "type": "string",
+                        "description": "atomic or best_effort",
+                        "name": "mode",
+                        "in": "query"
                     },
                     {
-                        "description": "Employee to update",
-                        "name": "employee",
+                        "description": "Operations; data holds a models.Customer",
+                        "name": "operations",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Employee"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.BulkOperation"
+                            }
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client-generated key; retries with the same key replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Employee"
+                            "$ref": "#/definitions/models.BulkResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "$ref": "#/definitions/models.BulkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BulkProblem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.BulkProblem"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/v1/customers/duplicates": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Deletes an employee by ID",
+                "description": "Returns pairs of live customers that are probably the same company, highest score first. Company names are compared without accents, punctuation and legal forms such as GmbH or Inc.; phone numbers by their digits (with or without country code); addresses after expanding abbreviations such as \"Str.\" and \"Ave.\", together with postal code and city. \"matches\" holds the similarity of each signal; signals missing on either side are left out of the score.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Employees"
+                    "Customers"
                 ],
-                "summary": "Delete an employee",
+                "summary": "Find duplicate customers",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Employee ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "number",
+                        "description": "Minimum score between 0 and 1 (default 0.8)",
+                        "name": "min_score",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.SuccessResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.CustomerDuplicate"
+                            }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/v1/orders": {
+        "/api/v1/customers/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns a list of all orders",
+                "description": "Returns a single customer by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Customers"
+                ],
+                "summary": "Get customer by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Customer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also return soft-deleted rows",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Related resources to embed: orders",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sparse fieldset, e.g. customer_id,company_name",
+                        "name": "fields[customer]",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get all orders",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Order"
+                            "$ref": "#/definitions/models.Customer"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Resource version for If-Match / If-None-Match"
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
             },
-            "post": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates a new order",
+                "description": "Updates an existing customer by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -677,154 +1088,161 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Customers"
                 ],
-                "summary": "Create a new order",
+                "summary": "Update an existing customer",
                 "parameters": [
                     {
-                        "description": "Order to create",
-                        "name": "order",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Order"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/models.SuccessResponse"
-                        }
+                        "type": "string",
+                        "description": "Customer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/v1/orders/paginated": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Returns a paginated list of orders",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Orders"
-                ],
-                "summary": "Get paginated orders",
-                "parameters": [
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
+                        "type": "string",
+                        "description": "ETag from a previous GET",
+                        "name": "If-Match",
+                        "in": "header"
                     },
                     {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Page size",
-                        "name": "page_size",
-                        "in": "query"
+                        "description": "Customer to update",
+                        "name": "customer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Customer"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Paginated-models_Order"
+                            "$ref": "#/definitions/models.Customer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/orders/{id}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns a single order by ID",
+                "description": "Soft-deletes a customer by ID; it stays referenced by history and can be restored",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Customers"
                 ],
-                "summary": "Get order by ID",
+                "summary": "Delete a customer",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Order ID",
+                        "type": "string",
+                        "description": "Customer ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous GET",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Order"
+                            "$ref": "#/definitions/models.Customer"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
             },
-            "put": {
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates an existing order",
+                "description": "Applies a JSON Merge Patch (RFC 7396) or JSON Patch (RFC 6902) and updates only the supplied fields",
                 "consumes": [
-                    "application/json"
+                    "application/merge-patch+json",
+                    "application/json-patch+json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Customers"
                 ],
-                "summary": "Update an order",
+                "summary": "Partially update a customer",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Order ID",
+                        "type": "string",
+                        "description": "Customer ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Order to update",
-                        "name": "order",
+                        "type": "string",
+                        "description": "ETag from a previous GET",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Merge patch object or JSON Patch operations",
+                        "name": "patch",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Order"
+                            "type": "object"
                         }
                     }
                 ],
@@ -832,75 +1250,118 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.SuccessResponse"
+                            "$ref": "#/definitions/models.Customer"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/v1/customers/{id}/data-export": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Deletes an existing order",
+                "description": "Returns everything stored about a customer, also when deleted: the customer record, their orders with detail lines and ship addresses, and the audit history of the customer and those orders. ?format=zip returns a ZIP with one JSON file per part.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/zip"
                 ],
                 "tags": [
-                    "Orders"
+                    "Customers"
                 ],
-                "summary": "Delete an order",
+                "summary": "Export a customer's personal data",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Order ID",
+                        "type": "string",
+                        "description": "Customer ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "zip"
+                        ],
+                        "type": "string",
+                        "description": "json (default) or zip",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.SuccessResponse"
+                            "$ref": "#/definitions/models.CustomerDataExport"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/v1/orders/{id}/details": {
-            "get": {
+        "/api/v1/customers/{id}/erase": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns order details for a specific order by Order ID",
+                "description": "Pseudonymizes a customer, also when deleted: contact_name and the ship_name of their orders become a random \"anon-…\" pseudonym, and contact title, address, postal code, phone, fax and the orders' ship address and postal code are cleared. Company name, city, region, country and all order amounts are kept for reports. Old values are replaced with \"[erased]\" in the audit log, event log and webhook deliveries. Cannot be undone. Requires the admin role.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Customers"
                 ],
-                "summary": "Get order details by Order ID",
+                "summary": "Erase a customer's personal data",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Order ID",
+                        "type": "string",
+                        "description": "Customer ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -910,61 +1371,81 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.OrderDetail"
-                            }
+                            "$ref": "#/definitions/models.ErasureResult"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/v1/products": {
+        "/api/v1/customers/{id}/history": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns a list of all products",
+                "description": "Returns the audit log entries of one resource, newest first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Audit"
+                ],
+                "summary": "Change history of a resource",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Resource ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of entries (default 100, max 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get all products",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Product"
+                                "$ref": "#/definitions/models.AuditEntry"
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/v1/customers/{id}/merge": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates a new product",
+                "description": "Merges the duplicate customers into the customer in the path in one transaction: their orders are moved to it, blank fields are optionally filled from the duplicates, and the duplicates are soft-deleted. Every step is written to the audit log; the duplicates get a \"merged\" entry with merged_into.",
                 "consumes": [
                     "application/json"
                 ],
@@ -972,55 +1453,86 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Customers"
                 ],
-                "summary": "Create a new product",
+                "summary": "Merge duplicate customers",
                 "parameters": [
                     {
-                        "description": "Product to create",
-                        "name": "product",
+                        "type": "string",
+                        "description": "ID of the surviving customer",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the surviving customer from a previous GET",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Customers to merge",
+                        "name": "merge",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Product"
+                            "$ref": "#/definitions/models.CustomerMergeRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Product"
+                            "$ref": "#/definitions/models.CustomerMergeResult"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/v1/products/{id}": {
-            "get": {
+        "/api/v1/customers/{id}/restore": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns a single product by ID",
+                "description": "Restores a soft-deleted customer",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Customers"
                 ],
-                "summary": "Get product by ID",
+                "summary": "Restore a deleted customer",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Product ID",
+                        "type": "string",
+                        "description": "Customer ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1030,681 +1542,1018 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Product"
+                            "$ref": "#/definitions/models.Customer"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/v1/employees": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates an existing product",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns a list of all employees",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Employees"
                 ],
-                "summary": "Update a product",
+                "summary": "Get all employees",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "boolean",
+                        "description": "Also return soft-deleted rows",
+                        "name": "include_deleted",
+                        "in": "query"
                     },
                     {
-                        "description": "Product to update",
-                        "name": "product",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Product"
-                        }
+                        "type": "string",
+                        "description": "Related resources to embed: manager,territories",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sparse fieldset, e.g. employee_id,last_name",
+                        "name": "fields[employee]",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Product"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Employee"
+                            }
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
             },
-            "delete": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Deletes a product by ID",
+                "description": "Creates a new employee",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Employees"
                 ],
-                "summary": "Delete a product",
+                "summary": "Create a new employee",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Employee to create",
+                        "name": "employee",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Employee"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client-generated key; retries with the same key replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.SuccessResponse"
+                            "$ref": "#/definitions/models.Employee"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/v1/products/{id}/category": {
+        "/api/v1/employees/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns the category for a given product ID",
+                "description": "Returns a single employee by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Employees"
                 ],
-                "summary": "Get category for a product",
+                "summary": "Get employee by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Product ID",
+                        "description": "Employee ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also return soft-deleted rows",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Related resources to embed: manager,territories",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sparse fieldset, e.g. employee_id,last_name",
+                        "name": "fields[employee]",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ProductCategory"
+                            "$ref": "#/definitions/models.Employee"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Resource version for If-Match / If-None-Match"
+                            }
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/products/{id}/supplier": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns the supplier for a given product ID",
+                "description": "Updates an existing employee",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Employees"
                 ],
-                "summary": "Get supplier for a product",
+                "summary": "Update an employee",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Product ID",
+                        "description": "Employee ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous GET",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Employee to update",
+                        "name": "employee",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Employee"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ProductSupplier"
+                            "$ref": "#/definitions/models.Employee"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/regions": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns a list of all regions",
+                "description": "Soft-deletes an employee by ID; it stays referenced by history and can be restored",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Regions"
+                    "Employees"
+                ],
+                "summary": "Delete an employee",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Employee ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous GET",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
                 ],
-                "summary": "Get all regions",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Region"
-                            }
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/regions/{id}": {
-            "get": {
+            },
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns a single region by ID",
+                "description": "Applies a JSON Merge Patch (RFC 7396) or JSON Patch (RFC 6902) and updates only the supplied fields",
+                "consumes": [
+                    "application/merge-patch+json",
+                    "application/json-patch+json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Regions"
+                    "Employees"
                 ],
-                "summary": "Get region by ID",
+                "summary": "Partially update a employee",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Region ID",
+                        "description": "Employee ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous GET",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Merge patch object or JSON Patch operations",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Region"
+                            "$ref": "#/definitions/models.Employee"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "412": {
+                        "description": "Precondition Failed",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/v1/reports/average-order-value": {
+        "/api/v1/employees/{id}/data-export": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns the average value of orders",
+                "description": "Returns everything stored about an employee, also when deleted: the employee record, their territories and the audit history. ?format=zip returns a ZIP with one JSON file per part.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "application/zip"
                 ],
                 "tags": [
-                    "Reports"
+                    "Employees"
+                ],
+                "summary": "Export an employee's personal data",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Employee ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "zip"
+                        ],
+                        "type": "string",
+                        "description": "json (default) or zip",
+                        "name": "format",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Average order value",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.AverageOrderValue"
+                            "$ref": "#/definitions/models.EmployeeDataExport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/v1/reports/customer-growth": {
-            "get": {
+        "/api/v1/employees/{id}/erase": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns customer growth over time",
+                "description": "Pseudonymizes an employee, also when deleted: last_name becomes a random \"anon-…\" pseudonym, first_name becomes \"anon\", and birth date, address, postal code, phones, photo and notes are cleared. Title, hire date, city, region, country, manager and orders are kept. Old values are replaced with \"[erased]\" in the audit log, event log and webhook deliveries. Cannot be undone. Requires the admin role.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Reports"
+                    "Employees"
                 ],
-                "summary": "Customer growth",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.CustomerGrowth"
-                            }
+                "summary": "Erase an employee's personal data",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Employee ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErasureResult"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/v1/reports/employee-performance": {
+        "/api/v1/employees/{id}/history": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns performance metrics for employees",
+                "description": "Returns the audit log entries of one resource, newest first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Reports"
+                    "Audit"
+                ],
+                "summary": "Change history of a resource",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Resource ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of entries (default 100, max 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Employee performance",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.EmployeePerformance"
+                                "$ref": "#/definitions/models.AuditEntry"
                             }
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/reports/inventory-status": {
+        "/api/v1/employees/{id}/photo": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns current inventory levels and status",
+                "description": "Returns the photo bytes with the detected content type (JPEG, PNG, GIF, BMP or WebP). The OLE header of the original Northwind employee photos is stripped. With ?size= returns a thumbnail whose longest side is at most that many pixels (JPEG for JPEG sources, PNG otherwise).",
                 "produces": [
-                    "application/json"
+                    "image/jpeg",
+                    "image/png",
+                    "image/gif",
+                    "image/bmp",
+                    "image/webp"
                 ],
                 "tags": [
-                    "Reports"
+                    "Employees"
+                ],
+                "summary": "Get an employee's photo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Employee ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Thumbnail size in pixels (16-512)",
+                        "name": "size",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Inventory status",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.InventoryStatus"
-                            }
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Employee not found or has no photo",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/reports/monthly-sales": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns sales totals grouped by month",
+                "description": "Replaces the photo with a multipart upload (field \"file\"). Accepts JPEG, PNG, GIF, BMP and WebP up to 5 MB and 4096x4096 pixels; the content type is detected from the bytes.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Reports"
+                    "Employees"
+                ],
+                "summary": "Upload an employee's photo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Employee ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous GET of the image",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Image file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
                 ],
-                "summary": "Monthly sales",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.MonthlySales"
-                            }
+                            "$ref": "#/definitions/models.ImageInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/reports/order-status-summary": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns a breakdown of orders by status",
+                "description": "Removes the photo; the employee itself is kept",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Reports"
+                    "Employees"
+                ],
+                "summary": "Delete an employee's photo",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Employee ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous GET of the image",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
                 ],
-                "summary": "Order status summary",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.OrderStatusSummary"
-                            }
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Employee not found or has no photo",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/v1/reports/product-profitability": {
-            "get": {
+        "/api/v1/employees/{id}/restore": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns profitability metrics for products",
+                "description": "Restores a soft-deleted employee",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Reports"
+                    "Employees"
+                ],
+                "summary": "Restore a deleted employee",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Employee ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Product profitability",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.ProductProfitability"
-                            }
+                            "$ref": "#/definitions/models.Employee"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/v1/reports/region-sales": {
+        "/api/v1/events/stream": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns sales grouped by region",
+                "description": "Pushes create/update/delete/restore events as they are committed through the API. Each message carries the event ID as \"id\", the event type (e.g. \"order.updated\") as \"event\" and the event as JSON \"data\". Reconnect with the Last-Event-ID header (or ?last_event_id) to resume from the persisted event log; without it the stream starts at the next change.",
                 "produces": [
-                    "application/json"
+                    "text/event-stream"
                 ],
                 "tags": [
-                    "Reports"
+                    "Events"
+                ],
+                "summary": "Stream data changes (Server-Sent Events)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma-separated resources to receive, e.g. orders,products (default all)",
+                        "name": "resources",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resume after this event ID",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resume after this event ID (for clients that cannot set headers)",
+                        "name": "last_event_id",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Region sales",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.RegionSales"
-                            }
+                            "$ref": "#/definitions/models.ChangeEvent"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/v1/reports/sales-by-category": {
+        "/api/v1/events/ws": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns sales report grouped by category",
-                "produces": [
-                    "application/json"
-                ],
+                "description": "WebSocket variant of /events/stream. After the upgrade the server sends one JSON text message per event and a ping frame as heartbeat; messages from the client are ignored. Resume with ?last_event_id using the event_id of the last message received.",
                 "tags": [
-                    "Reports"
+                    "Events"
+                ],
+                "summary": "Stream data changes (WebSocket)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma-separated resources to receive, e.g. orders,products (default all)",
+                        "name": "resources",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resume after this event ID",
+                        "name": "last_event_id",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Sales by category",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "101": {
+                        "description": "Switching Protocols",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.SalesByCategory"
-                            }
+                            "$ref": "#/definitions/models.ChangeEvent"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "426": {
+                        "description": "Upgrade Required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/v1/reports/sales-by-employee": {
+        "/api/v1/graphql": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns sales report grouped by employee",
+                "description": "Read-only GraphQL endpoint for customers, orders (with details), products, categories, suppliers, employees, shippers and territories. List fields accept filter arguments plus limit (default 50, max 500) and offset, and return {items, totalCount, hasNextPage}. Relationships are loaded in batches per request. Query errors, including queries nested deeper than 8 fields, are reported with status 200 in the \"errors\" array. Requests larger than 64 KB get 413. GET takes query, variables (JSON) and operationName as query parameters.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Reports"
+                    "GraphQL"
                 ],
-                "summary": "Sales by employee",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                "summary": "GraphQL query",
+                "parameters": [
+                    {
+                        "description": "GraphQL request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.SalesByEmployee"
-                            }
+                            "$ref": "#/definitions/models.GraphQLRequest"
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/reports/sales-summary": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Returns an overall sales summary",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Reports"
                 ],
-                "summary": "Sales summary",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.SalesSummary"
+                            "$ref": "#/definitions/models.GraphQLResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/api/v1/reports/top-customers": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Returns customers with the highest total purchases",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Reports"
-                ],
-                "summary": "Top customers by total purchases",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.TopCustomer"
-                            }
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/reports/top-products": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns products with the highest sales",
+                "description": "Read-only GraphQL endpoint for customers, orders (with details), products, categories, suppliers, employees, shippers and territories. List fields accept filter arguments plus limit (default 50, max 500) and offset, and return {items, totalCount, hasNextPage}. Relationships are loaded in batches per request. Query errors, including queries nested deeper than 8 fields, are reported with status 200 in the \"errors\" array. Requests larger than 64 KB get 413. GET takes query, variables (JSON) and operationName as query parameters.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Reports"
+                    "GraphQL"
                 ],
-                "summary": "Top selling products",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                "summary": "GraphQL query",
+                "parameters": [
+                    {
+                        "description": "GraphQL request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.TopProduct"
-                            }
+                            "$ref": "#/definitions/models.GraphQLRequest"
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/reports/top-suppliers": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Returns suppliers ranked by performance or volume",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Reports"
                 ],
-                "summary": "Top suppliers",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.TopSupplier"
-                            }
+                            "$ref": "#/definitions/models.GraphQLResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/v1/shippers": {
+        "/api/v1/imports": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns a list of all shippers",
+                "description": "Returns import jobs, newest first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Shippers"
+                    "Imports"
                 ],
-                "summary": "Get all shippers",
+                "summary": "List import jobs",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Shipper"
+                                "$ref": "#/definitions/models.ImportJob"
                             }
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
@@ -1715,63 +2564,97 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates a new shipper",
+                "description": "Stores the CSV and starts a dry run in the background that validates every row. Poll the job until its status is \"validated\", then commit it. Without a mapping, CSV columns are matched to fields by name (e.g. \"Product Name\", \"product_name\" or \"ProductName\").",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Shippers"
+                    "Imports"
                 ],
-                "summary": "Create a new shipper",
+                "summary": "Upload a CSV import",
                 "parameters": [
                     {
-                        "description": "Shipper to create",
-                        "name": "shipper",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Shipper"
-                        }
+                        "enum": [
+                            "products",
+                            "customers",
+                            "suppliers"
+                        ],
+                        "type": "string",
+                        "description": "Resource to import",
+                        "name": "entity",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "CSV file with a header row",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "JSON object mapping CSV columns to fields, e.g. {\\",
+                        "name": "mapping",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client-generated key; retries with the same key replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "$ref": "#/definitions/models.Shipper"
+                            "$ref": "#/definitions/models.ImportJob"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the import job"
+                            }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/v1/shippers/{id}": {
+        "/api/v1/imports/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns a single shipper by ID",
+                "description": "Returns the status of an import job with its per-row validation report",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Shippers"
+                    "Imports"
                 ],
-                "summary": "Get shipper by ID",
+                "summary": "Get an import job",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Shipper ID",
+                        "type": "string",
+                        "description": "Import job ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1781,250 +2664,263 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Shipper"
+                            "$ref": "#/definitions/models.ImportJob"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/v1/imports/{id}/commit": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates an existing shipper by ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Imports the rows of a validated job in one transaction in the background. Jobs with invalid rows are rejected unless skip_invalid=true, which imports only the valid rows.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Shippers"
+                    "Imports"
                 ],
-                "summary": "Update an existing shipper",
+                "summary": "Commit an import job",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Shipper ID",
+                        "type": "string",
+                        "description": "Import job ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Shipper data to update",
-                        "name": "shipper",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Shipper"
-                        }
+                        "type": "boolean",
+                        "description": "Import valid rows and skip invalid ones",
+                        "name": "skip_invalid",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/models.Shipper"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.ImportJob"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/v1/odata/": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Deletes a shipper by ID",
-                "tags": [
-                    "Shippers"
+                "description": "Lists the OData v4 entity sets. Point Power BI or Excel (\"From OData feed\") at this URL.",
+                "produces": [
+                    "application/json"
                 ],
-                "summary": "Delete a shipper",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Shipper ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                "tags": [
+                    "OData"
                 ],
+                "summary": "OData service document",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.SuccessResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
                 }
             }
         },
-        "/api/v1/suppliers": {
+        "/api/v1/odata/$metadata": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns a list of all suppliers",
+                "description": "CSDL (XML) description of the Northwind entity types, keys and navigation properties.",
                 "produces": [
-                    "application/json"
+                    "text/xml"
                 ],
                 "tags": [
-                    "Suppliers"
+                    "OData"
                 ],
-                "summary": "Get all suppliers",
+                "summary": "OData metadata",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Supplier"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "type": "string"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/api/v1/odata/{set}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates a new supplier",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Supports $filter (eq ne gt ge lt le in, and/or/not, add sub mul div mod, contains/startswith/endswith/tolower/toupper/length/trim/substring/indexof/concat, year/month/day/hour/minute/second, round/floor/ceiling, navigation paths such as Customer/Country, and any/all/$count on collections), $select, $orderby, $top, $skip, $count=true and $expand with nested options. Use {set}('ALFKI'), {set}(10248) or OrderDetails(OrderID=10248,ProductID=11) for a single entity and {set}/$count for a plain-text count. Pages hold at most 1000 rows; follow @odata.nextLink for the rest. Errors use the OData error format.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Suppliers"
+                    "OData"
                 ],
-                "summary": "Create a new supplier",
+                "summary": "Query an OData entity set",
                 "parameters": [
                     {
-                        "description": "Supplier to create",
-                        "name": "supplier",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Supplier"
-                        }
-                    }
+                        "type": "string",
+                        "description": "Entity set, optionally with a key, e.g. Customers or Orders(10248)",
+                        "name": "set",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter expression, e.g. Country eq 'Germany'",
+                        "name": "$filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated properties",
+                        "name": "$select",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "e.g. OrderDate desc",
+                        "name": "$orderby",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of rows",
+                        "name": "$top",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Rows to skip",
+                        "name": "$skip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include @odata.count",
+                        "name": "$count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "e.g. Orders($select=OrderID;$top=5)",
+                        "name": "$expand",
+                        "in": "query"
+                    }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.SuccessResponse"
+                            "$ref": "#/definitions/models.ODataCollection"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.ODataError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ODataError"
                         }
                     }
                 }
             }
         },
-        "/api/v1/suppliers/{id}": {
+        "/api/v1/orders": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns a single supplier by ID",
+                "description": "Returns a list of all orders",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Suppliers"
+                    "Orders"
                 ],
-                "summary": "Get supplier by ID",
+                "summary": "Get all orders",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Supplier ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "Related resources to embed: customer,employee,shipper,details.product",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sparse fieldset, e.g. order_id,order_date",
+                        "name": "fields[order]",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Supplier"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Order"
+                            }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
             },
-            "put": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates an existing supplier",
+                "description": "Creates a new order",
                 "consumes": [
                     "application/json"
                 ],
@@ -2032,193 +2928,3581 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Suppliers"
+                    "Orders"
                 ],
-                "summary": "Update a supplier",
+                "summary": "Create a new order",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Supplier ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Order to create",
+                        "name": "order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Order"
+                        }
                     },
                     {
-                        "description": "Supplier to update",
-                        "name": "supplier",
+                        "type": "string",
+                        "description": "Client-generated key; retries with the same key replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/orders/bulk": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Runs create, update, patch (merge patch) and delete operations from a JSON array or NDJSON stream. mode=atomic (default) applies all or nothing; mode=best_effort applies each operation on its own and answers 207 when some fail.",
+                "consumes": [
+                    "application/json",
+                    "application/x-ndjson"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Bulk create/update/delete orders",
+                "parameters": [
+                    {
+                        "enum": [
+                            "atomic",
+                            "best_effort"
+                        ],
+                        "type": "string",
+                        "description": "atomic or best_effort",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Operations; data holds a models.Order",
+                        "name": "operations",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Supplier"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.BulkOperation"
+                            }
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client-generated key; retries with the same key replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.SuccessResponse"
+                            "$ref": "#/definitions/models.BulkResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "$ref": "#/definitions/models.BulkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BulkProblem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.BulkProblem"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/v1/orders/paginated": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Deletes a supplier by ID",
+                "description": "Returns a paginated list of orders",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Suppliers"
+                    "Orders"
                 ],
-                "summary": "Delete a supplier",
+                "summary": "Get paginated orders",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Supplier ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Related resources to embed: customer,employee,shipper,details.product",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sparse fieldset, e.g. order_id,order_date",
+                        "name": "fields[order]",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.SuccessResponse"
+                            "$ref": "#/definitions/models.Paginated-models_Order"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/v1/territories/{id}/employees": {
+        "/api/v1/orders/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns a list of employees associated with a specific territory ID",
+                "description": "Returns a single order by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Regions"
+                    "Orders"
                 ],
-                "summary": "Get employees by territory ID",
+                "summary": "Get order by ID",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Territory ID",
+                        "type": "integer",
+                        "description": "Order ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Related resources to embed: customer,employee,shipper,details.product",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sparse fieldset, e.g. order_id,order_date",
+                        "name": "fields[order]",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Employee"
+                            "$ref": "#/definitions/models.Order"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Resource version for If-Match / If-None-Match"
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.Problem"
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "models.AverageOrderValue": {
-            "type": "object",
-            "properties": {
-                "average": {
-                    "type": "number"
-                }
-            }
-        },
-        "models.Category": {
-            "type": "object",
-            "properties": {
-                "category_id": {
-                    "description": "INTEGER, Auto Increment",
-                    "type": "integer"
-                },
-                "category_name": {
-                    "description": "TEXT, nullable",
-                    "type": "string"
-                },
-                "description": {
-                    "description": "TEXT, nullable",
-                    "type": "string"
-                },
-                "picture": {
-                    "description": "BLOB, nullable",
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates an existing order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Update an order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous GET",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Order to update",
+                        "name": "order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Order"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes an existing order",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Delete an order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous GET",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also delete the order's detail lines",
+                        "name": "cascade",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Move the order's detail lines to this order instead",
+                        "name": "reassign_to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Still referenced; blockers lists the dependents",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Applies a JSON Merge Patch (RFC 7396) or JSON Patch (RFC 6902) and updates only the supplied fields",
+                "consumes": [
+                    "application/merge-patch+json",
+                    "application/json-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Partially update a order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous GET",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Merge patch object or JSON Patch operations",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/orders/{id}/details": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns order details for a specific order by Order ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get order details by Order ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Related resources to embed: product",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sparse fieldset, e.g. product_id,quantity",
+                        "name": "fields[order_detail]",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.OrderDetail"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/orders/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the audit log entries of one resource, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Audit"
+                ],
+                "summary": "Change history of a resource",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Resource ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of entries (default 100, max 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.AuditEntry"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a list of all products",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get all products",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Also return soft-deleted rows",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Full-text search; returns up to 100 best matches, most relevant first",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Related resources to embed: category,supplier",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sparse fieldset, e.g. product_id,product_name",
+                        "name": "fields[product]",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Product"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a new product",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Create a new product",
+                "parameters": [
+                    {
+                        "description": "Product to create",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client-generated key; retries with the same key replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/bulk": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Runs create, update, patch (merge patch) and delete operations from a JSON array or NDJSON stream. mode=atomic (default) applies all or nothing; mode=best_effort applies each operation on its own and answers 207 when some fail.",
+                "consumes": [
+                    "application/json",
+                    "application/x-ndjson"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Bulk create/update/delete products",
+                "parameters": [
+                    {
+                        "enum": [
+                            "atomic",
+                            "best_effort"
+                        ],
+                        "type": "string",
+                        "description": "atomic or best_effort",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Operations; data holds a models.Product",
+                        "name": "operations",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.BulkOperation"
+                            }
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client-generated key; retries with the same key replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.BulkResponse"
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "$ref": "#/definitions/models.BulkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.BulkProblem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.BulkProblem"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a single product by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get product by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also return soft-deleted rows",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Related resources to embed: category,supplier",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sparse fieldset, e.g. product_id,product_name",
+                        "name": "fields[product]",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Resource version for If-Match / If-None-Match"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates an existing product",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Update a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous GET",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Product to update",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Soft-deletes a product by ID; it stays referenced by history and can be restored",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Delete a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous GET",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Applies a JSON Merge Patch (RFC 7396) or JSON Patch (RFC 6902) and updates only the supplied fields",
+                "consumes": [
+                    "application/merge-patch+json",
+                    "application/json-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Partially update a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous GET",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Merge patch object or JSON Patch operations",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/{id}/category": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the category for a given product ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get category for a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductCategory"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the audit log entries of one resource, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Audit"
+                ],
+                "summary": "Change history of a resource",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Resource ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of entries (default 100, max 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.AuditEntry"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Restores a soft-deleted product",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Restore a deleted product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/products/{id}/supplier": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the supplier for a given product ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get supplier for a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductSupplier"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/regions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a list of all regions",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Regions"
+                ],
+                "summary": "Get all regions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Region"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/regions/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a single region by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Regions"
+                ],
+                "summary": "Get region by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Region ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Region"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Resource version for If-Match / If-None-Match"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reports/average-order-value": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the average value of orders",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Average order value",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.AverageOrderValue"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reports/customer-growth": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns customer growth over time",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Customer growth",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.CustomerGrowth"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reports/employee-performance": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns performance metrics for employees",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Employee performance",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.EmployeePerformance"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reports/inventory-status": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns current inventory levels and status",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Inventory status",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.InventoryStatus"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reports/monthly-sales": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns sales totals grouped by month",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Monthly sales",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.MonthlySales"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reports/order-status-summary": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a breakdown of orders by status",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Order status summary",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.OrderStatusSummary"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reports/product-profitability": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns profitability metrics for products",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Product profitability",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ProductProfitability"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reports/region-sales": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns sales grouped by region",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Region sales",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.RegionSales"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reports/sales-by-category": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns sales report grouped by category",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Sales by category",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.SalesByCategory"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reports/sales-by-employee": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns sales report grouped by employee",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Sales by employee",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.SalesByEmployee"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reports/sales-summary": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns an overall sales summary",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Sales summary",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SalesSummary"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reports/top-customers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns customers with the highest total purchases",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Top customers by total purchases",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TopCustomer"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reports/top-products": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns products with the highest sales",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Top selling products",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TopProduct"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reports/top-suppliers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns suppliers ranked by performance or volume",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Top suppliers",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TopSupplier"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/search": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Full-text search ranked by relevance; matches in names weigh more than matches in contact and address fields. Every word matches as a prefix (\"cha\" finds \"Chai\"). When nothing matches, words with a one or two letter typo are tried as well and \"fuzzy\" is true. Matched words in title and snippet are wrapped in \u003cmark\u003e\u003c/mark\u003e. Soft-deleted rows are not searchable.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Search customers, products and suppliers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search text",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "customers",
+                            "products",
+                            "suppliers"
+                        ],
+                        "type": "string",
+                        "description": "Comma-separated types to search (default all)",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of hits (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SearchResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/shippers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a list of all shippers",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shippers"
+                ],
+                "summary": "Get all shippers",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Also return soft-deleted rows",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sparse fieldset, e.g. shipper_id,company_name",
+                        "name": "fields[shipper]",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Shipper"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a new shipper",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shippers"
+                ],
+                "summary": "Create a new shipper",
+                "parameters": [
+                    {
+                        "description": "Shipper to create",
+                        "name": "shipper",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Shipper"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client-generated key; retries with the same key replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Shipper"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/shippers/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a single shipper by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shippers"
+                ],
+                "summary": "Get shipper by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Shipper ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also return soft-deleted rows",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sparse fieldset, e.g. shipper_id,company_name",
+                        "name": "fields[shipper]",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Shipper"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Resource version for If-Match / If-None-Match"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates an existing shipper by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shippers"
+                ],
+                "summary": "Update an existing shipper",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Shipper ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous GET",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Shipper data to update",
+                        "name": "shipper",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Shipper"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Shipper"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Soft-deletes a shipper by ID; it stays referenced by history and can be restored",
+                "tags": [
+                    "Shippers"
+                ],
+                "summary": "Delete a shipper",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Shipper ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous GET",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Applies a JSON Merge Patch (RFC 7396) or JSON Patch (RFC 6902) and updates only the supplied fields",
+                "consumes": [
+                    "application/merge-patch+json",
+                    "application/json-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shippers"
+                ],
+                "summary": "Partially update a shipper",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Shipper ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous GET",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Merge patch object or JSON Patch operations",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Shipper"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/shippers/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the audit log entries of one resource, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Audit"
+                ],
+                "summary": "Change history of a resource",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Resource ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of entries (default 100, max 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.AuditEntry"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/shippers/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Restores a soft-deleted shipper",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shippers"
+                ],
+                "summary": "Restore a deleted shipper",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Shipper ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Shipper"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/suppliers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a list of all suppliers",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suppliers"
+                ],
+                "summary": "Get all suppliers",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Also return soft-deleted rows",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Full-text search; returns up to 100 best matches, most relevant first",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Related resources to embed: products",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sparse fieldset, e.g. supplier_id,company_name",
+                        "name": "fields[supplier]",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Supplier"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a new supplier",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suppliers"
+                ],
+                "summary": "Create a new supplier",
+                "parameters": [
+                    {
+                        "description": "Supplier to create",
+                        "name": "supplier",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Supplier"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client-generated key; retries with the same key replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/suppliers/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a single supplier by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suppliers"
+                ],
+                "summary": "Get supplier by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Supplier ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also return soft-deleted rows",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Related resources to embed: products",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sparse fieldset, e.g. supplier_id,company_name",
+                        "name": "fields[supplier]",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Supplier"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Resource version for If-Match / If-None-Match"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates an existing supplier",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suppliers"
+                ],
+                "summary": "Update a supplier",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Supplier ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous GET",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Supplier to update",
+                        "name": "supplier",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Supplier"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Soft-deletes a supplier by ID; it stays referenced by history and can be restored",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suppliers"
+                ],
+                "summary": "Delete a supplier",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Supplier ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous GET",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also delete products from the supplier",
+                        "name": "cascade",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Move products from the supplier to this supplier instead",
+                        "name": "reassign_to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Still referenced; blockers lists the dependents",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Applies a JSON Merge Patch (RFC 7396) or JSON Patch (RFC 6902) and updates only the supplied fields",
+                "consumes": [
+                    "application/merge-patch+json",
+                    "application/json-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suppliers"
+                ],
+                "summary": "Partially update a supplier",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Supplier ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous GET",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Merge patch object or JSON Patch operations",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Supplier"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/suppliers/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the audit log entries of one resource, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Audit"
+                ],
+                "summary": "Change history of a resource",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Resource ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of entries (default 100, max 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.AuditEntry"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/suppliers/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Restores a soft-deleted supplier",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suppliers"
+                ],
+                "summary": "Restore a deleted supplier",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Supplier ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Supplier"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/territories/{id}/employees": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a list of employees associated with a specific territory ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Regions"
+                ],
+                "summary": "Get employees by territory ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Territory ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Employee"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/webhook-deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the delivery log, newest first. Use status=dead for the dead-letter list: deliveries that failed every retry.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "List webhook deliveries",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Only deliveries of this webhook",
+                        "name": "webhook_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "delivered",
+                            "dead"
+                        ],
+                        "type": "string",
+                        "description": "Delivery status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of deliveries (default 100, max 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.WebhookDelivery"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/webhook-deliveries/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns one delivery with its payload and the log of every attempt",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get a webhook delivery",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Delivery ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookDelivery"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/webhook-deliveries/{id}/retry": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moves a delivery out of the dead-letter list and schedules it again with a fresh set of attempts",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Retry a dead webhook delivery",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Delivery ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookDelivery"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns all webhook subscriptions; secrets are never included",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "List webhooks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Webhook"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Subscribes a URL to event types such as \"order.shipped\", \"product.low_stock\", \"customer.merged\", \"customer.erased\" or \"\u003cresource\u003e.\u003ccreated|updated|deleted|restored\u003e\" (\"*\" for all). Each delivery is a JSON POST signed with X-Webhook-Signature: sha256=HMAC-SHA256(secret, \"\u003cX-Webhook-Timestamp\u003e.\u003cbody\u003e\") in hex. The secret is returned only in this response; omit it to have one generated.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Create a webhook",
+                "parameters": [
+                    {
+                        "description": "Webhook to create",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookInput"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client-generated key; retries with the same key replay the first response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Webhook"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the webhook"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/webhooks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns one webhook subscription without its secret",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get a webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Webhook"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Resource version for If-Match / If-None-Match"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces the URL, event types and active flag. An empty secret keeps the current one. Pending deliveries of an inactive webhook wait until it is activated again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Update a webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous GET",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Webhook data",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a webhook subscription together with its pending deliveries and delivery log",
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Delete a webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous GET",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/models.Problem"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "models.AuditChange": {
+            "type": "object",
+            "properties": {
+                "after": {},
+                "before": {}
+            }
+        },
+        "models.AuditEntry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "updated"
+                },
+                "actor": {
+                    "type": "string",
+                    "example": "nancy"
+                },
+                "audit_id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "changes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/models.AuditChange"
+                    }
+                },
+                "entity": {
+                    "type": "string",
+                    "example": "orders"
+                },
+                "entity_id": {
+                    "type": "string",
+                    "example": "10248"
+                },
+                "occurred_at": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "3f1c2a9e-7b7d-4d8e-9a43-1c0e4b1d2f60"
+                }
+            }
+        },
+        "models.AverageOrderValue": {
+            "type": "object",
+            "properties": {
+                "average": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.Blocker": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "entity": {
+                    "type": "string",
+                    "example": "products"
+                },
+                "ids": {
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.BulkItemResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/models.Problem"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "1"
+                },
+                "index": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "op": {
+                    "type": "string",
+                    "example": "update"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "models.BulkOperation": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Data adalah resource lengkap (create/update) atau merge patch (patch).",
+                    "type": "object"
+                },
+                "id": {
+                    "description": "ID wajib untuk update, patch dan delete; angka atau string sesuai resource.",
+                    "type": "string",
+                    "example": "1"
+                },
+                "if_match": {
+                    "description": "IfMatch opsional, sama seperti header If-Match pada request tunggal.",
+                    "type": "string"
+                },
+                "op": {
+                    "type": "string",
+                    "enum": [
+                        "create",
+                        "update",
+                        "patch",
+                        "delete"
+                    ],
+                    "example": "update"
+                }
+            }
+        },
+        "models.BulkProblem": {
+            "type": "object",
+            "properties": {
+                "blockers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Blocker"
+                    }
+                },
+                "detail": {
+                    "type": "string",
+                    "example": "request has invalid fields"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FieldError"
+                    }
+                },
+                "instance": {
+                    "type": "string",
+                    "example": "3f1c2a9e-7b7d-4d8e-9a43-1c0e4b1d2f60"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BulkItemResult"
+                    }
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Validation failed"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "/problems/validation-error"
+                }
+            }
+        },
+        "models.BulkResponse": {
+            "type": "object",
+            "properties": {
+                "failed": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "mode": {
+                    "type": "string",
+                    "example": "atomic"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BulkItemResult"
                     }
+                },
+                "succeeded": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "models.Category": {
+            "type": "object",
+            "required": [
+                "category_name"
+            ],
+            "properties": {
+                "category_id": {
+                    "description": "INTEGER, Auto Increment",
+                    "type": "integer"
+                },
+                "category_name": {
+                    "description": "TEXT, nullable",
+                    "type": "string",
+                    "maxLength": 15
+                },
+                "deleted_at": {
+                    "description": "TEXT, diisi saat soft delete",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "TEXT, nullable",
+                    "type": "string"
+                }
+            }
+        },
+        "models.ChangeEvent": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "updated"
+                },
+                "after": {
+                    "type": "object"
+                },
+                "before": {
+                    "type": "object"
+                },
+                "entity": {
+                    "type": "string",
+                    "example": "orders"
+                },
+                "entity_id": {
+                    "type": "string",
+                    "example": "10248"
+                },
+                "event_id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "occurred_at": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "order.updated"
                 }
             }
         },
         "models.Customer": {
             "type": "object",
+            "required": [
+                "company_name"
+            ],
             "properties": {
                 "address": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 60
                 },
                 "city": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 15
                 },
                 "company_name": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 40
                 },
                 "contact_name": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 30
                 },
                 "contact_title": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 30
                 },
                 "country": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 15
                 },
                 "customer_id": {
+                    "description": "5 huruf (ALFKI) atau UUID, lihat services.CustomerIDStrategy",
+                    "type": "string",
+                    "maxLength": 36
+                },
+                "deleted_at": {
                     "type": "string"
                 },
                 "fax": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 24
                 },
                 "phone": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 24
                 },
                 "postal_code": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 10
                 },
                 "region": {
+                    "type": "string",
+                    "maxLength": 15
+                }
+            }
+        },
+        "models.CustomerDataExport": {
+            "type": "object",
+            "properties": {
+                "customer": {
+                    "$ref": "#/definitions/models.Customer"
+                },
+                "exported_at": {
                     "type": "string"
+                },
+                "history": {
+                    "description": "History adalah audit log customer dan order-ordernya, terbaru lebih dulu.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AuditEntry"
+                    }
+                },
+                "orders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrderWithDetails"
+                    }
+                }
+            }
+        },
+        "models.CustomerDuplicate": {
+            "type": "object",
+            "properties": {
+                "customers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Customer"
+                    }
+                },
+                "matches": {
+                    "description": "Matches berisi kemiripan per sinyal: company_name, phone, address.\nSinyal yang kosong di salah satu customer tidak ikut dinilai.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float64"
+                    }
+                },
+                "score": {
+                    "description": "Score 0..1, rata-rata berbobot dari Matches.",
+                    "type": "number",
+                    "example": 0.92
                 }
             }
         },
@@ -2238,65 +6522,152 @@ const docTemplate = `{
                 }
             }
         },
+        "models.CustomerMergeRequest": {
+            "type": "object",
+            "required": [
+                "duplicate_ids"
+            ],
+            "properties": {
+                "duplicate_ids": {
+                    "description": "DuplicateIDs digabung ke customer di path lalu di-soft delete.",
+                    "type": "array",
+                    "maxItems": 20,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "ALFKJ"
+                    ]
+                },
+                "fill_blanks": {
+                    "description": "FillBlanks mengisi field kosong customer tujuan dari duplikatnya.",
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.CustomerMergeResult": {
+            "type": "object",
+            "properties": {
+                "customer": {
+                    "$ref": "#/definitions/models.Customer"
+                },
+                "merged_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "ALFKJ"
+                    ]
+                },
+                "moved_order_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        10643
+                    ]
+                }
+            }
+        },
         "models.Employee": {
             "type": "object",
+            "required": [
+                "first_name",
+                "last_name"
+            ],
             "properties": {
                 "address": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 60
                 },
                 "birth_date": {
                     "type": "string"
                 },
                 "city": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 15
                 },
                 "country": {
+                    "type": "string",
+                    "maxLength": 15
+                },
+                "deleted_at": {
                     "type": "string"
                 },
                 "employee_id": {
                     "type": "integer"
                 },
                 "extension": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 4
                 },
                 "first_name": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 10
                 },
                 "hire_date": {
                     "type": "string"
                 },
                 "home_phone": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 24
                 },
                 "last_name": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 20
                 },
                 "notes": {
                     "type": "string"
                 },
-                "photo": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
-                },
                 "photo_path": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 255
                 },
                 "postal_code": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 10
                 },
                 "region": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 15
                 },
                 "reports_to": {
                     "type": "integer"
                 },
                 "title": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 30
                 },
                 "title_of_courtesy": {
+                    "type": "string",
+                    "maxLength": 25
+                }
+            }
+        },
+        "models.EmployeeDataExport": {
+            "type": "object",
+            "properties": {
+                "employee": {
+                    "$ref": "#/definitions/models.Employee"
+                },
+                "exported_at": {
                     "type": "string"
+                },
+                "history": {
+                    "description": "History adalah audit log employee, terbaru lebih dulu.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AuditEntry"
+                    }
+                },
+                "territories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Territory"
+                    }
                 }
             }
         },
@@ -2306,28 +6677,216 @@ const docTemplate = `{
                 "avg_order_value": {
                     "type": "number"
                 },
-                "employee_id": {
-                    "type": "integer"
+                "employee_id": {
+                    "type": "integer"
+                },
+                "employee_name": {
+                    "type": "string"
+                },
+                "orders_handled": {
+                    "type": "integer"
+                },
+                "total_sales": {
+                    "type": "number"
+                },
+                "unique_customers": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ErasureResult": {
+            "type": "object",
+            "properties": {
+                "entity": {
+                    "type": "string",
+                    "example": "customers"
+                },
+                "entity_id": {
+                    "type": "string",
+                    "example": "ALFKI"
+                },
+                "fields": {
+                    "description": "Fields adalah field JSON yang dikosongkan atau dipseudonimkan.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "contact_name",
+                        "phone"
+                    ]
+                },
+                "order_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        10643
+                    ]
+                },
+                "pseudonym": {
+                    "description": "Pseudonym menggantikan nama subject, mis. di contact_name.",
+                    "type": "string",
+                    "example": "anon-3f9a1c2e"
+                }
+            }
+        },
+        "models.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "company_name"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "is required"
+                }
+            }
+        },
+        "models.GraphQLError": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "limit: must be between 1 and 500"
+                },
+                "path": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.GraphQLRequest": {
+            "type": "object",
+            "required": [
+                "query"
+            ],
+            "properties": {
+                "operationName": {
+                    "type": "string"
+                },
+                "query": {
+                    "type": "string",
+                    "example": "{ customers(limit: 2) { items { customerId companyName } totalCount } }"
+                },
+                "variables": {
+                    "type": "object"
+                }
+            }
+        },
+        "models.GraphQLResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GraphQLError"
+                    }
+                }
+            }
+        },
+        "models.ImageInfo": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string",
+                    "example": "image/jpeg"
+                },
+                "height": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "size": {
+                    "description": "byte",
+                    "type": "integer",
+                    "example": 21626
+                },
+                "width": {
+                    "type": "integer",
+                    "example": 160
+                }
+            }
+        },
+        "models.ImportJob": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "entity": {
+                    "type": "string",
+                    "example": "products"
                 },
-                "employee_name": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ImportRowError"
+                    }
+                },
+                "file_name": {
+                    "type": "string",
+                    "example": "price-list.csv"
+                },
+                "imported_rows": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "invalid_rows": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "job_id": {
+                    "type": "string",
+                    "example": "5b0f1c7e-3d0a-4f43-9b61-0f5f8d1f2a10"
+                },
+                "mapping": {
+                    "description": "Mapping memetakan nama kolom CSV ke field JSON resource.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "message": {
                     "type": "string"
                 },
-                "orders_handled": {
-                    "type": "integer"
+                "status": {
+                    "type": "string",
+                    "example": "validated"
                 },
-                "total_sales": {
-                    "type": "number"
+                "total_rows": {
+                    "type": "integer",
+                    "example": 120
                 },
-                "unique_customers": {
-                    "type": "integer"
+                "updated_at": {
+                    "type": "string"
+                },
+                "valid_rows": {
+                    "type": "integer",
+                    "example": 118
                 }
             }
         },
-        "models.ErrorResponse": {
+        "models.ImportRowError": {
             "type": "object",
             "properties": {
-                "error": {
-                    "type": "string"
+                "field": {
+                    "type": "string",
+                    "example": "unit_price"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "must be a number"
+                },
+                "row": {
+                    "type": "integer",
+                    "example": 7
                 }
             }
         },
@@ -2370,12 +6929,60 @@ const docTemplate = `{
                 }
             }
         },
+        "models.ODataCollection": {
+            "type": "object",
+            "properties": {
+                "@odata.context": {
+                    "type": "string",
+                    "example": "http://localhost:8080/api/v1/odata/$metadata#Customers"
+                },
+                "@odata.count": {
+                    "type": "integer"
+                },
+                "@odata.nextLink": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "additionalProperties": {}
+                    }
+                }
+            }
+        },
+        "models.ODataError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/models.ODataErrorDetail"
+                }
+            }
+        },
+        "models.ODataErrorDetail": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "BadRequest"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "unknown property \"Foo\" on Customer"
+                },
+                "target": {
+                    "type": "string",
+                    "example": "$filter"
+                }
+            }
+        },
         "models.Order": {
             "type": "object",
             "properties": {
                 "customer_id": {
                     "description": "TEXT, nullable",
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 36
                 },
                 "employee_id": {
                     "description": "INTEGER, nullable",
@@ -2383,7 +6990,8 @@ const docTemplate = `{
                 },
                 "freight": {
                     "description": "NUMERIC, nullable (default 0)",
-                    "type": "number"
+                    "type": "number",
+                    "minimum": 0
                 },
                 "order_date": {
                     "description": "DATETIME, nullable (use *time.Time if you want time type)",
@@ -2399,27 +7007,33 @@ const docTemplate = `{
                 },
                 "ship_address": {
                     "description": "TEXT, nullable",
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 60
                 },
                 "ship_city": {
                     "description": "TEXT, nullable",
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 15
                 },
                 "ship_country": {
                     "description": "TEXT, nullable",
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 15
                 },
                 "ship_name": {
                     "description": "TEXT, nullable",
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 40
                 },
                 "ship_postal_code": {
                     "description": "TEXT, nullable",
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 10
                 },
                 "ship_region": {
                     "description": "TEXT, nullable",
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 15
                 },
                 "ship_via": {
                     "description": "INTEGER, nullable",
@@ -2436,7 +7050,9 @@ const docTemplate = `{
             "properties": {
                 "discount": {
                     "description": "REAL, Not Null (default 0)",
-                    "type": "number"
+                    "type": "number",
+                    "maximum": 1,
+                    "minimum": 0
                 },
                 "order_id": {
                     "description": "INTEGER, Not Null",
@@ -2452,7 +7068,8 @@ const docTemplate = `{
                 },
                 "unit_price": {
                     "description": "NUMERIC, Not Null (default 0)",
-                    "type": "number"
+                    "type": "number",
+                    "minimum": 0
                 }
             }
         },
@@ -2468,6 +7085,81 @@ const docTemplate = `{
                 }
             }
         },
+        "models.OrderWithDetails": {
+            "type": "object",
+            "properties": {
+                "customer_id": {
+                    "description": "TEXT, nullable",
+                    "type": "string",
+                    "maxLength": 36
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrderDetail"
+                    }
+                },
+                "employee_id": {
+                    "description": "INTEGER, nullable",
+                    "type": "integer"
+                },
+                "freight": {
+                    "description": "NUMERIC, nullable (default 0)",
+                    "type": "number",
+                    "minimum": 0
+                },
+                "order_date": {
+                    "description": "DATETIME, nullable (use *time.Time if you want time type)",
+                    "type": "string"
+                },
+                "order_id": {
+                    "description": "INTEGER, PK, Auto Increment, Not Null",
+                    "type": "integer"
+                },
+                "required_date": {
+                    "description": "DATETIME, nullable",
+                    "type": "string"
+                },
+                "ship_address": {
+                    "description": "TEXT, nullable",
+                    "type": "string",
+                    "maxLength": 60
+                },
+                "ship_city": {
+                    "description": "TEXT, nullable",
+                    "type": "string",
+                    "maxLength": 15
+                },
+                "ship_country": {
+                    "description": "TEXT, nullable",
+                    "type": "string",
+                    "maxLength": 15
+                },
+                "ship_name": {
+                    "description": "TEXT, nullable",
+                    "type": "string",
+                    "maxLength": 40
+                },
+                "ship_postal_code": {
+                    "description": "TEXT, nullable",
+                    "type": "string",
+                    "maxLength": 10
+                },
+                "ship_region": {
+                    "description": "TEXT, nullable",
+                    "type": "string",
+                    "maxLength": 15
+                },
+                "ship_via": {
+                    "description": "INTEGER, nullable",
+                    "type": "integer"
+                },
+                "shipped_date": {
+                    "description": "DATETIME, nullable",
+                    "type": "string"
+                }
+            }
+        },
         "models.Paginated-models_Order": {
             "type": "object",
             "properties": {
@@ -2497,38 +7189,90 @@ const docTemplate = `{
                 }
             }
         },
+        "models.Problem": {
+            "type": "object",
+            "properties": {
+                "blockers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Blocker"
+                    }
+                },
+                "detail": {
+                    "type": "string",
+                    "example": "request has invalid fields"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FieldError"
+                    }
+                },
+                "instance": {
+                    "type": "string",
+                    "example": "3f1c2a9e-7b7d-4d8e-9a43-1c0e4b1d2f60"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Validation failed"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "/problems/validation-error"
+                }
+            }
+        },
         "models.Product": {
             "type": "object",
+            "required": [
+                "product_name"
+            ],
             "properties": {
                 "category_id": {
                     "type": "integer"
                 },
-                "discontinued": {
+                "deleted_at": {
                     "type": "string"
                 },
+                "discontinued": {
+                    "type": "boolean"
+                },
                 "product_id": {
                     "type": "integer"
                 },
                 "product_name": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 40
                 },
                 "quantity_per_unit": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 20
                 },
                 "reorder_level": {
-                    "type": "integer"
+                    "type": "integer",
+                    "maximum": 32767,
+                    "minimum": 0
                 },
                 "supplier_id": {
                     "type": "integer"
                 },
                 "unit_price": {
-                    "type": "number"
+                    "type": "number",
+                    "minimum": 0
                 },
                 "units_in_stock": {
-                    "type": "integer"
+                    "type": "integer",
+                    "maximum": 32767,
+                    "minimum": 0
                 },
                 "units_on_order": {
-                    "type": "integer"
+                    "type": "integer",
+                    "maximum": 32767,
+                    "minimum": 0
                 }
             }
         },
@@ -2655,14 +7399,67 @@ const docTemplate = `{
                 }
             }
         },
+        "models.SearchHit": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "1"
+                },
+                "score": {
+                    "description": "makin besar makin relevan",
+                    "type": "number",
+                    "example": 4.2
+                },
+                "snippet": {
+                    "type": "string",
+                    "example": "10 boxes x 20 bags"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "\u003cmark\u003eChai\u003c/mark\u003e"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "products"
+                }
+            }
+        },
+        "models.SearchResult": {
+            "type": "object",
+            "properties": {
+                "fuzzy": {
+                    "description": "Fuzzy true kalau tidak ada hasil untuk ejaan persis sehingga kata\nyang mirip (typo) ikut dicari.",
+                    "type": "boolean"
+                },
+                "hits": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SearchHit"
+                    }
+                },
+                "query": {
+                    "type": "string",
+                    "example": "chai"
+                }
+            }
+        },
         "models.Shipper": {
             "type": "object",
+            "required": [
+                "company_name"
+            ],
             "properties": {
                 "company_name": {
+                    "type": "string",
+                    "maxLength": 40
+                },
+                "deleted_at": {
                     "type": "string"
                 },
                 "phone": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 24
                 },
                 "shipper_id": {
                     "type": "integer"
@@ -2679,45 +7476,75 @@ const docTemplate = `{
         },
         "models.Supplier": {
             "type": "object",
+            "required": [
+                "company_name"
+            ],
             "properties": {
                 "address": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 60
                 },
                 "city": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 15
                 },
                 "company_name": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 40
                 },
                 "contact_name": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 30
                 },
                 "contact_title": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 30
                 },
                 "country": {
+                    "type": "string",
+                    "maxLength": 15
+                },
+                "deleted_at": {
                     "type": "string"
                 },
                 "fax": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 24
                 },
                 "homepage": {
                     "type": "string"
                 },
                 "phone": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 24
                 },
                 "postal_code": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 10
                 },
                 "region": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 15
                 },
                 "supplier_id": {
                     "type": "integer"
                 }
             }
         },
+        "models.Territory": {
+            "type": "object",
+            "properties": {
+                "region_id": {
+                    "type": "integer"
+                },
+                "territory_description": {
+                    "type": "string"
+                },
+                "territory_id": {
+                    "type": "string"
+                }
+            }
+        },
         "models.TopCustomer": {
             "type": "object",
             "properties": {
@@ -2762,6 +7589,152 @@ const docTemplate = `{
                     "type": "number"
                 }
             }
+        },
+        "models.Webhook": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "order.shipped",
+                        "product.low_stock"
+                    ]
+                },
+                "secret": {
+                    "description": "Secret hanya dikirim sekali, di response create.",
+                    "type": "string",
+                    "example": "whsec_3b1f..."
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://partner.example.com/hooks/northwind"
+                },
+                "webhook_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.WebhookAttempt": {
+            "type": "object",
+            "properties": {
+                "attempt_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "attempted_at": {
+                    "type": "string"
+                },
+                "duration_ms": {
+                    "type": "integer",
+                    "example": 84
+                },
+                "error": {
+                    "type": "string"
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "models.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempt_log": {
+                    "description": "AttemptLog hanya diisi di endpoint detail delivery.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WebhookAttempt"
+                    }
+                },
+                "attempts": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "type": "string"
+                },
+                "delivery_id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "event_type": {
+                    "type": "string",
+                    "example": "order.shipped"
+                },
+                "last_error": {
+                    "type": "string",
+                    "example": "receiver returned 503"
+                },
+                "last_status_code": {
+                    "type": "integer",
+                    "example": 503
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending"
+                },
+                "webhook_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.WebhookInput": {
+            "type": "object",
+            "required": [
+                "events",
+                "url"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "events": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "order.shipped"
+                    ]
+                },
+                "secret": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "minLength": 16
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 2000,
+                    "example": "https://partner.example.com/hooks/northwind"
+                }
+            }
         }
     }
 }`
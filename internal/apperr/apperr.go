@@ -0,0 +1,146 @@
+// Package apperr mendefinisikan domain error yang dipakai bersama oleh
+// repositories, services dan handlers. middleware.ErrorFormatter memetakan
+// jenis error ini ke status HTTP.
+package apperr
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel untuk jenis error; cek dengan errors.Is(err, apperr.ErrNotFound).
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrValidation   = errors.New("validation failed")
+	ErrForeignKey   = errors.New("foreign key violation")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+	ErrPreconditionFailed   = errors.New("precondition failed")
+	ErrPreconditionRequired = errors.New("precondition required")
+)
+
+// FieldError menjelaskan satu field yang tidak valid.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// Error membawa jenis error, pesan yang aman ditampilkan ke client, dan
+// (opsional) penyebab internal yang hanya untuk log.
+type Error struct {
+	Kind    error
+	Message string
+	Field   string
+	// Fields diisi kalau lebih dari satu field tidak valid sekaligus.
+	Fields []FieldError
+	// Blockers diisi kalau delete ditolak karena masih ada data yang merujuk.
+	Blockers []Blocker
+	Err      error
+}
+
+// Blocker merangkum satu jenis data yang masih merujuk resource yang mau dihapus.
+type Blocker struct {
+	Entity string
+	Count  int
+	IDs    []string
+}
+
+func (e *Error) Error() string {
+	msg := e.Message
+	if e.Field != "" {
+		msg = e.Field + ": " + msg
+	}
+	for _, f := range e.Fields {
+		msg += "; " + f.Field + ": " + f.Message
+	}
+	if e.Err != nil {
+		return msg + ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *Error) Unwrap() []error {
+	errs := []error{e.Kind}
+	if e.Err != nil {
+		errs = append(errs, e.Err)
+	}
+	return errs
+}
+
+// Wrap menyimpan penyebab internal tanpa mengubah pesan untuk client.
+func (e *Error) Wrap(err error) *Error {
+	e.Err = err
+	return e
+}
+
+// WithBlockers melampirkan daftar data yang menghalangi delete.
+func (e *Error) WithBlockers(blockers ...Blocker) *Error {
+	e.Blockers = blockers
+	return e
+}
+
+func NotFound(format string, args ...any) *Error {
+	return &Error{Kind: ErrNotFound, Message: fmt.Sprintf(format, args...)}
+}
+
+func Conflict(format string, args ...any) *Error {
+	return &Error{Kind: ErrConflict, Message: fmt.Sprintf(format, args...)}
+}
+
+func ForeignKey(format string, args ...any) *Error {
+	return &Error{Kind: ErrForeignKey, Message: fmt.Sprintf(format, args...)}
+}
+
+// Validation menandai input yang tidak valid; field boleh kosong.
+func Validation(field, format string, args ...any) *Error {
+	return &Error{Kind: ErrValidation, Field: field, Message: fmt.Sprintf(format, args...)}
+}
+
+// Invalid mengumpulkan beberapa field error dalam satu validation error.
+func Invalid(fields ...FieldError) *Error {
+	return &Error{Kind: ErrValidation, Message: "request has invalid fields", Fields: fields}
+}
+
+func Unauthorized(format string, args ...any) *Error {
+	return &Error{Kind: ErrUnauthorized, Message: fmt.Sprintf(format, args...)}
+}
+
+// Forbidden menandai caller yang dikenal tetapi tidak punya role yang dibutuhkan.
+func Forbidden(format string, args ...any) *Error {
+	return &Error{Kind: ErrForbidden, Message: fmt.Sprintf(format, args...)}
+}
+
+func UnsupportedMediaType(format string, args ...any) *Error {
+	return &Error{Kind: ErrUnsupportedMediaType, Message: fmt.Sprintf(format, args...)}
+}
+
+func PreconditionFailed(format string, args ...any) *Error {
+	return &Error{Kind: ErrPreconditionFailed, Message: fmt.Sprintf(format, args...)}
+}
+
+func PreconditionRequired(format string, args ...any) *Error {
+	return &Error{Kind: ErrPreconditionRequired, Message: fmt.Sprintf(format, args...)}
+}
+
+// FieldErrors mengembalikan semua field error milik e, termasuk Field tunggal.
+func (e *Error) FieldErrors() []FieldError {
+	if len(e.Fields) > 0 {
+		return e.Fields
+	}
+	if e.Field != "" {
+		return []FieldError{{Field: e.Field, Message: e.Message}}
+	}
+	return nil
+}
+
+// As mengembalikan *Error terluar di rantai err, kalau ada.
+func As(err error) (*Error, bool) {
+	var e *Error
+	if errors.As(err, &e) {
+		return e, true
+	}
+	return nil, false
+}
@@ -2,16 +2,27 @@ package config
 
 import (
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/rs/zerolog/log"
 )
 
 type AppConfig struct {
-	JWTSecret   string
-	Port        string
+	JWTSecret string
+	Port      string
+	// GRPCPort port server gRPC (GRPC_PORT, default 9090)
+	GRPCPort    string
 	DBPath      string
 	Environment string // diganti dari "Env"
 	APIVersion  string // diganti dari "APIVer"
+	// RequireIfMatch mewajibkan header If-Match pada PUT/PATCH/DELETE (REQUIRE_IF_MATCH)
+	RequireIfMatch bool
+	// IdempotencyTTL lama response POST disimpan per Idempotency-Key (IDEMPOTENCY_TTL, default 24h)
+	IdempotencyTTL time.Duration
+	// CustomerIDStrategy cara CustomerID customer baru dibuat: classic, uuid
+	// atau client (CUSTOMER_ID_STRATEGY, default classic)
+	CustomerIDStrategy string
 }
 
 // LoadConfig membaca env vars dan memberi default
@@ -19,10 +30,15 @@ func LoadConfig() *AppConfig {
 	cfg := &AppConfig{
 		JWTSecret:   os.Getenv("JWT_SECRET"),
 		Port:        os.Getenv("PORT"),
+		GRPCPort:    os.Getenv("GRPC_PORT"),
 		DBPath:      os.Getenv("DB_PATH"),
 		Environment: os.Getenv("GO_ENV"),
 		APIVersion:  os.Getenv("API_VERSION"),
+
+		CustomerIDStrategy: os.Getenv("CUSTOMER_ID_STRATEGY"),
 	}
+	cfg.RequireIfMatch, _ = strconv.ParseBool(os.Getenv("REQUIRE_IF_MATCH"))
+	cfg.IdempotencyTTL, _ = time.ParseDuration(os.Getenv("IDEMPOTENCY_TTL"))
 
 	// Validasi & default
 	if cfg.JWTSecret == "" {
@@ -31,6 +47,9 @@ func LoadConfig() *AppConfig {
 	if cfg.Port == "" {
 		cfg.Port = "8080"
 	}
+	if cfg.GRPCPort == "" {
+		cfg.GRPCPort = "9090"
+	}
 	if cfg.DBPath == "" {
 		cfg.DBPath = "northwind.db"
 	}
@@ -40,6 +59,12 @@ func LoadConfig() *AppConfig {
 	if cfg.APIVersion == "" {
 		cfg.APIVersion = "v1"
 	}
+	if cfg.IdempotencyTTL <= 0 {
+		cfg.IdempotencyTTL = 24 * time.Hour
+	}
+	if cfg.CustomerIDStrategy == "" {
+		cfg.CustomerIDStrategy = "classic"
+	}
 
 	return cfg
 }
@@ -53,3 +78,11 @@ func (c *AppConfig) Env() string {
 func (c *AppConfig) APIVer() string {
 	return c.APIVersion
 }
+
+func (c *AppConfig) IfMatchRequired() bool {
+	return c.RequireIfMatch
+}
+
+func (c *AppConfig) IdempotencyWindow() time.Duration {
+	return c.IdempotencyTTL
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"database/sql"
 	"fmt"
+	"strings"
 
 	"github.com/rs/zerolog/log"
 
@@ -10,7 +11,7 @@ import (
 )
 
 func SetupDB(dbPath string) *sql.DB {
-	db, err := sql.Open("sqlite", dbPath)
+	db, err := sql.Open("sqlite", dsn(dbPath))
 
 	if err != nil {
 		log.Fatal().Msgf("failed to connect database: %v", err)
@@ -24,3 +25,13 @@ func SetupDB(dbPath string) *sql.DB {
 	fmt.Println("Connected to SQLite database!")
 	return db
 }
+
+// dsn menambahkan busy_timeout supaya transaksi yang berjalan bersamaan
+// menunggu lock, bukan langsung gagal dengan SQLITE_BUSY, dan menyalakan
+// foreign_keys supaya pelanggaran relasi dilaporkan sebagai ErrForeignKey.
+func dsn(dbPath string) string {
+	if strings.Contains(dbPath, "?") {
+		return dbPath
+	}
+	return "file:" + dbPath + "?_pragma=busy_timeout(5000)&_pragma=foreign_keys(1)"
+}
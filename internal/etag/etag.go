@@ -0,0 +1,94 @@
+// Package etag menghitung ETag per resource (hash dari representasi JSON-nya)
+// dan membawa precondition If-Match dari request HTTP ke services lewat context.
+package etag
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/visibility"
+)
+
+// Of mengembalikan strong ETag (sudah diberi tanda kutip) untuk v.
+func Of(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return Bytes(b)
+}
+
+// For seperti Of, tapi dari representasi v yang dilihat caller di ctx: field
+// yang disembunyikan darinya tidak ikut menentukan ETag.
+func For(ctx context.Context, v any) string {
+	b, err := visibility.Marshal(ctx, v)
+	if err != nil {
+		return ""
+	}
+	return Bytes(b)
+}
+
+// Bytes mengembalikan strong ETag untuk body yang sudah diserialisasi.
+func Bytes(b []byte) string {
+	sum := sha256.Sum256(b)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// Match membandingkan header If-Match/If-None-Match dengan tag. Weak
+// comparison mengabaikan prefix W/ (RFC 9110 8.8.3.2).
+func Match(header, tag string, weak bool) bool {
+	if strings.TrimSpace(header) == "*" {
+		return tag != ""
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if weak {
+			if strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(tag, "W/") {
+				return true
+			}
+			continue
+		}
+		if !strings.HasPrefix(candidate, "W/") && candidate == tag {
+			return true
+		}
+	}
+	return false
+}
+
+type ifMatchKey struct{}
+
+// WithIfMatch menyimpan nilai header If-Match di ctx.
+func WithIfMatch(ctx context.Context, header string) context.Context {
+	return context.WithValue(ctx, ifMatchKey{}, header)
+}
+
+// Check memastikan If-Match di ctx (kalau ada) cocok dengan ETag current.
+// Dipanggil services di dalam transaksi, setelah state lama dibaca. ETag
+// dihitung dari resource seperti yang dilihat caller (lihat For).
+func Check(ctx context.Context, current any) error {
+	header, _ := ctx.Value(ifMatchKey{}).(string)
+	if header == "" {
+		return nil
+	}
+	if !Match(header, For(ctx, current), false) {
+		return apperr.PreconditionFailed("resource has been modified; fetch it again and retry")
+	}
+	return nil
+}
+
+// CheckBytes seperti Check untuk isi biner (foto, gambar) yang ETag-nya
+// dihitung dari byte yang dikirim GET.
+func CheckBytes(ctx context.Context, current []byte) error {
+	header, _ := ctx.Value(ifMatchKey{}).(string)
+	if header == "" {
+		return nil
+	}
+	if !Match(header, Bytes(current), false) {
+		return apperr.PreconditionFailed("resource has been modified; fetch it again and retry")
+	}
+	return nil
+}
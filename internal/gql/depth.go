@@ -0,0 +1,87 @@
+package gql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+// maxDepth membatasi kedalaman selection sebuah query. Relasi bisa saling
+// bolak-balik (employee -> manager -> manager ..., customer -> orders ->
+// customer ...), jadi tanpa batas satu request bisa memuat hampir seluruh
+// database.
+const maxDepth = 8
+
+// checkDepth menolak query yang lebih dalam dari maxDepth, dengan fragment
+// ikut dihitung. Query yang tidak bisa di-parse dilewatkan supaya error
+// sintaksnya dilaporkan graphql.Do seperti biasa. Field introspection
+// (__schema, __type) tidak dihitung: ukurannya dibatasi schema, bukan data.
+func checkDepth(query string) error {
+	doc, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		return nil
+	}
+	d := depthCounter{fragments: map[string]*ast.FragmentDefinition{}, known: map[string]int{}, visiting: map[string]bool{}}
+	for _, def := range doc.Definitions {
+		if f, ok := def.(*ast.FragmentDefinition); ok {
+			d.fragments[f.Name.Value] = f
+		}
+	}
+	for _, def := range doc.Definitions {
+		if op, ok := def.(*ast.OperationDefinition); ok {
+			if n := d.depth(op.SelectionSet); n > maxDepth {
+				return fmt.Errorf("query depth %d exceeds the maximum of %d", n, maxDepth)
+			}
+		}
+	}
+	return nil
+}
+
+type depthCounter struct {
+	fragments map[string]*ast.FragmentDefinition
+	// known menyimpan kedalaman fragment yang sudah dihitung supaya fragment
+	// yang dipakai berulang kali tidak dihitung ulang secara eksponensial.
+	known    map[string]int
+	visiting map[string]bool
+}
+
+func (d depthCounter) depth(set *ast.SelectionSet) int {
+	if set == nil {
+		return 0
+	}
+	deepest := 0
+	for _, sel := range set.Selections {
+		n := 0
+		switch sel := sel.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(sel.Name.Value, "__") {
+				continue
+			}
+			n = 1 + d.depth(sel.SelectionSet)
+		case *ast.InlineFragment:
+			n = d.depth(sel.SelectionSet)
+		case *ast.FragmentSpread:
+			n = d.fragment(sel.Name.Value)
+		}
+		deepest = max(deepest, n)
+	}
+	return deepest
+}
+
+func (d depthCounter) fragment(name string) int {
+	if n, ok := d.known[name]; ok {
+		return n
+	}
+	f, ok := d.fragments[name]
+	// Fragment yang tidak ada atau saling memanggil ditolak validasi graphql.Do.
+	if !ok || d.visiting[name] {
+		return 0
+	}
+	d.visiting[name] = true
+	n := d.depth(f.SelectionSet)
+	delete(d.visiting, name)
+	d.known[name] = n
+	return n
+}
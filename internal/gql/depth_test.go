@@ -0,0 +1,51 @@
+package gql
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestCheckDepth(t *testing.T) {
+	nested := func(n int) string {
+		return "{ " + strings.Repeat("employee { ", n) + "lastName" + strings.Repeat(" }", n) + " }"
+	}
+	tests := []struct {
+		name, query string
+		wantErr     string
+	}{
+		{"flat", "{ shippers { totalCount } }", ""},
+		{"at limit", nested(7), ""},
+		{"over limit", nested(8), "query depth 9 exceeds the maximum of 8"},
+		{"fragment spread", "{ a { ...F } } fragment F on T { b { c { d { e { f { g { h { i } } } } } } } }", "query depth 9 exceeds the maximum of 8"},
+		{"inline fragment not counted", "{ a { ... on T { b { c { d { e { f { g { h } } } } } } } } }", ""},
+		{"deepest of several operations", "query A { a } query B " + nested(8), "query depth 9 exceeds the maximum of 8"},
+		{"introspection not counted", "{ __schema { types { fields { type { ofType { ofType { ofType { ofType { ofType { name } } } } } } } } } }", ""},
+		// Fragment yang saling memanggil dan sintaks salah dilaporkan graphql.Do.
+		{"fragment cycle", "{ a { ...F } } fragment F on T { b { ...G } } fragment G on T { c { ...F } }", ""},
+		{"syntax error", "{ a {", ""},
+	}
+	for _, tc := range tests {
+		err := checkDepth(tc.query)
+		switch {
+		case tc.wantErr == "" && err != nil:
+			t.Errorf("%s: unexpected error %v", tc.name, err)
+		case tc.wantErr != "" && (err == nil || err.Error() != tc.wantErr):
+			t.Errorf("%s: error = %v, want %q", tc.name, err, tc.wantErr)
+		}
+	}
+}
+
+// Fragment yang dipakai berulang di setiap level dihitung sekali saja, jadi
+// query "fragment bomb" tetap cepat ditolak.
+func TestCheckDepthRepeatedFragments(t *testing.T) {
+	var b strings.Builder
+	b.WriteString("{ a { ...F0 } }")
+	for i := 0; i < 30; i++ {
+		b.WriteString(" fragment F" + strconv.Itoa(i) + " on T { x { ...F" + strconv.Itoa(i+1) + " ...F" + strconv.Itoa(i+1) + " } }")
+	}
+	b.WriteString(" fragment F30 on T { y }")
+	if err := checkDepth(b.String()); err == nil || !strings.Contains(err.Error(), "query depth 32") {
+		t.Fatalf("error = %v", err)
+	}
+}
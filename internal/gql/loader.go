@@ -0,0 +1,113 @@
+package gql
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"northwind-api/internal/apperr"
+
+	"github.com/rs/zerolog/log"
+)
+
+// maxBatch membatasi jumlah kunci per query IN supaya tidak melewati batas
+// parameter SQLite.
+const maxBatch = 500
+
+// loader mengumpulkan kunci yang diminta resolver lalu memuat semuanya dengan
+// satu panggilan fetch saat thunk pertama dievaluasi. graphql-go mengevaluasi
+// thunk secara breadth-first, jadi semua baris di satu level sudah mendaftarkan
+// kuncinya sebelum fetch berjalan (menghindari N+1 query).
+type loader[K comparable, V any] struct {
+	fetch func(ctx context.Context, keys []K) (map[K]V, error)
+
+	mu      sync.Mutex
+	pending []K
+	queued  map[K]bool
+	values  map[K]V
+	errs    map[K]error
+}
+
+func newLoader[K comparable, V any](fetch func(ctx context.Context, keys []K) (map[K]V, error)) *loader[K, V] {
+	return &loader[K, V]{
+		fetch:  fetch,
+		queued: map[K]bool{},
+		values: map[K]V{},
+		errs:   map[K]error{},
+	}
+}
+
+// load mendaftarkan key dan mengembalikan thunk yang hasilnya nilai untuk key
+// tersebut (nilai nol kalau tidak ditemukan).
+func (l *loader[K, V]) load(ctx context.Context, key K) func() (interface{}, error) {
+	l.mu.Lock()
+	if !l.queued[key] {
+		l.queued[key] = true
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (interface{}, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if len(l.pending) > 0 {
+			l.flush(ctx)
+		}
+		if err := l.errs[key]; err != nil {
+			return nil, err
+		}
+		return l.values[key], nil
+	}
+}
+
+func (l *loader[K, V]) flush(ctx context.Context) {
+	keys := l.pending
+	l.pending = nil
+	for start := 0; start < len(keys); start += maxBatch {
+		chunk := keys[start:min(start+maxBatch, len(keys))]
+		values, err := l.fetch(ctx, chunk)
+		for _, k := range chunk {
+			if err != nil {
+				l.errs[k] = safeError(err)
+				continue
+			}
+			l.values[k] = values[k]
+		}
+	}
+}
+
+// byKey mengindeks baris hasil batch berdasarkan kuncinya.
+func byKey[K comparable, V any](rows []V, key func(V) K) map[K]*V {
+	out := make(map[K]*V, len(rows))
+	for i := range rows {
+		out[key(rows[i])] = &rows[i]
+	}
+	return out
+}
+
+// groupBy mengelompokkan baris per kunci; setiap kunci yang diminta selalu
+// mendapat slice (bisa kosong) supaya list non-null tidak menjadi null.
+func groupBy[K comparable, V any](keys []K, rows []V, key func(V) K) map[K][]V {
+	out := make(map[K][]V, len(keys))
+	for _, k := range keys {
+		out[k] = []V{}
+	}
+	for _, row := range rows {
+		k := key(row)
+		if _, ok := out[k]; ok {
+			out[k] = append(out[k], row)
+		}
+	}
+	return out
+}
+
+// safeError meneruskan domain error apa adanya; error lain (mis. dari
+// database) dicatat di log dan diganti pesan umum agar detail internal tidak bocor.
+func safeError(err error) error {
+	var appErr *apperr.Error
+	if errors.As(err, &appErr) {
+		return err
+	}
+	log.Error().Err(err).Msg("graphql resolver error")
+	return errors.New("internal server error")
+}
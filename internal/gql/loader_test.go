@@ -0,0 +1,97 @@
+package gql
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"northwind-api/internal/apperr"
+)
+
+// Semua kunci yang didaftarkan sebelum thunk pertama dievaluasi dimuat dengan
+// satu fetch; kunci yang sama hanya diminta sekali.
+func TestLoaderBatchesKeys(t *testing.T) {
+	var calls [][]int
+	l := newLoader(func(ctx context.Context, keys []int) (map[int]string, error) {
+		calls = append(calls, keys)
+		out := map[int]string{}
+		for _, k := range keys {
+			if k != 3 {
+				out[k] = "v" + strconv.Itoa(k)
+			}
+		}
+		return out, nil
+	})
+	ctx := context.Background()
+	thunks := []func() (interface{}, error){l.load(ctx, 1), l.load(ctx, 2), l.load(ctx, 1), l.load(ctx, 3)}
+
+	var got []interface{}
+	for _, th := range thunks {
+		v, err := th()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, v)
+	}
+	if want := []interface{}{"v1", "v2", "v1", ""}; !reflect.DeepEqual(got, want) {
+		t.Errorf("values = %v, want %v", got, want)
+	}
+	if want := [][]int{{1, 2, 3}}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("fetch calls = %v, want %v", calls, want)
+	}
+
+	// Level berikutnya memicu fetch baru hanya untuk kunci yang belum dimuat.
+	if v, _ := l.load(ctx, 4)(); v != "v4" {
+		t.Errorf("key 4 = %v", v)
+	}
+	if v, _ := l.load(ctx, 2)(); v != "v2" || len(calls) != 2 {
+		t.Errorf("key 2 = %v after %d fetches", v, len(calls))
+	}
+}
+
+func TestLoaderSplitsLargeBatches(t *testing.T) {
+	var sizes []int
+	l := newLoader(func(ctx context.Context, keys []int) (map[int]int, error) {
+		sizes = append(sizes, len(keys))
+		return map[int]int{}, nil
+	})
+	var last func() (interface{}, error)
+	for i := 0; i < maxBatch*2+1; i++ {
+		last = l.load(context.Background(), i)
+	}
+	if _, err := last(); err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{maxBatch, maxBatch, 1}; !reflect.DeepEqual(sizes, want) {
+		t.Errorf("batch sizes = %v, want %v", sizes, want)
+	}
+}
+
+// Error database tidak bocor ke client; domain error diteruskan apa adanya.
+func TestLoaderErrors(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{errors.New("no such table: Orders"), "internal server error"},
+		{apperr.Validation("id", "is invalid"), "id: is invalid"},
+	}
+	for _, tc := range tests {
+		l := newLoader(func(ctx context.Context, keys []int) (map[int]int, error) { return nil, tc.err })
+		_, err := l.load(context.Background(), 1)()
+		if err == nil || err.Error() != tc.want {
+			t.Errorf("%v: error = %v, want %q", tc.err, err, tc.want)
+		}
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	type row struct{ k, v int }
+	got := groupBy([]int{1, 2}, []row{{1, 10}, {3, 30}, {1, 11}}, func(r row) int { return r.k })
+	want := map[int][]row{1: {{1, 10}, {1, 11}}, 2: {}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("groupBy = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,143 @@
+package gql
+
+import (
+	"context"
+
+	"northwind-api/internal/models"
+	"northwind-api/internal/repositories"
+)
+
+// loaders berisi satu loader per relasi; dibuat ulang untuk setiap request
+// supaya cache tidak terbawa antar request.
+type loaders struct {
+	customers  *loader[string, *models.Customer]
+	employees  *loader[int, *models.Employee]
+	shippers   *loader[int, *models.Shipper]
+	products   *loader[int, *models.Product]
+	categories *loader[int, *models.Category]
+	suppliers  *loader[int, *models.Supplier]
+	regions    *loader[int, *models.Region]
+
+	ordersByCustomer      *loader[string, []models.Order]
+	detailsByOrder        *loader[int, []models.OrderDetail]
+	productsBySupplier    *loader[int, []models.Product]
+	productsByCategory    *loader[int, []models.Product]
+	territoriesByEmployee *loader[int, []models.Territory]
+	employeesByTerritory  *loader[string, []models.Employee]
+}
+
+func newLoaders(r repositories.Repositories) *loaders {
+	return &loaders{
+		customers: newLoader(func(ctx context.Context, ids []string) (map[string]*models.Customer, error) {
+			rows, err := r.Customers.GetCustomersByIDs(ctx, ids)
+			return byKey(rows, func(c models.Customer) string { return c.CustomerID }), err
+		}),
+		employees: newLoader(func(ctx context.Context, ids []int) (map[int]*models.Employee, error) {
+			rows, err := r.Employees.GetEmployeesByIDs(ctx, ids)
+			return byKey(rows, func(e models.Employee) int { return e.EmployeeID }), err
+		}),
+		shippers: newLoader(func(ctx context.Context, ids []int) (map[int]*models.Shipper, error) {
+			rows, err := r.Shippers.GetShippersByIDs(ctx, ids)
+			return byKey(rows, func(s models.Shipper) int { return s.ShipperID }), err
+		}),
+		products: newLoader(func(ctx context.Context, ids []int) (map[int]*models.Product, error) {
+			rows, err := r.Products.GetProductsByIDs(ctx, ids)
+			return byKey(rows, func(p models.Product) int { return p.ProductID }), err
+		}),
+		categories: newLoader(func(ctx context.Context, ids []int) (map[int]*models.Category, error) {
+			rows, err := r.Categories.GetCategoriesByIDs(ctx, ids)
+			return byKey(rows, func(c models.Category) int { return int(c.CategoryID) }), err
+		}),
+		suppliers: newLoader(func(ctx context.Context, ids []int) (map[int]*models.Supplier, error) {
+			rows, err := r.Suppliers.GetSuppliersByIDs(ctx, ids)
+			return byKey(rows, func(s models.Supplier) int { return int(s.SupplierID) }), err
+		}),
+		// tabel Regions kecil, cukup dimuat seluruhnya sekali per request
+		regions: newLoader(func(ctx context.Context, _ []int) (map[int]*models.Region, error) {
+			rows, err := r.Regions.GetAllRegions(ctx)
+			return byKey(rows, func(reg models.Region) int { return reg.RegionID }), err
+		}),
+
+		ordersByCustomer: newLoader(func(ctx context.Context, ids []string) (map[string][]models.Order, error) {
+			rows, err := r.Orders.GetOrdersByCustomerIDs(ctx, ids)
+			return groupBy(ids, rows, func(o models.Order) string { return *o.CustomerID }), err
+		}),
+		detailsByOrder: newLoader(func(ctx context.Context, ids []int) (map[int][]models.OrderDetail, error) {
+			rows, err := r.Orders.GetOrderDetailsByOrderIDs(ctx, ids)
+			return groupBy(ids, rows, func(d models.OrderDetail) int { return int(d.OrderID) }), err
+		}),
+		productsBySupplier: newLoader(func(ctx context.Context, ids []int) (map[int][]models.Product, error) {
+			rows, err := r.Products.GetProductsBySupplierIDs(ctx, ids)
+			return groupBy(ids, rows, func(p models.Product) int { return *p.SupplierID }), err
+		}),
+		productsByCategory: newLoader(func(ctx context.Context, ids []int) (map[int][]models.Product, error) {
+			rows, err := r.Products.GetProductsByCategoryIDs(ctx, ids)
+			return groupBy(ids, rows, func(p models.Product) int { return *p.CategoryID }), err
+		}),
+		territoriesByEmployee: newLoader(func(ctx context.Context, ids []int) (map[int][]models.Territory, error) {
+			links, err := r.Regions.GetEmployeeTerritoriesByEmployeeIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			territories, err := r.Regions.GetTerritoriesByIDs(ctx, uniq(links, func(l models.EmployeeTerritory) string { return l.TerritoryID }))
+			if err != nil {
+				return nil, err
+			}
+			byID := byKey(territories, func(t models.Territory) string { return t.TerritoryID })
+			out := make(map[int][]models.Territory, len(ids))
+			for id, ls := range groupBy(ids, links, func(l models.EmployeeTerritory) int { return l.EmployeeID }) {
+				out[id] = []models.Territory{}
+				for _, l := range ls {
+					if t, ok := byID[l.TerritoryID]; ok {
+						out[id] = append(out[id], *t)
+					}
+				}
+			}
+			return out, nil
+		}),
+		employeesByTerritory: newLoader(func(ctx context.Context, ids []string) (map[string][]models.Employee, error) {
+			links, err := r.Regions.GetEmployeeTerritoriesByTerritoryIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			employees, err := r.Employees.GetEmployeesByIDs(ctx, uniq(links, func(l models.EmployeeTerritory) int { return l.EmployeeID }))
+			if err != nil {
+				return nil, err
+			}
+			byID := byKey(employees, func(e models.Employee) int { return e.EmployeeID })
+			out := make(map[string][]models.Employee, len(ids))
+			for id, ls := range groupBy(ids, links, func(l models.EmployeeTerritory) string { return l.TerritoryID }) {
+				out[id] = []models.Employee{}
+				for _, l := range ls {
+					if e, ok := byID[l.EmployeeID]; ok {
+						out[id] = append(out[id], *e)
+					}
+				}
+			}
+			return out, nil
+		}),
+	}
+}
+
+// uniq mengambil kunci unik dari baris, dengan urutan kemunculan pertama.
+func uniq[K comparable, V any](rows []V, key func(V) K) []K {
+	seen := map[K]bool{}
+	var out []K
+	for _, row := range rows {
+		if k := key(row); !seen[k] {
+			seen[k] = true
+			out = append(out, k)
+		}
+	}
+	return out
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
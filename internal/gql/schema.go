@@ -0,0 +1,401 @@
+// Package gql menyediakan endpoint GraphQL read-only di atas repositories.
+// Relasi antar objek dimuat lewat loader per request (lihat loader.go) supaya
+// satu query bersarang hanya menghasilkan satu query batch per relasi.
+package gql
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/models"
+	"northwind-api/internal/repositories"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+)
+
+const (
+	defaultLimit = 50
+	maxLimit     = 500
+)
+
+type Schema struct {
+	schema graphql.Schema
+	repos  repositories.Repositories
+}
+
+func NewSchema(r repositories.Repositories) (*Schema, error) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{Query: queryType(newObjectTypes(), r)})
+	if err != nil {
+		return nil, err
+	}
+	return &Schema{schema: schema, repos: r}, nil
+}
+
+// Execute menjalankan satu operasi GraphQL dengan loader baru untuk request ini.
+// Query yang terlalu dalam ditolak sebelum dijalankan.
+func (s *Schema) Execute(ctx context.Context, req models.GraphQLRequest) *graphql.Result {
+	if err := checkDepth(req.Query); err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+	return graphql.Do(graphql.Params{
+		Schema:         s.schema,
+		RequestString:  req.Query,
+		VariableValues: req.Variables,
+		OperationName:  req.OperationName,
+		Context:        withLoaders(ctx, newLoaders(s.repos)),
+	})
+}
+
+func queryType(t *objectTypes, r repositories.Repositories) *graphql.Object {
+	str := graphql.String
+	id := func(typ graphql.Input) graphql.FieldConfigArgument {
+		return graphql.FieldConfigArgument{"id": {Type: graphql.NewNonNull(typ)}}
+	}
+
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"customers": {
+				Type: pageType(t.customer),
+				Args: listArgs(graphql.FieldConfigArgument{
+					"country": {Type: str},
+					"city":    {Type: str},
+					"search":  {Type: str, Description: "Potongan nama perusahaan (tidak peka huruf besar/kecil)"},
+				}),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					limit, offset, err := pageArgs(p)
+					if err != nil {
+						return nil, err
+					}
+					f := repositories.CustomerFilter{Limit: limit, Offset: offset}
+					f.Country, _ = p.Args["country"].(string)
+					f.City, _ = p.Args["city"].(string)
+					f.Search, _ = p.Args["search"].(string)
+					rows, total, err := r.Customers.ListCustomers(p.Context, f)
+					if err != nil {
+						return nil, safeError(err)
+					}
+					return pageOf(rows, total, offset), nil
+				},
+			},
+			"customer": {
+				Type: t.customer,
+				Args: id(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return one(r.Customers.GetCustomerByID(p.Context, p.Args["id"].(string)))
+				},
+			},
+
+			"orders": {
+				Type: pageType(t.order),
+				Args: listArgs(graphql.FieldConfigArgument{
+					"customerId":  {Type: str},
+					"employeeId":  {Type: graphql.Int},
+					"shipperId":   {Type: graphql.Int},
+					"shipCountry": {Type: str},
+					"shipped":     {Type: graphql.Boolean, Description: "true: sudah dikirim (ShippedDate terisi), false: belum"},
+				}),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					limit, offset, err := pageArgs(p)
+					if err != nil {
+						return nil, err
+					}
+					f := repositories.OrderFilter{Limit: limit, Offset: offset}
+					f.CustomerID, _ = p.Args["customerId"].(string)
+					f.ShipCountry, _ = p.Args["shipCountry"].(string)
+					if v, ok := p.Args["employeeId"].(int); ok {
+						f.EmployeeID = int64(v)
+					}
+					if v, ok := p.Args["shipperId"].(int); ok {
+						f.ShipperID = int64(v)
+					}
+					if v, ok := p.Args["shipped"].(bool); ok {
+						f.Shipped = &v
+					}
+					rows, total, err := r.Orders.ListOrders(p.Context, f)
+					if err != nil {
+						return nil, safeError(err)
+					}
+					return pageOf(rows, total, offset), nil
+				},
+			},
+			"order": {
+				Type: t.order,
+				Args: id(graphql.Int),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return one(r.Orders.GetOrderByID(p.Context, p.Args["id"].(int)))
+				},
+			},
+
+			"products": {
+				Type: pageType(t.product),
+				Args: listArgs(graphql.FieldConfigArgument{
+					"categoryId":   {Type: graphql.Int},
+					"supplierId":   {Type: graphql.Int},
+					"discontinued": {Type: graphql.Boolean},
+					"search":       {Type: str, Description: "Potongan nama produk (tidak peka huruf besar/kecil)"},
+				}),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					limit, offset, err := pageArgs(p)
+					if err != nil {
+						return nil, err
+					}
+					f := repositories.ProductFilter{Limit: limit, Offset: offset}
+					f.CategoryID, _ = p.Args["categoryId"].(int)
+					f.SupplierID, _ = p.Args["supplierId"].(int)
+					f.Search, _ = p.Args["search"].(string)
+					if v, ok := p.Args["discontinued"].(bool); ok {
+						f.Discontinued = &v
+					}
+					rows, total, err := r.Products.ListProducts(p.Context, f)
+					if err != nil {
+						return nil, safeError(err)
+					}
+					return pageOf(rows, total, offset), nil
+				},
+			},
+			"product": {
+				Type: t.product,
+				Args: id(graphql.Int),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return one(r.Products.GetProductByID(p.Context, p.Args["id"].(int)))
+				},
+			},
+
+			"categories": {
+				Type: pageType(t.category),
+				Args: listArgs(nil),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					rows, err := r.Categories.GetAllCategories(p.Context)
+					if err != nil {
+						return nil, safeError(err)
+					}
+					sort.SliceStable(rows, func(i, j int) bool { return rows[i].CategoryID < rows[j].CategoryID })
+					return paginate(p, rows)
+				},
+			},
+			"category": {
+				Type: t.category,
+				Args: id(graphql.Int),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return one(r.Categories.GetCategoryByID(p.Context, p.Args["id"].(int)))
+				},
+			},
+
+			"suppliers": {
+				Type: pageType(t.supplier),
+				Args: listArgs(graphql.FieldConfigArgument{
+					"country": {Type: str},
+					"search":  {Type: str, Description: "Potongan nama perusahaan (tidak peka huruf besar/kecil)"},
+				}),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					rows, err := r.Suppliers.GetAllSuppliers(p.Context)
+					if err != nil {
+						return nil, safeError(err)
+					}
+					var f filter[models.Supplier]
+					if v, ok := p.Args["country"].(string); ok {
+						f.add(func(s models.Supplier) bool { return s.Country != nil && strings.EqualFold(*s.Country, v) })
+					}
+					if v, ok := p.Args["search"].(string); ok {
+						f.add(func(s models.Supplier) bool { return contains(s.CompanyName, v) })
+					}
+					rows = f.apply(rows)
+					sort.SliceStable(rows, func(i, j int) bool { return rows[i].SupplierID < rows[j].SupplierID })
+					return paginate(p, rows)
+				},
+			},
+			"supplier": {
+				Type: t.supplier,
+				Args: id(graphql.Int),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return one(r.Suppliers.GetSupplierByID(p.Context, p.Args["id"].(int)))
+				},
+			},
+
+			"employees": {
+				Type: pageType(t.employee),
+				Args: listArgs(graphql.FieldConfigArgument{
+					"country":   {Type: str},
+					"reportsTo": {Type: graphql.Int, Description: "Hanya bawahan langsung employee ini"},
+				}),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					rows, err := r.Employees.GetAllEmployees(p.Context)
+					if err != nil {
+						return nil, safeError(err)
+					}
+					var f filter[models.Employee]
+					if v, ok := p.Args["country"].(string); ok {
+						f.add(func(e models.Employee) bool { return strings.EqualFold(e.Country, v) })
+					}
+					if v, ok := p.Args["reportsTo"].(int); ok {
+						f.add(func(e models.Employee) bool { return e.ReportsTo != nil && *e.ReportsTo == v })
+					}
+					rows = f.apply(rows)
+					sort.SliceStable(rows, func(i, j int) bool { return rows[i].EmployeeID < rows[j].EmployeeID })
+					return paginate(p, rows)
+				},
+			},
+			"employee": {
+				Type: t.employee,
+				Args: id(graphql.Int),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return one(r.Employees.GetEmployeeByID(p.Context, p.Args["id"].(int)))
+				},
+			},
+
+			"shippers": {
+				Type: pageType(t.shipper),
+				Args: listArgs(nil),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					rows, err := r.Shippers.GetAllShippers(p.Context)
+					if err != nil {
+						return nil, safeError(err)
+					}
+					sort.SliceStable(rows, func(i, j int) bool { return rows[i].ShipperID < rows[j].ShipperID })
+					return paginate(p, rows)
+				},
+			},
+			"shipper": {
+				Type: t.shipper,
+				Args: id(graphql.Int),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return one(r.Shippers.GetShipperByID(p.Context, p.Args["id"].(int)))
+				},
+			},
+
+			"territories": {
+				Type: pageType(t.territory),
+				Args: listArgs(graphql.FieldConfigArgument{"regionId": {Type: graphql.Int}}),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					rows, err := r.Regions.GetAllTerritories(p.Context)
+					if err != nil {
+						return nil, safeError(err)
+					}
+					var f filter[models.Territory]
+					if v, ok := p.Args["regionId"].(int); ok {
+						f.add(func(t models.Territory) bool { return t.RegionID == v })
+					}
+					return paginate(p, f.apply(rows))
+				},
+			},
+			"territory": {
+				Type: t.territory,
+				Args: id(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					rows, err := r.Regions.GetTerritoriesByIDs(p.Context, []string{p.Args["id"].(string)})
+					if err != nil || len(rows) == 0 {
+						return nil, safeErrorOrNil(err)
+					}
+					return rows[0], nil
+				},
+			},
+		},
+	})
+}
+
+type page struct {
+	Items       interface{}
+	TotalCount  int
+	HasNextPage bool
+}
+
+// pageType membungkus list objek dengan info pagination.
+func pageType(item *graphql.Object) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: item.Name() + "Page",
+		Fields: graphql.Fields{
+			"items":       {Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(item)))},
+			"totalCount":  {Type: graphql.NewNonNull(graphql.Int)},
+			"hasNextPage": {Type: graphql.NewNonNull(graphql.Boolean)},
+		},
+	})
+}
+
+// listArgs menambahkan argumen limit/offset ke filter list.
+func listArgs(args graphql.FieldConfigArgument) graphql.FieldConfigArgument {
+	if args == nil {
+		args = graphql.FieldConfigArgument{}
+	}
+	args["limit"] = &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultLimit}
+	args["offset"] = &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 0}
+	return args
+}
+
+// pageArgs membaca dan memvalidasi argumen limit/offset sebuah field list.
+func pageArgs(p graphql.ResolveParams) (limit, offset int, err error) {
+	limit, _ = p.Args["limit"].(int)
+	offset, _ = p.Args["offset"].(int)
+	if limit < 1 || limit > maxLimit {
+		return 0, 0, apperr.Validation("limit", "must be between 1 and %d", maxLimit)
+	}
+	if offset < 0 {
+		return 0, 0, apperr.Validation("offset", "must not be negative")
+	}
+	return limit, offset, nil
+}
+
+// paginate memotong list kecil yang sudah dimuat seluruhnya (categories,
+// shippers, ...); list besar dipaginasi repository lalu dibungkus pageOf.
+func paginate[T any](p graphql.ResolveParams, items []T) (interface{}, error) {
+	limit, offset, err := pageArgs(p)
+	if err != nil {
+		return nil, err
+	}
+	start := min(offset, len(items))
+	end := min(start+limit, len(items))
+	return pageOf(items[start:end], len(items), offset), nil
+}
+
+// pageOf membungkus satu halaman items mulai dari offset, dari total baris.
+func pageOf[T any](items []T, total, offset int) page {
+	if items == nil {
+		items = []T{}
+	}
+	return page{Items: items, TotalCount: total, HasNextPage: offset+len(items) < total}
+}
+
+// filter menampung kondisi dari argumen query; baris harus memenuhi semuanya.
+type filter[T any] []func(T) bool
+
+func (f *filter[T]) add(keep func(T) bool) { *f = append(*f, keep) }
+
+func (f filter[T]) apply(rows []T) []T {
+	out := rows[:0:0]
+	for _, row := range rows {
+		ok := true
+		for _, keep := range f {
+			if !keep(row) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			out = append(out, row)
+		}
+	}
+	return out
+}
+
+func contains(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// one mengubah NotFound dari lookup tunggal menjadi null, sesuai konvensi GraphQL.
+func one[T any](v T, err error) (interface{}, error) {
+	if err != nil {
+		return nil, safeErrorOrNil(err)
+	}
+	return v, nil
+}
+
+func safeErrorOrNil(err error) error {
+	if err == nil || errors.Is(err, apperr.ErrNotFound) {
+		return nil
+	}
+	return safeError(err)
+}
@@ -0,0 +1,359 @@
+package gql
+
+import (
+	"reflect"
+	"strings"
+
+	"northwind-api/internal/models"
+	"northwind-api/internal/visibility"
+
+	"github.com/graphql-go/graphql"
+)
+
+// Nama field mengikuti konvensi GraphQL (camelCase). Resolver default
+// graphql-go mencocokkan nama field dengan nama field struct tanpa melihat
+// huruf besar/kecil, jadi hanya relasi dan nama yang berbeda yang butuh resolver.
+
+type objectTypes struct {
+	customer, order, orderDetail, product, category, supplier *graphql.Object
+	employee, shipper, territory, region                      *graphql.Object
+}
+
+func newObjectTypes() *objectTypes {
+	t := &objectTypes{}
+	str := graphql.String
+	nnStr := graphql.NewNonNull(graphql.String)
+	nnInt := graphql.NewNonNull(graphql.Int)
+	nnFloat := graphql.NewNonNull(graphql.Float)
+	listOf := func(o *graphql.Object) graphql.Output {
+		return graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(o)))
+	}
+
+	t.region = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Region",
+		Fields: graphql.Fields{
+			"regionId":          {Type: nnInt},
+			"regionDescription": {Type: nnStr},
+		},
+	})
+
+	t.shipper = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Shipper",
+		Fields: graphql.Fields{
+			"shipperId":   {Type: nnInt},
+			"companyName": {Type: nnStr},
+			"phone":       {Type: str},
+			"deletedAt":   {Type: graphql.DateTime},
+		},
+	})
+
+	t.customer = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Customer",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return restricted[models.Customer](graphql.Fields{
+				"customerId":   {Type: nnStr},
+				"companyName":  {Type: nnStr},
+				"contactName":  {Type: str},
+				"contactTitle": {Type: str},
+				"address":      {Type: str},
+				"city":         {Type: str},
+				"region":       {Type: str},
+				"postalCode":   {Type: str},
+				"country":      {Type: str},
+				"phone":        {Type: str},
+				"fax":          {Type: str},
+				"deletedAt":    {Type: graphql.DateTime},
+				"orders": {
+					Type:        listOf(t.order),
+					Description: "Order milik customer ini, urut OrderID",
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						c := source[models.Customer](p)
+						return loadersFrom(p.Context).ordersByCustomer.load(p.Context, c.CustomerID), nil
+					},
+				},
+			})
+		}),
+	})
+
+	t.employee = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Employee",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return restricted[models.Employee](graphql.Fields{
+				"employeeId":      {Type: nnInt},
+				"lastName":        {Type: nnStr},
+				"firstName":       {Type: nnStr},
+				"title":           {Type: str},
+				"titleOfCourtesy": {Type: str},
+				"birthDate":       {Type: str},
+				"hireDate":        {Type: str},
+				"address":         {Type: str},
+				"city":            {Type: str},
+				"region":          {Type: str},
+				"postalCode":      {Type: str},
+				"country":         {Type: str},
+				"homePhone":       {Type: str},
+				"extension":       {Type: str},
+				"notes":           {Type: str},
+				"reportsTo":       {Type: graphql.Int},
+				"photoPath":       {Type: str},
+				"deletedAt":       {Type: graphql.DateTime},
+				"manager": {
+					Type:        t.employee,
+					Description: "Atasan langsung (ReportsTo)",
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						e := source[models.Employee](p)
+						if e.ReportsTo == nil {
+							return nil, nil
+						}
+						return loadersFrom(p.Context).employees.load(p.Context, *e.ReportsTo), nil
+					},
+				},
+				"territories": {
+					Type: listOf(t.territory),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						e := source[models.Employee](p)
+						return loadersFrom(p.Context).territoriesByEmployee.load(p.Context, e.EmployeeID), nil
+					},
+				},
+			})
+		}),
+	})
+
+	t.territory = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Territory",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"territoryId": {Type: nnStr},
+				"territoryDescription": {
+					Type: nnStr,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return source[models.Territory](p).TerritoryDesc, nil
+					},
+				},
+				"regionId": {Type: nnInt},
+				"region": {
+					Type: t.region,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return loadersFrom(p.Context).regions.load(p.Context, source[models.Territory](p).RegionID), nil
+					},
+				},
+				"employees": {
+					Type: listOf(t.employee),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return loadersFrom(p.Context).employeesByTerritory.load(p.Context, source[models.Territory](p).TerritoryID), nil
+					},
+				},
+			}
+		}),
+	})
+
+	t.category = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Category",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"categoryId":   {Type: nnInt},
+				"categoryName": {Type: str},
+				"description":  {Type: str},
+				"deletedAt":    {Type: graphql.DateTime},
+				"products": {
+					Type: listOf(t.product),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						c := source[models.Category](p)
+						return loadersFrom(p.Context).productsByCategory.load(p.Context, int(c.CategoryID)), nil
+					},
+				},
+			}
+		}),
+	})
+
+	t.supplier = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Supplier",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"supplierId":   {Type: nnInt},
+				"companyName":  {Type: nnStr},
+				"contactName":  {Type: str},
+				"contactTitle": {Type: str},
+				"address":      {Type: str},
+				"city":         {Type: str},
+				"region":       {Type: str},
+				"postalCode":   {Type: str},
+				"country":      {Type: str},
+				"phone":        {Type: str},
+				"fax":          {Type: str},
+				"homePage":     {Type: str},
+				"deletedAt":    {Type: graphql.DateTime},
+				"products": {
+					Type: listOf(t.product),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						s := source[models.Supplier](p)
+						return loadersFrom(p.Context).productsBySupplier.load(p.Context, int(s.SupplierID)), nil
+					},
+				},
+			}
+		}),
+	})
+
+	t.product = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Product",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"productId":       {Type: nnInt},
+				"productName":     {Type: nnStr},
+				"supplierId":      {Type: graphql.Int},
+				"categoryId":      {Type: graphql.Int},
+				"quantityPerUnit": {Type: str},
+				"unitPrice":       {Type: nnFloat},
+				"unitsInStock":    {Type: nnInt},
+				"unitsOnOrder":    {Type: nnInt},
+				"reorderLevel":    {Type: nnInt},
+				"discontinued":    {Type: graphql.NewNonNull(graphql.Boolean)},
+				"deletedAt":       {Type: graphql.DateTime},
+				"supplier": {
+					Type: t.supplier,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						pr := source[models.Product](p)
+						if pr.SupplierID == nil {
+							return nil, nil
+						}
+						return loadersFrom(p.Context).suppliers.load(p.Context, *pr.SupplierID), nil
+					},
+				},
+				"category": {
+					Type: t.category,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						pr := source[models.Product](p)
+						if pr.CategoryID == nil {
+							return nil, nil
+						}
+						return loadersFrom(p.Context).categories.load(p.Context, *pr.CategoryID), nil
+					},
+				},
+			}
+		}),
+	})
+
+	t.orderDetail = graphql.NewObject(graphql.ObjectConfig{
+		Name: "OrderDetail",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"orderId":   {Type: nnInt},
+				"productId": {Type: nnInt},
+				"unitPrice": {Type: nnFloat},
+				"quantity":  {Type: nnInt},
+				"discount":  {Type: nnFloat},
+				"product": {
+					Type: t.product,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						d := source[models.OrderDetail](p)
+						return loadersFrom(p.Context).products.load(p.Context, int(d.ProductID)), nil
+					},
+				},
+			}
+		}),
+	})
+
+	t.order = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Order",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"orderId":        {Type: nnInt},
+				"customerId":     {Type: str},
+				"employeeId":     {Type: graphql.Int},
+				"orderDate":      {Type: str},
+				"requiredDate":   {Type: str},
+				"shippedDate":    {Type: str},
+				"shipVia":        {Type: graphql.Int},
+				"freight":        {Type: graphql.Float},
+				"shipName":       {Type: str},
+				"shipAddress":    {Type: str},
+				"shipCity":       {Type: str},
+				"shipRegion":     {Type: str},
+				"shipPostalCode": {Type: str},
+				"shipCountry":    {Type: str},
+				"customer": {
+					Type: t.customer,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						o := source[models.Order](p)
+						if o.CustomerID == nil {
+							return nil, nil
+						}
+						return loadersFrom(p.Context).customers.load(p.Context, *o.CustomerID), nil
+					},
+				},
+				"employee": {
+					Type: t.employee,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						o := source[models.Order](p)
+						if o.EmployeeID == nil {
+							return nil, nil
+						}
+						return loadersFrom(p.Context).employees.load(p.Context, int(*o.EmployeeID)), nil
+					},
+				},
+				"shipper": {
+					Type:        t.shipper,
+					Description: "Shipper dari kolom ShipVia",
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						o := source[models.Order](p)
+						if o.ShipVia == nil {
+							return nil, nil
+						}
+						return loadersFrom(p.Context).shippers.load(p.Context, int(*o.ShipVia)), nil
+					},
+				},
+				"details": {
+					Type: listOf(t.orderDetail),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						o := source[models.Order](p)
+						return loadersFrom(p.Context).detailsByOrder.load(p.Context, int(o.OrderID)), nil
+					},
+				},
+			}
+		}),
+	})
+
+	return t
+}
+
+// source mengambil objek induk resolver; graphql-go bisa memberikan nilai
+// struct atau pointer tergantung dari mana objek itu berasal.
+// restricted membuat field yang punya kebijakan visibility di model T
+// bernilai null untuk caller yang tidak boleh melihatnya.
+func restricted[T any](fields graphql.Fields) graphql.Fields {
+	for _, r := range visibility.Rules(reflect.TypeFor[T]()) {
+		name := camelCase(r.Field)
+		f, ok := fields[name]
+		if !ok {
+			continue
+		}
+		field := r.Field
+		f.Resolve = func(p graphql.ResolveParams) (interface{}, error) {
+			if !visibility.Visible[T](p.Context, field) {
+				return nil, nil
+			}
+			return graphql.DefaultResolveFn(p)
+		}
+	}
+	return fields
+}
+
+// camelCase: "birth_date" -> "birthDate".
+func camelCase(s string) string {
+	parts := strings.Split(s, "_")
+	for i := 1; i < len(parts); i++ {
+		parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+	}
+	return strings.Join(parts, "")
+}
+
+func source[T any](p graphql.ResolveParams) T {
+	switch v := p.Source.(type) {
+	case T:
+		return v
+	case *T:
+		return *v
+	}
+	var zero T
+	return zero
+}
@@ -0,0 +1,183 @@
+package grpcserver
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/etag"
+	"northwind-api/internal/models"
+	pb "northwind-api/internal/pb/northwindv1"
+	"northwind-api/internal/visibility"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+)
+
+// paginate memotong items sesuai PageRequest. page_token berisi offset
+// baris berikutnya; client cukup mengirim balik next_page_token apa adanya.
+func paginate[T any](items []T, req *pb.PageRequest) ([]T, *pb.PageResponse, error) {
+	size, offset := defaultPageSize, 0
+	if req != nil {
+		if req.PageSize < 0 || req.PageSize > maxPageSize {
+			return nil, nil, apperr.Validation("page_size", "must be between 0 and %d", maxPageSize)
+		}
+		if req.PageSize > 0 {
+			size = int(req.PageSize)
+		}
+		if req.PageToken != "" {
+			n, err := strconv.Atoi(req.PageToken)
+			if err != nil || n < 0 {
+				return nil, nil, apperr.Validation("page_token", "is not a valid page token")
+			}
+			offset = n
+		}
+	}
+	start := min(offset, len(items))
+	end := min(start+size, len(items))
+	page := &pb.PageResponse{TotalSize: int32(len(items))}
+	if end < len(items) {
+		page.NextPageToken = strconv.Itoa(end)
+	}
+	return items[start:end], page, nil
+}
+
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+// convert mengubah pointer angka antar tipe (mis. *int di model dan *int32 di proto).
+func convert[B, A ~int | ~int32 | ~int64](p *A) *B {
+	if p == nil {
+		return nil
+	}
+	v := B(*p)
+	return &v
+}
+
+// customerToPB mengosongkan field yang tidak boleh dilihat caller (protobuf
+// tidak punya null); ETag dihitung dari representasi yang sama dengan REST.
+func customerToPB(ctx context.Context, c models.Customer) *pb.Customer {
+	tag := etag.For(ctx, c)
+	visibility.Redact(ctx, &c)
+	return &pb.Customer{
+		CustomerId:   c.CustomerID,
+		CompanyName:  c.CompanyName,
+		ContactName:  c.ContactName,
+		ContactTitle: c.ContactTitle,
+		Address:      c.Address,
+		City:         c.City,
+		Region:       c.Region,
+		PostalCode:   c.PostalCode,
+		Country:      c.Country,
+		Phone:        c.Phone,
+		Fax:          c.Fax,
+		DeletedAt:    timestamp(c.DeletedAt),
+		Etag:         tag,
+	}
+}
+
+func customerFromPB(c *pb.Customer) models.Customer {
+	return models.Customer{
+		CustomerID:   c.GetCustomerId(),
+		CompanyName:  c.GetCompanyName(),
+		ContactName:  c.GetContactName(),
+		ContactTitle: c.GetContactTitle(),
+		Address:      c.GetAddress(),
+		City:         c.GetCity(),
+		Region:       c.GetRegion(),
+		PostalCode:   c.GetPostalCode(),
+		Country:      c.GetCountry(),
+		Phone:        c.GetPhone(),
+		Fax:          c.GetFax(),
+	}
+}
+
+func productToPB(ctx context.Context, p models.Product) *pb.Product {
+	return &pb.Product{
+		ProductId:       int32(p.ProductID),
+		ProductName:     p.ProductName,
+		SupplierId:      convert[int32](p.SupplierID),
+		CategoryId:      convert[int32](p.CategoryID),
+		QuantityPerUnit: p.QuantityPerUnit,
+		UnitPrice:       p.UnitPrice,
+		UnitsInStock:    int32(p.UnitsInStock),
+		UnitsOnOrder:    int32(p.UnitsOnOrder),
+		ReorderLevel:    int32(p.ReorderLevel),
+		Discontinued:    p.Discontinued,
+		DeletedAt:       timestamp(p.DeletedAt),
+		Etag:            etag.For(ctx, p),
+	}
+}
+
+func productFromPB(p *pb.Product) models.Product {
+	return models.Product{
+		ProductID:       int(p.GetProductId()),
+		ProductName:     p.GetProductName(),
+		SupplierID:      convert[int](p.SupplierId),
+		CategoryID:      convert[int](p.CategoryId),
+		QuantityPerUnit: p.QuantityPerUnit,
+		UnitPrice:       p.GetUnitPrice(),
+		UnitsInStock:    int(p.GetUnitsInStock()),
+		UnitsOnOrder:    int(p.GetUnitsOnOrder()),
+		ReorderLevel:    int(p.GetReorderLevel()),
+		Discontinued:    p.GetDiscontinued(),
+	}
+}
+
+func orderToPB(ctx context.Context, o models.Order, details []models.OrderDetail) *pb.Order {
+	out := &pb.Order{
+		OrderId:        o.OrderID,
+		CustomerId:     o.CustomerID,
+		EmployeeId:     o.EmployeeID,
+		OrderDate:      o.OrderDate,
+		RequiredDate:   o.RequiredDate,
+		ShippedDate:    o.ShippedDate,
+		ShipVia:        o.ShipVia,
+		Freight:        o.Freight,
+		ShipName:       o.ShipName,
+		ShipAddress:    o.ShipAddress,
+		ShipCity:       o.ShipCity,
+		ShipRegion:     o.ShipRegion,
+		ShipPostalCode: o.ShipPostalCode,
+		ShipCountry:    o.ShipCountry,
+		Etag:           etag.For(ctx, o),
+	}
+	for _, d := range details {
+		out.Details = append(out.Details, &pb.OrderDetail{
+			OrderId:   d.OrderID,
+			ProductId: d.ProductID,
+			UnitPrice: d.UnitPrice,
+			Quantity:  int32(d.Quantity),
+			Discount:  d.Discount,
+		})
+	}
+	return out
+}
+
+func orderFromPB(o *pb.Order) models.Order {
+	return models.Order{
+		OrderID:        o.GetOrderId(),
+		CustomerID:     o.CustomerId,
+		EmployeeID:     o.EmployeeId,
+		OrderDate:      o.OrderDate,
+		RequiredDate:   o.RequiredDate,
+		ShippedDate:    o.ShippedDate,
+		ShipVia:        o.ShipVia,
+		Freight:        o.Freight,
+		ShipName:       o.ShipName,
+		ShipAddress:    o.ShipAddress,
+		ShipCity:       o.ShipCity,
+		ShipRegion:     o.ShipRegion,
+		ShipPostalCode: o.ShipPostalCode,
+		ShipCountry:    o.ShipCountry,
+	}
+}
@@ -0,0 +1,107 @@
+package grpcserver
+
+import (
+	"context"
+	"strings"
+
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/etag"
+	pb "northwind-api/internal/pb/northwindv1"
+	"northwind-api/internal/services"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type customerServer struct {
+	pb.UnimplementedCustomerServiceServer
+	preconditions
+	svc *services.CustomerService
+}
+
+func (s *customerServer) ListCustomers(ctx context.Context, req *pb.ListCustomersRequest) (*pb.ListCustomersResponse, error) {
+	customers, err := s.svc.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.Country != "" {
+		filtered := customers[:0]
+		for _, c := range customers {
+			if strings.EqualFold(c.Country, req.Country) {
+				filtered = append(filtered, c)
+			}
+		}
+		customers = filtered
+	}
+	items, page, err := paginate(customers, req.Page)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.ListCustomersResponse{Page: page}
+	for _, c := range items {
+		resp.Customers = append(resp.Customers, customerToPB(ctx, c))
+	}
+	return resp, nil
+}
+
+func (s *customerServer) GetCustomer(ctx context.Context, req *pb.GetCustomerRequest) (*pb.Customer, error) {
+	c, err := s.svc.Get(ctx, req.CustomerId)
+	if err != nil {
+		return nil, err
+	}
+	return customerToPB(ctx, c), nil
+}
+
+func (s *customerServer) CreateCustomer(ctx context.Context, req *pb.CreateCustomerRequest) (*pb.Customer, error) {
+	if req.Customer == nil {
+		return nil, apperr.Validation("customer", "is required")
+	}
+	c := customerFromPB(req.Customer)
+	if err := s.svc.Create(ctx, &c); err != nil {
+		return nil, err
+	}
+	return customerToPB(ctx, c), nil
+}
+
+func (s *customerServer) UpdateCustomer(ctx context.Context, req *pb.UpdateCustomerRequest) (*pb.Customer, error) {
+	if req.Customer == nil {
+		return nil, apperr.Validation("customer", "is required")
+	}
+	c := customerFromPB(req.Customer)
+	ctx, err := s.ifMatch(ctx, req.Etag)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.svc.Update(ctx, &c); err != nil {
+		return nil, err
+	}
+	return customerToPB(ctx, c), nil
+}
+
+func (s *customerServer) DeleteCustomer(ctx context.Context, req *pb.DeleteCustomerRequest) (*emptypb.Empty, error) {
+	ctx, err := s.ifMatch(ctx, req.Etag)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.svc.Delete(ctx, req.CustomerId); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// preconditions adalah padanan middleware.Preconditions untuk update dan
+// delete lewat gRPC.
+type preconditions struct {
+	// required mewajibkan etag, sama seperti REQUIRE_IF_MATCH di HTTP.
+	required bool
+}
+
+// ifMatch membawa etag dari request ke services, sama seperti header If-Match di HTTP.
+func (p preconditions) ifMatch(ctx context.Context, tag string) (context.Context, error) {
+	if tag == "" {
+		if p.required {
+			return ctx, apperr.PreconditionRequired("etag is required; send the etag from a previous get")
+		}
+		return ctx, nil
+	}
+	return etag.WithIfMatch(ctx, tag), nil
+}
@@ -0,0 +1,77 @@
+package grpcserver
+
+import (
+	"errors"
+	"fmt"
+
+	"northwind-api/internal/apperr"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// codeFor adalah padanan middleware.StatusFor untuk gRPC.
+func codeFor(err error) codes.Code {
+	switch {
+	case errors.Is(err, apperr.ErrValidation), errors.Is(err, apperr.ErrUnsupportedMediaType):
+		return codes.InvalidArgument
+	case errors.Is(err, apperr.ErrUnauthorized):
+		return codes.Unauthenticated
+	case errors.Is(err, apperr.ErrForbidden):
+		return codes.PermissionDenied
+	case errors.Is(err, apperr.ErrNotFound):
+		return codes.NotFound
+	case errors.Is(err, apperr.ErrConflict):
+		return codes.Aborted
+	case errors.Is(err, apperr.ErrForeignKey),
+		errors.Is(err, apperr.ErrPreconditionFailed),
+		errors.Is(err, apperr.ErrPreconditionRequired):
+		return codes.FailedPrecondition
+	default:
+		return codes.Internal
+	}
+}
+
+// toStatus mengubah err menjadi status gRPC. Seperti problem+json di HTTP,
+// detail internal tidak dikirim ke client; field yang tidak valid dilampirkan
+// sebagai BadRequest dan data yang menghalangi delete sebagai PreconditionFailure.
+func toStatus(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	code := codeFor(err)
+	e, ok := apperr.As(err)
+	if code == codes.Internal || !ok {
+		return status.Error(codes.Internal, "internal server error")
+	}
+
+	if len(e.Blockers) > 0 {
+		// delete ditolak karena masih dirujuk: state, bukan konflik versi
+		code = codes.FailedPrecondition
+	}
+	st := status.New(code, e.Message)
+	var details []*errdetails.BadRequest_FieldViolation
+	for _, f := range e.FieldErrors() {
+		details = append(details, &errdetails.BadRequest_FieldViolation{Field: f.Field, Description: f.Message})
+	}
+	if len(details) > 0 {
+		if withDetails, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: details}); err == nil {
+			st = withDetails
+		}
+	}
+	var violations []*errdetails.PreconditionFailure_Violation
+	for _, b := range e.Blockers {
+		violations = append(violations, &errdetails.PreconditionFailure_Violation{
+			Type:        "DEPENDENTS",
+			Subject:     b.Entity,
+			Description: fmt.Sprintf("%d %s still reference this resource", b.Count, b.Entity),
+		})
+	}
+	if len(violations) > 0 {
+		if withDetails, err := st.WithDetails(&errdetails.PreconditionFailure{Violations: violations}); err == nil {
+			st = withDetails
+		}
+	}
+	return st.Err()
+}
@@ -0,0 +1,108 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/requestctx"
+	"northwind-api/internal/utils"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// metadataRequestID sama dengan header X-Request-ID di HTTP (metadata gRPC selalu huruf kecil).
+const metadataRequestID = "x-request-id"
+
+// requestInfo memasang request ID (dari metadata atau baru) di ctx dan mengirimnya balik sebagai header.
+func requestInfo(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	id := firstMetadata(ctx, metadataRequestID)
+	if id == "" {
+		id = uuid.NewString()
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs(metadataRequestID, id))
+	return handler(requestctx.WithID(ctx, id), req)
+}
+
+func logUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	log.Info().
+		Str("method", info.FullMethod).
+		Str("code", status.Code(err).String()).
+		Dur("duration", time.Since(start)).
+		Str("request_id", requestctx.ID(ctx)).
+		Msg("grpc request")
+	return resp, err
+}
+
+func authUnary(required bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authenticate(ctx, required, info.FullMethod)
+		if err != nil {
+			return nil, toStatus(err)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// authStream melindungi RPC streaming (reflection, health Watch) dengan aturan yang sama.
+func authStream(required bool) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if _, err := authenticate(ss.Context(), required, info.FullMethod); err != nil {
+			return toStatus(err)
+		}
+		return handler(srv, ss)
+	}
+}
+
+// authenticate memvalidasi JWT dari metadata "authorization" dan memasang actor
+// untuk audit log serta role untuk visibility field. Health check tidak butuh token supaya bisa dipakai load balancer.
+func authenticate(ctx context.Context, required bool, method string) (context.Context, error) {
+	if !required || strings.HasPrefix(method, "/grpc.health.v1.Health/") {
+		return ctx, nil
+	}
+	actor, roles, err := utils.Authenticate(firstMetadata(ctx, "authorization"))
+	if err != nil {
+		return ctx, err
+	}
+	return requestctx.WithRoles(requestctx.WithActor(ctx, actor), roles), nil
+}
+
+// recoverUnary mengubah panic di handler menjadi Internal alih-alih mematikan server.
+func recoverUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic in %s: %v", info.FullMethod, r)
+		}
+	}()
+	return handler(ctx, req)
+}
+
+// errorsUnary memetakan domain error dari services ke status gRPC.
+func errorsUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	resp, err := handler(ctx, req)
+	if err != nil {
+		if _, ok := apperr.As(err); !ok {
+			if _, isStatus := status.FromError(err); !isStatus {
+				log.Error().Err(err).Str("method", info.FullMethod).Str("request_id", requestctx.ID(ctx)).Msg("grpc request failed")
+			}
+		}
+		return nil, toStatus(err)
+	}
+	return resp, nil
+}
+
+func firstMetadata(ctx context.Context, key string) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if v := md.Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
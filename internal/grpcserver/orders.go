@@ -0,0 +1,92 @@
+package grpcserver
+
+import (
+	"context"
+
+	"northwind-api/internal/apperr"
+	pb "northwind-api/internal/pb/northwindv1"
+	"northwind-api/internal/services"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type orderServer struct {
+	pb.UnimplementedOrderServiceServer
+	preconditions
+	svc *services.OrderService
+}
+
+func (s *orderServer) ListOrders(ctx context.Context, req *pb.ListOrdersRequest) (*pb.ListOrdersResponse, error) {
+	orders, err := s.svc.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	filtered := orders[:0]
+	for _, o := range orders {
+		switch {
+		case req.CustomerId != "" && (o.CustomerID == nil || *o.CustomerID != req.CustomerId):
+		case req.EmployeeId != nil && (o.EmployeeID == nil || *o.EmployeeID != *req.EmployeeId):
+		default:
+			filtered = append(filtered, o)
+		}
+	}
+	items, page, err := paginate(filtered, req.Page)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.ListOrdersResponse{Page: page}
+	for _, o := range items {
+		resp.Orders = append(resp.Orders, orderToPB(ctx, o, nil))
+	}
+	return resp, nil
+}
+
+func (s *orderServer) GetOrder(ctx context.Context, req *pb.GetOrderRequest) (*pb.Order, error) {
+	o, err := s.svc.Get(ctx, int(req.OrderId))
+	if err != nil {
+		return nil, err
+	}
+	details, err := s.svc.Details(ctx, int(req.OrderId))
+	if err != nil {
+		return nil, err
+	}
+	return orderToPB(ctx, o, details), nil
+}
+
+func (s *orderServer) CreateOrder(ctx context.Context, req *pb.CreateOrderRequest) (*pb.Order, error) {
+	if req.Order == nil {
+		return nil, apperr.Validation("order", "is required")
+	}
+	o := orderFromPB(req.Order)
+	if err := s.svc.Create(ctx, &o); err != nil {
+		return nil, err
+	}
+	return orderToPB(ctx, o, nil), nil
+}
+
+func (s *orderServer) UpdateOrder(ctx context.Context, req *pb.UpdateOrderRequest) (*pb.Order, error) {
+	if req.Order == nil {
+		return nil, apperr.Validation("order", "is required")
+	}
+	o := orderFromPB(req.Order)
+	ctx, err := s.ifMatch(ctx, req.Etag)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.svc.Update(ctx, &o); err != nil {
+		return nil, err
+	}
+	return orderToPB(ctx, o, nil), nil
+}
+
+func (s *orderServer) DeleteOrder(ctx context.Context, req *pb.DeleteOrderRequest) (*emptypb.Empty, error) {
+	opts := services.DeleteOptions{Cascade: req.Cascade, ReassignTo: int(req.ReassignTo)}
+	ctx, err := s.ifMatch(ctx, req.Etag)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.svc.Delete(ctx, int(req.OrderId), opts); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
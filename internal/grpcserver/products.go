@@ -0,0 +1,88 @@
+package grpcserver
+
+import (
+	"context"
+
+	"northwind-api/internal/apperr"
+	pb "northwind-api/internal/pb/northwindv1"
+	"northwind-api/internal/services"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type productServer struct {
+	pb.UnimplementedProductServiceServer
+	preconditions
+	svc *services.ProductService
+}
+
+func (s *productServer) ListProducts(ctx context.Context, req *pb.ListProductsRequest) (*pb.ListProductsResponse, error) {
+	products, err := s.svc.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	filtered := products[:0]
+	for _, p := range products {
+		switch {
+		case req.CategoryId != nil && (p.CategoryID == nil || *p.CategoryID != int(*req.CategoryId)):
+		case req.SupplierId != nil && (p.SupplierID == nil || *p.SupplierID != int(*req.SupplierId)):
+		case req.Discontinued != nil && p.Discontinued != *req.Discontinued:
+		default:
+			filtered = append(filtered, p)
+		}
+	}
+	items, page, err := paginate(filtered, req.Page)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.ListProductsResponse{Page: page}
+	for _, p := range items {
+		resp.Products = append(resp.Products, productToPB(ctx, p))
+	}
+	return resp, nil
+}
+
+func (s *productServer) GetProduct(ctx context.Context, req *pb.GetProductRequest) (*pb.Product, error) {
+	p, err := s.svc.Get(ctx, int(req.ProductId))
+	if err != nil {
+		return nil, err
+	}
+	return productToPB(ctx, p), nil
+}
+
+func (s *productServer) CreateProduct(ctx context.Context, req *pb.CreateProductRequest) (*pb.Product, error) {
+	if req.Product == nil {
+		return nil, apperr.Validation("product", "is required")
+	}
+	p := productFromPB(req.Product)
+	if err := s.svc.Create(ctx, &p); err != nil {
+		return nil, err
+	}
+	return productToPB(ctx, p), nil
+}
+
+func (s *productServer) UpdateProduct(ctx context.Context, req *pb.UpdateProductRequest) (*pb.Product, error) {
+	if req.Product == nil {
+		return nil, apperr.Validation("product", "is required")
+	}
+	p := productFromPB(req.Product)
+	ctx, err := s.ifMatch(ctx, req.Etag)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.svc.Update(ctx, &p); err != nil {
+		return nil, err
+	}
+	return productToPB(ctx, p), nil
+}
+
+func (s *productServer) DeleteProduct(ctx context.Context, req *pb.DeleteProductRequest) (*emptypb.Empty, error) {
+	ctx, err := s.ifMatch(ctx, req.Etag)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.svc.Delete(ctx, int(req.ProductId)); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,96 @@
+package grpcserver
+
+import (
+	"context"
+
+	pb "northwind-api/internal/pb/northwindv1"
+	"northwind-api/internal/repositories"
+)
+
+// reportServer membaca langsung dari ReportStore, sama seperti ReportHandler.
+type reportServer struct {
+	pb.UnimplementedReportServiceServer
+	repo repositories.ReportStore
+}
+
+func (s *reportServer) GetSalesSummary(ctx context.Context, _ *pb.GetSalesSummaryRequest) (*pb.SalesSummary, error) {
+	r, err := s.repo.GetSalesSummary(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SalesSummary{
+		TotalRevenue:      r.TotalRevenue,
+		TotalOrders:       r.TotalOrders,
+		TotalCustomers:    r.TotalCustomers,
+		AverageOrderValue: r.AverageOrderValue,
+		FirstOrderDate:    r.FirstOrderDate,
+		LastOrderDate:     r.LastOrderDate,
+	}, nil
+}
+
+func (s *reportServer) ListTopCustomers(ctx context.Context, _ *pb.ListTopCustomersRequest) (*pb.ListTopCustomersResponse, error) {
+	rows, err := s.repo.GetTopCustomers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.ListTopCustomersResponse{}
+	for _, r := range rows {
+		resp.Customers = append(resp.Customers, &pb.TopCustomer{CustomerId: r.CustomerID, CompanyName: r.CompanyName, TotalPurchase: r.TotalPurchase})
+	}
+	return resp, nil
+}
+
+func (s *reportServer) ListTopProducts(ctx context.Context, _ *pb.ListTopProductsRequest) (*pb.ListTopProductsResponse, error) {
+	rows, err := s.repo.GetTopProducts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.ListTopProductsResponse{}
+	for _, r := range rows {
+		resp.Products = append(resp.Products, &pb.TopProduct{ProductId: int32(r.ProductID), ProductName: r.ProductName, TotalSold: int32(r.TotalSold)})
+	}
+	return resp, nil
+}
+
+func (s *reportServer) ListSalesByCategory(ctx context.Context, _ *pb.ListSalesByCategoryRequest) (*pb.ListSalesByCategoryResponse, error) {
+	rows, err := s.repo.GetSalesByCategory(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.ListSalesByCategoryResponse{}
+	for _, r := range rows {
+		resp.Categories = append(resp.Categories, &pb.CategorySales{CategoryId: int32(r.CategoryID), CategoryName: r.CategoryName, TotalSales: r.TotalSales})
+	}
+	return resp, nil
+}
+
+func (s *reportServer) ListMonthlySales(ctx context.Context, _ *pb.ListMonthlySalesRequest) (*pb.ListMonthlySalesResponse, error) {
+	rows, err := s.repo.GetMonthlySales(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.ListMonthlySalesResponse{}
+	for _, r := range rows {
+		resp.Months = append(resp.Months, &pb.MonthlySales{YearMonth: r.YearMonth, TotalSales: r.TotalSales, Orders: r.Orders})
+	}
+	return resp, nil
+}
+
+func (s *reportServer) ListInventoryStatus(ctx context.Context, _ *pb.ListInventoryStatusRequest) (*pb.ListInventoryStatusResponse, error) {
+	rows, err := s.repo.GetInventoryStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.ListInventoryStatusResponse{}
+	for _, r := range rows {
+		resp.Products = append(resp.Products, &pb.InventoryStatus{
+			ProductId:    r.ProductID,
+			ProductName:  r.ProductName,
+			UnitsInStock: r.UnitsInStock,
+			UnitsOnOrder: r.UnitsOnOrder,
+			ReorderLevel: r.ReorderLevel,
+			Status:       r.Status,
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,79 @@
+// Package grpcserver menyajikan API gRPC (proto/northwind/v1) untuk orders,
+// products, customers dan reports di atas services dan repositories yang sama
+// dengan API HTTP, plus gRPC health checking dan server reflection.
+package grpcserver
+
+import (
+	"context"
+
+	pb "northwind-api/internal/pb/northwindv1"
+	"northwind-api/internal/repositories"
+	"northwind-api/internal/services"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+type Deps struct {
+	Services *services.Services
+	Reports  repositories.ReportStore
+	// RequireAuth mewajibkan JWT di metadata "authorization", sama seperti
+	// route HTTP di production. Health check selalu terbuka.
+	RequireAuth bool
+	// IfMatchRequired menolak update/delete tanpa etag, sama seperti
+	// REQUIRE_IF_MATCH di HTTP.
+	IfMatchRequired bool
+}
+
+type Server struct {
+	*grpc.Server
+	health *health.Server
+}
+
+// serviceNames dilaporkan ke health check; "" adalah status server secara keseluruhan.
+var serviceNames = []string{
+	"",
+	pb.CustomerService_ServiceDesc.ServiceName,
+	pb.ProductService_ServiceDesc.ServiceName,
+	pb.OrderService_ServiceDesc.ServiceName,
+	pb.ReportService_ServiceDesc.ServiceName,
+}
+
+func New(d Deps) *Server {
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(requestInfo, logUnary, authUnary(d.RequireAuth), errorsUnary, recoverUnary),
+		grpc.ChainStreamInterceptor(authStream(d.RequireAuth)),
+	)
+	pre := preconditions{required: d.IfMatchRequired}
+	pb.RegisterCustomerServiceServer(srv, &customerServer{preconditions: pre, svc: d.Services.Customers})
+	pb.RegisterProductServiceServer(srv, &productServer{preconditions: pre, svc: d.Services.Products})
+	pb.RegisterOrderServiceServer(srv, &orderServer{preconditions: pre, svc: d.Services.Orders})
+	pb.RegisterReportServiceServer(srv, &reportServer{repo: d.Reports})
+
+	hs := health.NewServer()
+	for _, name := range serviceNames {
+		hs.SetServingStatus(name, healthpb.HealthCheckResponse_SERVING)
+	}
+	healthpb.RegisterHealthServer(srv, hs)
+	reflection.Register(srv)
+
+	return &Server{Server: srv, health: hs}
+}
+
+// Shutdown menandai server NOT_SERVING lalu menunggu RPC yang sedang berjalan
+// selesai; kalau ctx habis lebih dulu, koneksi diputus paksa.
+func (s *Server) Shutdown(ctx context.Context) {
+	s.health.Shutdown()
+	done := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.Stop()
+	}
+}
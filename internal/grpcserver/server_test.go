@@ -0,0 +1,233 @@
+package grpcserver_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"northwind-api/internal/grpcserver"
+	"northwind-api/internal/models"
+	pb "northwind-api/internal/pb/northwindv1"
+	"northwind-api/internal/repositories/memory"
+	"northwind-api/internal/services"
+	"northwind-api/internal/utils"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func strPtr(s string) *string { return &s }
+func i64Ptr(i int64) *int64   { return &i }
+
+// dial menjalankan server di atas bufconn dengan data contoh dan mengembalikan koneksi client.
+func dial(t *testing.T, requireAuth bool) *grpc.ClientConn {
+	t.Helper()
+	return dialWith(t, grpcserver.Deps{RequireAuth: requireAuth})
+}
+
+// dialWith seperti dial dengan opsi Deps sendiri; Services dan Reports diisi di sini.
+func dialWith(t *testing.T, d grpcserver.Deps) *grpc.ClientConn {
+	t.Helper()
+	s := memory.NewStore()
+	s.Customers.Seed(
+		models.Customer{CustomerID: "ALFKI", CompanyName: "Alfreds Futterkiste", Country: "Germany"},
+		models.Customer{CustomerID: "ANATR", CompanyName: "Ana Trujillo", Country: "Mexico"},
+		models.Customer{CustomerID: "ANTON", CompanyName: "Antonio Moreno", Country: "Mexico"},
+	)
+	s.Products.Seed(models.Product{ProductID: 1, ProductName: "Chai", UnitPrice: 18})
+	s.Orders.Seed(models.Order{OrderID: 10248, CustomerID: strPtr("ALFKI")})
+	s.Orders.SeedDetails(models.OrderDetail{OrderID: 10248, ProductID: 1, UnitPrice: 14, Quantity: 12})
+	s.Reports.SalesSummary = models.SalesSummary{TotalOrders: 1, TotalRevenue: 168}
+
+	repos := s.Repositories()
+	d.Services, d.Reports = services.New(repos, s.TxRunner(), nil), repos.Reports
+	srv := grpcserver.New(d)
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestCustomersOverGRPC(t *testing.T) {
+	ctx := context.Background()
+	customers := pb.NewCustomerServiceClient(dial(t, false))
+
+	page, err := customers.ListCustomers(ctx, &pb.ListCustomersRequest{Country: "mexico", Page: &pb.PageRequest{PageSize: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Customers) != 1 || page.Customers[0].CustomerId != "ANATR" || page.Page.TotalSize != 2 || page.Page.NextPageToken == "" {
+		t.Fatalf("first page = %v", page)
+	}
+	page, err = customers.ListCustomers(ctx, &pb.ListCustomersRequest{Country: "mexico", Page: &pb.PageRequest{PageSize: 1, PageToken: page.Page.NextPageToken}})
+	if err != nil || len(page.Customers) != 1 || page.Customers[0].CustomerId != "ANTON" || page.Page.NextPageToken != "" {
+		t.Fatalf("second page = %v, %v", page, err)
+	}
+
+	created, err := customers.CreateCustomer(ctx, &pb.CreateCustomerRequest{Customer: &pb.Customer{CompanyName: "Around the Horn"}})
+	if err != nil || created.CustomerId == "" || created.Etag == "" {
+		t.Fatalf("create = %v, %v", created, err)
+	}
+
+	_, err = customers.CreateCustomer(ctx, &pb.CreateCustomerRequest{Customer: &pb.Customer{ContactName: "Maria"}})
+	if status.Code(err) != codes.InvalidArgument || violation(err) != "company_name" {
+		t.Fatalf("create without company: %v", err)
+	}
+
+	alfki, err := customers.GetCustomer(ctx, &pb.GetCustomerRequest{CustomerId: "ALFKI"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	alfki.CompanyName = "Alfreds Futterkiste GmbH"
+	updated, err := customers.UpdateCustomer(ctx, &pb.UpdateCustomerRequest{Customer: alfki, Etag: alfki.Etag})
+	if err != nil || updated.CompanyName != "Alfreds Futterkiste GmbH" || updated.Etag == alfki.Etag {
+		t.Fatalf("update = %v, %v", updated, err)
+	}
+	// etag lama sudah tidak berlaku
+	_, err = customers.UpdateCustomer(ctx, &pb.UpdateCustomerRequest{Customer: alfki, Etag: alfki.Etag})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("update with stale etag: %v", err)
+	}
+	if _, err := customers.DeleteCustomer(ctx, &pb.DeleteCustomerRequest{CustomerId: created.CustomerId, Etag: created.Etag}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := customers.GetCustomer(ctx, &pb.GetCustomerRequest{CustomerId: created.CustomerId}); status.Code(err) != codes.NotFound {
+		t.Fatalf("get deleted customer: %v", err)
+	}
+}
+
+func TestOrdersAndReportsOverGRPC(t *testing.T) {
+	ctx := context.Background()
+	conn := dial(t, false)
+	orders := pb.NewOrderServiceClient(conn)
+
+	o, err := orders.GetOrder(ctx, &pb.GetOrderRequest{OrderId: 10248})
+	if err != nil || o.GetCustomerId() != "ALFKI" || len(o.Details) != 1 || o.Details[0].Quantity != 12 {
+		t.Fatalf("get order = %v, %v", o, err)
+	}
+
+	// order yang masih punya detail hanya bisa dihapus dengan cascade/reassign
+	_, err = orders.DeleteOrder(ctx, &pb.DeleteOrderRequest{OrderId: 10248})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("delete order with details: %v", err)
+	}
+	var blocked bool
+	for _, d := range status.Convert(err).Details() {
+		if pf, ok := d.(*errdetails.PreconditionFailure); ok && len(pf.Violations) > 0 {
+			blocked = true
+		}
+	}
+	if !blocked {
+		t.Errorf("delete error has no PreconditionFailure details: %v", status.Convert(err).Details())
+	}
+
+	_, err = orders.CreateOrder(ctx, &pb.CreateOrderRequest{Order: &pb.Order{CustomerId: strPtr("NOPE1"), EmployeeId: i64Ptr(1)}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("create order with missing refs: %v", err)
+	}
+
+	list, err := orders.ListOrders(ctx, &pb.ListOrdersRequest{Page: &pb.PageRequest{PageToken: "x"}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("list with bad token = %v, %v", list, err)
+	}
+
+	summary, err := pb.NewReportServiceClient(conn).GetSalesSummary(ctx, &pb.GetSalesSummaryRequest{})
+	if err != nil || summary.TotalOrders != 1 || summary.TotalRevenue != 168 {
+		t.Fatalf("sales summary = %v, %v", summary, err)
+	}
+}
+
+func TestIfMatchRequiredOverGRPC(t *testing.T) {
+	ctx := context.Background()
+	conn := dialWith(t, grpcserver.Deps{IfMatchRequired: true})
+	products := pb.NewProductServiceClient(conn)
+
+	chai, err := products.GetProduct(ctx, &pb.GetProductRequest{ProductId: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	chai.UnitPrice = 19
+	if _, err := products.UpdateProduct(ctx, &pb.UpdateProductRequest{Product: chai}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("update without etag: %v", err)
+	}
+	if _, err := pb.NewCustomerServiceClient(conn).DeleteCustomer(ctx, &pb.DeleteCustomerRequest{CustomerId: "ANTON"}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("delete without etag: %v", err)
+	}
+	updated, err := products.UpdateProduct(ctx, &pb.UpdateProductRequest{Product: chai, Etag: chai.Etag})
+	if err != nil || updated.UnitPrice != 19 {
+		t.Fatalf("update with etag = %v, %v", updated, err)
+	}
+}
+
+func TestAuthHealthAndReflection(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret")
+	ctx := context.Background()
+	conn := dial(t, true)
+
+	// health check tidak butuh token
+	h, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: "northwind.v1.OrderService"})
+	if err != nil || h.Status != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("health = %v, %v", h, err)
+	}
+
+	products := pb.NewProductServiceClient(conn)
+	if _, err := products.GetProduct(ctx, &pb.GetProductRequest{ProductId: 1}); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("without token: %v", err)
+	}
+	token, err := utils.GenerateJWT("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	authed := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+	p, err := products.GetProduct(authed, &pb.GetProductRequest{ProductId: 1})
+	if err != nil || p.ProductName != "Chai" {
+		t.Fatalf("with token = %v, %v", p, err)
+	}
+
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(authed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.Send(&reflectionpb.ServerReflectionRequest{MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{}}); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := map[string]bool{}
+	for _, s := range resp.GetListServicesResponse().GetService() {
+		found[s.Name] = true
+	}
+	for _, name := range []string{"northwind.v1.CustomerService", "northwind.v1.ProductService", "northwind.v1.OrderService", "northwind.v1.ReportService", "grpc.health.v1.Health"} {
+		if !found[name] {
+			t.Errorf("reflection does not list %s: %v", name, found)
+		}
+	}
+}
+
+// violation mengembalikan field pertama dari detail BadRequest di err.
+func violation(err error) string {
+	for _, d := range status.Convert(err).Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok && len(br.FieldViolations) > 0 {
+			return br.FieldViolations[0].Field
+		}
+	}
+	return ""
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"net/http"
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuditHandler struct {
+	Svc *services.AuditService
+}
+
+// @Summary List audit log entries
+// @Description Returns recorded create/update/delete/restore changes, newest first, with actor, request ID and a per-field before/after diff
+// @Tags Audit
+// @Produce json
+// @Security BearerAuth
+// @Param entity query string false "Resource name" Enums(customers, employees, shippers, products, categories, suppliers, orders)
+// @Param id query string false "Resource ID; requires entity"
+// @Param limit query int false "Maximum number of entries (default 100, max 1000)"
+// @Success 200 {array} models.AuditEntry
+// @Failure 400 {object} models.Problem
+// @Router /api/v1/audit [get]
+func (h *AuditHandler) GetAll(c *gin.Context) {
+	h.list(c, c.Query("entity"), c.Query("id"))
+}
+
+// @Summary Change history of a resource
+// @Description Returns the audit log entries of one resource, newest first
+// @Tags Audit
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Resource ID"
+// @Param limit query int false "Maximum number of entries (default 100, max 1000)"
+// @Success 200 {array} models.AuditEntry
+// @Failure 400 {object} models.Problem
+// @Router /api/v1/customers/{id}/history [get]
+// @Router /api/v1/employees/{id}/history [get]
+// @Router /api/v1/shippers/{id}/history [get]
+// @Router /api/v1/products/{id}/history [get]
+// @Router /api/v1/categories/{id}/history [get]
+// @Router /api/v1/suppliers/{id}/history [get]
+// @Router /api/v1/orders/{id}/history [get]
+func (h *AuditHandler) History(entity string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		h.list(c, entity, c.Param("id"))
+	}
+}
+
+func (h *AuditHandler) list(c *gin.Context, entity, id string) {
+	limit := 0
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			respondError(c, apperr.Validation("limit", "must be a positive integer"))
+			return
+		}
+		limit = n
+	}
+	entries, err := h.Svc.List(c.Request.Context(), entity, id, limit)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondJSON(c, http.StatusOK, entries)
+}
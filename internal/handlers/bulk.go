@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/middleware"
+	"northwind-api/internal/models"
+	"northwind-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+const ContentTypeNDJSON = "application/x-ndjson"
+
+// maxBulkSize membatasi ukuran body bulk; batas jumlah operasi ada di
+// services.MaxBulkOperations.
+const maxBulkSize = 10 << 20
+
+type bulkRunner func(ctx context.Context, mode services.BulkMode, ops []models.BulkOperation) ([]services.BulkResult, error)
+
+// handleBulk membaca operasi dari body, menjalankannya lewat run dan menulis
+// hasil per item. Batch atomic yang gagal dijawab problem+json berisi hasil
+// yang sama, supaya client tahu operasi mana penyebabnya.
+func handleBulk(c *gin.Context, run bulkRunner) {
+	mode, err := services.ParseBulkMode(c.Query("mode"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	ops, ok := bindBulk(c)
+	if !ok {
+		return
+	}
+	results, err := run(c.Request.Context(), mode, ops)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	resp := models.BulkResponse{Mode: string(mode), Results: make([]models.BulkItemResult, len(results))}
+	failedStatus := 0
+	for i, r := range results {
+		item := bulkItem(c, r)
+		resp.Results[i] = item
+		if r.Err == nil {
+			resp.Succeeded++
+			continue
+		}
+		if !errors.Is(r.Err, services.ErrRolledBack) {
+			resp.Failed++
+			if failedStatus == 0 {
+				failedStatus = item.Status
+			}
+		}
+	}
+
+	switch {
+	case failedStatus == 0:
+		respondJSON(c, http.StatusOK, resp)
+	case mode == services.BulkBestEffort:
+		respondJSON(c, http.StatusMultiStatus, resp)
+	default:
+		p := models.BulkProblem{
+			Problem: models.Problem{
+				Type:     "/problems/bulk-operation-failed",
+				Title:    "Bulk operation failed",
+				Status:   failedStatus,
+				Detail:   fmt.Sprintf("%d of %d operations failed; no changes were applied", resp.Failed, len(results)),
+				Instance: middleware.RequestIDFrom(c.Request.Context()),
+			},
+			Results: resp.Results,
+		}
+		c.Header("Content-Type", middleware.ContentTypeProblem)
+		respondJSON(c, p.Status, p)
+	}
+}
+
+func bulkItem(c *gin.Context, r services.BulkResult) models.BulkItemResult {
+	item := models.BulkItemResult{Index: r.Index, Op: r.Op, ID: r.ID, Status: http.StatusOK}
+	switch {
+	case r.Err == nil:
+		if r.Op == services.BulkCreate {
+			item.Status = http.StatusCreated
+		}
+	case errors.Is(r.Err, services.ErrRolledBack):
+		item.Status = http.StatusFailedDependency
+		item.Error = &models.Problem{
+			Type:   "/problems/rolled-back",
+			Title:  "Rolled back",
+			Status: http.StatusFailedDependency,
+			Detail: r.Err.Error(),
+		}
+	default:
+		p := middleware.ProblemFor(r.Err)
+		if p.Status >= http.StatusInternalServerError {
+			log.Error().Err(r.Err).
+				Str("path", c.Request.URL.Path).
+				Int("index", r.Index).
+				Str("request_id", middleware.RequestIDFrom(c.Request.Context())).
+				Msg("bulk operation failed")
+		}
+		item.Status, item.Error = p.Status, &p
+	}
+	return item
+}
+
+// bindBulk membaca body berupa JSON array atau NDJSON (satu operasi per baris).
+func bindBulk(c *gin.Context) ([]models.BulkOperation, bool) {
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxBulkSize))
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		middleware.WriteProblem(c, http.StatusRequestEntityTooLarge, fmt.Sprintf("request body must be at most %d MB", maxBulkSize>>20))
+		c.Abort()
+		return nil, false
+	}
+	if err != nil || len(bytes.TrimSpace(body)) == 0 {
+		respondError(c, apperr.Validation("", "request body is required"))
+		return nil, false
+	}
+
+	var ops []models.BulkOperation
+	switch c.ContentType() {
+	case ContentTypeNDJSON, "application/ndjson":
+		ops, err = parseNDJSON(body)
+	case "application/json", "":
+		err = json.Unmarshal(body, &ops)
+		if err != nil {
+			err = apperr.Validation("", "body must be a JSON array of operations").Wrap(err)
+		}
+	default:
+		err = apperr.UnsupportedMediaType("bulk endpoints accept application/json or %s", ContentTypeNDJSON)
+	}
+	if err != nil {
+		respondError(c, err)
+		return nil, false
+	}
+	return ops, true
+}
+
+// parseNDJSON berhenti begitu operasi melewati services.MaxBulkOperations,
+// tanpa mem-parse sisa body.
+func parseNDJSON(body []byte) ([]models.BulkOperation, error) {
+	var ops []models.BulkOperation
+	sc := bufio.NewScanner(bytes.NewReader(body))
+	sc.Buffer(make([]byte, 0, 64*1024), maxBulkSize)
+	for line := 1; sc.Scan(); line++ {
+		text := bytes.TrimSpace(sc.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		if len(ops) == services.MaxBulkOperations {
+			return nil, apperr.Validation("", "at most %d operations are allowed per request", services.MaxBulkOperations)
+		}
+		var op models.BulkOperation
+		if err := json.Unmarshal(text, &op); err != nil {
+			return nil, apperr.Validation(fmt.Sprintf("line %d", line), "must be a JSON object").Wrap(err)
+		}
+		ops = append(ops, op)
+	}
+	return ops, sc.Err()
+}
@@ -3,14 +3,14 @@ package handlers
 import (
 	"net/http"
 	"northwind-api/internal/models"
-	"northwind-api/internal/repositories"
-	"northwind-api/internal/utils"
+	"northwind-api/internal/services"
 
 	"github.com/gin-gonic/gin"
 )
 
 type CategoryHandler struct {
-	Repo *repositories.CategoryRepository
+	Svc      *services.CategoryService
+	Includes *services.IncludeService
 }
 
 // @Summary Get all categories
@@ -18,16 +18,19 @@ type CategoryHandler struct {
 // @Tags Categories
 // @Produce json
 // @Security BearerAuth
+// @Param include_deleted query bool false "Also return soft-deleted rows"
+// @Param include query string false "Related resources to embed: products"
+// @Param fields[category] query string false "Sparse fieldset, e.g. category_id,category_name"
 // @Success 200 {array} models.Category
-// @Failure 500 {object} models.ErrorResponse
+// @Failure 500 {object} models.Problem
 // @Router /api/v1/categories [get]
 func (h *CategoryHandler) GetAll(c *gin.Context) {
-	categories, err := h.Repo.GetAllCategories(c.Request.Context())
+	categories, err := h.Svc.List(c.Request.Context())
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, categories)
+	respondList(c, h.Includes, "category", categories)
 }
 
 // @Summary Get category by ID
@@ -36,17 +39,24 @@ func (h *CategoryHandler) GetAll(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Category ID"
+// @Param include_deleted query bool false "Also return soft-deleted rows"
+// @Param include query string false "Related resources to embed: products"
+// @Param fields[category] query string false "Sparse fieldset, e.g. category_id,category_name"
 // @Success 200 {object} models.Category
-// @Failure 404 {object} models.ErrorResponse
+// @Header 200 {string} ETag "Resource version for If-Match / If-None-Match"
+// @Failure 404 {object} models.Problem
 // @Router /api/v1/categories/{id} [get]
 func (h *CategoryHandler) GetOne(c *gin.Context) {
-	id := c.Param("id")
-	category, err := h.Repo.GetCategoryByID(c.Request.Context(), utils.ParseInt(id))
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
+	category, err := h.Svc.Get(c.Request.Context(), id)
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, category)
+	respondOne(c, h.Includes, "category", category)
 }
 
 // @Summary Create a new category
@@ -56,23 +66,23 @@ func (h *CategoryHandler) GetOne(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param category body models.Category true "Category to create"
+// @Param Idempotency-Key header string false "Client-generated key; retries with the same key replay the first response"
 // @Success 201 {object} models.SuccessResponse
-// @Failure 500 {object} models.ErrorResponse
+// @Failure 400 {object} models.Problem
+// @Failure 409 {object} models.Problem
+// @Failure 500 {object} models.Problem
 // @Router /api/v1/categories [post]
 func (h *CategoryHandler) Create(c *gin.Context) {
 	var category models.Category
-	if err := c.ShouldBindJSON(&category); err != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &category) {
 		return
 	}
 
-	id, err := h.Repo.CreateCategory(c.Request.Context(), &category)
-	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.Svc.Create(c.Request.Context(), &category); err != nil {
+		respondError(c, err)
 		return
 	}
-	category.CategoryID = id
-	c.JSON(http.StatusCreated, gin.H{"message": "Category created successfully"})
+	respondJSON(c, http.StatusCreated, gin.H{"message": "Category created successfully"})
 }
 
 // @Summary Update a category
@@ -82,41 +92,165 @@ func (h *CategoryHandler) Create(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Category ID"
+// @Param If-Match header string false "ETag from a previous GET"
 // @Param category body models.Category true "Category to update"
 // @Success 200 {object} models.SuccessResponse
-// @Failure 500 {object} models.ErrorResponse
+// @Failure 400 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Failure 412 {object} models.Problem
+// @Failure 428 {object} models.Problem
 // @Router /api/v1/categories/{id} [put]
 func (h *CategoryHandler) Update(c *gin.Context) {
-	id := c.Param("id")
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
 	var category models.Category
-	if err := c.ShouldBindJSON(&category); err != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+	if !bindJSON(c, &category) {
 		return
 	}
-	category.CategoryID = int64(utils.ParseInt(id))
-	if err := h.Repo.UpdateCategory(c.Request.Context(), &category); err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	category.CategoryID = int64(id)
+	if err := h.Svc.Update(c.Request.Context(), &category); err != nil {
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"message": "Category updated successfully"})
+	respondJSON(c, http.StatusOK, gin.H{"message": "Category updated successfully"})
+}
+
+// @Summary Partially update a category
+// @Description Applies a JSON Merge Patch (RFC 7396) or JSON Patch (RFC 6902) and updates only the supplied fields
+// @Tags Categories
+// @Accept application/merge-patch+json,application/json-patch+json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Category ID"
+// @Param If-Match header string false "ETag from a previous GET"
+// @Param patch body object true "Merge patch object or JSON Patch operations"
+// @Success 200 {object} models.Category
+// @Failure 400 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 415 {object} models.Problem
+// @Failure 412 {object} models.Problem
+// @Failure 428 {object} models.Problem
+// @Router /api/v1/categories/{id} [patch]
+func (h *CategoryHandler) Patch(c *gin.Context) {
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
+	p, ok := bindPatch(c)
+	if !ok {
+		return
+	}
+	category, err := h.Svc.Patch(c.Request.Context(), id, p)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondResource(c, category)
 }
 
 // @Summary Delete a category
-// @Description Deletes a category by ID
+// @Description Soft-deletes a category by ID; it stays referenced by history and can be restored
 // @Tags Categories
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Category ID"
+// @Param If-Match header string false "ETag from a previous GET"
+// @Param cascade query bool false "Also delete products in the category"
+// @Param reassign_to query int false "Move products in the category to this category instead"
 // @Success 200 {object} models.SuccessResponse
-// @Failure 500 {object} models.ErrorResponse
+// @Failure 500 {object} models.Problem
+// @Failure 409 {object} models.Problem "Still referenced; blockers lists the dependents"
+// @Failure 412 {object} models.Problem
+// @Failure 428 {object} models.Problem
 // @Router /api/v1/categories/{id} [delete]
 func (h *CategoryHandler) Delete(c *gin.Context) {
-	id := c.Param("id")
-	err := h.Repo.DeleteCategory(c.Request.Context(), utils.ParseInt(id))
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
+	opts, ok := bindDeleteOptions(c)
+	if !ok {
+		return
+	}
+	err := h.Svc.Delete(c.Request.Context(), id, opts)
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Category deleted successfully"})
+	respondJSON(c, http.StatusOK, gin.H{"message": "Category deleted successfully"})
+}
+
+// @Summary Restore a deleted category
+// @Description Restores a soft-deleted category
+// @Tags Categories
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Category ID"
+// @Success 200 {object} models.Category
+// @Failure 404 {object} models.Problem
+// @Failure 409 {object} models.Problem
+// @Router /api/v1/categories/{id}/restore [post]
+func (h *CategoryHandler) Restore(c *gin.Context) {
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
+	category, err := h.Svc.Restore(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondResource(c, category)
+}
+
+// @Summary Get a category's picture
+// @Description Returns the picture bytes with the detected content type (JPEG, PNG, GIF, BMP or WebP). The OLE header of the original Northwind category pictures is stripped. With ?size= returns a thumbnail whose longest side is at most that many pixels (JPEG for JPEG sources, PNG otherwise).
+// @Tags Categories
+// @Produce image/jpeg,image/png,image/gif,image/bmp,image/webp
+// @Security BearerAuth
+// @Param id path int true "Category ID"
+// @Param size query int false "Thumbnail size in pixels (16-512)"
+// @Success 200 {file} binary
+// @Failure 400 {object} models.Problem
+// @Failure 404 {object} models.Problem "Category not found or has no picture"
+// @Router /api/v1/categories/{id}/picture [get]
+func (h *CategoryHandler) Picture(c *gin.Context) {
+	getImage(c, h.Svc.Picture)
+}
+
+// @Summary Upload a category's picture
+// @Description Replaces the picture with a multipart upload (field "file"). Accepts JPEG, PNG, GIF, BMP and WebP up to 5 MB and 4096x4096 pixels; the content type is detected from the bytes.
+// @Tags Categories
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Category ID"
+// @Param If-Match header string false "ETag from a previous GET of the image"
+// @Param file formData file true "Image file"
+// @Success 200 {object} models.ImageInfo
+// @Failure 400 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 413 {object} models.Problem
+// @Failure 412 {object} models.Problem
+// @Router /api/v1/categories/{id}/picture [put]
+func (h *CategoryHandler) UploadPicture(c *gin.Context) {
+	putImage(c, h.Svc.SetPicture)
+}
+
+// @Summary Delete a category's picture
+// @Description Removes the picture; the category itself is kept
+// @Tags Categories
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Category ID"
+// @Param If-Match header string false "ETag from a previous GET of the image"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 404 {object} models.Problem "Category not found or has no picture"
+// @Failure 412 {object} models.Problem
+// @Router /api/v1/categories/{id}/picture [delete]
+func (h *CategoryHandler) DeletePicture(c *gin.Context) {
+	deleteImage(c, h.Svc.SetPicture, "Picture deleted successfully")
 }
@@ -2,14 +2,17 @@ package handlers
 
 import (
 	"net/http"
+	"northwind-api/internal/apperr"
 	"northwind-api/internal/models"
-	"northwind-api/internal/repositories"
+	"northwind-api/internal/services"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
 
 type CustomerHandler struct {
-	Repo *repositories.CustomerRepository
+	Svc      *services.CustomerService
+	Includes *services.IncludeService
 }
 
 // @Summary Get all customers
@@ -17,16 +20,29 @@ type CustomerHandler struct {
 // @Tags Customers
 // @Produce json
 // @Security BearerAuth
+// @Param include_deleted query bool false "Also return soft-deleted rows"
+// @Param q query string false "Full-text search; returns up to 100 best matches, most relevant first"
+// @Param include query string false "Related resources to embed: orders"
+// @Param fields[customer] query string false "Sparse fieldset, e.g. customer_id,company_name"
 // @Success 200 {array} models.Customer
-// @Failure 404 {object} models.ErrorResponse
+// @Failure 404 {object} models.Problem
 // @Router /api/v1/customers [get]
 func (h *CustomerHandler) GetAll(c *gin.Context) {
-	customers, err := h.Repo.GetAllCustomers(c.Request.Context())
+	ctx := c.Request.Context()
+	var (
+		customers []models.Customer
+		err       error
+	)
+	if q := c.Query("q"); q != "" {
+		customers, err = h.Svc.Search(ctx, q)
+	} else {
+		customers, err = h.Svc.List(ctx)
+	}
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, customers)
+	respondList(c, h.Includes, "customer", customers)
 }
 
 // @Summary Get customer by ID
@@ -35,45 +51,48 @@ func (h *CustomerHandler) GetAll(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Customer ID"
+// @Param include_deleted query bool false "Also return soft-deleted rows"
+// @Param include query string false "Related resources to embed: orders"
+// @Param fields[customer] query string false "Sparse fieldset, e.g. customer_id,company_name"
 // @Success 200 {object} models.Customer
-// @Failure 404 {object} models.ErrorResponse
+// @Header 200 {string} ETag "Resource version for If-Match / If-None-Match"
+// @Failure 404 {object} models.Problem
 // @Router /api/v1/customers/{id} [get]
 func (h *CustomerHandler) GetOne(c *gin.Context) {
 	id := c.Param("id")
-	customer, err := h.Repo.GetCustomerByID(c.Request.Context(), id)
+	customer, err := h.Svc.Get(c.Request.Context(), id)
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, customer)
+	respondOne(c, h.Includes, "customer", customer)
 }
 
 // @Summary Create a new customer
-// @Description Creates a new customer
+// @Description Creates a new customer. How customer_id is assigned depends on CUSTOMER_ID_STRATEGY: classic derives a 5-letter code from company_name, uuid generates a UUID (customer_id in the body is ignored for both), client requires a unique 5-character uppercase customer_id.
 // @Tags Customers
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param customer body models.Customer true "Customer to create"
+// @Param Idempotency-Key header string false "Client-generated key; retries with the same key replay the first response"
 // @Success 201 {object} models.Customer
-// @Failure 500 {object} models.ErrorResponse
+// @Failure 400 {object} models.Problem
+// @Failure 409 {object} models.Problem
+// @Failure 500 {object} models.Problem
 // @Router /api/v1/customers [post]
 func (h *CustomerHandler) Create(c *gin.Context) {
 	var customer models.Customer
 
-	if err := c.ShouldBindJSON(&customer); err != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+	if !bindJSON(c, &customer) {
 		return
 	}
-	id, err := h.Repo.CreateCustomer(c.Request.Context(), &customer)
-	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.Svc.Create(c.Request.Context(), &customer); err != nil {
+		respondError(c, err)
 		return
 	}
 
-	customer.CustomerID = id
-
-	c.JSON(http.StatusCreated, customer)
+	respondJSON(c, http.StatusCreated, customer)
 }
 
 // @Summary Update an existing customer
@@ -83,43 +102,214 @@ func (h *CustomerHandler) Create(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Customer ID"
+// @Param If-Match header string false "ETag from a previous GET"
 // @Param customer body models.Customer true "Customer to update"
 // @Success 200 {object} models.Customer
-// @Failure 500 {object} models.ErrorResponse
+// @Failure 400 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Failure 412 {object} models.Problem
+// @Failure 428 {object} models.Problem
 // @Router /api/v1/customers/{id} [put]
 func (h *CustomerHandler) Update(c *gin.Context) {
 	id := c.Param("id")
 	var customer models.Customer
 
-	if err := c.ShouldBindJSON(&customer); err != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+	if !bindJSON(c, &customer) {
 		return
 	}
 	customer.CustomerID = id
-	if err := h.Repo.UpdateCustomer(c.Request.Context(), &customer); err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.Svc.Update(c.Request.Context(), &customer); err != nil {
+		respondError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "customer updated successfully"})
+	respondJSON(c, http.StatusOK, gin.H{"message": "customer updated successfully"})
+}
+
+// @Summary Partially update a customer
+// @Description Applies a JSON Merge Patch (RFC 7396) or JSON Patch (RFC 6902) and updates only the supplied fields
+// @Tags Customers
+// @Accept application/merge-patch+json,application/json-patch+json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Customer ID"
+// @Param If-Match header string false "ETag from a previous GET"
+// @Param patch body object true "Merge patch object or JSON Patch operations"
+// @Success 200 {object} models.Customer
+// @Failure 400 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 415 {object} models.Problem
+// @Failure 412 {object} models.Problem
+// @Failure 428 {object} models.Problem
+// @Router /api/v1/customers/{id} [patch]
+func (h *CustomerHandler) Patch(c *gin.Context) {
+	id := c.Param("id")
+	p, ok := bindPatch(c)
+	if !ok {
+		return
+	}
+	customer, err := h.Svc.Patch(c.Request.Context(), id, p)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondResource(c, customer)
 }
 
 // @Summary Delete a customer
-// @Description Deletes a customer by ID
+// @Description Soft-deletes a customer by ID; it stays referenced by history and can be restored
 // @Tags Customers
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Customer ID"
+// @Param If-Match header string false "ETag from a previous GET"
 // @Success 200 {object} models.Customer
-// @Failure 500 {object} models.ErrorResponse
+// @Failure 500 {object} models.Problem
+// @Failure 412 {object} models.Problem
+// @Failure 428 {object} models.Problem
 // @Router /api/v1/customers/{id} [delete]
 func (h *CustomerHandler) Delete(c *gin.Context) {
 	id := c.Param("id")
-	err := h.Repo.DeleteCustomer(c.Request.Context(), id)
+	err := h.Svc.Delete(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondJSON(c, http.StatusOK, gin.H{"message": "customer deleted successfully"})
+}
+
+// @Summary Restore a deleted customer
+// @Description Restores a soft-deleted customer
+// @Tags Customers
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Customer ID"
+// @Success 200 {object} models.Customer
+// @Failure 404 {object} models.Problem
+// @Failure 409 {object} models.Problem
+// @Router /api/v1/customers/{id}/restore [post]
+func (h *CustomerHandler) Restore(c *gin.Context) {
+	id := c.Param("id")
+	customer, err := h.Svc.Restore(c.Request.Context(), id)
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
+	respondResource(c, customer)
+}
 
-	c.JSON(http.StatusOK, gin.H{"message": "customer deleted successfully"})
+// @Summary Find duplicate customers
+// @Description Returns pairs of live customers that are probably the same company, highest score first. Company names are compared without accents, punctuation and legal forms such as GmbH or Inc.; phone numbers by their digits (with or without country code); addresses after expanding abbreviations such as "Str." and "Ave.", together with postal code and city. "matches" holds the similarity of each signal; signals missing on either side are left out of the score.
+// @Tags Customers
+// @Produce json
+// @Security BearerAuth
+// @Param min_score query number false "Minimum score between 0 and 1 (default 0.8)"
+// @Success 200 {array} models.CustomerDuplicate
+// @Failure 400 {object} models.Problem
+// @Router /api/v1/customers/duplicates [get]
+func (h *CustomerHandler) Duplicates(c *gin.Context) {
+	minScore := 0.0
+	if v := c.Query("min_score"); v != "" {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			respondError(c, apperr.Validation("min_score", "must be a number"))
+			return
+		}
+		minScore = f
+	}
+	dups, err := h.Svc.Duplicates(c.Request.Context(), minScore)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondJSON(c, http.StatusOK, dups)
+}
+
+// @Summary Merge duplicate customers
+// @Description Merges the duplicate customers into the customer in the path in one transaction: their orders are moved to it, blank fields are optionally filled from the duplicates, and the duplicates are soft-deleted. Every step is written to the audit log; the duplicates get a "merged" entry with merged_into.
+// @Tags Customers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID of the surviving customer"
+// @Param If-Match header string false "ETag of the surviving customer from a previous GET"
+// @Param merge body models.CustomerMergeRequest true "Customers to merge"
+// @Success 200 {object} models.CustomerMergeResult
+// @Failure 400 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 412 {object} models.Problem
+// @Failure 428 {object} models.Problem
+// @Router /api/v1/customers/{id}/merge [post]
+func (h *CustomerHandler) Merge(c *gin.Context) {
+	var req models.CustomerMergeRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	res, err := h.Svc.Merge(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondJSON(c, http.StatusOK, res)
+}
+
+// @Summary Export a customer's personal data
+// @Description Returns everything stored about a customer, also when deleted: the customer record, their orders with detail lines and ship addresses, and the audit history of the customer and those orders. ?format=zip returns a ZIP with one JSON file per part.
+// @Tags Customers
+// @Produce json,application/zip
+// @Security BearerAuth
+// @Param id path string true "Customer ID"
+// @Param format query string false "json (default) or zip" Enums(json, zip)
+// @Success 200 {object} models.CustomerDataExport
+// @Failure 400 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Router /api/v1/customers/{id}/data-export [get]
+func (h *CustomerHandler) ExportData(c *gin.Context) {
+	id := c.Param("id")
+	export, err := h.Svc.ExportData(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondExport(c, "customer-"+id, export)
+}
+
+// @Summary Erase a customer's personal data
+// @Description Pseudonymizes a customer, also when deleted: contact_name and the ship_name of their orders become a random "anon-…" pseudonym, and contact title, address, postal code, phone, fax and the orders' ship address and postal code are cleared. Company name, city, region, country and all order amounts are kept for reports. Old values are replaced with "[erased]" in the audit log, event log and webhook deliveries. Cannot be undone. Requires the admin role.
+// @Tags Customers
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Customer ID"
+// @Success 200 {object} models.ErasureResult
+// @Failure 403 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Router /api/v1/customers/{id}/erase [post]
+func (h *CustomerHandler) Erase(c *gin.Context) {
+	res, err := h.Svc.Erase(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondJSON(c, http.StatusOK, res)
+}
+
+// @Summary Bulk create/update/delete customers
+// @Description Runs create, update, patch (merge patch) and delete operations from a JSON array or NDJSON stream. mode=atomic (default) applies all or nothing; mode=best_effort applies each operation on its own and answers 207 when some fail.
+// @Tags Customers
+// @Accept json,application/x-ndjson
+// @Produce json
+// @Security BearerAuth
+// @Param mode query string false "atomic or best_effort" Enums(atomic, best_effort)
+// @Param operations body []models.BulkOperation true "Operations; data holds a models.Customer"
+// @Param Idempotency-Key header string false "Client-generated key; retries with the same key replay the first response"
+// @Success 200 {object} models.BulkResponse
+// @Success 207 {object} models.BulkResponse
+// @Failure 400 {object} models.BulkProblem
+// @Failure 409 {object} models.BulkProblem
+// @Failure 413 {object} models.Problem
+// @Failure 415 {object} models.Problem
+// @Router /api/v1/customers/bulk [post]
+func (h *CustomerHandler) Bulk(c *gin.Context) {
+	handleBulk(c, h.Svc.Bulk)
 }
@@ -3,14 +3,15 @@ package handlers
 import (
 	"net/http"
 	"northwind-api/internal/models"
-	"northwind-api/internal/repositories"
-	"northwind-api/internal/utils"
+	"northwind-api/internal/services"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
 
 type EmployeeHandler struct {
-	Repo *repositories.EmployeeRepository
+	Svc      *services.EmployeeService
+	Includes *services.IncludeService
 }
 
 // @Summary Get all employees
@@ -18,16 +19,19 @@ type EmployeeHandler struct {
 // @Tags Employees
 // @Produce json
 // @Security BearerAuth
+// @Param include_deleted query bool false "Also return soft-deleted rows"
+// @Param include query string false "Related resources to embed: manager,territories"
+// @Param fields[employee] query string false "Sparse fieldset, e.g. employee_id,last_name"
 // @Success 200 {array} models.Employee
-// @Failure 500 {object} models.ErrorResponse
+// @Failure 500 {object} models.Problem
 // @Router /api/v1/employees [get]
 func (h *EmployeeHandler) GetAll(c *gin.Context) {
-	employees, err := h.Repo.GetAllEmployees(c.Request.Context())
+	employees, err := h.Svc.List(c.Request.Context())
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, employees)
+	respondList(c, h.Includes, "employee", employees)
 }
 
 // @Summary Get employee by ID
@@ -36,17 +40,24 @@ func (h *EmployeeHandler) GetAll(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Employee ID"
+// @Param include_deleted query bool false "Also return soft-deleted rows"
+// @Param include query string false "Related resources to embed: manager,territories"
+// @Param fields[employee] query string false "Sparse fieldset, e.g. employee_id,last_name"
 // @Success 200 {object} models.Employee
-// @Failure 500 {object} models.ErrorResponse
+// @Header 200 {string} ETag "Resource version for If-Match / If-None-Match"
+// @Failure 500 {object} models.Problem
 // @Router /api/v1/employees/{id} [get]
 func (h *EmployeeHandler) GetOne(c *gin.Context) {
-	id := c.Param("id")
-	employee, err := h.Repo.GetEmployeeByID(c.Request.Context(), utils.ParseInt(id))
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
+	employee, err := h.Svc.Get(c.Request.Context(), id)
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, employee)
+	respondOne(c, h.Includes, "employee", employee)
 }
 
 // @Summary Create a new employee
@@ -56,24 +67,24 @@ func (h *EmployeeHandler) GetOne(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param employee body models.Employee true "Employee to create"
+// @Param Idempotency-Key header string false "Client-generated key; retries with the same key replay the first response"
 // @Success 201 {object} models.Employee
-// @Failure 500 {object} models.ErrorResponse
+// @Failure 400 {object} models.Problem
+// @Failure 409 {object} models.Problem
+// @Failure 500 {object} models.Problem
 // @Router /api/v1/employees [post]
 func (h *EmployeeHandler) Create(c *gin.Context) {
 	var emp models.Employee
-	if err := c.ShouldBindJSON(&emp); err != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &emp) {
 		return
 	}
 
-	id, err := h.Repo.CreateEmployee(c.Request.Context(), emp)
-	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.Svc.Create(c.Request.Context(), &emp); err != nil {
+		respondError(c, err)
 		return
 	}
 
-	emp.EmployeeID = int(id)
-	c.JSON(http.StatusCreated, gin.H{"message": "employee created successfully"})
+	respondJSON(c, http.StatusCreated, gin.H{"message": "employee created successfully"})
 }
 
 // @Summary Update an employee
@@ -83,40 +94,204 @@ func (h *EmployeeHandler) Create(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Employee ID"
+// @Param If-Match header string false "ETag from a previous GET"
 // @Param employee body models.Employee true "Employee to update"
 // @Success 200 {object} models.Employee
-// @Failure 500 {object} models.ErrorResponse
+// @Failure 400 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Failure 412 {object} models.Problem
+// @Failure 428 {object} models.Problem
 // @Router /api/v1/employees/{id} [put]
 func (h *EmployeeHandler) Update(c *gin.Context) {
-	id := c.Param("id")
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
 	var emp models.Employee
-	if err := c.ShouldBindJSON(&emp); err != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+	if !bindJSON(c, &emp) {
+		return
+	}
+	emp.EmployeeID = id
+	if err := h.Svc.Update(c.Request.Context(), &emp); err != nil {
+		respondError(c, err)
+		return
+	}
+	respondJSON(c, http.StatusOK, gin.H{"message": "employee updated successfully"})
+}
+
+// @Summary Partially update a employee
+// @Description Applies a JSON Merge Patch (RFC 7396) or JSON Patch (RFC 6902) and updates only the supplied fields
+// @Tags Employees
+// @Accept application/merge-patch+json,application/json-patch+json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Param If-Match header string false "ETag from a previous GET"
+// @Param patch body object true "Merge patch object or JSON Patch operations"
+// @Success 200 {object} models.Employee
+// @Failure 400 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 415 {object} models.Problem
+// @Failure 412 {object} models.Problem
+// @Failure 428 {object} models.Problem
+// @Router /api/v1/employees/{id} [patch]
+func (h *EmployeeHandler) Patch(c *gin.Context) {
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
+	p, ok := bindPatch(c)
+	if !ok {
 		return
 	}
-	emp.EmployeeID = utils.ParseInt(id)
-	if err := h.Repo.UpdateEmployee(c.Request.Context(), &emp); err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	employee, err := h.Svc.Patch(c.Request.Context(), id, p)
+	if err != nil {
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"message": "employee updated successfully"})
+	respondResource(c, employee)
 }
 
 // @Summary Delete an employee
-// @Description Deletes an employee by ID
+// @Description Soft-deletes an employee by ID; it stays referenced by history and can be restored
 // @Tags Employees
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Employee ID"
+// @Param If-Match header string false "ETag from a previous GET"
 // @Success 200 {object} models.SuccessResponse
-// @Failure 500 {object} models.ErrorResponse
+// @Failure 500 {object} models.Problem
+// @Failure 412 {object} models.Problem
+// @Failure 428 {object} models.Problem
 // @Router /api/v1/employees/{id} [delete]
 func (h *EmployeeHandler) Delete(c *gin.Context) {
-	id := c.Param("id")
-	err := h.Repo.DeleteEmployee(c.Request.Context(), utils.ParseInt(id))
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
+	err := h.Svc.Delete(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondJSON(c, http.StatusOK, gin.H{"message": "employee deleted successfully"})
+}
+
+// @Summary Restore a deleted employee
+// @Description Restores a soft-deleted employee
+// @Tags Employees
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Success 200 {object} models.Employee
+// @Failure 404 {object} models.Problem
+// @Failure 409 {object} models.Problem
+// @Router /api/v1/employees/{id}/restore [post]
+func (h *EmployeeHandler) Restore(c *gin.Context) {
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
+	employee, err := h.Svc.Restore(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondResource(c, employee)
+}
+
+// @Summary Export an employee's personal data
+// @Description Returns everything stored about an employee, also when deleted: the employee record, their territories and the audit history. ?format=zip returns a ZIP with one JSON file per part.
+// @Tags Employees
+// @Produce json,application/zip
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Param format query string false "json (default) or zip" Enums(json, zip)
+// @Success 200 {object} models.EmployeeDataExport
+// @Failure 400 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Router /api/v1/employees/{id}/data-export [get]
+func (h *EmployeeHandler) ExportData(c *gin.Context) {
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
+	export, err := h.Svc.ExportData(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondExport(c, "employee-"+strconv.Itoa(id), export)
+}
+
+// @Summary Erase an employee's personal data
+// @Description Pseudonymizes an employee, also when deleted: last_name becomes a random "anon-…" pseudonym, first_name becomes "anon", and birth date, address, postal code, phones, photo and notes are cleared. Title, hire date, city, region, country, manager and orders are kept. Old values are replaced with "[erased]" in the audit log, event log and webhook deliveries. Cannot be undone. Requires the admin role.
+// @Tags Employees
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Success 200 {object} models.ErasureResult
+// @Failure 403 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Router /api/v1/employees/{id}/erase [post]
+func (h *EmployeeHandler) Erase(c *gin.Context) {
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
+	res, err := h.Svc.Erase(c.Request.Context(), id)
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"message": "employee deleted successfully"})
+	respondJSON(c, http.StatusOK, res)
+}
+
+// @Summary Get an employee's photo
+// @Description Returns the photo bytes with the detected content type (JPEG, PNG, GIF, BMP or WebP). The OLE header of the original Northwind employee photos is stripped. With ?size= returns a thumbnail whose longest side is at most that many pixels (JPEG for JPEG sources, PNG otherwise).
+// @Tags Employees
+// @Produce image/jpeg,image/png,image/gif,image/bmp,image/webp
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Param size query int false "Thumbnail size in pixels (16-512)"
+// @Success 200 {file} binary
+// @Failure 400 {object} models.Problem
+// @Failure 404 {object} models.Problem "Employee not found or has no photo"
+// @Router /api/v1/employees/{id}/photo [get]
+func (h *EmployeeHandler) Photo(c *gin.Context) {
+	getImage(c, h.Svc.Photo)
+}
+
+// @Summary Upload an employee's photo
+// @Description Replaces the photo with a multipart upload (field "file"). Accepts JPEG, PNG, GIF, BMP and WebP up to 5 MB and 4096x4096 pixels; the content type is detected from the bytes.
+// @Tags Employees
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Param If-Match header string false "ETag from a previous GET of the image"
+// @Param file formData file true "Image file"
+// @Success 200 {object} models.ImageInfo
+// @Failure 400 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 413 {object} models.Problem
+// @Failure 412 {object} models.Problem
+// @Router /api/v1/employees/{id}/photo [put]
+func (h *EmployeeHandler) UploadPhoto(c *gin.Context) {
+	putImage(c, h.Svc.SetPhoto)
+}
+
+// @Summary Delete an employee's photo
+// @Description Removes the photo; the employee itself is kept
+// @Tags Employees
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Employee ID"
+// @Param If-Match header string false "ETag from a previous GET of the image"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 404 {object} models.Problem "Employee not found or has no photo"
+// @Failure 412 {object} models.Problem
+// @Router /api/v1/employees/{id}/photo [delete]
+func (h *EmployeeHandler) DeletePhoto(c *gin.Context) {
+	deleteImage(c, h.Svc.SetPhoto, "Photo deleted successfully")
 }
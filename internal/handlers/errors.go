@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/patch"
+	"northwind-api/internal/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// respondError menyerahkan err ke middleware.ErrorFormatter, yang memilih
+// status HTTP dan menyembunyikan detail internal dari client.
+func respondError(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.Abort()
+}
+
+// bindJSON mem-bind dan memvalidasi (tag binding) body request ke v. Kalau
+// gagal, error sudah dikirim dan handler cukup return.
+func bindJSON(c *gin.Context, v any) bool {
+	if err := c.ShouldBindJSON(v); err != nil {
+		respondError(c, bindError(err))
+		return false
+	}
+	return true
+}
+
+// paramID membaca path param :id numerik.
+func paramID(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		respondError(c, apperr.Validation("id", "must be a positive integer"))
+		return 0, false
+	}
+	return id, true
+}
+
+// bindDeleteOptions membaca ?cascade=true dan ?reassign_to=<id> untuk DELETE.
+func bindDeleteOptions(c *gin.Context) (services.DeleteOptions, bool) {
+	var opts services.DeleteOptions
+	if v, ok := c.GetQuery("cascade"); ok {
+		cascade, err := strconv.ParseBool(v)
+		if err != nil {
+			respondError(c, apperr.Validation("cascade", "must be a boolean"))
+			return opts, false
+		}
+		opts.Cascade = cascade
+	}
+	if v, ok := c.GetQuery("reassign_to"); ok {
+		id, err := strconv.Atoi(v)
+		if err != nil || id <= 0 {
+			respondError(c, apperr.Validation("reassign_to", "must be a positive integer"))
+			return opts, false
+		}
+		opts.ReassignTo = id
+	}
+	return opts, true
+}
+
+// bindPatch membaca body PATCH; jenis patch ditentukan dari Content-Type.
+func bindPatch(c *gin.Context) (patch.Patch, bool) {
+	body, err := c.GetRawData()
+	if err != nil || len(body) == 0 {
+		respondError(c, apperr.Validation("", "request body is required"))
+		return patch.Patch{}, false
+	}
+	p, err := patch.Parse(c.ContentType(), body)
+	if err != nil {
+		respondError(c, err)
+		return patch.Patch{}, false
+	}
+	return p, true
+}
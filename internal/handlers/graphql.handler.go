@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/gql"
+	"northwind-api/internal/middleware"
+	"northwind-api/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxGraphQLSize membatasi ukuran body POST (atau query string GET) GraphQL.
+const maxGraphQLSize = 64 << 10
+
+type GraphQLHandler struct {
+	Schema *gql.Schema
+}
+
+// @Summary GraphQL query
+// @Description Read-only GraphQL endpoint for customers, orders (with details), products, categories, suppliers, employees, shippers and territories. List fields accept filter arguments plus limit (default 50, max 500) and offset, and return {items, totalCount, hasNextPage}. Relationships are loaded in batches per request. Query errors, including queries nested deeper than 8 fields, are reported with status 200 in the "errors" array. Requests larger than 64 KB get 413. GET takes query, variables (JSON) and operationName as query parameters.
+// @Tags GraphQL
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.GraphQLRequest true "GraphQL request"
+// @Success 200 {object} models.GraphQLResponse
+// @Failure 400 {object} models.Problem
+// @Failure 413 {object} models.Problem
+// @Router /api/v1/graphql [post]
+// @Router /api/v1/graphql [get]
+func (h *GraphQLHandler) Query(c *gin.Context) {
+	var req models.GraphQLRequest
+	if c.Request.Method == http.MethodGet {
+		if len(c.Request.URL.RawQuery) > maxGraphQLSize {
+			graphQLTooLarge(c)
+			return
+		}
+		req.Query = c.Query("query")
+		req.OperationName = c.Query("operationName")
+		if req.Query == "" {
+			respondError(c, apperr.Validation("query", "is required"))
+			return
+		}
+		if v := c.Query("variables"); v != "" {
+			if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+				respondError(c, apperr.Validation("variables", "must be a JSON object"))
+				return
+			}
+		}
+	} else {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxGraphQLSize)
+		if err := c.ShouldBindJSON(&req); err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				graphQLTooLarge(c)
+				return
+			}
+			respondError(c, bindError(err))
+			return
+		}
+	}
+	respondJSON(c, http.StatusOK, h.Schema.Execute(c.Request.Context(), req))
+}
+
+func graphQLTooLarge(c *gin.Context) {
+	middleware.WriteProblem(c, http.StatusRequestEntityTooLarge, fmt.Sprintf("request must be at most %d KB", maxGraphQLSize>>10))
+	c.Abort()
+}
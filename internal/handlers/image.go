@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/images"
+	"northwind-api/internal/middleware"
+	"northwind-api/internal/models"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead memberi ruang untuk boundary dan header multipart di atas
+// images.MaxSize.
+const multipartOverhead = 64 << 10
+
+// bindImage membaca file gambar dari field multipart "file". File yang lebih
+// besar dari images.MaxSize ditolak dengan 413.
+func bindImage(c *gin.Context) ([]byte, bool) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, images.MaxSize+multipartOverhead)
+	fh, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			imageTooLarge(c)
+			return nil, false
+		}
+		respondError(c, apperr.Validation("file", "is required").Wrap(err))
+		return nil, false
+	}
+	if fh.Size > images.MaxSize {
+		imageTooLarge(c)
+		return nil, false
+	}
+	f, err := fh.Open()
+	if err != nil {
+		respondError(c, err)
+		return nil, false
+	}
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	if err != nil {
+		respondError(c, err)
+		return nil, false
+	}
+	return data, true
+}
+
+func imageTooLarge(c *gin.Context) {
+	middleware.WriteProblem(c, http.StatusRequestEntityTooLarge, fmt.Sprintf("file must be at most %d MB", images.MaxSize>>20))
+	c.Abort()
+}
+
+// queryThumbnail membaca ?size=; 0 berarti gambar asli.
+func queryThumbnail(c *gin.Context) (int, bool) {
+	v := c.Query("size")
+	if v == "" {
+		return 0, true
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		respondError(c, apperr.Validation("size", "must be an integer"))
+		return 0, false
+	}
+	return n, true
+}
+
+// respondImage mengirim byte gambar apa adanya dengan tipe hasil deteksi.
+// ETag dan 304 diurus middleware.ConditionalGET.
+func respondImage(c *gin.Context, img models.Image) {
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("Cache-Control", "private, no-cache")
+	c.Data(http.StatusOK, img.ContentType, img.Data)
+}
+
+// getImage, putImage dan deleteImage adalah isi handler GET, PUT dan DELETE
+// gambar resource (foto employee, gambar category); load dan store adalah
+// method service-nya.
+func getImage(c *gin.Context, load func(ctx context.Context, id, size int) (models.Image, error)) {
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
+	size, ok := queryThumbnail(c)
+	if !ok {
+		return
+	}
+	img, err := load(c.Request.Context(), id, size)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondImage(c, img)
+}
+
+func putImage(c *gin.Context, store func(ctx context.Context, id int, data []byte) (*models.ImageInfo, error)) {
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
+	data, ok := bindImage(c)
+	if !ok {
+		return
+	}
+	info, err := store(c.Request.Context(), id, data)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondJSON(c, http.StatusOK, info)
+}
+
+func deleteImage(c *gin.Context, store func(ctx context.Context, id int, data []byte) (*models.ImageInfo, error), message string) {
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
+	if _, err := store(c.Request.Context(), id, nil); err != nil {
+		respondError(c, err)
+		return
+	}
+	respondJSON(c, http.StatusOK, gin.H{"message": message})
+}
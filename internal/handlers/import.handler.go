@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/middleware"
+	"northwind-api/internal/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxImportSize membatasi ukuran upload CSV.
+const maxImportSize = 10 << 20
+
+type ImportHandler struct {
+	Svc *services.ImportService
+}
+
+// @Summary Upload a CSV import
+// @Description Stores the CSV and starts a dry run in the background that validates every row. Poll the job until its status is "validated", then commit it. Without a mapping, CSV columns are matched to fields by name (e.g. "Product Name", "product_name" or "ProductName").
+// @Tags Imports
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param entity formData string true "Resource to import" Enums(products, customers, suppliers)
+// @Param file formData file true "CSV file with a header row"
+// @Param mapping formData string false "JSON object mapping CSV columns to fields, e.g. {\"Name\":\"product_name\"}"
+// @Param Idempotency-Key header string false "Client-generated key; retries with the same key replay the first response"
+// @Success 202 {object} models.ImportJob
+// @Header 202 {string} Location "URL of the import job"
+// @Failure 400 {object} models.Problem
+// @Failure 413 {object} models.Problem
+// @Router /api/v1/imports [post]
+func (h *ImportHandler) Create(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize)
+	fh, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			middleware.WriteProblem(c, http.StatusRequestEntityTooLarge, fmt.Sprintf("file must be at most %d MB", maxImportSize>>20))
+			c.Abort()
+			return
+		}
+		respondError(c, apperr.Validation("file", "is required").Wrap(err))
+		return
+	}
+	f, err := fh.Open()
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	var mapping map[string]string
+	if raw := c.PostForm("mapping"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &mapping); err != nil {
+			respondError(c, apperr.Validation("mapping", "must be a JSON object of column names to fields").Wrap(err))
+			return
+		}
+	}
+
+	job, err := h.Svc.Start(c.Request.Context(), c.PostForm("entity"), fh.Filename, data, mapping)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Header("Location", c.FullPath()+"/"+job.JobID)
+	respondJSON(c, http.StatusAccepted, job)
+}
+
+// @Summary List import jobs
+// @Description Returns import jobs, newest first
+// @Tags Imports
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.ImportJob
+// @Failure 500 {object} models.Problem
+// @Router /api/v1/imports [get]
+func (h *ImportHandler) GetAll(c *gin.Context) {
+	jobs, err := h.Svc.List(c.Request.Context())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondJSON(c, http.StatusOK, jobs)
+}
+
+// @Summary Get an import job
+// @Description Returns the status of an import job with its per-row validation report
+// @Tags Imports
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Import job ID"
+// @Success 200 {object} models.ImportJob
+// @Failure 404 {object} models.Problem
+// @Router /api/v1/imports/{id} [get]
+func (h *ImportHandler) GetOne(c *gin.Context) {
+	job, err := h.Svc.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondJSON(c, http.StatusOK, job)
+}
+
+// @Summary Commit an import job
+// @Description Imports the rows of a validated job in one transaction in the background. Jobs with invalid rows are rejected unless skip_invalid=true, which imports only the valid rows.
+// @Tags Imports
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Import job ID"
+// @Param skip_invalid query bool false "Import valid rows and skip invalid ones"
+// @Success 202 {object} models.ImportJob
+// @Failure 404 {object} models.Problem
+// @Failure 409 {object} models.Problem
+// @Router /api/v1/imports/{id}/commit [post]
+func (h *ImportHandler) Commit(c *gin.Context) {
+	skipInvalid, _ := strconv.ParseBool(c.Query("skip_invalid"))
+	job, err := h.Svc.Commit(c.Request.Context(), c.Param("id"), skipInvalid)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondJSON(c, http.StatusAccepted, job)
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"net/http"
+	"northwind-api/internal/models"
+	"northwind-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// inclusion membaca ?include= dan ?fields[type]= untuk tipe resource typ.
+// Hasil nil (tanpa error) berarti tidak ada yang diminta.
+func inclusion(c *gin.Context, svc *services.IncludeService, typ string) (*services.Inclusion, bool) {
+	if svc == nil {
+		return nil, true
+	}
+	in, err := svc.Parse(typ, c.Query("include"), c.QueryMap("fields"))
+	if err != nil {
+		respondError(c, err)
+		return nil, false
+	}
+	return in, true
+}
+
+// respondOne seperti respondResource, tapi dengan relasi dan sparse
+// fieldset kalau diminta. Response compound tidak memakai ETag versi
+// resource (isinya juga bergantung pada relasi); ETag-nya dihitung dari body.
+func respondOne(c *gin.Context, svc *services.IncludeService, typ string, v any) {
+	in, ok := inclusion(c, svc, typ)
+	if !ok {
+		return
+	}
+	if in == nil {
+		respondResource(c, v)
+		return
+	}
+	item, err := svc.One(c.Request.Context(), in, v)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondJSON(c, http.StatusOK, item)
+}
+
+// respondList menulis list resource dengan relasi/sparse fieldset kalau diminta.
+func respondList[T any](c *gin.Context, svc *services.IncludeService, typ string, items []T) {
+	in, ok := inclusion(c, svc, typ)
+	if !ok {
+		return
+	}
+	if in == nil {
+		respondJSON(c, http.StatusOK, items)
+		return
+	}
+	out, err := svc.Many(c.Request.Context(), in, items)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondJSON(c, http.StatusOK, out)
+}
+
+// respondPage sama seperti respondList untuk response Paginated.
+func respondPage[T any](c *gin.Context, svc *services.IncludeService, typ string, p *models.Paginated[T]) {
+	in, ok := inclusion(c, svc, typ)
+	if !ok {
+		return
+	}
+	if in == nil {
+		respondJSON(c, http.StatusOK, p)
+		return
+	}
+	items, err := svc.Many(c.Request.Context(), in, p.Items)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondJSON(c, http.StatusOK, models.Paginated[map[string]any]{
+		Items: items, Page: p.Page, PageSize: p.PageSize, TotalItems: p.TotalItems,
+		TotalPages: p.TotalPages, HasNext: p.HasNext, HasPrev: p.HasPrev,
+	})
+}
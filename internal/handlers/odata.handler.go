@@ -0,0 +1,261 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/middleware"
+	"northwind-api/internal/models"
+	"northwind-api/internal/odata"
+	"northwind-api/internal/repositories"
+	"northwind-api/internal/visibility"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+const odataJSON = "application/json; odata.metadata=minimal"
+
+type ODataHandler struct {
+	Svc *odata.Service
+}
+
+// Serve membagi /odata/*path ke service document, $metadata atau entity set.
+func (h *ODataHandler) Serve(c *gin.Context) {
+	switch path := strings.TrimPrefix(c.Param("path"), "/"); path {
+	case "":
+		h.ServiceDocument(c)
+	case "$metadata":
+		h.Metadata(c)
+	default:
+		h.Resource(c)
+	}
+}
+
+// @Summary OData service document
+// @Description Lists the OData v4 entity sets. Point Power BI or Excel ("From OData feed") at this URL.
+// @Tags OData
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]any
+// @Router /api/v1/odata/ [get]
+func (h *ODataHandler) ServiceDocument(c *gin.Context) {
+	sets := make([]odata.Record, len(h.Svc.Model.Sets))
+	for i, s := range h.Svc.Model.Sets {
+		sets[i] = odata.Record{{Name: "name", Value: s.Name}, {Name: "kind", Value: "EntitySet"}, {Name: "url", Value: s.Name}}
+	}
+	writeOData(c, odata.Record{{Name: "@odata.context", Value: odataBase(c) + "/$metadata"}, {Name: "value", Value: sets}})
+}
+
+// @Summary OData metadata
+// @Description CSDL (XML) description of the Northwind entity types, keys and navigation properties.
+// @Tags OData
+// @Produce xml
+// @Security BearerAuth
+// @Success 200 {string} string
+// @Router /api/v1/odata/$metadata [get]
+func (h *ODataHandler) Metadata(c *gin.Context) {
+	doc, err := h.Svc.Model.Metadata()
+	if err != nil {
+		writeODataError(c, err)
+		return
+	}
+	c.Header("OData-Version", "4.0")
+	c.Data(http.StatusOK, "application/xml", doc)
+}
+
+// @Summary Query an OData entity set
+// @Description Supports $filter (eq ne gt ge lt le in, and/or/not, add sub mul div mod, contains/startswith/endswith/tolower/toupper/length/trim/substring/indexof/concat, year/month/day/hour/minute/second, round/floor/ceiling, navigation paths such as Customer/Country, and any/all/$count on collections), $select, $orderby, $top, $skip, $count=true and $expand with nested options. Use {set}('ALFKI'), {set}(10248) or OrderDetails(OrderID=10248,ProductID=11) for a single entity and {set}/$count for a plain-text count. Pages hold at most 1000 rows; follow @odata.nextLink for the rest. Errors use the OData error format.
+// @Tags OData
+// @Produce json
+// @Security BearerAuth
+// @Param set path string true "Entity set, optionally with a key, e.g. Customers or Orders(10248)"
+// @Param $filter query string false "Filter expression, e.g. Country eq 'Germany'"
+// @Param $select query string false "Comma-separated properties"
+// @Param $orderby query string false "e.g. OrderDate desc"
+// @Param $top query int false "Maximum number of rows"
+// @Param $skip query int false "Rows to skip"
+// @Param $count query bool false "Include @odata.count"
+// @Param $expand query string false "e.g. Orders($select=OrderID;$top=5)"
+// @Success 200 {object} models.ODataCollection
+// @Failure 400 {object} models.ODataError
+// @Failure 404 {object} models.ODataError
+// @Router /api/v1/odata/{set} [get]
+func (h *ODataHandler) Resource(c *gin.Context) {
+	path := strings.TrimPrefix(c.Param("path"), "/")
+	segment, rest, _ := strings.Cut(path, "/")
+	name, key := segment, ""
+	if i := strings.IndexByte(segment, '('); i >= 0 {
+		name, key = segment[:i], segment[i:]
+	}
+	set := h.Svc.Model.Set(name)
+	if set == nil || (rest != "" && (rest != "$count" || key != "")) {
+		writeODataError(c, apperr.NotFound("resource %q not found", path))
+		return
+	}
+	ctx := c.Request.Context()
+	values, err := odataValues(c.Request.URL.RawQuery)
+	if err != nil {
+		writeODataError(c, err)
+		return
+	}
+	q, err := odata.ParseQuery(set, values, repositories.IncludesDeleted(ctx), odataHidden(ctx))
+	if err != nil {
+		writeODataError(c, err)
+		return
+	}
+
+	switch {
+	case rest == "$count":
+		n, err := h.Svc.Count(ctx, q)
+		if err != nil {
+			writeODataError(c, err)
+			return
+		}
+		c.Header("OData-Version", "4.0")
+		c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(strconv.FormatInt(n, 10)))
+
+	case key != "":
+		keyValues, err := odata.ParseKey(set, key)
+		if err != nil {
+			writeODataError(c, err)
+			return
+		}
+		rec, err := h.Svc.Get(ctx, q, keyValues)
+		if err != nil {
+			writeODataError(c, err)
+			return
+		}
+		meta := odata.Field{Name: "@odata.context", Value: odataBase(c) + "/$metadata#" + set.Name + selectList(q) + "/$entity"}
+		writeOData(c, append(odata.Record{meta}, rec...))
+
+	default:
+		page, err := h.Svc.List(ctx, q)
+		if err != nil {
+			writeODataError(c, err)
+			return
+		}
+		body := odata.Record{{Name: "@odata.context", Value: odataBase(c) + "/$metadata#" + set.Name + selectList(q)}}
+		if page.Count != nil {
+			body = append(body, odata.Field{Name: "@odata.count", Value: *page.Count})
+		}
+		records := page.Records
+		if records == nil {
+			records = []odata.Record{}
+		}
+		body = append(body, odata.Field{Name: "value", Value: records})
+		if page.More {
+			body = append(body, odata.Field{Name: "@odata.nextLink", Value: nextLink(c, q, len(records))})
+		}
+		writeOData(c, body)
+	}
+}
+
+// odataHidden menerapkan kebijakan visibility ke properti OData. Nama entity
+// set sama dengan nama resource REST, properti sama dengan kolom database.
+func odataHidden(ctx context.Context) odata.Hidden {
+	return func(set *odata.EntitySet, p *odata.Property) bool {
+		for _, r := range visibility.Hidden(ctx, visibility.Resource(strings.ToLower(set.Name))) {
+			if r.Column == p.Name {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// odataValues membaca query string dengan hanya & sebagai pemisah. url.Query
+// membuang pasangan yang berisi ";", padahal OData memakainya di dalam
+// $expand=Orders($select=OrderID;$top=5).
+func odataValues(raw string) (url.Values, error) {
+	values := url.Values{}
+	for _, pair := range strings.Split(raw, "&") {
+		if pair == "" {
+			continue
+		}
+		k, v, _ := strings.Cut(pair, "=")
+		key, err := url.QueryUnescape(k)
+		if err != nil {
+			return nil, apperr.Validation("query", "invalid escape in %q", k)
+		}
+		value, err := url.QueryUnescape(v)
+		if err != nil {
+			return nil, apperr.Validation(key, "invalid escape in value")
+		}
+		values.Add(key, value)
+	}
+	return values, nil
+}
+
+// odataBase adalah URL absolut root service, dipakai di @odata.context.
+func odataBase(c *gin.Context) string {
+	return odataOrigin(c) + strings.TrimSuffix(c.FullPath(), "/*path")
+}
+
+func odataOrigin(c *gin.Context) string {
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	if proto := c.GetHeader("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	return scheme + "://" + c.Request.Host
+}
+
+// selectList menambahkan "(A,B)" ke context URL kalau ada $select.
+func selectList(q *odata.Query) string {
+	if q.Select == nil {
+		return ""
+	}
+	names := make([]string, len(q.Select))
+	for i, p := range q.Select {
+		names[i] = p.Name
+	}
+	return "(" + strings.Join(names, ",") + ")"
+}
+
+// nextLink menyalin query string request dengan $skip digeser sebanyak baris
+// yang sudah dikirim (dan $top dikurangi kalau client mengisinya).
+func nextLink(c *gin.Context, q *odata.Query, sent int) string {
+	values, _ := odataValues(c.Request.URL.RawQuery)
+	values.Set("$skip", strconv.Itoa(q.Skip+sent))
+	if q.Top >= 0 {
+		values.Set("$top", strconv.Itoa(q.Top-sent))
+	}
+	u := *c.Request.URL
+	u.RawQuery = values.Encode()
+	return odataOrigin(c) + u.RequestURI()
+}
+
+func writeOData(c *gin.Context, body odata.Record) {
+	out, err := json.Marshal(body)
+	if err != nil {
+		writeODataError(c, err)
+		return
+	}
+	c.Header("OData-Version", "4.0")
+	c.Data(http.StatusOK, odataJSON, out)
+}
+
+// writeODataError menulis error dalam format OData. Status dipilih sama
+// seperti ErrorFormatter; detail error internal hanya masuk log.
+func writeODataError(c *gin.Context, err error) {
+	status := middleware.StatusFor(err)
+	detail := models.ODataErrorDetail{Code: strings.ReplaceAll(http.StatusText(status), " ", ""), Message: "internal server error"}
+	if status >= http.StatusInternalServerError {
+		log.Error().Err(err).
+			Str("path", c.Request.URL.Path).
+			Str("request_id", middleware.RequestIDFrom(c.Request.Context())).
+			Msg("odata request failed")
+	} else if e, ok := apperr.As(err); ok {
+		detail.Message, detail.Target = e.Message, e.Field
+	}
+	c.Header("OData-Version", "4.0")
+	c.JSON(status, models.ODataError{Error: detail})
+	c.Abort()
+}
@@ -3,15 +3,15 @@ package handlers
 import (
 	"net/http"
 	"northwind-api/internal/models"
-	"northwind-api/internal/repositories"
-	"northwind-api/internal/utils"
+	"northwind-api/internal/services"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
 
 type OrderHandler struct {
-	Repo *repositories.OrderRepository
+	Svc      *services.OrderService
+	Includes *services.IncludeService
 }
 
 // @Summary Get all orders
@@ -19,16 +19,18 @@ type OrderHandler struct {
 // @Tags Orders
 // @Produce json
 // @Security BearerAuth
+// @Param include query string false "Related resources to embed: customer,employee,shipper,details.product"
+// @Param fields[order] query string false "Sparse fieldset, e.g. order_id,order_date"
 // @Success 200 {array} models.Order
-// @Failure 500 {object} models.ErrorResponse
+// @Failure 500 {object} models.Problem
 // @Router /api/v1/orders [get]
 func (h *OrderHandler) GetAll(c *gin.Context) {
-	orders, err := h.Repo.GetAllOrders(c.Request.Context())
+	orders, err := h.Svc.List(c.Request.Context())
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, orders)
+	respondList(c, h.Includes, "order", orders)
 }
 
 // @Summary Get paginated orders
@@ -38,8 +40,10 @@ func (h *OrderHandler) GetAll(c *gin.Context) {
 // @Security BearerAuth
 // @Param page query int false "Page number" default(1)
 // @Param page_size query int false "Page size" default(10)
+// @Param include query string false "Related resources to embed: customer,employee,shipper,details.product"
+// @Param fields[order] query string false "Sparse fieldset, e.g. order_id,order_date"
 // @Success 200 {object} models.Paginated[models.Order]
-// @Failure 500 {object} models.ErrorResponse
+// @Failure 500 {object} models.Problem
 // @Router /api/v1/orders/paginated [get]
 func (h *OrderHandler) GetPaginated(c *gin.Context) {
 	// Parse query parameters with defaults
@@ -58,12 +62,12 @@ func (h *OrderHandler) GetPaginated(c *gin.Context) {
 	}
 
 	// Fetch paginated orders
-	paginatedOrders, err := h.Repo.GetOrdersPage(c.Request.Context(), page, pageSize)
+	paginatedOrders, err := h.Svc.Page(c.Request.Context(), page, pageSize)
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, paginatedOrders)
+	respondPage(c, h.Includes, "order", paginatedOrders)
 }
 
 // @Summary Get order by ID
@@ -72,17 +76,23 @@ func (h *OrderHandler) GetPaginated(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Order ID"
+// @Param include query string false "Related resources to embed: customer,employee,shipper,details.product"
+// @Param fields[order] query string false "Sparse fieldset, e.g. order_id,order_date"
 // @Success 200 {object} models.Order
-// @Failure 404 {object} models.ErrorResponse
+// @Header 200 {string} ETag "Resource version for If-Match / If-None-Match"
+// @Failure 404 {object} models.Problem
 // @Router /api/v1/orders/{id} [get]
 func (h *OrderHandler) GetOne(c *gin.Context) {
-	id := c.Param("id")
-	order, err := h.Repo.GetOrderByID(c.Request.Context(), utils.ParseInt(id))
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
+	order, err := h.Svc.Get(c.Request.Context(), id)
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, order)
+	respondOne(c, h.Includes, "order", order)
 }
 
 // @Summary Create a new order
@@ -92,25 +102,24 @@ func (h *OrderHandler) GetOne(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param order body models.Order true "Order to create"
+// @Param Idempotency-Key header string false "Client-generated key; retries with the same key replay the first response"
 // @Success 201 {object} models.SuccessResponse
-// @Failure 500 {object} models.ErrorResponse
+// @Failure 400 {object} models.Problem
+// @Failure 409 {object} models.Problem
+// @Failure 500 {object} models.Problem
 // @Router /api/v1/orders [post]
 func (h *OrderHandler) Create(c *gin.Context) {
 	var order models.Order
-	if err := c.ShouldBindJSON(&order); err != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &order) {
 		return
 	}
 
-	id, err := h.Repo.CreateOrder(c.Request.Context(), &order)
-	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.Svc.Create(c.Request.Context(), &order); err != nil {
+		respondError(c, err)
 		return
 	}
 
-	order.OrderID = id
-
-	c.JSON(http.StatusCreated, gin.H{"message": "Order created successfully", "order_id": order.OrderID})
+	respondJSON(c, http.StatusCreated, gin.H{"message": "Order created successfully", "order_id": order.OrderID})
 }
 
 // @Summary Update an order
@@ -120,23 +129,62 @@ func (h *OrderHandler) Create(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Order ID"
+// @Param If-Match header string false "ETag from a previous GET"
 // @Param order body models.Order true "Order to update"
 // @Success 200 {object} models.SuccessResponse
-// @Failure 500 {object} models.ErrorResponse
+// @Failure 400 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Failure 412 {object} models.Problem
+// @Failure 428 {object} models.Problem
 // @Router /api/v1/orders/{id} [put]
 func (h *OrderHandler) Update(c *gin.Context) {
-	id := utils.ParseInt(c.Param("id"))
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
 	var order models.Order
-	if err := c.ShouldBindJSON(&order); err != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+	if !bindJSON(c, &order) {
 		return
 	}
 	order.OrderID = int64(id)
-	if err := h.Repo.UpdateOrder(c.Request.Context(), &order); err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.Svc.Update(c.Request.Context(), &order); err != nil {
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"message": "Order updated successfully"})
+	respondJSON(c, http.StatusOK, gin.H{"message": "Order updated successfully"})
+}
+
+// @Summary Partially update a order
+// @Description Applies a JSON Merge Patch (RFC 7396) or JSON Patch (RFC 6902) and updates only the supplied fields
+// @Tags Orders
+// @Accept application/merge-patch+json,application/json-patch+json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Order ID"
+// @Param If-Match header string false "ETag from a previous GET"
+// @Param patch body object true "Merge patch object or JSON Patch operations"
+// @Success 200 {object} models.Order
+// @Failure 400 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 415 {object} models.Problem
+// @Failure 412 {object} models.Problem
+// @Failure 428 {object} models.Problem
+// @Router /api/v1/orders/{id} [patch]
+func (h *OrderHandler) Patch(c *gin.Context) {
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
+	p, ok := bindPatch(c)
+	if !ok {
+		return
+	}
+	order, err := h.Svc.Patch(c.Request.Context(), id, p)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondResource(c, order)
 }
 
 // @Summary Delete an order
@@ -145,18 +193,31 @@ func (h *OrderHandler) Update(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Order ID"
+// @Param If-Match header string false "ETag from a previous GET"
+// @Param cascade query bool false "Also delete the order's detail lines"
+// @Param reassign_to query int false "Move the order's detail lines to this order instead"
 // @Success 200 {object} models.SuccessResponse
-// @Failure 500 {object} models.ErrorResponse
+// @Failure 500 {object} models.Problem
+// @Failure 409 {object} models.Problem "Still referenced; blockers lists the dependents"
+// @Failure 412 {object} models.Problem
+// @Failure 428 {object} models.Problem
 // @Router /api/v1/orders/{id} [delete]
 func (h *OrderHandler) Delete(c *gin.Context) {
-	id := c.Param("id")
-	err := h.Repo.DeleteOrder(c.Request.Context(), utils.ParseInt(id))
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
+	opts, ok := bindDeleteOptions(c)
+	if !ok {
+		return
+	}
+	err := h.Svc.Delete(c.Request.Context(), id, opts)
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Order deleted successfully"})
+	respondJSON(c, http.StatusOK, gin.H{"message": "Order deleted successfully"})
 }
 
 // GET /orders/{id}/details → detail item yang dipesan
@@ -166,15 +227,40 @@ func (h *OrderHandler) Delete(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Order ID"
+// @Param include query string false "Related resources to embed: product"
+// @Param fields[order_detail] query string false "Sparse fieldset, e.g. product_id,quantity"
 // @Success 200 {array} models.OrderDetail
-// @Failure 404 {object} models.ErrorResponse
+// @Failure 404 {object} models.Problem
 // @Router /api/v1/orders/{id}/details [get]
 func (h *OrderHandler) GetOrderDetails(c *gin.Context) {
-	id := c.Param("id")
-	details, err := h.Repo.GetOrderDetailsByOrderID(c.Request.Context(), utils.ParseInt(id))
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
+	details, err := h.Svc.Details(c.Request.Context(), id)
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, details)
+	respondList(c, h.Includes, "order_detail", details)
+}
+
+// @Summary Bulk create/update/delete orders
+// @Description Runs create, update, patch (merge patch) and delete operations from a JSON array or NDJSON stream. mode=atomic (default) applies all or nothing; mode=best_effort applies each operation on its own and answers 207 when some fail.
+// @Tags Orders
+// @Accept json,application/x-ndjson
+// @Produce json
+// @Security BearerAuth
+// @Param mode query string false "atomic or best_effort" Enums(atomic, best_effort)
+// @Param operations body []models.BulkOperation true "Operations; data holds a models.Order"
+// @Param Idempotency-Key header string false "Client-generated key; retries with the same key replay the first response"
+// @Success 200 {object} models.BulkResponse
+// @Success 207 {object} models.BulkResponse
+// @Failure 400 {object} models.BulkProblem
+// @Failure 409 {object} models.BulkProblem
+// @Failure 413 {object} models.Problem
+// @Failure 415 {object} models.Problem
+// @Router /api/v1/orders/bulk [post]
+func (h *OrderHandler) Bulk(c *gin.Context) {
+	handleBulk(c, h.Svc.Bulk)
 }
@@ -3,14 +3,14 @@ package handlers
 import (
 	"net/http"
 	"northwind-api/internal/models"
-	"northwind-api/internal/repositories"
-	"northwind-api/internal/utils"
+	"northwind-api/internal/services"
 
 	"github.com/gin-gonic/gin"
 )
 
 type ProductHandler struct {
-	Repo *repositories.ProductRepository
+	Svc      *services.ProductService
+	Includes *services.IncludeService
 }
 
 // @Summary Get all products
@@ -18,16 +18,29 @@ type ProductHandler struct {
 // @Tags Products
 // @Produce json
 // @Security BearerAuth
+// @Param include_deleted query bool false "Also return soft-deleted rows"
+// @Param q query string false "Full-text search; returns up to 100 best matches, most relevant first"
+// @Param include query string false "Related resources to embed: category,supplier"
+// @Param fields[product] query string false "Sparse fieldset, e.g. product_id,product_name"
 // @Success 200 {array} models.Product
-// @Failure 500 {object} models.ErrorResponse
+// @Failure 500 {object} models.Problem
 // @Router /api/v1/products [get]
 func (h *ProductHandler) GetAll(c *gin.Context) {
-	products, err := h.Repo.GetAllProducts(c.Request.Context())
+	ctx := c.Request.Context()
+	var (
+		products []models.Product
+		err      error
+	)
+	if q := c.Query("q"); q != "" {
+		products, err = h.Svc.Search(ctx, q)
+	} else {
+		products, err = h.Svc.List(ctx)
+	}
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, products)
+	respondList(c, h.Includes, "product", products)
 }
 
 // @Summary Get product by ID
@@ -36,17 +49,24 @@ func (h *ProductHandler) GetAll(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Product ID"
+// @Param include_deleted query bool false "Also return soft-deleted rows"
+// @Param include query string false "Related resources to embed: category,supplier"
+// @Param fields[product] query string false "Sparse fieldset, e.g. product_id,product_name"
 // @Success 200 {object} models.Product
-// @Failure 500 {object} models.ErrorResponse
+// @Header 200 {string} ETag "Resource version for If-Match / If-None-Match"
+// @Failure 500 {object} models.Problem
 // @Router /api/v1/products/{id} [get]
 func (h *ProductHandler) GetOne(c *gin.Context) {
-	id := c.Param("id")
-	product, err := h.Repo.GetProductByID(c.Request.Context(), utils.ParseInt(id))
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
+	product, err := h.Svc.Get(c.Request.Context(), id)
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, product)
+	respondOne(c, h.Includes, "product", product)
 }
 
 // @Summary Create a new product
@@ -56,25 +76,24 @@ func (h *ProductHandler) GetOne(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param product body models.Product true "Product to create"
+// @Param Idempotency-Key header string false "Client-generated key; retries with the same key replay the first response"
 // @Success 201 {object} models.Product
-// @Failure 500 {object} models.ErrorResponse
+// @Failure 400 {object} models.Problem
+// @Failure 409 {object} models.Problem
+// @Failure 500 {object} models.Problem
 // @Router /api/v1/products [post]
 func (h *ProductHandler) Create(c *gin.Context) {
 	var product models.Product
-	if err := c.ShouldBindJSON(&product); err != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &product) {
 		return
 	}
 
-	_, err := h.Repo.CreateProduct(c.Request.Context(), product)
-	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.Svc.Create(c.Request.Context(), &product); err != nil {
+		respondError(c, err)
 		return
 	}
 
-	// product.ID = int(id)
-
-	c.JSON(http.StatusCreated, gin.H{"message": "product created successfully"})
+	respondJSON(c, http.StatusCreated, gin.H{"message": "product created successfully"})
 }
 
 // @Summary Update a product
@@ -84,42 +103,110 @@ func (h *ProductHandler) Create(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Product ID"
+// @Param If-Match header string false "ETag from a previous GET"
 // @Param product body models.Product true "Product to update"
 // @Success 200 {object} models.Product
-// @Failure 500 {object} models.ErrorResponse
+// @Failure 400 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Failure 412 {object} models.Problem
+// @Failure 428 {object} models.Problem
 // @Router /api/v1/products/{id} [put]
 func (h *ProductHandler) Update(c *gin.Context) {
-	id := utils.ParseInt(c.Param("id"))
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
 	var product models.Product
-	if err := c.ShouldBindJSON(&product); err != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+	if !bindJSON(c, &product) {
 		return
 	}
 	product.ProductID = id
-	if err := h.Repo.UpdateProduct(c.Request.Context(), &product); err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.Svc.Update(c.Request.Context(), &product); err != nil {
+		respondError(c, err)
+		return
+	}
+	respondJSON(c, http.StatusOK, gin.H{"message": "product updated successfully"})
+}
+
+// @Summary Partially update a product
+// @Description Applies a JSON Merge Patch (RFC 7396) or JSON Patch (RFC 6902) and updates only the supplied fields
+// @Tags Products
+// @Accept application/merge-patch+json,application/json-patch+json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param If-Match header string false "ETag from a previous GET"
+// @Param patch body object true "Merge patch object or JSON Patch operations"
+// @Success 200 {object} models.Product
+// @Failure 400 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 415 {object} models.Problem
+// @Failure 412 {object} models.Problem
+// @Failure 428 {object} models.Problem
+// @Router /api/v1/products/{id} [patch]
+func (h *ProductHandler) Patch(c *gin.Context) {
+	id, ok := paramID(c)
+	if !ok {
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"message": "product updated successfully"})
+	p, ok := bindPatch(c)
+	if !ok {
+		return
+	}
+	product, err := h.Svc.Patch(c.Request.Context(), id, p)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondResource(c, product)
 }
 
 // @Summary Delete a product
-// @Description Deletes a product by ID
+// @Description Soft-deletes a product by ID; it stays referenced by history and can be restored
 // @Tags Products
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Product ID"
+// @Param If-Match header string false "ETag from a previous GET"
 // @Success 200 {object} models.SuccessResponse
-// @Failure 500 {object} models.ErrorResponse
+// @Failure 500 {object} models.Problem
+// @Failure 412 {object} models.Problem
+// @Failure 428 {object} models.Problem
 // @Router /api/v1/products/{id} [delete]
 func (h *ProductHandler) Delete(c *gin.Context) {
-	id := c.Param("id")
-	err := h.Repo.DeleteProduct(c.Request.Context(), utils.ParseInt(id))
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
+	err := h.Svc.Delete(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondJSON(c, http.StatusOK, gin.H{"message": "product deleted successfully"})
+}
+
+// @Summary Restore a deleted product
+// @Description Restores a soft-deleted product
+// @Tags Products
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Success 200 {object} models.Product
+// @Failure 404 {object} models.Problem
+// @Failure 409 {object} models.Problem
+// @Router /api/v1/products/{id}/restore [post]
+func (h *ProductHandler) Restore(c *gin.Context) {
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
+	product, err := h.Svc.Restore(c.Request.Context(), id)
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"message": "product deleted successfully"})
+	respondResource(c, product)
 }
 
 // GET /products/{id}/supplier
@@ -130,16 +217,19 @@ func (h *ProductHandler) Delete(c *gin.Context) {
 // @Security BearerAuth
 // @Param id path int true "Product ID"
 // @Success 200 {object} models.ProductSupplier
-// @Failure 500 {object} models.ErrorResponse
+// @Failure 500 {object} models.Problem
 // @Router /api/v1/products/{id}/supplier [get]
 func (h *ProductHandler) GetSupplier(c *gin.Context) {
-	id := c.Param("id")
-	supplier, err := h.Repo.GetSupplierByProductID(c.Request.Context(), utils.ParseInt(id))
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
+	supplier, err := h.Svc.Supplier(c.Request.Context(), id)
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, supplier)
+	respondJSON(c, http.StatusOK, supplier)
 }
 
 // GET /products/{id}/category
@@ -150,14 +240,37 @@ func (h *ProductHandler) GetSupplier(c *gin.Context) {
 // @Security BearerAuth
 // @Param id path int true "Product ID"
 // @Success 200 {object} models.ProductCategory
-// @Failure 500 {object} models.ErrorResponse
+// @Failure 500 {object} models.Problem
 // @Router /api/v1/products/{id}/category [get]
 func (h *ProductHandler) GetCategory(c *gin.Context) {
-	id := c.Param("id")
-	category, err := h.Repo.GetCategoryByProductID(c.Request.Context(), utils.ParseInt(id))
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
+	category, err := h.Svc.Category(c.Request.Context(), id)
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, category)
+	respondJSON(c, http.StatusOK, category)
+}
+
+// @Summary Bulk create/update/delete products
+// @Description Runs create, update, patch (merge patch) and delete operations from a JSON array or NDJSON stream. mode=atomic (default) applies all or nothing; mode=best_effort applies each operation on its own and answers 207 when some fail.
+// @Tags Products
+// @Accept json,application/x-ndjson
+// @Produce json
+// @Security BearerAuth
+// @Param mode query string false "atomic or best_effort" Enums(atomic, best_effort)
+// @Param operations body []models.BulkOperation true "Operations; data holds a models.Product"
+// @Param Idempotency-Key header string false "Client-generated key; retries with the same key replay the first response"
+// @Success 200 {object} models.BulkResponse
+// @Success 207 {object} models.BulkResponse
+// @Failure 400 {object} models.BulkProblem
+// @Failure 409 {object} models.BulkProblem
+// @Failure 413 {object} models.Problem
+// @Failure 415 {object} models.Problem
+// @Router /api/v1/products/bulk [post]
+func (h *ProductHandler) Bulk(c *gin.Context) {
+	handleBulk(c, h.Svc.Bulk)
 }
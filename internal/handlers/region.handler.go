@@ -3,13 +3,12 @@ package handlers
 import (
 	"net/http"
 	"northwind-api/internal/repositories"
-	"northwind-api/internal/utils"
 
 	"github.com/gin-gonic/gin"
 )
 
 type RegionHandler struct {
-	Repo *repositories.RegionRepository
+	Repo repositories.RegionStore
 }
 
 // @Summary Get all regions
@@ -18,15 +17,15 @@ type RegionHandler struct {
 // @Produce json
 // @Security BearerAuth
 // @Success 200 {array} models.Region
-// @Failure 500 {object} models.ErrorResponse
+// @Failure 500 {object} models.Problem
 // @Router /api/v1/regions [get]
 func (h *RegionHandler) GetAll(c *gin.Context) {
 	regions, err := h.Repo.GetAllRegions(c.Request.Context())
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, regions)
+	respondJSON(c, http.StatusOK, regions)
 }
 
 // @Summary Get region by ID
@@ -36,17 +35,21 @@ func (h *RegionHandler) GetAll(c *gin.Context) {
 // @Param id path int true "Region ID"
 // @Security BearerAuth
 // @Success 200 {object} models.Region
-// @Failure 404 {object} models.ErrorResponse
-// @Failure 500 {object} models.ErrorResponse
+// @Header 200 {string} ETag "Resource version for If-Match / If-None-Match"
+// @Failure 404 {object} models.Problem
+// @Failure 500 {object} models.Problem
 // @Router /api/v1/regions/{id} [get]
 func (h *RegionHandler) GetOne(c *gin.Context) {
-	id := c.Param("id")
-	region, err := h.Repo.GetRegionsByID(c.Request.Context(), utils.ParseInt(id))
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
+	region, err := h.Repo.GetRegionsByID(c.Request.Context(), id)
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, region)
+	respondResource(c, region)
 }
 
 // GET /territories/{id}/employees → karyawan di territory tertentu
@@ -57,14 +60,14 @@ func (h *RegionHandler) GetOne(c *gin.Context) {
 // @Param id path string true "Territory ID"
 // @Security BearerAuth
 // @Success 200 {array} models.Employee
-// @Failure 500 {object} models.ErrorResponse
+// @Failure 500 {object} models.Problem
 // @Router /api/v1/territories/{id}/employees [get]
 func (h *RegionHandler) GetEmployeesByTerritoryID(c *gin.Context) {
 	id := c.Param("id")
 	employees, err := h.Repo.GetEmployeesByTerritoryID(c.Request.Context(), id)
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, employees)
+	respondJSON(c, http.StatusOK, employees)
 }
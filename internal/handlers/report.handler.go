@@ -8,7 +8,7 @@ import (
 )
 
 type ReportHandler struct {
-	Repo *repositories.ReportRepository
+	Repo repositories.ReportStore
 }
 
 // @Summary Top customers by total purchases
@@ -21,10 +21,10 @@ type ReportHandler struct {
 func (h *ReportHandler) GetTopCustomers(c *gin.Context) {
 	result, err := h.Repo.GetTopCustomers(c.Request.Context())
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, result)
+	respondJSON(c, http.StatusOK, result)
 }
 
 // @Summary Top selling products
@@ -37,10 +37,10 @@ func (h *ReportHandler) GetTopCustomers(c *gin.Context) {
 func (h *ReportHandler) GetTopProducts(c *gin.Context) {
 	result, err := h.Repo.GetTopProducts(c.Request.Context())
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, result)
+	respondJSON(c, http.StatusOK, result)
 }
 
 // @Summary Sales by category
@@ -53,10 +53,10 @@ func (h *ReportHandler) GetTopProducts(c *gin.Context) {
 func (h *ReportHandler) GetSalesByCategory(c *gin.Context) {
 	result, err := h.Repo.GetSalesByCategory(c.Request.Context())
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, result)
+	respondJSON(c, http.StatusOK, result)
 }
 
 // @Summary Sales by employee
@@ -69,10 +69,10 @@ func (h *ReportHandler) GetSalesByCategory(c *gin.Context) {
 func (h *ReportHandler) GetSalesByEmployee(c *gin.Context) {
 	result, err := h.Repo.GetSalesByEmployee(c.Request.Context())
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, result)
+	respondJSON(c, http.StatusOK, result)
 }
 
 // @Summary Sales summary
@@ -85,10 +85,10 @@ func (h *ReportHandler) GetSalesByEmployee(c *gin.Context) {
 func (h *ReportHandler) GetSalesSummary(c *gin.Context) {
 	result, err := h.Repo.GetSalesSummary(c.Request.Context())
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, result)
+	respondJSON(c, http.StatusOK, result)
 }
 
 // @Summary Monthly sales
@@ -101,10 +101,10 @@ func (h *ReportHandler) GetSalesSummary(c *gin.Context) {
 func (h *ReportHandler) GetMonthlySales(c *gin.Context) {
 	result, err := h.Repo.GetMonthlySales(c.Request.Context())
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, result)
+	respondJSON(c, http.StatusOK, result)
 }
 
 // @Summary Inventory status
@@ -117,10 +117,10 @@ func (h *ReportHandler) GetMonthlySales(c *gin.Context) {
 func (h *ReportHandler) GetInventoryStatus(c *gin.Context) {
 	result, err := h.Repo.GetInventoryStatus(c.Request.Context())
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, result)
+	respondJSON(c, http.StatusOK, result)
 }
 
 // @Summary Top suppliers
@@ -133,10 +133,10 @@ func (h *ReportHandler) GetInventoryStatus(c *gin.Context) {
 func (h *ReportHandler) GetTopSuppliers(c *gin.Context) {
 	result, err := h.Repo.GetTopSuppliers(c.Request.Context())
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, result)
+	respondJSON(c, http.StatusOK, result)
 }
 
 // @Summary Customer growth
@@ -149,10 +149,10 @@ func (h *ReportHandler) GetTopSuppliers(c *gin.Context) {
 func (h *ReportHandler) GetCustomerGrowth(c *gin.Context) {
 	result, err := h.Repo.GetCustomerGrowth(c.Request.Context())
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, result)
+	respondJSON(c, http.StatusOK, result)
 }
 
 // @Summary Order status summary
@@ -165,10 +165,10 @@ func (h *ReportHandler) GetCustomerGrowth(c *gin.Context) {
 func (h *ReportHandler) GetOrderStatusSummary(c *gin.Context) {
 	result, err := h.Repo.GetOrderStatusSummary(c.Request.Context())
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, result)
+	respondJSON(c, http.StatusOK, result)
 }
 
 // @Summary Region sales
@@ -181,10 +181,10 @@ func (h *ReportHandler) GetOrderStatusSummary(c *gin.Context) {
 func (h *ReportHandler) GetRegionSales(c *gin.Context) {
 	result, err := h.Repo.GetRegionSales(c.Request.Context())
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, result)
+	respondJSON(c, http.StatusOK, result)
 }
 
 // @Summary Employee performance
@@ -197,10 +197,10 @@ func (h *ReportHandler) GetRegionSales(c *gin.Context) {
 func (h *ReportHandler) GetEmployeePerformance(c *gin.Context) {
 	result, err := h.Repo.GetEmployeePerformance(c.Request.Context())
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, result)
+	respondJSON(c, http.StatusOK, result)
 }
 
 // @Summary Product profitability
@@ -213,10 +213,10 @@ func (h *ReportHandler) GetEmployeePerformance(c *gin.Context) {
 func (h *ReportHandler) GetProductProfitability(c *gin.Context) {
 	result, err := h.Repo.GetProductProfitability(c.Request.Context())
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, result)
+	respondJSON(c, http.StatusOK, result)
 }
 
 // @Summary Average order value
@@ -229,8 +229,8 @@ func (h *ReportHandler) GetProductProfitability(c *gin.Context) {
 func (h *ReportHandler) GetAverageOrderValue(c *gin.Context) {
 	result, err := h.Repo.GetAverageOrderValue(c.Request.Context())
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, result)
+	respondJSON(c, http.StatusOK, result)
 }
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/etag"
+	"northwind-api/internal/visibility"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+)
+
+// respondJSON adalah satu-satunya jalan response JSON handler: field yang
+// tidak boleh dilihat caller (lihat package visibility) disembunyikan di sini,
+// termasuk di relasi yang di-embed dan snapshot audit/event.
+func respondJSON(c *gin.Context, status int, v any) {
+	body, err := visibility.Marshal(c.Request.Context(), v)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Data(status, "application/json; charset=utf-8", body)
+}
+
+// respondResource menulis satu resource beserta ETag versinya, yang dipakai
+// client untuk If-Match (PUT/PATCH/DELETE) dan If-None-Match (GET). ETag
+// dihitung dari body yang dikirim, jadi sama dengan yang dicek etag.Check.
+func respondResource(c *gin.Context, v any) {
+	body, err := visibility.Marshal(c.Request.Context(), v)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Header("ETag", etag.Bytes(body))
+	c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
+
+// respondExport menulis export data pribadi sebagai JSON, atau dengan
+// ?format=zip sebagai <name>.zip berisi satu file JSON per bagian export
+// (mis. customer.json, orders.json, history.json).
+func respondExport(c *gin.Context, name string, v any) {
+	switch c.DefaultQuery("format", "json") {
+	case "json":
+		setAttachment(c, name+".json")
+		respondJSON(c, http.StatusOK, v)
+	case "zip":
+		raw, err := visibility.Marshal(c.Request.Context(), v)
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		var parts map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &parts); err != nil {
+			respondError(c, err)
+			return
+		}
+		keys := make([]string, 0, len(parts))
+		for k := range parts {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		for _, k := range keys {
+			var part bytes.Buffer
+			if err := json.Indent(&part, parts[k], "", "  "); err != nil {
+				respondError(c, err)
+				return
+			}
+			w, err := zw.Create(k + ".json")
+			if err == nil {
+				_, err = part.WriteTo(w)
+			}
+			if err != nil {
+				respondError(c, err)
+				return
+			}
+		}
+		if err := zw.Close(); err != nil {
+			respondError(c, err)
+			return
+		}
+		setAttachment(c, name+".zip")
+		c.Data(http.StatusOK, "application/zip", buf.Bytes())
+	default:
+		respondError(c, apperr.Validation("format", "must be json or zip"))
+	}
+}
+
+// setAttachment menulis Content-Disposition untuk filename. Nama berasal dari
+// ID di path, jadi karakter selain huruf ASCII, angka, titik, '-' dan '_'
+// diganti '_' supaya tidak bisa keluar dari tanda kutip atau menyisipkan path.
+func setAttachment(c *gin.Context, filename string) {
+	safe := strings.Map(func(r rune) rune {
+		if r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune(".-_", r)) {
+			return r
+		}
+		return '_'
+	}, filename)
+	c.Header("Content-Disposition", `attachment; filename="`+safe+`"`)
+}
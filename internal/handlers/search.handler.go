@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"net/http"
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/services"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SearchHandler struct {
+	Svc *services.SearchService
+}
+
+// @Summary Search customers, products and suppliers
+// @Description Full-text search ranked by relevance; matches in names weigh more than matches in contact and address fields. Every word matches as a prefix ("cha" finds "Chai"). When nothing matches, words with a one or two letter typo are tried as well and "fuzzy" is true. Matched words in title and snippet are wrapped in <mark></mark>. Soft-deleted rows are not searchable.
+// @Tags Search
+// @Produce json
+// @Security BearerAuth
+// @Param q query string true "Search text"
+// @Param type query string false "Comma-separated types to search (default all)" Enums(customers, products, suppliers)
+// @Param limit query int false "Maximum number of hits (default 20, max 100)"
+// @Success 200 {object} models.SearchResult
+// @Failure 400 {object} models.Problem
+// @Router /api/v1/search [get]
+func (h *SearchHandler) Search(c *gin.Context) {
+	var types []string
+	if t := c.Query("type"); t != "" {
+		types = strings.Split(t, ",")
+	}
+	limit := 0
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			respondError(c, apperr.Validation("limit", "must be an integer"))
+			return
+		}
+		limit = n
+	}
+	res, err := h.Svc.Search(c.Request.Context(), c.Query("q"), types, limit)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondJSON(c, http.StatusOK, res)
+}
@@ -3,14 +3,14 @@ package handlers
 import (
 	"net/http"
 	"northwind-api/internal/models"
-	"northwind-api/internal/repositories"
-	"northwind-api/internal/utils"
+	"northwind-api/internal/services"
 
 	"github.com/gin-gonic/gin"
 )
 
 type ShipperHandler struct {
-	Repo *repositories.ShipperRepository
+	Svc      *services.ShipperService
+	Includes *services.IncludeService
 }
 
 // @Summary Get all shippers
@@ -18,16 +18,18 @@ type ShipperHandler struct {
 // @Tags Shippers
 // @Produce json
 // @Security BearerAuth
+// @Param include_deleted query bool false "Also return soft-deleted rows"
+// @Param fields[shipper] query string false "Sparse fieldset, e.g. shipper_id,company_name"
 // @Success 200 {array} models.Shipper
-// @Failure 500 {object} models.ErrorResponse
+// @Failure 500 {object} models.Problem
 // @Router /api/v1/shippers [get]
 func (h *ShipperHandler) GetAll(c *gin.Context) {
-	shippers, err := h.Repo.GetAllShippers(c.Request.Context())
+	shippers, err := h.Svc.List(c.Request.Context())
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, shippers)
+	respondList(c, h.Includes, "shipper", shippers)
 }
 
 // @Summary Get shipper by ID
@@ -36,18 +38,24 @@ func (h *ShipperHandler) GetAll(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Shipper ID"
 // @Security BearerAuth
+// @Param include_deleted query bool false "Also return soft-deleted rows"
+// @Param fields[shipper] query string false "Sparse fieldset, e.g. shipper_id,company_name"
 // @Success 200 {object} models.Shipper
-// @Failure 404 {object} models.ErrorResponse
-// @Failure 500 {object} models.ErrorResponse
+// @Header 200 {string} ETag "Resource version for If-Match / If-None-Match"
+// @Failure 404 {object} models.Problem
+// @Failure 500 {object} models.Problem
 // @Router /api/v1/shippers/{id} [get]
 func (h *ShipperHandler) GetOne(c *gin.Context) {
-	id := c.Param("id")
-	shipper, err := h.Repo.GetShipperByID(c.Request.Context(), utils.ParseInt(id))
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
+	shipper, err := h.Svc.Get(c.Request.Context(), id)
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, shipper)
+	respondOne(c, h.Includes, "shipper", shipper)
 }
 
 // @Summary Create a new shipper
@@ -57,21 +65,22 @@ func (h *ShipperHandler) GetOne(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param shipper body models.Shipper true "Shipper to create"
+// @Param Idempotency-Key header string false "Client-generated key; retries with the same key replay the first response"
 // @Success 201 {object} models.Shipper
-// @Failure 500 {object} models.ErrorResponse
+// @Failure 400 {object} models.Problem
+// @Failure 409 {object} models.Problem
+// @Failure 500 {object} models.Problem
 // @Router /api/v1/shippers [post]
 func (h *ShipperHandler) Create(c *gin.Context) {
 	var shipper models.Shipper
-	if err := c.ShouldBindJSON(&shipper); err != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &shipper) {
 		return
 	}
-	_, err := h.Repo.CreateShipper(c.Request.Context(), shipper)
-	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.Svc.Create(c.Request.Context(), &shipper); err != nil {
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusCreated, gin.H{"message": "Shipper created successfully"})
+	respondJSON(c, http.StatusCreated, gin.H{"message": "Shipper created successfully"})
 }
 
 // @Summary Update an existing shipper
@@ -80,42 +89,109 @@ func (h *ShipperHandler) Create(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path int true "Shipper ID"
+// @Param If-Match header string false "ETag from a previous GET"
 // @Param shipper body models.Shipper true "Shipper data to update"
 // @Security BearerAuth
 // @Success 200 {object} models.Shipper
-// @Failure 400 {object} models.ErrorResponse
-// @Failure 404 {object} models.ErrorResponse
-// @Failure 500 {object} models.ErrorResponse
+// @Failure 400 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Failure 412 {object} models.Problem
+// @Failure 428 {object} models.Problem
 // @Router /api/v1/shippers/{id} [put]
 func (h *ShipperHandler) Update(c *gin.Context) {
-	id := c.Param("id")
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
 	var shipper models.Shipper
-	if err := c.ShouldBindJSON(&shipper); err != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &shipper) {
 		return
 	}
-	shipper.ShipperID = utils.ParseInt(id)
-	if err := h.Repo.UpdateShipper(c.Request.Context(), &shipper); err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	shipper.ShipperID = id
+	if err := h.Svc.Update(c.Request.Context(), &shipper); err != nil {
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"message": "Shipper updated successfully"})
+	respondJSON(c, http.StatusOK, gin.H{"message": "Shipper updated successfully"})
+}
+
+// @Summary Partially update a shipper
+// @Description Applies a JSON Merge Patch (RFC 7396) or JSON Patch (RFC 6902) and updates only the supplied fields
+// @Tags Shippers
+// @Accept application/merge-patch+json,application/json-patch+json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Shipper ID"
+// @Param If-Match header string false "ETag from a previous GET"
+// @Param patch body object true "Merge patch object or JSON Patch operations"
+// @Success 200 {object} models.Shipper
+// @Failure 400 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 415 {object} models.Problem
+// @Failure 412 {object} models.Problem
+// @Failure 428 {object} models.Problem
+// @Router /api/v1/shippers/{id} [patch]
+func (h *ShipperHandler) Patch(c *gin.Context) {
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
+	p, ok := bindPatch(c)
+	if !ok {
+		return
+	}
+	shipper, err := h.Svc.Patch(c.Request.Context(), id, p)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondResource(c, shipper)
 }
 
 // @Summary Delete a shipper
-// @Description Deletes a shipper by ID
+// @Description Soft-deletes a shipper by ID; it stays referenced by history and can be restored
 // @Tags Shippers
 // @Param id path int true "Shipper ID"
+// @Param If-Match header string false "ETag from a previous GET"
 // @Security BearerAuth
 // @Success 200 {object} models.SuccessResponse
-// @Failure 404 {object} models.ErrorResponse
-// @Failure 500 {object} models.ErrorResponse
+// @Failure 404 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Failure 412 {object} models.Problem
+// @Failure 428 {object} models.Problem
 // @Router /api/v1/shippers/{id} [delete]
 func (h *ShipperHandler) Delete(c *gin.Context) {
-	id := c.Param("id")
-	if err := h.Repo.DeleteShipper(c.Request.Context(), utils.ParseInt(id)); err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
+	if err := h.Svc.Delete(c.Request.Context(), id); err != nil {
+		respondError(c, err)
+		return
+	}
+	respondJSON(c, http.StatusOK, gin.H{"message": "Shipper deleted successfully"})
+}
+
+// @Summary Restore a deleted shipper
+// @Description Restores a soft-deleted shipper
+// @Tags Shippers
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Shipper ID"
+// @Success 200 {object} models.Shipper
+// @Failure 404 {object} models.Problem
+// @Failure 409 {object} models.Problem
+// @Router /api/v1/shippers/{id}/restore [post]
+func (h *ShipperHandler) Restore(c *gin.Context) {
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
+	shipper, err := h.Svc.Restore(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"message": "Shipper deleted successfully"})
+	respondResource(c, shipper)
 }
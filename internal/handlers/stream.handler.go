@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"northwind-api/internal/middleware"
+	"northwind-api/internal/services"
+	"northwind-api/internal/visibility"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// streamHeartbeat adalah jeda maksimum tanpa kiriman apa pun, supaya proxy
+	// tidak memutus koneksi yang idle dan client cepat tahu kalau koneksi putus.
+	streamHeartbeat = 15 * time.Second
+	// streamRetry adalah saran jeda reconnect untuk EventSource (ms).
+	streamRetry = 3000
+	wsWriteWait = 10 * time.Second
+)
+
+type StreamHandler struct {
+	Svc *services.StreamService
+}
+
+// @Summary Stream data changes (Server-Sent Events)
+// @Description Pushes create/update/delete/restore events as they are committed through the API. Each message carries the event ID as "id", the event type (e.g. "order.updated") as "event" and the event as JSON "data". Reconnect with the Last-Event-ID header (or ?last_event_id) to resume from the persisted event log; without it the stream starts at the next change.
+// @Tags Events
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Param resources query string false "Comma-separated resources to receive, e.g. orders,products (default all)"
+// @Param Last-Event-ID header string false "Resume after this event ID"
+// @Param last_event_id query string false "Resume after this event ID (for clients that cannot set headers)"
+// @Success 200 {object} models.ChangeEvent
+// @Failure 400 {object} models.Problem
+// @Router /api/v1/events/stream [get]
+func (h *StreamHandler) SSE(c *gin.Context) {
+	lastID := c.GetHeader("Last-Event-ID")
+	if lastID == "" {
+		lastID = c.Query("last_event_id")
+	}
+	sub, err := h.Svc.Subscribe(c.Request.Context(), resources(c), lastID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	defer sub.Close()
+
+	// Stream berumur panjang: lepas WriteTimeout server untuk request ini.
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", streamRetry)
+	c.Writer.Flush()
+
+	ctx := c.Request.Context()
+	for {
+		events, err := sub.Next(ctx, streamHeartbeat)
+		if err != nil {
+			if !errors.Is(err, context.Canceled) {
+				log.Error().Err(err).Msg("event stream stopped")
+			}
+			return
+		}
+		if len(events) == 0 {
+			fmt.Fprint(c.Writer, ": keep-alive\n\n")
+		}
+		for _, e := range events {
+			data, err := visibility.Marshal(ctx, e)
+			if err != nil {
+				log.Error().Err(err).Int64("event_id", e.EventID).Msg("error encoding event")
+				return
+			}
+			fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", e.EventID, e.Type, data)
+		}
+		c.Writer.Flush()
+	}
+}
+
+// @Summary Stream data changes (WebSocket)
+// @Description WebSocket variant of /events/stream. After the upgrade the server sends one JSON text message per event and a ping frame as heartbeat; messages from the client are ignored. Resume with ?last_event_id using the event_id of the last message received.
+// @Tags Events
+// @Security BearerAuth
+// @Param resources query string false "Comma-separated resources to receive, e.g. orders,products (default all)"
+// @Param last_event_id query string false "Resume after this event ID"
+// @Success 101 {object} models.ChangeEvent
+// @Failure 400 {object} models.Problem
+// @Failure 426 {object} models.Problem
+// @Router /api/v1/events/ws [get]
+func (h *StreamHandler) WebSocket(c *gin.Context) {
+	if !websocket.IsWebSocketUpgrade(c.Request) {
+		c.Header("Upgrade", "websocket")
+		middleware.WriteProblem(c, http.StatusUpgradeRequired, "this endpoint requires a WebSocket upgrade")
+		c.Abort()
+		return
+	}
+	sub, err := h.Svc.Subscribe(c.Request.Context(), resources(c), c.Query("last_event_id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	defer sub.Close()
+
+	upgrader := websocket.Upgrader{
+		Error: func(_ http.ResponseWriter, _ *http.Request, status int, reason error) {
+			middleware.WriteProblem(c, status, reason.Error())
+			c.Abort()
+		},
+	}
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	// Koneksi yang sudah di-hijack tidak ikut batal saat client pergi;
+	// read loop yang mendeteksinya (sekaligus memproses close/pong frame).
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		events, err := sub.Next(ctx, streamHeartbeat)
+		if err != nil {
+			if !errors.Is(err, context.Canceled) {
+				log.Error().Err(err).Msg("event stream stopped")
+				_ = conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseInternalServerErr, "event stream stopped"),
+					time.Now().Add(wsWriteWait))
+			}
+			return
+		}
+		if len(events) == 0 {
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait)); err != nil {
+				return
+			}
+		}
+		for _, e := range events {
+			_ = conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			data, err := visibility.Marshal(ctx, e)
+			if err == nil {
+				err = conn.WriteMessage(websocket.TextMessage, data)
+			}
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+// resources membaca ?resources=a,b (boleh diulang).
+func resources(c *gin.Context) []string {
+	var out []string
+	for _, v := range c.QueryArray("resources") {
+		for _, r := range strings.Split(v, ",") {
+			if r = strings.TrimSpace(r); r != "" {
+				out = append(out, r)
+			}
+		}
+	}
+	return out
+}
@@ -3,14 +3,14 @@ package handlers
 import (
 	"net/http"
 	"northwind-api/internal/models"
-	"northwind-api/internal/repositories"
-	"northwind-api/internal/utils"
+	"northwind-api/internal/services"
 
 	"github.com/gin-gonic/gin"
 )
 
 type SupplierHandler struct {
-	Repo *repositories.SupplierRepository
+	Svc      *services.SupplierService
+	Includes *services.IncludeService
 }
 
 // @Summary Get all suppliers
@@ -18,16 +18,29 @@ type SupplierHandler struct {
 // @Tags Suppliers
 // @Produce json
 // @Security BearerAuth
+// @Param include_deleted query bool false "Also return soft-deleted rows"
+// @Param q query string false "Full-text search; returns up to 100 best matches, most relevant first"
+// @Param include query string false "Related resources to embed: products"
+// @Param fields[supplier] query string false "Sparse fieldset, e.g. supplier_id,company_name"
 // @Success 200 {array} models.Supplier
-// @Failure 500 {object} models.ErrorResponse
+// @Failure 500 {object} models.Problem
 // @Router /api/v1/suppliers [get]
 func (h *SupplierHandler) GetAll(c *gin.Context) {
-	suppliers, err := h.Repo.GetAllSuppliers(c.Request.Context())
+	ctx := c.Request.Context()
+	var (
+		suppliers []models.Supplier
+		err       error
+	)
+	if q := c.Query("q"); q != "" {
+		suppliers, err = h.Svc.Search(ctx, q)
+	} else {
+		suppliers, err = h.Svc.List(ctx)
+	}
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, suppliers)
+	respondList(c, h.Includes, "supplier", suppliers)
 }
 
 // @Summary Get supplier by ID
@@ -36,17 +49,24 @@ func (h *SupplierHandler) GetAll(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Supplier ID"
+// @Param include_deleted query bool false "Also return soft-deleted rows"
+// @Param include query string false "Related resources to embed: products"
+// @Param fields[supplier] query string false "Sparse fieldset, e.g. supplier_id,company_name"
 // @Success 200 {object} models.Supplier
-// @Failure 404 {object} models.ErrorResponse
+// @Header 200 {string} ETag "Resource version for If-Match / If-None-Match"
+// @Failure 404 {object} models.Problem
 // @Router /api/v1/suppliers/{id} [get]
 func (h *SupplierHandler) GetOne(c *gin.Context) {
-	id := c.Param("id")
-	supplier, err := h.Repo.GetSupplierByID(c.Request.Context(), utils.ParseInt(id))
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
+	supplier, err := h.Svc.Get(c.Request.Context(), id)
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, supplier)
+	respondOne(c, h.Includes, "supplier", supplier)
 }
 
 // @Summary Create a new supplier
@@ -56,22 +76,24 @@ func (h *SupplierHandler) GetOne(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param supplier body models.Supplier true "Supplier to create"
+// @Param Idempotency-Key header string false "Client-generated key; retries with the same key replay the first response"
 // @Success 201 {object} models.SuccessResponse
-// @Failure 500 {object} models.ErrorResponse
+// @Failure 400 {object} models.Problem
+// @Failure 409 {object} models.Problem
+// @Failure 500 {object} models.Problem
 // @Router /api/v1/suppliers [post]
 func (h *SupplierHandler) Create(c *gin.Context) {
 	var supplier models.Supplier
-	if err := c.ShouldBindJSON(&supplier); err != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if !bindJSON(c, &supplier) {
 		return
 	}
 
-	if err := h.Repo.CreateSupplier(c.Request.Context(), &supplier); err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.Svc.Create(c.Request.Context(), &supplier); err != nil {
+		respondError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{"message": "Supplier created successfully"})
+	respondJSON(c, http.StatusCreated, gin.H{"message": "Supplier created successfully"})
 }
 
 // @Summary Update a supplier
@@ -81,41 +103,116 @@ func (h *SupplierHandler) Create(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Supplier ID"
+// @Param If-Match header string false "ETag from a previous GET"
 // @Param supplier body models.Supplier true "Supplier to update"
 // @Success 200 {object} models.SuccessResponse
-// @Failure 500 {object} models.ErrorResponse
+// @Failure 400 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Failure 412 {object} models.Problem
+// @Failure 428 {object} models.Problem
 // @Router /api/v1/suppliers/{id} [put]
 func (h *SupplierHandler) Update(c *gin.Context) {
-	id := utils.ParseInt(c.Param("id"))
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
 	var supplier models.Supplier
-	if err := c.ShouldBindJSON(&supplier); err != nil {
-		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+	if !bindJSON(c, &supplier) {
 		return
 	}
 	supplier.SupplierID = int64(id)
-	if err := h.Repo.UpdateSupplier(c.Request.Context(), &supplier); err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.Svc.Update(c.Request.Context(), &supplier); err != nil {
+		respondError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"message": "Supplier updated successfully"})
+	respondJSON(c, http.StatusOK, gin.H{"message": "Supplier updated successfully"})
+}
+
+// @Summary Partially update a supplier
+// @Description Applies a JSON Merge Patch (RFC 7396) or JSON Patch (RFC 6902) and updates only the supplied fields
+// @Tags Suppliers
+// @Accept application/merge-patch+json,application/json-patch+json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Supplier ID"
+// @Param If-Match header string false "ETag from a previous GET"
+// @Param patch body object true "Merge patch object or JSON Patch operations"
+// @Success 200 {object} models.Supplier
+// @Failure 400 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 415 {object} models.Problem
+// @Failure 412 {object} models.Problem
+// @Failure 428 {object} models.Problem
+// @Router /api/v1/suppliers/{id} [patch]
+func (h *SupplierHandler) Patch(c *gin.Context) {
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
+	p, ok := bindPatch(c)
+	if !ok {
+		return
+	}
+	supplier, err := h.Svc.Patch(c.Request.Context(), id, p)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondResource(c, supplier)
 }
 
 // @Summary Delete a supplier
-// @Description Deletes a supplier by ID
+// @Description Soft-deletes a supplier by ID; it stays referenced by history and can be restored
 // @Tags Suppliers
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Supplier ID"
+// @Param If-Match header string false "ETag from a previous GET"
+// @Param cascade query bool false "Also delete products from the supplier"
+// @Param reassign_to query int false "Move products from the supplier to this supplier instead"
 // @Success 200 {object} models.SuccessResponse
-// @Failure 500 {object} models.ErrorResponse
+// @Failure 500 {object} models.Problem
+// @Failure 409 {object} models.Problem "Still referenced; blockers lists the dependents"
+// @Failure 412 {object} models.Problem
+// @Failure 428 {object} models.Problem
 // @Router /api/v1/suppliers/{id} [delete]
 func (h *SupplierHandler) Delete(c *gin.Context) {
-	id := c.Param("id")
-	err := h.Repo.DeleteSupplier(c.Request.Context(), utils.ParseInt(id))
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
+	opts, ok := bindDeleteOptions(c)
+	if !ok {
+		return
+	}
+	err := h.Svc.Delete(c.Request.Context(), id, opts)
 	if err != nil {
-		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Supplier deleted successfully"})
+	respondJSON(c, http.StatusOK, gin.H{"message": "Supplier deleted successfully"})
+}
+
+// @Summary Restore a deleted supplier
+// @Description Restores a soft-deleted supplier
+// @Tags Suppliers
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Supplier ID"
+// @Success 200 {object} models.Supplier
+// @Failure 404 {object} models.Problem
+// @Failure 409 {object} models.Problem
+// @Router /api/v1/suppliers/{id}/restore [post]
+func (h *SupplierHandler) Restore(c *gin.Context) {
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
+	supplier, err := h.Svc.Restore(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondResource(c, supplier)
 }
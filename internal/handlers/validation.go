@@ -0,0 +1,24 @@
+package handlers
+
+import (
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/validation"
+
+	"github.com/gin-gonic/gin/binding"
+)
+
+// ginValidator membuat binding gin memakai aturan dari package validation.
+type ginValidator struct{}
+
+func (ginValidator) ValidateStruct(obj any) error { return validation.Struct(obj) }
+func (ginValidator) Engine() any                  { return validation.Engine() }
+
+func init() {
+	binding.Validator = ginValidator{}
+}
+
+// bindError mengubah error dari ShouldBindJSON menjadi validation error
+// dengan daftar field yang tidak valid.
+func bindError(err error) *apperr.Error {
+	return validation.JSONError(err)
+}
@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	"net/http"
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/models"
+	"northwind-api/internal/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookHandler struct {
+	Svc *services.WebhookService
+}
+
+// @Summary List webhooks
+// @Description Returns all webhook subscriptions; secrets are never included
+// @Tags Webhooks
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.Webhook
+// @Failure 500 {object} models.Problem
+// @Router /api/v1/webhooks [get]
+func (h *WebhookHandler) GetAll(c *gin.Context) {
+	webhooks, err := h.Svc.List(c.Request.Context())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondJSON(c, http.StatusOK, webhooks)
+}
+
+// @Summary Get a webhook
+// @Description Returns one webhook subscription without its secret
+// @Tags Webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Webhook ID"
+// @Success 200 {object} models.Webhook
+// @Header 200 {string} ETag "Resource version for If-Match / If-None-Match"
+// @Failure 404 {object} models.Problem
+// @Router /api/v1/webhooks/{id} [get]
+func (h *WebhookHandler) GetOne(c *gin.Context) {
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
+	w, err := h.Svc.Get(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondResource(c, w)
+}
+
+// @Summary Create a webhook
+// @Description Subscribes a URL to event types such as "order.shipped", "product.low_stock", "customer.merged", "customer.erased" or "<resource>.<created|updated|deleted|restored>" ("*" for all). Each delivery is a JSON POST signed with X-Webhook-Signature: sha256=HMAC-SHA256(secret, "<X-Webhook-Timestamp>.<body>") in hex. The secret is returned only in this response; omit it to have one generated.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param webhook body models.WebhookInput true "Webhook to create"
+// @Param Idempotency-Key header string false "Client-generated key; retries with the same key replay the first response"
+// @Success 201 {object} models.Webhook
+// @Header 201 {string} Location "URL of the webhook"
+// @Failure 400 {object} models.Problem
+// @Router /api/v1/webhooks [post]
+func (h *WebhookHandler) Create(c *gin.Context) {
+	var in models.WebhookInput
+	if !bindJSON(c, &in) {
+		return
+	}
+	w, err := h.Svc.Create(c.Request.Context(), in)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Header("Location", c.FullPath()+"/"+strconv.Itoa(w.WebhookID))
+	respondJSON(c, http.StatusCreated, w)
+}
+
+// @Summary Update a webhook
+// @Description Replaces the URL, event types and active flag. An empty secret keeps the current one. Pending deliveries of an inactive webhook wait until it is activated again.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Webhook ID"
+// @Param If-Match header string false "ETag from a previous GET"
+// @Param webhook body models.WebhookInput true "Webhook data"
+// @Success 200 {object} models.Webhook
+// @Failure 400 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 412 {object} models.Problem
+// @Failure 428 {object} models.Problem
+// @Router /api/v1/webhooks/{id} [put]
+func (h *WebhookHandler) Update(c *gin.Context) {
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
+	var in models.WebhookInput
+	if !bindJSON(c, &in) {
+		return
+	}
+	w, err := h.Svc.Update(c.Request.Context(), id, in)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondResource(c, w)
+}
+
+// @Summary Delete a webhook
+// @Description Deletes a webhook subscription together with its pending deliveries and delivery log
+// @Tags Webhooks
+// @Security BearerAuth
+// @Param id path int true "Webhook ID"
+// @Param If-Match header string false "ETag from a previous GET"
+// @Success 200 {object} models.SuccessResponse
+// @Failure 404 {object} models.Problem
+// @Failure 412 {object} models.Problem
+// @Failure 428 {object} models.Problem
+// @Router /api/v1/webhooks/{id} [delete]
+func (h *WebhookHandler) Delete(c *gin.Context) {
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
+	if err := h.Svc.Delete(c.Request.Context(), id); err != nil {
+		respondError(c, err)
+		return
+	}
+	respondJSON(c, http.StatusOK, gin.H{"message": "Webhook deleted successfully"})
+}
+
+// @Summary List webhook deliveries
+// @Description Returns the delivery log, newest first. Use status=dead for the dead-letter list: deliveries that failed every retry.
+// @Tags Webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param webhook_id query int false "Only deliveries of this webhook"
+// @Param status query string false "Delivery status" Enums(pending, delivered, dead)
+// @Param limit query int false "Maximum number of deliveries (default 100, max 1000)"
+// @Success 200 {array} models.WebhookDelivery
+// @Failure 400 {object} models.Problem
+// @Router /api/v1/webhook-deliveries [get]
+func (h *WebhookHandler) Deliveries(c *gin.Context) {
+	webhookID, ok := queryPositive(c, "webhook_id")
+	if !ok {
+		return
+	}
+	limit, ok := queryPositive(c, "limit")
+	if !ok {
+		return
+	}
+	deliveries, err := h.Svc.Deliveries(c.Request.Context(), webhookID, c.Query("status"), limit)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondJSON(c, http.StatusOK, deliveries)
+}
+
+// @Summary Get a webhook delivery
+// @Description Returns one delivery with its payload and the log of every attempt
+// @Tags Webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Delivery ID"
+// @Success 200 {object} models.WebhookDelivery
+// @Failure 404 {object} models.Problem
+// @Router /api/v1/webhook-deliveries/{id} [get]
+func (h *WebhookHandler) GetDelivery(c *gin.Context) {
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
+	d, err := h.Svc.Delivery(c.Request.Context(), int64(id))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondJSON(c, http.StatusOK, d)
+}
+
+// @Summary Retry a dead webhook delivery
+// @Description Moves a delivery out of the dead-letter list and schedules it again with a fresh set of attempts
+// @Tags Webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Delivery ID"
+// @Success 200 {object} models.WebhookDelivery
+// @Failure 404 {object} models.Problem
+// @Failure 409 {object} models.Problem
+// @Router /api/v1/webhook-deliveries/{id}/retry [post]
+func (h *WebhookHandler) Retry(c *gin.Context) {
+	id, ok := paramID(c)
+	if !ok {
+		return
+	}
+	d, err := h.Svc.Retry(c.Request.Context(), int64(id))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondJSON(c, http.StatusOK, d)
+}
+
+// queryPositive membaca query param integer positif opsional; 0 kalau tidak ada.
+func queryPositive(c *gin.Context, name string) (int, bool) {
+	v := c.Query(name)
+	if v == "" {
+		return 0, true
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		respondError(c, apperr.Validation(name, "must be a positive integer"))
+		return 0, false
+	}
+	return n, true
+}
@@ -0,0 +1,104 @@
+package images
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"sync"
+)
+
+// ThumbnailCache menyimpan thumbnail yang sudah dibuat, supaya GET ?size=
+// berulang tidak men-decode dan memperkecil gambar asli setiap kali, dan
+// membatasi berapa thumbnail yang dibuat bersamaan. Kunci cache adalah hash
+// isi gambar, jadi gambar yang diganti otomatis mendapat thumbnail baru.
+type ThumbnailCache struct {
+	maxBytes int
+	sem      chan struct{}
+
+	mu      sync.Mutex
+	bytes   int
+	order   *list.List // elemen terdepan paling baru dipakai
+	entries map[thumbnailKey]*list.Element
+}
+
+type thumbnailKey struct {
+	sum  [sha256.Size]byte
+	size int
+}
+
+type thumbnailEntry struct {
+	key         thumbnailKey
+	data        []byte
+	contentType string
+}
+
+// NewThumbnailCache membuat cache berisi thumbnail sampai total maxBytes,
+// dengan paling banyak workers thumbnail dibuat bersamaan.
+func NewThumbnailCache(maxBytes, workers int) *ThumbnailCache {
+	return &ThumbnailCache{
+		maxBytes: maxBytes,
+		sem:      make(chan struct{}, max(workers, 1)),
+		order:    list.New(),
+		entries:  map[thumbnailKey]*list.Element{},
+	}
+}
+
+// Thumbnail mengembalikan thumbnail data dari cache, atau membuatnya dengan
+// Thumbnail kalau belum ada. Kalau semua worker sibuk, Thumbnail menunggu
+// sampai ada yang selesai atau ctx selesai.
+func (c *ThumbnailCache) Thumbnail(ctx context.Context, data []byte, size int) ([]byte, string, error) {
+	key := thumbnailKey{sha256.Sum256(data), size}
+	if e, ok := c.get(key); ok {
+		return e.data, e.contentType, nil
+	}
+
+	select {
+	case c.sem <- struct{}{}:
+		defer func() { <-c.sem }()
+	case <-ctx.Done():
+		return nil, "", ctx.Err()
+	}
+	// Request lain untuk gambar yang sama mungkin sudah selesai selagi
+	// menunggu giliran.
+	if e, ok := c.get(key); ok {
+		return e.data, e.contentType, nil
+	}
+	thumb, contentType, err := Thumbnail(data, size)
+	if err != nil {
+		return nil, "", err
+	}
+	c.put(&thumbnailEntry{key: key, data: thumb, contentType: contentType})
+	return thumb, contentType, nil
+}
+
+func (c *ThumbnailCache) get(key thumbnailKey) (*thumbnailEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*thumbnailEntry), true
+}
+
+// put menyimpan e dan membuang thumbnail yang paling lama tidak dipakai
+// sampai total ukurannya kembali di bawah maxBytes.
+func (c *ThumbnailCache) put(e *thumbnailEntry) {
+	if len(e.data) > c.maxBytes {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[e.key]; ok {
+		return
+	}
+	c.entries[e.key] = c.order.PushFront(e)
+	c.bytes += len(e.data)
+	for c.bytes > c.maxBytes {
+		oldest := c.order.Back()
+		old := c.order.Remove(oldest).(*thumbnailEntry)
+		delete(c.entries, old.key)
+		c.bytes -= len(old.data)
+	}
+}
@@ -0,0 +1,116 @@
+// Package images memeriksa gambar yang diunggah (foto employee, gambar
+// category) dan membuat thumbnail-nya, semuanya dalam Go murni.
+package images
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"slices"
+
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/models"
+
+	_ "golang.org/x/image/bmp"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	// MaxSize adalah ukuran file maksimum yang diterima saat upload.
+	MaxSize = 5 << 20
+	// MaxDimension membatasi lebar dan tinggi supaya decode untuk thumbnail
+	// tidak memakan memori berlebihan.
+	MaxDimension = 4096
+
+	// MinThumbnail dan MaxThumbnail adalah batas ?size= thumbnail.
+	MinThumbnail = 16
+	MaxThumbnail = 512
+
+	// OLEHeaderSize adalah panjang header OLE object (Access "Bitmap Image")
+	// yang membungkus BMP di Categories.Picture dan Employees.Photo database
+	// Northwind asli.
+	OLEHeaderSize = 78
+)
+
+// ContentTypes adalah tipe gambar yang diterima.
+var ContentTypes = []string{"image/jpeg", "image/png", "image/gif", "image/bmp", "image/webp"}
+
+// StripOLE membuang header OLE Northwind kalau ada; data lain dikembalikan
+// apa adanya.
+func StripOLE(data []byte) []byte {
+	if len(data) > OLEHeaderSize+2 && data[0] == 0x15 && data[1] == 0x1c &&
+		string(data[OLEHeaderSize:OLEHeaderSize+2]) == "BM" {
+		return data[OLEHeaderSize:]
+	}
+	return data
+}
+
+// Inspect mendeteksi tipe dan dimensi data (tanpa header OLE) dan menolak
+// file yang bukan gambar yang didukung, rusak atau terlalu besar.
+func Inspect(data []byte) (models.ImageInfo, error) {
+	info := models.ImageInfo{ContentType: http.DetectContentType(data), Size: len(data)}
+	if len(data) == 0 {
+		return info, apperr.Validation("file", "must not be empty")
+	}
+	if len(data) > MaxSize {
+		return info, apperr.Validation("file", "must be at most %d MB", MaxSize>>20)
+	}
+	if !slices.Contains(ContentTypes, info.ContentType) {
+		return info, apperr.Validation("file", "unsupported content type %s; expected JPEG, PNG, GIF, BMP or WebP", info.ContentType)
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return info, apperr.Validation("file", "is not a valid %s image", info.ContentType).Wrap(err)
+	}
+	if cfg.Width > MaxDimension || cfg.Height > MaxDimension {
+		return info, apperr.Validation("file", "must be at most %dx%d pixels", MaxDimension, MaxDimension)
+	}
+	// Decode penuh (dimensinya sudah dibatasi) supaya file terpotong tidak
+	// tersimpan dan baru gagal saat thumbnail dibuat.
+	if _, _, err := image.Decode(bytes.NewReader(data)); err != nil {
+		return info, apperr.Validation("file", "is not a valid %s image", info.ContentType).Wrap(err)
+	}
+	info.Width, info.Height = cfg.Width, cfg.Height
+	return info, nil
+}
+
+// Describe membaca tipe, ukuran dan dimensi gambar yang sudah tersimpan dari
+// header-nya saja, tanpa decode penuh. Dimensinya 0 kalau header tidak bisa
+// dibaca.
+func Describe(data []byte) models.ImageInfo {
+	info := models.ImageInfo{ContentType: http.DetectContentType(data), Size: len(data)}
+	if cfg, _, err := image.DecodeConfig(bytes.NewReader(data)); err == nil {
+		info.Width, info.Height = cfg.Width, cfg.Height
+	}
+	return info
+}
+
+// Thumbnail memperkecil gambar supaya sisi terpanjangnya size piksel,
+// dengan rasio yang sama; gambar yang lebih kecil tidak diperbesar. Hasilnya
+// JPEG untuk sumber JPEG dan PNG untuk yang lain.
+func Thumbnail(data []byte, size int) ([]byte, string, error) {
+	src, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("error decoding image: %w", err)
+	}
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if longest := max(w, h); longest > size {
+		w, h = max(w*size/longest, 1), max(h*size/longest, 1)
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, b, draw.Src, nil)
+
+	var buf bytes.Buffer
+	if format == "jpeg" {
+		err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 85})
+		return buf.Bytes(), "image/jpeg", err
+	}
+	err = png.Encode(&buf, dst)
+	return buf.Bytes(), "image/png", err
+}
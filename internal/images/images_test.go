@@ -0,0 +1,121 @@
+package images
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"northwind-api/internal/apperr"
+
+	"golang.org/x/image/bmp"
+)
+
+func encode(t *testing.T, enc func(*bytes.Buffer, image.Image) error, w, h int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for x := range w {
+		img.Set(x, 0, color.RGBA{R: 200, A: 255})
+	}
+	var buf bytes.Buffer
+	if err := enc(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func pngOf(b *bytes.Buffer, m image.Image) error  { return png.Encode(b, m) }
+func bmpOf(b *bytes.Buffer, m image.Image) error  { return bmp.Encode(b, m) }
+func jpegOf(b *bytes.Buffer, m image.Image) error { return jpeg.Encode(b, m, nil) }
+
+func TestStripOLE(t *testing.T) {
+	raw := encode(t, bmpOf, 8, 4)
+	ole := append(append([]byte{0x15, 0x1c}, make([]byte, OLEHeaderSize-2)...), raw...)
+	if got := StripOLE(ole); !bytes.Equal(got, raw) {
+		t.Errorf("StripOLE(ole) kept %d bytes, want %d", len(got), len(raw))
+	}
+	if got := StripOLE(raw); !bytes.Equal(got, raw) {
+		t.Error("StripOLE changed a plain BMP")
+	}
+	info, err := Inspect(StripOLE(ole))
+	if err != nil || info.ContentType != "image/bmp" || info.Width != 8 || info.Height != 4 {
+		t.Errorf("Inspect = %+v, %v", info, err)
+	}
+}
+
+func TestInspectRejects(t *testing.T) {
+	tests := map[string][]byte{
+		"empty":     {},
+		"text":      []byte("hello, world"),
+		"truncated": encode(t, pngOf, 8, 8)[:40],
+		"too wide":  encode(t, pngOf, MaxDimension+1, 1),
+	}
+	for name, data := range tests {
+		if _, err := Inspect(data); !errors.Is(err, apperr.ErrValidation) {
+			t.Errorf("%s: err = %v, want validation error", name, err)
+		}
+	}
+}
+
+func TestThumbnail(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         []byte
+		size         int
+		wantType     string
+		wantW, wantH int
+	}{
+		{"png landscape", encode(t, pngOf, 40, 20), 16, "image/png", 16, 8},
+		{"bmp becomes png", encode(t, bmpOf, 20, 40), 10, "image/png", 5, 10},
+		{"jpeg stays jpeg", encode(t, jpegOf, 64, 64), 32, "image/jpeg", 32, 32},
+		{"not enlarged", encode(t, pngOf, 12, 6), 64, "image/png", 12, 6},
+	}
+	for _, tc := range tests {
+		out, contentType, err := Thumbnail(tc.data, tc.size)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		cfg, _, err := image.DecodeConfig(bytes.NewReader(out))
+		if err != nil || contentType != tc.wantType || cfg.Width != tc.wantW || cfg.Height != tc.wantH {
+			t.Errorf("%s: %s %dx%d (%v), want %s %dx%d", tc.name, contentType, cfg.Width, cfg.Height, err, tc.wantType, tc.wantW, tc.wantH)
+		}
+	}
+}
+
+func TestThumbnailCache(t *testing.T) {
+	ctx := context.Background()
+	a, b := encode(t, pngOf, 40, 20), encode(t, pngOf, 20, 40)
+	first, _, err := Thumbnail(a, 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := NewThumbnailCache(len(first), 1)
+
+	got, contentType, err := c.Thumbnail(ctx, a, 16)
+	if err != nil || !bytes.Equal(got, first) || contentType != "image/png" {
+		t.Fatalf("first = %d bytes %s, %v", len(got), contentType, err)
+	}
+	if again, _, _ := c.Thumbnail(ctx, a, 16); &again[0] != &got[0] {
+		t.Error("second call did not come from the cache")
+	}
+	// Thumbnail gambar lain tidak muat bersama yang pertama, jadi yang
+	// pertama dibuang.
+	if _, _, err := c.Thumbnail(ctx, b, 16); err != nil {
+		t.Fatal(err)
+	}
+	if again, _, _ := c.Thumbnail(ctx, a, 16); &again[0] == &got[0] {
+		t.Error("evicted thumbnail came from the cache")
+	}
+
+	// Kalau semua worker sibuk, pemanggil berhenti menunggu saat ctx selesai.
+	c.sem <- struct{}{}
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, _, err := c.Thumbnail(canceled, encode(t, pngOf, 8, 8), 16); !errors.Is(err, context.Canceled) {
+		t.Errorf("busy: err = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,161 @@
+// internal/middleware/conditional.go
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/etag"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Preconditions meneruskan header If-Match pada PUT/PATCH/DELETE ke services
+// (dicek di dalam transaksi terhadap state terbaru). Kalau required, request
+// tanpa If-Match ditolak dengan 428.
+func Preconditions(required bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodPut, http.MethodPatch, http.MethodDelete:
+		default:
+			c.Next()
+			return
+		}
+		header := c.GetHeader("If-Match")
+		if header == "" && required {
+			_ = c.Error(apperr.PreconditionRequired("If-Match header is required; send the ETag from a previous GET"))
+			c.Abort()
+			return
+		}
+		if header != "" {
+			c.Request = c.Request.WithContext(etag.WithIfMatch(c.Request.Context(), header))
+		}
+		c.Next()
+	}
+}
+
+// ConditionalGET memberi ETag pada response GET 200 dan menjawab 304 kalau
+// If-None-Match cocok. ETag yang sudah dipasang handler (versi resource)
+// dipakai apa adanya; selain itu dihitung dari body.
+func ConditionalGET() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet {
+			c.Next()
+			return
+		}
+		w := &bufferedWriter{ResponseWriter: c.Writer, status: http.StatusOK}
+		c.Writer = w
+		c.Next()
+		c.Writer = w.ResponseWriter
+		if w.streaming {
+			return
+		}
+		if w.status != http.StatusOK || w.body.Len() == 0 {
+			w.flush()
+			return
+		}
+
+		tag := w.Header().Get("ETag")
+		if tag == "" {
+			tag = etag.Bytes(w.body.Bytes())
+			w.Header().Set("ETag", tag)
+		}
+		if etag.Match(c.GetHeader("If-None-Match"), tag, true) {
+			w.Header().Del("Content-Type")
+			w.Header().Del("Content-Length")
+			w.ResponseWriter.WriteHeader(http.StatusNotModified)
+			w.ResponseWriter.WriteHeaderNow()
+			return
+		}
+		w.flush()
+	}
+}
+
+// bufferedWriter menahan body sampai handler selesai. Handler streaming
+// (yang memanggil Flush) langsung diteruskan tanpa buffer.
+type bufferedWriter struct {
+	gin.ResponseWriter
+	body      bytes.Buffer
+	status    int
+	written   bool
+	streaming bool
+}
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	if w.streaming {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	w.status = code
+	w.written = true
+}
+
+func (w *bufferedWriter) WriteHeaderNow() {
+	if w.streaming {
+		w.ResponseWriter.WriteHeaderNow()
+		return
+	}
+	w.written = true
+}
+
+func (w *bufferedWriter) Write(b []byte) (int, error) {
+	if w.streaming {
+		return w.ResponseWriter.Write(b)
+	}
+	w.written = true
+	return w.body.Write(b)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	if w.streaming {
+		return w.ResponseWriter.WriteString(s)
+	}
+	w.written = true
+	return w.body.WriteString(s)
+}
+
+func (w *bufferedWriter) Status() int {
+	if w.streaming {
+		return w.ResponseWriter.Status()
+	}
+	return w.status
+}
+
+func (w *bufferedWriter) Size() int {
+	if w.streaming {
+		return w.ResponseWriter.Size()
+	}
+	return w.body.Len()
+}
+
+func (w *bufferedWriter) Written() bool {
+	if w.streaming {
+		return w.ResponseWriter.Written()
+	}
+	return w.written
+}
+
+func (w *bufferedWriter) Flush() {
+	if !w.streaming {
+		w.streaming = true
+		w.flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// Unwrap dipakai http.ResponseController (mis. untuk SetWriteDeadline).
+func (w *bufferedWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *bufferedWriter) flush() {
+	if w.written {
+		w.ResponseWriter.WriteHeader(w.status)
+		w.ResponseWriter.WriteHeaderNow()
+	}
+	if w.body.Len() > 0 {
+		_, _ = w.ResponseWriter.Write(w.body.Bytes())
+		w.body.Reset()
+	}
+}
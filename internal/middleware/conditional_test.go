@@ -0,0 +1,54 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"northwind-api/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestConditionalGET(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middleware.ConditionalGET())
+	r.GET("/list", func(c *gin.Context) { c.JSON(http.StatusOK, []int{1, 2, 3}) })
+	r.GET("/missing", func(c *gin.Context) { c.JSON(http.StatusNotFound, gin.H{}) })
+	r.GET("/stream", func(c *gin.Context) {
+		c.Header("Content-Type", "text/event-stream")
+		c.String(http.StatusOK, "data: 1\n\n")
+		c.Writer.Flush()
+		c.String(http.StatusOK, "data: 2\n\n")
+	})
+
+	serve := func(path, ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	first := serve("/list", "")
+	tag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || tag == "" || first.Body.String() != "[1,2,3]" {
+		t.Fatalf("first: %d %q %q", first.Code, tag, first.Body.String())
+	}
+	if rec := serve("/list", "W/"+tag); rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+		t.Fatalf("weak If-None-Match: %d %q", rec.Code, rec.Body.String())
+	}
+	if rec := serve("/list", `"other"`); rec.Code != http.StatusOK {
+		t.Fatalf("non-matching If-None-Match: %d", rec.Code)
+	}
+	if rec := serve("/missing", ""); rec.Code != http.StatusNotFound || rec.Header().Get("ETag") != "" {
+		t.Fatalf("404 must pass through untagged: %d %q", rec.Code, rec.Header().Get("ETag"))
+	}
+	rec := serve("/stream", "")
+	if rec.Body.String() != "data: 1\n\ndata: 2\n\n" || rec.Header().Get("ETag") != "" || !rec.Flushed {
+		t.Fatalf("stream: %q etag=%q flushed=%v", rec.Body.String(), rec.Header().Get("ETag"), rec.Flushed)
+	}
+}
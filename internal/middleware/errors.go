@@ -2,32 +2,131 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/models"
 
 	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
 )
 
+const ContentTypeProblem = "application/problem+json"
+
 func NotFoundHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		c.JSON(http.StatusNotFound, gin.H{"error": "resource not found"})
+		WriteProblem(c, http.StatusNotFound, "resource not found")
 	}
 }
 
 func MethodNotAllowedHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		c.JSON(http.StatusMethodNotAllowed, gin.H{"error": "method not allowed"})
+		WriteProblem(c, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// StatusFor memetakan jenis apperr ke status HTTP. Error yang tidak dikenal selalu 500.
+func StatusFor(err error) int {
+	switch {
+	case errors.Is(err, apperr.ErrValidation):
+		return http.StatusBadRequest
+	case errors.Is(err, apperr.ErrUnauthorized):
+		return http.StatusUnauthorized
+	case errors.Is(err, apperr.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, apperr.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, apperr.ErrConflict), errors.Is(err, apperr.ErrForeignKey):
+		return http.StatusConflict
+	case errors.Is(err, apperr.ErrUnsupportedMediaType):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, apperr.ErrPreconditionFailed):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, apperr.ErrPreconditionRequired):
+		return http.StatusPreconditionRequired
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// problemTypes memberi URI "type" per jenis error; status lain memakai about:blank.
+var problemTypes = []struct {
+	kind  error
+	uri   string
+	title string
+}{
+	{apperr.ErrValidation, "/problems/validation-error", "Validation failed"},
+	{apperr.ErrUnauthorized, "/problems/unauthorized", "Unauthorized"},
+	{apperr.ErrForbidden, "/problems/forbidden", "Forbidden"},
+	{apperr.ErrNotFound, "/problems/not-found", "Resource not found"},
+	{apperr.ErrForeignKey, "/problems/foreign-key-violation", "Related resource conflict"},
+	{apperr.ErrConflict, "/problems/conflict", "Conflict"},
+	{apperr.ErrUnsupportedMediaType, "/problems/unsupported-media-type", "Unsupported media type"},
+	{apperr.ErrPreconditionFailed, "/problems/precondition-failed", "Precondition failed"},
+	{apperr.ErrPreconditionRequired, "/problems/precondition-required", "Precondition required"},
+}
+
+// problemFor membangun body problem+json untuk err. Detail internal
+// (query, driver error, dsb.) hanya masuk log, tidak ke client.
+func problemFor(err error, status int) models.Problem {
+	p := models.Problem{Type: "about:blank", Title: http.StatusText(status), Status: status}
+	if status >= http.StatusInternalServerError {
+		p.Detail = "internal server error"
+		return p
+	}
+	for _, t := range problemTypes {
+		if errors.Is(err, t.kind) {
+			p.Type, p.Title = t.uri, t.title
+			break
+		}
 	}
+	if e, ok := apperr.As(err); ok {
+		p.Detail = e.Message
+		for _, f := range e.FieldErrors() {
+			p.Errors = append(p.Errors, models.FieldError{Field: f.Field, Message: f.Message})
+		}
+		for _, b := range e.Blockers {
+			p.Blockers = append(p.Blockers, models.Blocker{Entity: b.Entity, Count: b.Count, IDs: b.IDs})
+		}
+	}
+	return p
 }
 
-// ErrorFormatter turns accumulated errors into a single 500 if not already written.
+// ProblemFor adalah problemFor dengan status dari StatusFor, untuk handler
+// yang melaporkan beberapa error sekaligus (mis. hasil bulk per item).
+func ProblemFor(err error) models.Problem {
+	return problemFor(err, StatusFor(err))
+}
+
+// WriteProblem menulis response application/problem+json dengan instance = request ID.
+func WriteProblem(c *gin.Context, status int, detail string) {
+	writeProblem(c, models.Problem{Type: "about:blank", Title: http.StatusText(status), Status: status, Detail: detail})
+}
+
+func writeProblem(c *gin.Context, p models.Problem) {
+	p.Instance = RequestIDFrom(c.Request.Context())
+	c.Header("Content-Type", ContentTypeProblem)
+	c.JSON(p.Status, p)
+}
+
+// ErrorFormatter mengubah error terakhir di c.Errors menjadi response
+// problem+json, kalau handler belum menulis response sendiri.
 func ErrorFormatter() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Next()
-		if len(c.Errors) > 0 && !c.Writer.Written() {
-			// In production, avoid leaking internals. Log c.Errors for observability.
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"error": "internal server error",
-			})
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		err := c.Errors.Last().Err
+		status := StatusFor(err)
+		if status >= http.StatusInternalServerError {
+			log.Error().Err(err).
+				Str("method", c.Request.Method).
+				Str("path", c.Request.URL.Path).
+				Str("request_id", RequestIDFrom(c.Request.Context())).
+				Msg("request failed")
 		}
+		writeProblem(c, problemFor(err, status))
 	}
 }
@@ -0,0 +1,68 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/middleware"
+	"northwind-api/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestErrorFormatter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		err        error
+		want       int
+		wantType   string
+		wantDetail string
+		wantFields int
+	}{
+		{"validation", apperr.Validation("freight", "must not be negative"), 400, "/problems/validation-error", "must not be negative", 1},
+		{"invalid fields", apperr.Invalid(apperr.FieldError{Field: "a", Message: "is required"}, apperr.FieldError{Field: "b", Message: "is required"}), 400, "/problems/validation-error", "request has invalid fields", 2},
+		{"unauthorized", apperr.Unauthorized("missing token"), 401, "/problems/unauthorized", "missing token", 0},
+		{"not found", apperr.NotFound("order with ID %d not found", 1), 404, "/problems/not-found", "order with ID 1 not found", 0},
+		{"conflict", apperr.Conflict("already exists"), 409, "/problems/conflict", "already exists", 0},
+		{"foreign key", apperr.ForeignKey("still in use").Wrap(errors.New("FOREIGN KEY constraint failed")), 409, "/problems/foreign-key-violation", "still in use", 0},
+		{"internal", errors.New("SQL logic error: no such table: Orders"), 500, "about:blank", "internal server error", 0},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(middleware.RequestID(), middleware.ErrorFormatter())
+			r.GET("/", func(c *gin.Context) { _ = c.Error(tc.err) })
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			if rec.Code != tc.want {
+				t.Fatalf("status = %d, want %d", rec.Code, tc.want)
+			}
+			if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, middleware.ContentTypeProblem) {
+				t.Fatalf("Content-Type = %q", ct)
+			}
+			body := rec.Body.String()
+			var p models.Problem
+			if err := json.Unmarshal(rec.Body.Bytes(), &p); err != nil {
+				t.Fatal(err)
+			}
+			if p.Status != tc.want || p.Type != tc.wantType || p.Detail != tc.wantDetail || len(p.Errors) != tc.wantFields {
+				t.Fatalf("unexpected problem: %s", body)
+			}
+			if p.Instance == "" || p.Instance != rec.Header().Get(middleware.HeaderRequestID) {
+				t.Fatalf("instance %q does not match request ID", p.Instance)
+			}
+			if strings.Contains(body, "constraint") || strings.Contains(body, "SQL") {
+				t.Fatalf("internal detail leaked: %s", body)
+			}
+		})
+	}
+}
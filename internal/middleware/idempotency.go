@@ -0,0 +1,137 @@
+// internal/middleware/idempotency.go
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/models"
+	"northwind-api/internal/repositories"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	HeaderIdempotencyKey = "Idempotency-Key"
+	// HeaderIdempotentReplayed menandai response yang diambil dari simpanan, bukan diproses ulang.
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLen = 255
+)
+
+// Idempotency membuat POST dengan header Idempotency-Key aman di-retry:
+// response 2xx pertama disimpan selama ttl dan diputar ulang untuk request
+// yang sama, sedangkan key yang dipakai ulang dengan body lain ditolak 409.
+// Request yang gagal tidak disimpan, jadi boleh diulang dengan key yang sama.
+func Idempotency(store repositories.IdempotencyStore, ttl time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(HeaderIdempotencyKey)
+		if c.Request.Method != http.MethodPost || key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLen {
+			abortWith(c, apperr.Validation(HeaderIdempotencyKey, "must be at most %d characters", maxIdempotencyKeyLen))
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			abortWith(c, apperr.Validation("", "could not read request body").Wrap(err))
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := c.Request.Context()
+		now := time.Now().UTC()
+		rec := models.IdempotencyRecord{
+			Key:         key,
+			Fingerprint: fingerprint(c.Request, body),
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(ttl),
+		}
+		if err := store.ReserveIdempotencyKey(ctx, rec); err != nil {
+			if errors.Is(err, apperr.ErrConflict) {
+				replay(c, store, rec)
+				return
+			}
+			abortWith(c, err)
+			return
+		}
+
+		w := &teeWriter{ResponseWriter: c.Writer}
+		c.Writer = w
+		c.Next()
+		c.Writer = w.ResponseWriter
+
+		// Simpan/lepas key walaupun client sudah memutus koneksi.
+		ctx = context.WithoutCancel(ctx)
+		status := w.Status()
+		if w.Written() && status >= 200 && status < 300 {
+			err = store.CompleteIdempotencyKey(ctx, key, status, w.Header().Get("Content-Type"), w.body.Bytes())
+		} else {
+			err = store.ReleaseIdempotencyKey(ctx, key)
+		}
+		if err != nil {
+			log.Error().Err(err).Str("idempotency_key", key).Msg("failed to record idempotent response")
+		}
+	}
+}
+
+// replay menjawab request yang key-nya sudah pernah dipakai.
+func replay(c *gin.Context, store repositories.IdempotencyStore, rec models.IdempotencyRecord) {
+	existing, err := store.GetIdempotencyKey(c.Request.Context(), rec.Key)
+	switch {
+	case errors.Is(err, apperr.ErrNotFound):
+		abortWith(c, apperr.Conflict("request with this Idempotency-Key just finished or expired; retry"))
+	case err != nil:
+		abortWith(c, err)
+	case existing.Fingerprint != rec.Fingerprint:
+		abortWith(c, apperr.Conflict("Idempotency-Key was already used with a different request"))
+	case existing.StatusCode == 0:
+		abortWith(c, apperr.Conflict("a request with this Idempotency-Key is still being processed"))
+	default:
+		c.Header(HeaderIdempotentReplayed, "true")
+		c.Data(existing.StatusCode, existing.ContentType, existing.Body)
+		c.Abort()
+	}
+}
+
+// fingerprint mengikat key ke method, path, credential dan body request,
+// supaya key milik client lain atau body lain tidak memutar ulang response.
+func fingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	io.WriteString(h, r.Method+" "+r.URL.RequestURI()+"\n")
+	io.WriteString(h, r.Header.Get("Authorization")+"\n")
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func abortWith(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.Abort()
+}
+
+// teeWriter meneruskan response ke client sambil menyalin body-nya.
+type teeWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *teeWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *teeWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,34 @@
+// internal/middleware/include_deleted.go
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/repositories"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IncludeDeleted membaca ?include_deleted=true pada GET dan meneruskannya ke
+// repositories supaya baris yang sudah di-soft delete ikut dikembalikan.
+func IncludeDeleted() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		raw, ok := c.GetQuery("include_deleted")
+		if c.Request.Method != http.MethodGet || !ok {
+			c.Next()
+			return
+		}
+		include, err := strconv.ParseBool(raw)
+		if err != nil {
+			_ = c.Error(apperr.Validation("include_deleted", "must be a boolean"))
+			c.Abort()
+			return
+		}
+		if include {
+			c.Request = c.Request.WithContext(repositories.WithDeleted(c.Request.Context()))
+		}
+		c.Next()
+	}
+}
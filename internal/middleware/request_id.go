@@ -2,17 +2,31 @@
 package middleware
 
 import (
+	"context"
+
+	"northwind-api/internal/requestctx"
+
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
 const HeaderRequestID = "X-Request-ID"
 
+// RequestID memakai X-Request-ID dari client kalau ada, atau membuat yang baru.
+// ID dikembalikan di response header dan disimpan di context request.
 func RequestID() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		if c.GetHeader(HeaderRequestID) == "" {
-			c.Writer.Header().Set(HeaderRequestID, uuid.NewString())
+		id := c.GetHeader(HeaderRequestID)
+		if id == "" {
+			id = uuid.NewString()
 		}
+		c.Writer.Header().Set(HeaderRequestID, id)
+		c.Request = c.Request.WithContext(requestctx.WithID(c.Request.Context(), id))
 		c.Next()
 	}
 }
+
+// RequestIDFrom mengembalikan request ID yang dipasang oleh RequestID, atau "".
+func RequestIDFrom(ctx context.Context) string {
+	return requestctx.ID(ctx)
+}
@@ -0,0 +1,72 @@
+// Package migrations menambahkan tabel/kolom milik API di atas skema
+// Northwind. File .sql di folder sql/ dijalankan berurutan sekali saja,
+// dicatat di tabel schema_migrations.
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:embed sql/*.sql
+var files embed.FS
+
+// Apply menjalankan migration yang belum tercatat, masing-masing dalam satu transaksi.
+func Apply(ctx context.Context, db *sql.DB) error {
+	if _, err := db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version    TEXT PRIMARY KEY,
+			applied_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ', 'now'))
+		)`); err != nil {
+		return fmt.Errorf("creating schema_migrations: %w", err)
+	}
+
+	names, err := fs.Glob(files, "sql/*.sql")
+	if err != nil {
+		return err
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		version := strings.TrimSuffix(strings.TrimPrefix(name, "sql/"), ".sql")
+		var exists int
+		if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM schema_migrations WHERE version = ?`, version).Scan(&exists); err != nil {
+			return fmt.Errorf("checking migration %s: %w", version, err)
+		}
+		if exists > 0 {
+			continue
+		}
+		body, err := files.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		if err := apply(ctx, db, version, string(body)); err != nil {
+			return fmt.Errorf("migration %s: %w", version, err)
+		}
+		log.Info().Str("version", version).Msg("migration applied")
+	}
+	return nil
+}
+
+func apply(ctx context.Context, db *sql.DB, version, body string) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, body); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version) VALUES (?)`, version); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
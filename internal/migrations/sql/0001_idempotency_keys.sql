@@ -0,0 +1,12 @@
+-- Response POST yang disimpan per Idempotency-Key supaya retry tidak membuat data ganda.
+CREATE TABLE IF NOT EXISTS IdempotencyKeys (
+    IdempotencyKey TEXT PRIMARY KEY,
+    Fingerprint    TEXT    NOT NULL,
+    StatusCode     INTEGER NOT NULL DEFAULT 0, -- 0 = request masih diproses
+    ContentType    TEXT,
+    ResponseBody   BLOB,
+    CreatedAt      TEXT    NOT NULL,
+    ExpiresAt      TEXT    NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS IdempotencyKeys_ExpiresAt ON IdempotencyKeys (ExpiresAt);
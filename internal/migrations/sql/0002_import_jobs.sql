@@ -0,0 +1,20 @@
+-- Job import CSV (products, customers, suppliers). File asli disimpan supaya
+-- commit bisa dijalankan terpisah dari dry-run.
+CREATE TABLE IF NOT EXISTS ImportJobs (
+    JobID        TEXT PRIMARY KEY,
+    Entity       TEXT    NOT NULL,
+    Status       TEXT    NOT NULL,
+    FileName     TEXT,
+    Mapping      TEXT    NOT NULL DEFAULT '{}', -- JSON: kolom CSV -> field
+    Data         BLOB    NOT NULL,
+    TotalRows    INTEGER NOT NULL DEFAULT 0,
+    ValidRows    INTEGER NOT NULL DEFAULT 0,
+    InvalidRows  INTEGER NOT NULL DEFAULT 0,
+    ImportedRows INTEGER NOT NULL DEFAULT 0,
+    Errors       TEXT    NOT NULL DEFAULT '[]', -- JSON: error per baris
+    Message      TEXT,
+    CreatedAt    TEXT    NOT NULL,
+    UpdatedAt    TEXT    NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS ImportJobs_CreatedAt ON ImportJobs (CreatedAt);
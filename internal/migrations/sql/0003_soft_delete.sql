@@ -0,0 +1,8 @@
+-- Soft delete untuk master data: baris yang dihapus tetap ada untuk order lama
+-- dan laporan, tapi disembunyikan dari list kecuali ?include_deleted=true.
+ALTER TABLE Customers  ADD COLUMN DeletedAt TEXT;
+ALTER TABLE Products   ADD COLUMN DeletedAt TEXT;
+ALTER TABLE Suppliers  ADD COLUMN DeletedAt TEXT;
+ALTER TABLE Employees  ADD COLUMN DeletedAt TEXT;
+ALTER TABLE Shippers   ADD COLUMN DeletedAt TEXT;
+ALTER TABLE Categories ADD COLUMN DeletedAt TEXT;
@@ -0,0 +1,14 @@
+-- Audit log: satu baris per create/update/delete yang di-commit lewat services,
+-- ditulis di transaksi yang sama dengan perubahannya.
+CREATE TABLE IF NOT EXISTS AuditLog (
+    AuditID    INTEGER PRIMARY KEY AUTOINCREMENT,
+    Entity     TEXT NOT NULL, -- nama resource, mis. "orders"
+    EntityID   TEXT NOT NULL,
+    Action     TEXT NOT NULL, -- created | updated | deleted | restored
+    Actor      TEXT NOT NULL,
+    RequestID  TEXT,
+    Changes    TEXT NOT NULL DEFAULT '{}', -- JSON: field -> {before, after}
+    OccurredAt TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS AuditLog_Entity ON AuditLog (Entity, EntityID, AuditID);
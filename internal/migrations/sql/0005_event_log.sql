@@ -0,0 +1,14 @@
+-- Event log: satu baris per domain event yang di-commit lewat services, ditulis
+-- di transaksi yang sama. EventID dipakai sebagai Last-Event-ID untuk resume stream.
+CREATE TABLE IF NOT EXISTS EventLog (
+    EventID    INTEGER PRIMARY KEY AUTOINCREMENT,
+    Type       TEXT NOT NULL, -- mis. "order.created"
+    Entity     TEXT NOT NULL, -- nama resource, mis. "orders"
+    EntityID   TEXT NOT NULL,
+    Action     TEXT NOT NULL, -- created | updated | deleted | restored
+    Before     TEXT,          -- JSON snapshot, NULL untuk create
+    After      TEXT,          -- JSON snapshot, NULL untuk delete
+    OccurredAt TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS EventLog_Entity ON EventLog (Entity, EventID);
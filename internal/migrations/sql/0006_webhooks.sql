@@ -0,0 +1,39 @@
+-- Webhook: subscription partner, outbox pengiriman (ditulis di transaksi yang
+-- sama dengan perubahan datanya) dan log setiap percobaan kirim.
+CREATE TABLE IF NOT EXISTS Webhooks (
+    WebhookID INTEGER PRIMARY KEY AUTOINCREMENT,
+    URL       TEXT    NOT NULL,
+    Events    TEXT    NOT NULL DEFAULT '[]', -- JSON: tipe event, mis. ["order.shipped"]
+    Secret    TEXT    NOT NULL,              -- kunci HMAC-SHA256 untuk signature
+    Active    INTEGER NOT NULL DEFAULT 1,
+    CreatedAt TEXT    NOT NULL,
+    UpdatedAt TEXT    NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS WebhookDeliveries (
+    DeliveryID     INTEGER PRIMARY KEY AUTOINCREMENT,
+    WebhookID      INTEGER NOT NULL REFERENCES Webhooks (WebhookID) ON DELETE CASCADE,
+    EventType      TEXT    NOT NULL,
+    Payload        TEXT    NOT NULL, -- JSON body yang dikirim
+    Status         TEXT    NOT NULL, -- pending | delivered | dead
+    Attempts       INTEGER NOT NULL DEFAULT 0,
+    NextAttemptAt  TEXT,             -- NULL kalau sudah delivered/dead
+    LastStatusCode INTEGER,
+    LastError      TEXT,
+    CreatedAt      TEXT    NOT NULL,
+    DeliveredAt    TEXT
+);
+
+CREATE INDEX IF NOT EXISTS WebhookDeliveries_Due ON WebhookDeliveries (Status, NextAttemptAt);
+CREATE INDEX IF NOT EXISTS WebhookDeliveries_Webhook ON WebhookDeliveries (WebhookID, DeliveryID);
+
+CREATE TABLE IF NOT EXISTS WebhookAttempts (
+    AttemptID   INTEGER PRIMARY KEY AUTOINCREMENT,
+    DeliveryID  INTEGER NOT NULL REFERENCES WebhookDeliveries (DeliveryID) ON DELETE CASCADE,
+    AttemptedAt TEXT    NOT NULL,
+    StatusCode  INTEGER, -- NULL kalau request gagal sebelum dapat response
+    Error       TEXT,
+    DurationMs  INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS WebhookAttempts_Delivery ON WebhookAttempts (DeliveryID, AttemptID);
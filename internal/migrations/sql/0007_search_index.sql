@@ -0,0 +1,30 @@
+-- Index full-text untuk /search dan ?q= di list customers, products dan
+-- suppliers. Diperbarui services di transaksi yang sama dengan perubahannya;
+-- baris yang di-soft delete dikeluarkan dari index.
+CREATE VIRTUAL TABLE IF NOT EXISTS SearchIndex USING fts5(
+    Entity UNINDEXED,   -- customers | products | suppliers
+    EntityID UNINDEXED,
+    Title,              -- nama utama, bobotnya lebih besar saat ranking
+    Body,               -- kontak, alamat, dsb.
+    tokenize = 'unicode61 remove_diacritics 2',
+    prefix = '2 3'
+);
+
+-- Daftar kata di index, dipakai untuk mencari ejaan yang mirip (typo).
+CREATE VIRTUAL TABLE IF NOT EXISTS SearchVocab USING fts5vocab(SearchIndex, 'row');
+
+INSERT INTO SearchIndex (Entity, EntityID, Title, Body)
+SELECT 'customers', CustomerID, COALESCE(CompanyName, ''),
+       TRIM(COALESCE(ContactName, '') || ' ' || COALESCE(ContactTitle, '') || ' ' || COALESCE(Address, '') || ' ' ||
+            COALESCE(City, '') || ' ' || COALESCE(Region, '') || ' ' || COALESCE(PostalCode, '') || ' ' || COALESCE(Country, ''))
+FROM Customers WHERE DeletedAt IS NULL;
+
+INSERT INTO SearchIndex (Entity, EntityID, Title, Body)
+SELECT 'products', CAST(ProductID AS TEXT), COALESCE(ProductName, ''), COALESCE(QuantityPerUnit, '')
+FROM Products WHERE DeletedAt IS NULL;
+
+INSERT INTO SearchIndex (Entity, EntityID, Title, Body)
+SELECT 'suppliers', CAST(SupplierID AS TEXT), COALESCE(CompanyName, ''),
+       TRIM(COALESCE(ContactName, '') || ' ' || COALESCE(ContactTitle, '') || ' ' || COALESCE(Address, '') || ' ' ||
+            COALESCE(City, '') || ' ' || COALESCE(Region, '') || ' ' || COALESCE(PostalCode, '') || ' ' || COALESCE(Country, ''))
+FROM Suppliers WHERE DeletedAt IS NULL;
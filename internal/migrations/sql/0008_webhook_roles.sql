@@ -0,0 +1,8 @@
+-- Role pembuat/pengubah terakhir webhook. Payload dibentuk dengan role ini
+-- supaya subscriber tidak menerima field yang tidak boleh dilihatnya.
+-- NULL berarti disimpan tanpa identitas (auth mati), jadi tidak dibatasi.
+-- Webhook lama belum punya role, jadi diperlakukan seperti caller tanpa role
+-- sampai disimpan ulang.
+ALTER TABLE Webhooks ADD COLUMN Roles TEXT;
+
+UPDATE Webhooks SET Roles = '[]';
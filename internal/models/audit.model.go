@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// AuditEntry mencatat satu perubahan data: siapa, kapan, lewat request apa,
+// dan field mana yang berubah.
+type AuditEntry struct {
+	AuditID    int64                  `json:"audit_id" db:"AuditID" example:"42"`
+	Entity     string                 `json:"entity" db:"Entity" example:"orders"`
+	EntityID   string                 `json:"entity_id" db:"EntityID" example:"10248"`
+	Action     string                 `json:"action" db:"Action" example:"updated"`
+	Actor      string                 `json:"actor" db:"Actor" example:"nancy"`
+	RequestID  string                 `json:"request_id,omitempty" db:"RequestID" example:"3f1c2a9e-7b7d-4d8e-9a43-1c0e4b1d2f60"`
+	Changes    map[string]AuditChange `json:"changes" db:"Changes"`
+	OccurredAt time.Time              `json:"occurred_at" db:"OccurredAt"`
+}
+
+// AuditChange adalah nilai satu field sebelum dan sesudah perubahan;
+// before null untuk create, after null untuk delete.
+type AuditChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
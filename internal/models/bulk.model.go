@@ -0,0 +1,38 @@
+package models
+
+import "encoding/json"
+
+// BulkOperation adalah satu baris di body /{resource}/bulk (JSON array atau NDJSON).
+type BulkOperation struct {
+	Op string `json:"op" enums:"create,update,patch,delete" example:"update"`
+	// ID wajib untuk update, patch dan delete; angka atau string sesuai resource.
+	ID json.RawMessage `json:"id,omitempty" swaggertype:"string" example:"1"`
+	// Data adalah resource lengkap (create/update) atau merge patch (patch).
+	Data json.RawMessage `json:"data,omitempty" swaggertype:"object"`
+	// IfMatch opsional, sama seperti header If-Match pada request tunggal.
+	IfMatch string `json:"if_match,omitempty"`
+}
+
+// BulkItemResult adalah hasil satu operasi; Index menunjuk posisi di request.
+type BulkItemResult struct {
+	Index  int      `json:"index" example:"0"`
+	Op     string   `json:"op" example:"update"`
+	ID     string   `json:"id,omitempty" example:"1"`
+	Status int      `json:"status" example:"200"`
+	Error  *Problem `json:"error,omitempty"`
+}
+
+// BulkResponse dikirim kalau batch diproses (seluruhnya atau best-effort).
+type BulkResponse struct {
+	Mode      string           `json:"mode" example:"atomic"`
+	Succeeded int              `json:"succeeded" example:"2"`
+	Failed    int              `json:"failed" example:"0"`
+	Results   []BulkItemResult `json:"results"`
+}
+
+// BulkProblem adalah problem+json untuk batch atomic yang di-rollback,
+// dengan hasil per item sebagai extension member.
+type BulkProblem struct {
+	Problem
+	Results []BulkItemResult `json:"results"`
+}
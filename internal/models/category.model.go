@@ -1,8 +1,11 @@
 package models
 
+import "time"
+
 type Category struct {
-	CategoryID   int64   `json:"category_id" db:"CategoryID"`     // INTEGER, Auto Increment
-	CategoryName *string `json:"category_name" db:"CategoryName"` // TEXT, nullable
-	Description  *string `json:"description" db:"Description"`    // TEXT, nullable
-	Picture      []byte  `json:"picture" db:"Picture"`            // BLOB, nullable
+	CategoryID   int64      `json:"category_id" db:"CategoryID"`                                        // INTEGER, Auto Increment
+	CategoryName *string    `json:"category_name" db:"CategoryName" binding:"required,notblank,max=15"` // TEXT, nullable
+	Description  *string    `json:"description" db:"Description"`                                       // TEXT, nullable
+	Picture      []byte     `json:"-" db:"Picture"`                                                     // BLOB, lewat /categories/{id}/picture
+	DeletedAt    *time.Time `json:"deleted_at,omitempty" db:"DeletedAt"`                                // TEXT, diisi saat soft delete
 }
@@ -1,15 +1,43 @@
 package models
 
+import "time"
+
+// Customer: data kontak (contact_name, contact_title, phone, fax) null untuk
+// caller tanpa role sales (lihat package visibility).
 type Customer struct {
-	CustomerID   string `json:"customer_id"`
-	CompanyName  string `json:"company_name"`
-	ContactName  string `json:"contact_name"`
-	ContactTitle string `json:"contact_title"`
-	Address      string `json:"address"`
-	City         string `json:"city"`
-	Region       string `json:"region"`
-	PostalCode   string `json:"postal_code"`
-	Country      string `json:"country"`
-	Phone        string `json:"phone"`
-	Fax          string `json:"fax"`
+	CustomerID   string     `json:"customer_id" db:"CustomerID" binding:"omitempty,max=36"` // 5 huruf (ALFKI) atau UUID, lihat services.CustomerIDStrategy
+	CompanyName  string     `json:"company_name" db:"CompanyName" binding:"required,notblank,max=40"`
+	ContactName  string     `json:"contact_name" db:"ContactName" binding:"max=30" visible:"sales"`
+	ContactTitle string     `json:"contact_title" db:"ContactTitle" binding:"max=30" visible:"sales"`
+	Address      string     `json:"address" db:"Address" binding:"max=60"`
+	City         string     `json:"city" db:"City" binding:"max=15"`
+	Region       string     `json:"region" db:"Region" binding:"max=15"`
+	PostalCode   string     `json:"postal_code" db:"PostalCode" binding:"max=10"`
+	Country      string     `json:"country" db:"Country" binding:"omitempty,max=15,country"`
+	Phone        string     `json:"phone" db:"Phone" binding:"max=24" visible:"sales"`
+	Fax          string     `json:"fax" db:"Fax" binding:"max=24" visible:"sales"`
+	DeletedAt    *time.Time `json:"deleted_at,omitempty" db:"DeletedAt"`
+}
+
+// CustomerDuplicate adalah pasangan customer yang kemungkinan perusahaan yang sama.
+type CustomerDuplicate struct {
+	Customers []Customer `json:"customers"`
+	// Score 0..1, rata-rata berbobot dari Matches.
+	Score float64 `json:"score" example:"0.92"`
+	// Matches berisi kemiripan per sinyal: company_name, phone, address.
+	// Sinyal yang kosong di salah satu customer tidak ikut dinilai.
+	Matches map[string]float64 `json:"matches"`
+}
+
+type CustomerMergeRequest struct {
+	// DuplicateIDs digabung ke customer di path lalu di-soft delete.
+	DuplicateIDs []string `json:"duplicate_ids" binding:"required,min=1,max=20,dive,required" example:"ALFKJ"`
+	// FillBlanks mengisi field kosong customer tujuan dari duplikatnya.
+	FillBlanks bool `json:"fill_blanks"`
+}
+
+type CustomerMergeResult struct {
+	Customer      Customer `json:"customer"`
+	MergedIDs     []string `json:"merged_ids" example:"ALFKJ"`
+	MovedOrderIDs []int64  `json:"moved_order_ids" example:"10643"`
 }
@@ -1,22 +1,27 @@
 package models
 
+import "time"
+
+// Employee: birth_date, address dan home_phone null, notes tidak dikirim,
+// untuk caller tanpa role hr (lihat package visibility).
 type Employee struct {
-	EmployeeID      int    `json:"employee_id"`
-	LastName        string `json:"last_name"`
-	FirstName       string `json:"first_name"`
-	Title           string `json:"title"`
-	TitleOfCourtesy string `json:"title_of_courtesy"`
-	BirthDate       string `json:"birth_date"`
-	HireDate        string `json:"hire_date"`
-	Address         string `json:"address"`
-	City            string `json:"city"`
-	Region          string `json:"region"`
-	PostalCode      string `json:"postal_code"`
-	Country         string `json:"country"`
-	HomePhone       string `json:"home_phone"`
-	Extension       string `json:"extension"`
-	Photo           []byte `json:"photo"`
-	Notes           string `json:"notes"`
-	ReportsTo       *int   `json:"reports_to"`
-	PhotoPath       string `json:"photo_path"`
+	EmployeeID      int        `json:"employee_id" db:"EmployeeID"`
+	LastName        string     `json:"last_name" db:"LastName" binding:"required,notblank,max=20"`
+	FirstName       string     `json:"first_name" db:"FirstName" binding:"required,notblank,max=10"`
+	Title           string     `json:"title" db:"Title" binding:"max=30"`
+	TitleOfCourtesy string     `json:"title_of_courtesy" db:"TitleOfCourtesy" binding:"max=25"`
+	BirthDate       string     `json:"birth_date" db:"BirthDate" binding:"omitempty,isodate" visible:"hr"`
+	HireDate        string     `json:"hire_date" db:"HireDate" binding:"omitempty,isodate"`
+	Address         string     `json:"address" db:"Address" binding:"max=60" visible:"hr"`
+	City            string     `json:"city" db:"City" binding:"max=15"`
+	Region          string     `json:"region" db:"Region" binding:"max=15"`
+	PostalCode      string     `json:"postal_code" db:"PostalCode" binding:"max=10"`
+	Country         string     `json:"country" db:"Country" binding:"omitempty,max=15,country"`
+	HomePhone       string     `json:"home_phone" db:"HomePhone" binding:"max=24" visible:"hr"`
+	Extension       string     `json:"extension" db:"Extension" binding:"max=4"`
+	Photo           []byte     `json:"-" db:"Photo"` // lewat /employees/{id}/photo
+	Notes           string     `json:"notes" db:"Notes" visible:"hr,omit"`
+	ReportsTo       *int       `json:"reports_to" db:"ReportsTo" binding:"omitempty,gt=0"`
+	PhotoPath       string     `json:"photo_path" db:"PhotoPath" binding:"max=255"`
+	DeletedAt       *time.Time `json:"deleted_at,omitempty" db:"DeletedAt"`
 }
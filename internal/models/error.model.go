@@ -1,5 +1,25 @@
 package models
 
-type ErrorResponse struct {
-	Error string `json:"error"`
+// Problem adalah body error RFC 7807 (application/problem+json).
+type Problem struct {
+	Type     string       `json:"type" example:"/problems/validation-error"`
+	Title    string       `json:"title" example:"Validation failed"`
+	Status   int          `json:"status" example:"400"`
+	Detail   string       `json:"detail,omitempty" example:"request has invalid fields"`
+	Instance string       `json:"instance,omitempty" example:"3f1c2a9e-7b7d-4d8e-9a43-1c0e4b1d2f60"`
+	Errors   []FieldError `json:"errors,omitempty"`
+	Blockers []Blocker    `json:"blockers,omitempty"`
+}
+
+// FieldError menjelaskan satu field yang gagal validasi.
+type FieldError struct {
+	Field   string `json:"field" example:"company_name"`
+	Message string `json:"message" example:"is required"`
+}
+
+// Blocker merangkum data yang masih merujuk resource yang mau dihapus.
+type Blocker struct {
+	Entity string   `json:"entity" example:"products"`
+	Count  int      `json:"count" example:"3"`
+	IDs    []string `json:"ids,omitempty"`
 }
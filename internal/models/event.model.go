@@ -0,0 +1,19 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ChangeEvent adalah domain event yang sudah tersimpan di event log dan
+// dikirim ke client stream. EventID naik terus dan dipakai untuk resume.
+type ChangeEvent struct {
+	EventID    int64           `json:"event_id" db:"EventID" example:"42"`
+	Type       string          `json:"type" db:"Type" example:"order.updated"`
+	Entity     string          `json:"entity" db:"Entity" example:"orders"`
+	EntityID   string          `json:"entity_id" db:"EntityID" example:"10248"`
+	Action     string          `json:"action" db:"Action" example:"updated"`
+	Before     json.RawMessage `json:"before,omitempty" db:"Before" swaggertype:"object"`
+	After      json.RawMessage `json:"after,omitempty" db:"After" swaggertype:"object"`
+	OccurredAt time.Time       `json:"occurred_at" db:"OccurredAt"`
+}
@@ -0,0 +1,19 @@
+package models
+
+// GraphQLRequest adalah body standar GraphQL over HTTP.
+type GraphQLRequest struct {
+	Query         string         `json:"query" binding:"required,notblank" example:"{ customers(limit: 2) { items { customerId companyName } totalCount } }"`
+	Variables     map[string]any `json:"variables,omitempty" swaggertype:"object"`
+	OperationName string         `json:"operationName,omitempty"`
+}
+
+// GraphQLResponse hanya untuk dokumentasi; body sebenarnya ditulis oleh library GraphQL.
+type GraphQLResponse struct {
+	Data   map[string]any `json:"data,omitempty" swaggertype:"object"`
+	Errors []GraphQLError `json:"errors,omitempty"`
+}
+
+type GraphQLError struct {
+	Message string `json:"message" example:"limit: must be between 1 and 500"`
+	Path    []any  `json:"path,omitempty" swaggertype:"array,string"`
+}
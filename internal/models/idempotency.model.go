@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// IdempotencyRecord menyimpan hasil POST untuk satu Idempotency-Key.
+// StatusCode 0 berarti request pertama masih diproses.
+type IdempotencyRecord struct {
+	Key         string    `db:"IdempotencyKey"`
+	Fingerprint string    `db:"Fingerprint"`
+	StatusCode  int       `db:"StatusCode"`
+	ContentType string    `db:"ContentType"`
+	Body        []byte    `db:"ResponseBody"`
+	CreatedAt   time.Time `db:"CreatedAt"`
+	ExpiresAt   time.Time `db:"ExpiresAt"`
+}
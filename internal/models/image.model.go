@@ -0,0 +1,16 @@
+package models
+
+// ImageInfo menjelaskan foto employee atau gambar category yang tersimpan.
+type ImageInfo struct {
+	ContentType string `json:"content_type" example:"image/jpeg"`
+	Size        int    `json:"size" example:"21626"` // byte
+	Width       int    `json:"width" example:"160"`
+	Height      int    `json:"height" example:"120"`
+}
+
+// Image adalah isi gambar yang dikirim apa adanya oleh GET .../photo dan
+// .../picture.
+type Image struct {
+	Data        []byte
+	ContentType string
+}
@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// Status job import. Alurnya: validating -> validated -> committing -> completed,
+// atau failed kalau file tidak bisa diproses / commit gagal.
+const (
+	ImportValidating = "validating"
+	ImportValidated  = "validated"
+	ImportCommitting = "committing"
+	ImportCompleted  = "completed"
+	ImportFailed     = "failed"
+)
+
+// ImportJob adalah satu upload CSV beserta hasil dry-run dan commit-nya.
+type ImportJob struct {
+	JobID    string `json:"job_id" db:"JobID" example:"5b0f1c7e-3d0a-4f43-9b61-0f5f8d1f2a10"`
+	Entity   string `json:"entity" db:"Entity" example:"products"`
+	Status   string `json:"status" db:"Status" example:"validated"`
+	FileName string `json:"file_name,omitempty" db:"FileName" example:"price-list.csv"`
+	// Mapping memetakan nama kolom CSV ke field JSON resource.
+	Mapping      map[string]string `json:"mapping" db:"Mapping"`
+	TotalRows    int               `json:"total_rows" db:"TotalRows" example:"120"`
+	ValidRows    int               `json:"valid_rows" db:"ValidRows" example:"118"`
+	InvalidRows  int               `json:"invalid_rows" db:"InvalidRows" example:"2"`
+	ImportedRows int               `json:"imported_rows" db:"ImportedRows" example:"0"`
+	Errors       []ImportRowError  `json:"errors,omitempty" db:"Errors"`
+	Message      string            `json:"message,omitempty" db:"Message"`
+	CreatedAt    time.Time         `json:"created_at" db:"CreatedAt"`
+	UpdatedAt    time.Time         `json:"updated_at" db:"UpdatedAt"`
+	// Data adalah isi file CSV; tidak pernah dikirim ke client dan dibuang
+	// begitu job selesai.
+	Data []byte `json:"-" db:"Data"`
+}
+
+// Finished: job sudah completed atau failed dan tidak akan diproses lagi.
+func (j ImportJob) Finished() bool {
+	return j.Status == ImportCompleted || j.Status == ImportFailed
+}
+
+// ImportRowError menjelaskan satu field yang tidak valid di satu baris CSV.
+// Row adalah nomor baris di file (header = baris 1).
+type ImportRowError struct {
+	Row     int    `json:"row" example:"7"`
+	Field   string `json:"field,omitempty" example:"unit_price"`
+	Message string `json:"message" example:"must be a number"`
+}
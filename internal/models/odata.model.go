@@ -0,0 +1,21 @@
+package models
+
+// ODataError adalah format error OData v4 ({"error": {...}}) yang dipahami
+// client seperti Power BI dan Excel.
+type ODataError struct {
+	Error ODataErrorDetail `json:"error"`
+}
+
+type ODataErrorDetail struct {
+	Code    string `json:"code" example:"BadRequest"`
+	Message string `json:"message" example:"unknown property \"Foo\" on Customer"`
+	Target  string `json:"target,omitempty" example:"$filter"`
+}
+
+// ODataCollection hanya untuk dokumentasi; body sebenarnya disusun package odata.
+type ODataCollection struct {
+	Context  string           `json:"@odata.context" example:"http://localhost:8080/api/v1/odata/$metadata#Customers"`
+	Count    *int64           `json:"@odata.count,omitempty"`
+	Value    []map[string]any `json:"value"`
+	NextLink string           `json:"@odata.nextLink,omitempty"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// CustomerDataExport berisi semua data yang merujuk ke satu customer.
+type CustomerDataExport struct {
+	Customer Customer           `json:"customer"`
+	Orders   []OrderWithDetails `json:"orders"`
+	// History adalah audit log customer dan order-ordernya, terbaru lebih dulu.
+	History    []AuditEntry `json:"history"`
+	ExportedAt time.Time    `json:"exported_at"`
+}
+
+type OrderWithDetails struct {
+	Order
+	Details []OrderDetail `json:"details"`
+}
+
+// EmployeeDataExport berisi semua data yang merujuk ke satu employee.
+type EmployeeDataExport struct {
+	Employee    Employee    `json:"employee"`
+	Territories []Territory `json:"territories"`
+	// History adalah audit log employee, terbaru lebih dulu.
+	History    []AuditEntry `json:"history"`
+	ExportedAt time.Time    `json:"exported_at"`
+}
+
+// ErasureResult melaporkan apa saja yang dipseudonimkan oleh erasure.
+type ErasureResult struct {
+	Entity   string `json:"entity" example:"customers"`
+	EntityID string `json:"entity_id" example:"ALFKI"`
+	// Pseudonym menggantikan nama subject, mis. di contact_name.
+	Pseudonym string `json:"pseudonym" example:"anon-3f9a1c2e"`
+	// Fields adalah field JSON yang dikosongkan atau dipseudonimkan.
+	Fields   []string `json:"fields" example:"contact_name,phone"`
+	OrderIDs []int64  `json:"order_ids" example:"10643"`
+}
@@ -1,16 +1,19 @@
 package models
 
+import "time"
+
 type Product struct {
-	ProductID       int     `json:"product_id"`
-	ProductName     string  `json:"product_name"`
-	SupplierID      *int    `json:"supplier_id,omitempty"`
-	CategoryID      *int    `json:"category_id,omitempty"`
-	QuantityPerUnit *string `json:"quantity_per_unit,omitempty"`
-	UnitPrice       float64 `json:"unit_price"`
-	UnitsInStock    int     `json:"units_in_stock"`
-	UnitsOnOrder    int     `json:"units_on_order"`
-	ReorderLevel    int     `json:"reorder_level"`
-	Discontinued    string  `json:"discontinued"`
+	ProductID       int        `json:"product_id" db:"ProductID"`
+	ProductName     string     `json:"product_name" db:"ProductName" binding:"required,notblank,max=40"`
+	SupplierID      *int       `json:"supplier_id,omitempty" db:"SupplierID" binding:"omitempty,gt=0"`
+	CategoryID      *int       `json:"category_id,omitempty" db:"CategoryID" binding:"omitempty,gt=0"`
+	QuantityPerUnit *string    `json:"quantity_per_unit,omitempty" db:"QuantityPerUnit" binding:"omitempty,max=20"`
+	UnitPrice       float64    `json:"unit_price" db:"UnitPrice" binding:"gte=0"`
+	UnitsInStock    int        `json:"units_in_stock" db:"UnitsInStock" binding:"gte=0,lte=32767"`
+	UnitsOnOrder    int        `json:"units_on_order" db:"UnitsOnOrder" binding:"gte=0,lte=32767"`
+	ReorderLevel    int        `json:"reorder_level" db:"ReorderLevel" binding:"gte=0,lte=32767"`
+	Discontinued    bool       `json:"discontinued" db:"Discontinued"`
+	DeletedAt       *time.Time `json:"deleted_at,omitempty" db:"DeletedAt"`
 }
 
 // GetSupplierByProductID mengembalikan SupplierID & CompanyName (minimalis untuk endpoint /products/{id}/supplier)
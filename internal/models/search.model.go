@@ -0,0 +1,27 @@
+package models
+
+// SearchDocument adalah isi index pencarian untuk satu entity.
+type SearchDocument struct {
+	Entity   string // customers | products | suppliers
+	EntityID string
+	Title    string
+	Body     string
+}
+
+// SearchHit adalah satu hasil GET /search. Title dan Snippet adalah HTML:
+// teksnya sudah di-escape dan kata yang cocok dibungkus <mark>...</mark>.
+type SearchHit struct {
+	Type    string  `json:"type" example:"products"`
+	ID      string  `json:"id" example:"1"`
+	Title   string  `json:"title" example:"<mark>Chai</mark>"`
+	Snippet string  `json:"snippet" example:"10 boxes x 20 bags"`
+	Score   float64 `json:"score" example:"4.2"` // makin besar makin relevan
+}
+
+type SearchResult struct {
+	Query string `json:"query" example:"chai"`
+	// Fuzzy true kalau tidak ada hasil untuk ejaan persis sehingga kata
+	// yang mirip (typo) ikut dicari.
+	Fuzzy bool        `json:"fuzzy"`
+	Hits  []SearchHit `json:"hits"`
+}
@@ -1,7 +1,10 @@
 package models
 
+import "time"
+
 type Shipper struct {
-	ShipperID   int    `json:"shipper_id,omitempty"`
-	CompanyName string `json:"company_name"`
-	Phone       string `json:"phone"`
+	ShipperID   int        `json:"shipper_id,omitempty" db:"ShipperID"`
+	CompanyName string     `json:"company_name" db:"CompanyName" binding:"required,notblank,max=40"`
+	Phone       string     `json:"phone" db:"Phone" binding:"max=24"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty" db:"DeletedAt"`
 }
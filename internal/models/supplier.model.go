@@ -1,16 +1,19 @@
 package models
 
+import "time"
+
 type Supplier struct {
-	SupplierID   int64   `json:"supplier_id" db:"SupplierID"`
-	CompanyName  string  `json:"company_name" db:"CompanyName"`
-	ContactName  *string `json:"contact_name" db:"ContactName"`
-	ContactTitle *string `json:"contact_title" db:"ContactTitle"`
-	Address      *string `json:"address" db:"Address"`
-	City         *string `json:"city" db:"City"`
-	Region       *string `json:"region" db:"Region"`
-	PostalCode   *string `json:"postal_code" db:"PostalCode"`
-	Country      *string `json:"country" db:"Country"`
-	Phone        *string `json:"phone" db:"Phone"`
-	Fax          *string `json:"fax" db:"Fax"`
-	HomePage     *string `json:"homepage" db:"HomePage"`
+	SupplierID   int64      `json:"supplier_id" db:"SupplierID"`
+	CompanyName  string     `json:"company_name" db:"CompanyName" binding:"required,notblank,max=40"`
+	ContactName  *string    `json:"contact_name" db:"ContactName" binding:"omitempty,max=30"`
+	ContactTitle *string    `json:"contact_title" db:"ContactTitle" binding:"omitempty,max=30"`
+	Address      *string    `json:"address" db:"Address" binding:"omitempty,max=60"`
+	City         *string    `json:"city" db:"City" binding:"omitempty,max=15"`
+	Region       *string    `json:"region" db:"Region" binding:"omitempty,max=15"`
+	PostalCode   *string    `json:"postal_code" db:"PostalCode" binding:"omitempty,max=10"`
+	Country      *string    `json:"country" db:"Country" binding:"omitempty,max=15,country"`
+	Phone        *string    `json:"phone" db:"Phone" binding:"omitempty,max=24"`
+	Fax          *string    `json:"fax" db:"Fax" binding:"omitempty,max=24"`
+	HomePage     *string    `json:"homepage" db:"HomePage"`
+	DeletedAt    *time.Time `json:"deleted_at,omitempty" db:"DeletedAt"`
 }
@@ -5,3 +5,9 @@ type Territory struct {
 	TerritoryDesc string `json:"territory_description"`
 	RegionID      int    `json:"region_id"`
 }
+
+// EmployeeTerritory adalah satu baris tabel EmployeeTerritories.
+type EmployeeTerritory struct {
+	EmployeeID  int    `json:"employee_id"`
+	TerritoryID string `json:"territory_id"`
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Status pengiriman webhook: pending -> delivered, atau dead setelah semua
+// percobaan gagal (masuk dead-letter list, bisa di-retry manual).
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryDead      = "dead"
+)
+
+// Webhook adalah subscription partner: event apa saja yang dikirim ke URL mana.
+type Webhook struct {
+	WebhookID int      `json:"webhook_id" db:"WebhookID" example:"1"`
+	URL       string   `json:"url" db:"URL" example:"https://partner.example.com/hooks/northwind"`
+	Events    []string `json:"events" db:"Events" example:"order.shipped,product.low_stock"`
+	// Secret hanya dikirim sekali, di response create.
+	Secret string `json:"secret,omitempty" db:"Secret" example:"whsec_3b1f..."`
+	Active bool   `json:"active" db:"Active" example:"true"`
+	// Roles adalah role caller yang terakhir membuat/mengubah webhook; payload
+	// dibentuk dengan role ini. nil berarti tanpa identitas (tidak dibatasi).
+	Roles     []string  `json:"-" db:"Roles"`
+	CreatedAt time.Time `json:"created_at" db:"CreatedAt"`
+	UpdatedAt time.Time `json:"updated_at" db:"UpdatedAt"`
+}
+
+// WebhookInput adalah body create/update webhook. Secret kosong saat create
+// dibuatkan server; saat update berarti secret lama dipertahankan.
+type WebhookInput struct {
+	URL    string   `json:"url" binding:"required,url,max=2000" example:"https://partner.example.com/hooks/northwind"`
+	Events []string `json:"events" binding:"required,min=1,dive,required" example:"order.shipped"`
+	Secret string   `json:"secret,omitempty" binding:"omitempty,min=16,max=200"`
+	Active *bool    `json:"active,omitempty" example:"true"`
+}
+
+// WebhookDelivery adalah satu event untuk satu webhook di outbox, beserta
+// hasil percobaan terakhirnya.
+type WebhookDelivery struct {
+	DeliveryID     int64           `json:"delivery_id" db:"DeliveryID" example:"7"`
+	WebhookID      int             `json:"webhook_id" db:"WebhookID" example:"1"`
+	EventType      string          `json:"event_type" db:"EventType" example:"order.shipped"`
+	Payload        json.RawMessage `json:"payload" db:"Payload" swaggertype:"object"`
+	Status         string          `json:"status" db:"Status" example:"pending"`
+	Attempts       int             `json:"attempts" db:"Attempts" example:"2"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty" db:"NextAttemptAt"`
+	LastStatusCode *int            `json:"last_status_code,omitempty" db:"LastStatusCode" example:"503"`
+	LastError      string          `json:"last_error,omitempty" db:"LastError" example:"receiver returned 503"`
+	CreatedAt      time.Time       `json:"created_at" db:"CreatedAt"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty" db:"DeliveredAt"`
+	// AttemptLog hanya diisi di endpoint detail delivery.
+	AttemptLog []WebhookAttempt `json:"attempt_log,omitempty"`
+}
+
+// WebhookAttempt mencatat satu percobaan kirim.
+type WebhookAttempt struct {
+	AttemptID   int64     `json:"attempt_id" db:"AttemptID" example:"12"`
+	AttemptedAt time.Time `json:"attempted_at" db:"AttemptedAt"`
+	StatusCode  *int      `json:"status_code,omitempty" db:"StatusCode" example:"200"`
+	Error       string    `json:"error,omitempty" db:"Error"`
+	DurationMs  int64     `json:"duration_ms" db:"DurationMs" example:"84"`
+}
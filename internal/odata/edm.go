@@ -0,0 +1,281 @@
+// Package odata menerjemahkan request OData v4 ($filter, $select, $orderby,
+// $top, $skip, $count, $expand) menjadi SQL berparameter atas tabel Northwind
+// yang sama dengan yang dipakai repositories, dan menyajikan $metadata (CSDL).
+package odata
+
+import "slices"
+
+// Namespace dan nama container yang muncul di $metadata.
+const (
+	Namespace = "Northwind"
+	Container = "NorthwindEntities"
+)
+
+// Tipe primitif EDM yang dipakai model Northwind.
+const (
+	EdmString         = "Edm.String"
+	EdmInt32          = "Edm.Int32"
+	EdmDecimal        = "Edm.Decimal"
+	EdmSingle         = "Edm.Single"
+	EdmBoolean        = "Edm.Boolean"
+	EdmDateTimeOffset = "Edm.DateTimeOffset"
+)
+
+// Property adalah satu kolom tabel yang diekspos sebagai properti entity.
+// Nama properti sama dengan nama kolom, mengikuti layanan OData Northwind klasik.
+type Property struct {
+	Name      string
+	Type      string
+	Nullable  bool
+	MaxLength int
+	// Trim dipakai untuk kolom CHAR yang berisi padding (TerritoryDescription).
+	Trim bool
+}
+
+// selectSQL adalah ekspresi kolom untuk SELECT.
+func (p *Property) selectSQL(alias string) string {
+	col := alias + "." + p.Name
+	if p.Trim {
+		return "TRIM(" + col + ")"
+	}
+	return col
+}
+
+// exprSQL adalah ekspresi kolom di $filter/$orderby. Tanggal disimpan sebagai
+// teks dengan format campuran, jadi dinormalkan dulu dengan datetime().
+func (p *Property) exprSQL(alias string) string {
+	if p.Type == EdmDateTimeOffset {
+		return "datetime(" + p.selectSQL(alias) + ")"
+	}
+	return p.selectSQL(alias)
+}
+
+// Link adalah tabel penghubung untuk relasi many-to-many
+// (EmployeeTerritories). From merujuk kolom sumber, To merujuk kolom target.
+type Link struct {
+	Table, From, To string
+}
+
+// NavProperty adalah relasi ke entity set lain: baris target dengan kolom
+// Remote = kolom Local milik sumber (lewat Link kalau ada).
+type NavProperty struct {
+	Name   string
+	Target *EntitySet
+	Many   bool
+	Local  string
+	Remote string
+	Link   *Link
+}
+
+// Type mengembalikan tipe relasi dalam notasi CSDL.
+func (n *NavProperty) Type() string {
+	t := Namespace + "." + n.Target.EntityType
+	if n.Many {
+		return "Collection(" + t + ")"
+	}
+	return t
+}
+
+// EntitySet adalah satu tabel yang diekspos sebagai entity set.
+type EntitySet struct {
+	Name       string
+	EntityType string
+	Table      string
+	Key        []string
+	Properties []*Property
+	Navs       []*NavProperty
+	// SoftDelete diisi untuk tabel master data yang memakai kolom DeletedAt.
+	SoftDelete bool
+}
+
+// Property mencari properti berdasarkan nama (case-sensitive, sesuai OData).
+func (s *EntitySet) Property(name string) *Property {
+	for _, p := range s.Properties {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// Nav mencari navigation property berdasarkan nama.
+func (s *EntitySet) Nav(name string) *NavProperty {
+	for _, n := range s.Navs {
+		if n.Name == name {
+			return n
+		}
+	}
+	return nil
+}
+
+// keyProperties mengembalikan properti kunci sesuai urutan Key.
+func (s *EntitySet) keyProperties() []*Property {
+	out := make([]*Property, len(s.Key))
+	for i, k := range s.Key {
+		out[i] = s.Property(k)
+	}
+	return out
+}
+
+// liveSQL adalah kondisi baris yang belum dihapus (soft delete).
+func (s *EntitySet) liveSQL(alias string, includeDeleted bool) string {
+	if !s.SoftDelete || includeDeleted {
+		return ""
+	}
+	return alias + ".DeletedAt IS NULL"
+}
+
+// Model adalah kumpulan entity set yang diekspos.
+type Model struct {
+	Sets []*EntitySet
+}
+
+// Set mencari entity set berdasarkan nama.
+func (m *Model) Set(name string) *EntitySet {
+	i := slices.IndexFunc(m.Sets, func(s *EntitySet) bool { return s.Name == name })
+	if i < 0 {
+		return nil
+	}
+	return m.Sets[i]
+}
+
+func str(name string, maxLength int) *Property {
+	return &Property{Name: name, Type: EdmString, Nullable: true, MaxLength: maxLength}
+}
+
+func required(p *Property) *Property {
+	p.Nullable = false
+	return p
+}
+
+func typed(name, typ string) *Property {
+	return &Property{Name: name, Type: typ, Nullable: true}
+}
+
+func deletedAt() *Property { return typed("DeletedAt", EdmDateTimeOffset) }
+
+// Northwind membangun model entity untuk database Northwind. Kolom biner
+// (Photo, Picture) tidak diekspos.
+func Northwind() *Model {
+	customers := &EntitySet{
+		Name: "Customers", EntityType: "Customer", Table: "Customers", Key: []string{"CustomerID"}, SoftDelete: true,
+		Properties: []*Property{
+			required(str("CustomerID", 5)), required(str("CompanyName", 40)), str("ContactName", 30), str("ContactTitle", 30),
+			str("Address", 60), str("City", 15), str("Region", 15), str("PostalCode", 10), str("Country", 15),
+			str("Phone", 24), str("Fax", 24), deletedAt(),
+		},
+	}
+	employees := &EntitySet{
+		Name: "Employees", EntityType: "Employee", Table: "Employees", Key: []string{"EmployeeID"}, SoftDelete: true,
+		Properties: []*Property{
+			required(typed("EmployeeID", EdmInt32)), required(str("LastName", 20)), required(str("FirstName", 10)),
+			str("Title", 30), str("TitleOfCourtesy", 25), typed("BirthDate", EdmDateTimeOffset), typed("HireDate", EdmDateTimeOffset),
+			str("Address", 60), str("City", 15), str("Region", 15), str("PostalCode", 10), str("Country", 15),
+			str("HomePhone", 24), str("Extension", 4), str("Notes", 0), typed("ReportsTo", EdmInt32), str("PhotoPath", 255), deletedAt(),
+		},
+	}
+	shippers := &EntitySet{
+		Name: "Shippers", EntityType: "Shipper", Table: "Shippers", Key: []string{"ShipperID"}, SoftDelete: true,
+		Properties: []*Property{
+			required(typed("ShipperID", EdmInt32)), required(str("CompanyName", 40)), str("Phone", 24), deletedAt(),
+		},
+	}
+	categories := &EntitySet{
+		Name: "Categories", EntityType: "Category", Table: "Categories", Key: []string{"CategoryID"}, SoftDelete: true,
+		Properties: []*Property{
+			required(typed("CategoryID", EdmInt32)), required(str("CategoryName", 15)), str("Description", 0), deletedAt(),
+		},
+	}
+	suppliers := &EntitySet{
+		Name: "Suppliers", EntityType: "Supplier", Table: "Suppliers", Key: []string{"SupplierID"}, SoftDelete: true,
+		Properties: []*Property{
+			required(typed("SupplierID", EdmInt32)), required(str("CompanyName", 40)), str("ContactName", 30), str("ContactTitle", 30),
+			str("Address", 60), str("City", 15), str("Region", 15), str("PostalCode", 10), str("Country", 15),
+			str("Phone", 24), str("Fax", 24), str("HomePage", 0), deletedAt(),
+		},
+	}
+	products := &EntitySet{
+		Name: "Products", EntityType: "Product", Table: "Products", Key: []string{"ProductID"}, SoftDelete: true,
+		Properties: []*Property{
+			required(typed("ProductID", EdmInt32)), required(str("ProductName", 40)), typed("SupplierID", EdmInt32), typed("CategoryID", EdmInt32),
+			str("QuantityPerUnit", 20), typed("UnitPrice", EdmDecimal), typed("UnitsInStock", EdmInt32), typed("UnitsOnOrder", EdmInt32),
+			typed("ReorderLevel", EdmInt32), required(typed("Discontinued", EdmBoolean)), deletedAt(),
+		},
+	}
+	orders := &EntitySet{
+		Name: "Orders", EntityType: "Order", Table: "Orders", Key: []string{"OrderID"},
+		Properties: []*Property{
+			required(typed("OrderID", EdmInt32)), str("CustomerID", 5), typed("EmployeeID", EdmInt32),
+			typed("OrderDate", EdmDateTimeOffset), typed("RequiredDate", EdmDateTimeOffset), typed("ShippedDate", EdmDateTimeOffset),
+			typed("ShipVia", EdmInt32), typed("Freight", EdmDecimal), str("ShipName", 40), str("ShipAddress", 60),
+			str("ShipCity", 15), str("ShipRegion", 15), str("ShipPostalCode", 10), str("ShipCountry", 15),
+		},
+	}
+	details := &EntitySet{
+		Name: "OrderDetails", EntityType: "OrderDetail", Table: "OrderDetails", Key: []string{"OrderID", "ProductID"},
+		Properties: []*Property{
+			required(typed("OrderID", EdmInt32)), required(typed("ProductID", EdmInt32)), required(typed("UnitPrice", EdmDecimal)),
+			required(typed("Quantity", EdmInt32)), required(typed("Discount", EdmSingle)),
+		},
+	}
+	regions := &EntitySet{
+		Name: "Regions", EntityType: "Region", Table: "Regions", Key: []string{"RegionID"},
+		Properties: []*Property{
+			required(typed("RegionID", EdmInt32)), required(str("RegionDescription", 50)),
+		},
+	}
+	territories := &EntitySet{
+		Name: "Territories", EntityType: "Territory", Table: "Territories", Key: []string{"TerritoryID"},
+		Properties: []*Property{
+			required(str("TerritoryID", 20)), {Name: "TerritoryDescription", Type: EdmString, MaxLength: 50, Trim: true},
+			required(typed("RegionID", EdmInt32)),
+		},
+	}
+
+	employeeTerritories := &Link{Table: "EmployeeTerritories", From: "EmployeeID", To: "TerritoryID"}
+	customers.Navs = []*NavProperty{
+		{Name: "Orders", Target: orders, Many: true, Local: "CustomerID", Remote: "CustomerID"},
+	}
+	employees.Navs = []*NavProperty{
+		{Name: "Orders", Target: orders, Many: true, Local: "EmployeeID", Remote: "EmployeeID"},
+		{Name: "Manager", Target: employees, Local: "ReportsTo", Remote: "EmployeeID"},
+		{Name: "DirectReports", Target: employees, Many: true, Local: "EmployeeID", Remote: "ReportsTo"},
+		{Name: "Territories", Target: territories, Many: true, Local: "EmployeeID", Remote: "TerritoryID", Link: employeeTerritories},
+	}
+	shippers.Navs = []*NavProperty{
+		{Name: "Orders", Target: orders, Many: true, Local: "ShipperID", Remote: "ShipVia"},
+	}
+	categories.Navs = []*NavProperty{
+		{Name: "Products", Target: products, Many: true, Local: "CategoryID", Remote: "CategoryID"},
+	}
+	suppliers.Navs = []*NavProperty{
+		{Name: "Products", Target: products, Many: true, Local: "SupplierID", Remote: "SupplierID"},
+	}
+	products.Navs = []*NavProperty{
+		{Name: "Category", Target: categories, Local: "CategoryID", Remote: "CategoryID"},
+		{Name: "Supplier", Target: suppliers, Local: "SupplierID", Remote: "SupplierID"},
+		{Name: "OrderDetails", Target: details, Many: true, Local: "ProductID", Remote: "ProductID"},
+	}
+	orders.Navs = []*NavProperty{
+		{Name: "Customer", Target: customers, Local: "CustomerID", Remote: "CustomerID"},
+		{Name: "Employee", Target: employees, Local: "EmployeeID", Remote: "EmployeeID"},
+		{Name: "Shipper", Target: shippers, Local: "ShipVia", Remote: "ShipperID"},
+		{Name: "OrderDetails", Target: details, Many: true, Local: "OrderID", Remote: "OrderID"},
+	}
+	details.Navs = []*NavProperty{
+		{Name: "Order", Target: orders, Local: "OrderID", Remote: "OrderID"},
+		{Name: "Product", Target: products, Local: "ProductID", Remote: "ProductID"},
+	}
+	regions.Navs = []*NavProperty{
+		{Name: "Territories", Target: territories, Many: true, Local: "RegionID", Remote: "RegionID"},
+	}
+	territories.Navs = []*NavProperty{
+		{Name: "Region", Target: regions, Local: "RegionID", Remote: "RegionID"},
+		{Name: "Employees", Target: employees, Many: true, Local: "TerritoryID", Remote: "EmployeeID",
+			Link: &Link{Table: employeeTerritories.Table, From: employeeTerritories.To, To: employeeTerritories.From}},
+	}
+
+	return &Model{Sets: []*EntitySet{
+		customers, employees, shippers, categories, suppliers, products, orders, details, regions, territories,
+	}}
+}
@@ -0,0 +1,585 @@
+package odata
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"northwind-api/internal/apperr"
+)
+
+// kind adalah tipe hasil ekspresi, dipakai untuk memeriksa operand.
+type kind int
+
+const (
+	kNull kind = iota
+	kString
+	kInt
+	kNumber
+	kBool
+	kDateTime
+)
+
+func (k kind) String() string {
+	return [...]string{"null", "string", "integer", "number", "boolean", "datetime"}[k]
+}
+
+func kindOf(p *Property) kind {
+	switch p.Type {
+	case EdmInt32:
+		return kInt
+	case EdmDecimal, EdmSingle:
+		return kNumber
+	case EdmBoolean:
+		return kBool
+	case EdmDateTimeOffset:
+		return kDateTime
+	}
+	return kString
+}
+
+func (k kind) numeric() bool { return k == kInt || k == kNumber }
+
+// expr adalah potongan SQL hasil terjemahan beserta argumennya (urut sesuai
+// kemunculan "?" di sql).
+type expr struct {
+	sql  string
+	args []any
+	kind kind
+}
+
+// sqlTime adalah format tanggal hasil datetime() SQLite, supaya literal bisa
+// dibandingkan langsung dengan kolom yang sudah dinormalkan.
+const sqlTime = "2006-01-02 15:04:05"
+
+// scope adalah entity yang bisa dirujuk di ekspresi: $it (root) atau variabel lambda.
+type scope struct {
+	set   *EntitySet
+	alias string
+	name  string
+	outer *scope
+}
+
+// translator mengubah ekspresi OData menjadi SQL untuk satu statement.
+// Alias tabel t0 adalah entity utama; subquery mendapat t1, t2, dst.
+type translator struct {
+	option         string
+	includeDeleted bool
+	hidden         Hidden
+	aliases        int
+
+	toks []token
+	pos  int
+	root *scope
+	sc   *scope
+}
+
+func newTranslator(set *EntitySet, includeDeleted bool) *translator {
+	root := &scope{set: set, alias: "t0"}
+	return &translator{includeDeleted: includeDeleted, root: root, sc: root}
+}
+
+func (t *translator) errorf(format string, args ...any) error {
+	return apperr.Validation(t.option, format, args...)
+}
+
+// parse menerjemahkan satu ekspresi lengkap (tanpa sisa token).
+func (t *translator) parse(option, src string) (expr, error) {
+	t.option = option
+	toks, err := lex(src)
+	if err != nil {
+		return expr{}, t.errorf("%v", err)
+	}
+	t.toks, t.pos, t.sc = toks, 0, t.root
+	e, err := t.parseOr()
+	if err != nil {
+		return expr{}, err
+	}
+	if tok := t.peek(); tok.kind != tEOF {
+		return expr{}, t.errorf("unexpected %q at position %d", tok.text, tok.pos)
+	}
+	return e, nil
+}
+
+func (t *translator) peek() token { return t.toks[t.pos] }
+
+func (t *translator) next() token {
+	tok := t.toks[t.pos]
+	if tok.kind != tEOF {
+		t.pos++
+	}
+	return tok
+}
+
+func (t *translator) expect(k tokenKind, what string) error {
+	if tok := t.next(); tok.kind != k {
+		return t.errorf("expected %s at position %d", what, tok.pos)
+	}
+	return nil
+}
+
+// keyword melaporkan apakah token berikutnya adalah kata kunci kw.
+func (t *translator) keyword(kw string) bool {
+	tok := t.peek()
+	return tok.kind == tIdent && tok.text == kw
+}
+
+func (t *translator) newAlias() string {
+	t.aliases++
+	return "t" + strconv.Itoa(t.aliases)
+}
+
+func (t *translator) parseOr() (expr, error) {
+	left, err := t.parseAnd()
+	for err == nil && t.keyword("or") {
+		t.next()
+		var right expr
+		if right, err = t.parseAnd(); err == nil {
+			left, err = t.logical("OR", left, right)
+		}
+	}
+	return left, err
+}
+
+func (t *translator) parseAnd() (expr, error) {
+	left, err := t.parseCompare()
+	for err == nil && t.keyword("and") {
+		t.next()
+		var right expr
+		if right, err = t.parseCompare(); err == nil {
+			left, err = t.logical("AND", left, right)
+		}
+	}
+	return left, err
+}
+
+func (t *translator) logical(op string, a, b expr) (expr, error) {
+	if a.kind != kBool || b.kind != kBool {
+		return expr{}, t.errorf("operands of %s must be boolean", strings.ToLower(op))
+	}
+	return join(kBool, "(", a, " "+op+" ", b, ")"), nil
+}
+
+var comparisons = map[string]string{
+	"eq": "IS", "ne": "IS NOT", "gt": ">", "ge": ">=", "lt": "<", "le": "<=",
+}
+
+func (t *translator) parseCompare() (expr, error) {
+	left, err := t.parseAdditive()
+	if err != nil {
+		return expr{}, err
+	}
+	tok := t.peek()
+	if tok.kind != tIdent {
+		return left, nil
+	}
+	if tok.text == "in" {
+		t.next()
+		return t.parseIn(left)
+	}
+	op, ok := comparisons[tok.text]
+	if !ok {
+		return left, nil
+	}
+	t.next()
+	right, err := t.parseAdditive()
+	if err != nil {
+		return expr{}, err
+	}
+	if !comparable(left.kind, right.kind) {
+		return expr{}, t.errorf("cannot compare %s with %s using %s", left.kind, right.kind, tok.text)
+	}
+	// eq/ne memakai IS supaya perbandingan dengan null mengikuti semantik OData.
+	return join(kBool, "(", left, " "+op+" ", right, ")"), nil
+}
+
+func comparable(a, b kind) bool {
+	return a == b || a == kNull || b == kNull || (a.numeric() && b.numeric())
+}
+
+func (t *translator) parseIn(left expr) (expr, error) {
+	if err := t.expect(tLParen, "( after in"); err != nil {
+		return expr{}, err
+	}
+	out := join(kBool, "(", left, " IN (")
+	for i := 0; ; i++ {
+		item, err := t.parseAdditive()
+		if err != nil {
+			return expr{}, err
+		}
+		if !comparable(left.kind, item.kind) {
+			return expr{}, t.errorf("cannot compare %s with %s using in", left.kind, item.kind)
+		}
+		if i > 0 {
+			out.sql += ", "
+		}
+		out = join(kBool, out, item)
+		if t.peek().kind == tComma {
+			t.next()
+			continue
+		}
+		if err := t.expect(tRParen, ") after in list"); err != nil {
+			return expr{}, err
+		}
+		out.sql += "))"
+		return out, nil
+	}
+}
+
+var arithmetic = map[string]string{"add": "+", "sub": "-", "mul": "*", "div": "/", "mod": "%", "divby": "/"}
+
+func (t *translator) parseAdditive() (expr, error) {
+	left, err := t.parseMultiplicative()
+	for err == nil && (t.keyword("add") || t.keyword("sub")) {
+		op := t.next().text
+		var right expr
+		if right, err = t.parseMultiplicative(); err == nil {
+			left, err = t.arith(op, left, right)
+		}
+	}
+	return left, err
+}
+
+func (t *translator) parseMultiplicative() (expr, error) {
+	left, err := t.parseUnary()
+	for err == nil && (t.keyword("mul") || t.keyword("div") || t.keyword("divby") || t.keyword("mod")) {
+		op := t.next().text
+		var right expr
+		if right, err = t.parseUnary(); err == nil {
+			left, err = t.arith(op, left, right)
+		}
+	}
+	return left, err
+}
+
+func (t *translator) arith(op string, a, b expr) (expr, error) {
+	if !a.kind.numeric() || !b.kind.numeric() {
+		return expr{}, t.errorf("operands of %s must be numeric", op)
+	}
+	k := kNumber
+	if a.kind == kInt && b.kind == kInt && op != "divby" {
+		k = kInt
+	}
+	if op == "divby" {
+		a = join(kNumber, "CAST(", a, " AS REAL)")
+	}
+	return join(k, "(", a, " "+arithmetic[op]+" ", b, ")"), nil
+}
+
+func (t *translator) parseUnary() (expr, error) {
+	switch {
+	case t.keyword("not"):
+		t.next()
+		e, err := t.parseUnary()
+		if err != nil {
+			return expr{}, err
+		}
+		if e.kind != kBool {
+			return expr{}, t.errorf("operand of not must be boolean")
+		}
+		return join(kBool, "(NOT ", e, ")"), nil
+	case t.peek().kind == tMinus:
+		t.next()
+		e, err := t.parseUnary()
+		if err != nil {
+			return expr{}, err
+		}
+		if !e.kind.numeric() {
+			return expr{}, t.errorf("operand of - must be numeric")
+		}
+		return join(e.kind, "(-", e, ")"), nil
+	}
+	return t.parsePrimary()
+}
+
+func (t *translator) parsePrimary() (expr, error) {
+	tok := t.next()
+	switch tok.kind {
+	case tLParen:
+		e, err := t.parseOr()
+		if err != nil {
+			return expr{}, err
+		}
+		return e, t.expect(tRParen, ")")
+	case tString:
+		return param(kString, tok.text), nil
+	case tNumber:
+		return t.number(tok)
+	case tDate:
+		d, err := time.Parse("2006-01-02", tok.text)
+		if err != nil {
+			return expr{}, t.errorf("invalid date %q", tok.text)
+		}
+		return param(kDateTime, d.Format(sqlTime)), nil
+	case tDateTime:
+		d, err := time.Parse(time.RFC3339Nano, tok.text)
+		if err != nil {
+			// detik boleh tidak ditulis (1997-01-01T10:00Z)
+			if d, err = time.Parse("2006-01-02T15:04Z07:00", tok.text); err != nil {
+				return expr{}, t.errorf("invalid datetime %q", tok.text)
+			}
+		}
+		return param(kDateTime, d.UTC().Format(sqlTime)), nil
+	case tIdent:
+		switch tok.text {
+		case "true":
+			return expr{sql: "1", kind: kBool}, nil
+		case "false":
+			return expr{sql: "0", kind: kBool}, nil
+		case "null":
+			return expr{sql: "NULL", kind: kNull}, nil
+		}
+		if t.peek().kind == tLParen {
+			return t.call(tok)
+		}
+		return t.member(tok)
+	case tEOF:
+		return expr{}, t.errorf("unexpected end of expression")
+	}
+	return expr{}, t.errorf("unexpected %q at position %d", tok.text, tok.pos)
+}
+
+func (t *translator) number(tok token) (expr, error) {
+	text := strings.TrimRight(tok.text, "mMdDfFlL")
+	if i, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return param(kInt, i), nil
+	}
+	f, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return expr{}, t.errorf("invalid number %q", tok.text)
+	}
+	return param(kNumber, f), nil
+}
+
+// member menerjemahkan path properti: Prop, Nav/Prop, Nav/any(x: ...),
+// Nav/$count, atau var/Prop di dalam lambda.
+func (t *translator) member(first token) (expr, error) {
+	sc, name := t.root, first.text
+	if first.text == "$it" || t.lambdaScope(first.text) != nil {
+		if first.text != "$it" {
+			sc = t.lambdaScope(first.text)
+		}
+		if err := t.expect(tSlash, "/ after "+first.text); err != nil {
+			return expr{}, err
+		}
+		tok := t.next()
+		if tok.kind != tIdent {
+			return expr{}, t.errorf("expected property name at position %d", tok.pos)
+		}
+		name = tok.text
+	}
+	return t.resolve(sc.set, sc.alias, name)
+}
+
+func (t *translator) lambdaScope(name string) *scope {
+	for s := t.sc; s != nil; s = s.outer {
+		if s.name != "" && s.name == name {
+			return s
+		}
+	}
+	return nil
+}
+
+func (t *translator) resolve(set *EntitySet, alias, name string) (expr, error) {
+	if p := set.Property(name); p != nil {
+		if t.hidden != nil && t.hidden(set, p) {
+			return expr{}, t.errorf("property %s of %s is not visible to the caller", name, set.EntityType)
+		}
+		if t.peek().kind == tSlash {
+			return expr{}, t.errorf("property %s of %s has no members", name, set.EntityType)
+		}
+		return expr{sql: p.exprSQL(alias), kind: kindOf(p)}, nil
+	}
+	n := set.Nav(name)
+	if n == nil {
+		return expr{}, t.errorf("unknown property %q on %s", name, set.EntityType)
+	}
+	if err := t.expect(tSlash, "/ after navigation property "+name); err != nil {
+		return expr{}, err
+	}
+	tok := t.next()
+	if tok.kind != tIdent {
+		return expr{}, t.errorf("expected property name at position %d", tok.pos)
+	}
+	inner := t.newAlias()
+	from, on := navJoin(n, alias, inner)
+	if !n.Many {
+		e, err := t.resolve(n.Target, inner, tok.text)
+		if err != nil {
+			return expr{}, err
+		}
+		return join(e.kind, "(SELECT ", e, " FROM "+from+" WHERE "+on+")"), nil
+	}
+	if live := n.Target.liveSQL(inner, t.includeDeleted); live != "" {
+		on += " AND " + live
+	}
+	switch tok.text {
+	case "$count":
+		return expr{sql: "(SELECT COUNT(*) FROM " + from + " WHERE " + on + ")", kind: kInt}, nil
+	case "any", "all":
+		return t.lambda(tok.text, n, from, on, inner)
+	}
+	return expr{}, t.errorf("collection %s must be followed by any(), all() or $count", name)
+}
+
+// lambda menerjemahkan Nav/any(x: cond) menjadi EXISTS dan Nav/all(x: cond)
+// menjadi NOT EXISTS baris yang tidak memenuhi cond.
+func (t *translator) lambda(op string, n *NavProperty, from, on, alias string) (expr, error) {
+	if err := t.expect(tLParen, "( after "+op); err != nil {
+		return expr{}, err
+	}
+	if op == "any" && t.peek().kind == tRParen {
+		t.next()
+		return expr{sql: "EXISTS (SELECT 1 FROM " + from + " WHERE " + on + ")", kind: kBool}, nil
+	}
+	v := t.next()
+	if v.kind != tIdent {
+		return expr{}, t.errorf("expected lambda variable at position %d", v.pos)
+	}
+	if err := t.expect(tColon, ": after lambda variable"); err != nil {
+		return expr{}, err
+	}
+	t.sc = &scope{set: n.Target, alias: alias, name: v.text, outer: t.sc}
+	body, err := t.parseOr()
+	t.sc = t.sc.outer
+	if err != nil {
+		return expr{}, err
+	}
+	if body.kind != kBool {
+		return expr{}, t.errorf("body of %s must be boolean", op)
+	}
+	if err := t.expect(tRParen, ") after lambda"); err != nil {
+		return expr{}, err
+	}
+	if op == "any" {
+		return join(kBool, "EXISTS (SELECT 1 FROM "+from+" WHERE "+on+" AND ", body, ")"), nil
+	}
+	return join(kBool, "NOT EXISTS (SELECT 1 FROM "+from+" WHERE "+on+" AND NOT COALESCE(", body, ", 0))"), nil
+}
+
+// navJoin mengembalikan klausa FROM dan kondisi join untuk baris target
+// relasi n (alias inner) milik baris sumber (alias outer).
+func navJoin(n *NavProperty, outer, inner string) (from, on string) {
+	from = n.Target.Table + " " + inner
+	if n.Link == nil {
+		return from, inner + "." + n.Remote + " = " + outer + "." + n.Local
+	}
+	link := "l" + strings.TrimPrefix(inner, "t")
+	from += " JOIN " + n.Link.Table + " " + link + " ON " + link + "." + n.Link.To + " = " + inner + "." + n.Remote
+	return from, link + "." + n.Link.From + " = " + outer + "." + n.Local
+}
+
+// call menerjemahkan fungsi bawaan OData ke fungsi SQLite.
+func (t *translator) call(name token) (expr, error) {
+	t.next() // (
+	var args []expr
+	if t.peek().kind != tRParen {
+		for {
+			a, err := t.parseOr()
+			if err != nil {
+				return expr{}, err
+			}
+			args = append(args, a)
+			if t.peek().kind != tComma {
+				break
+			}
+			t.next()
+		}
+	}
+	if err := t.expect(tRParen, ") after arguments of "+name.text); err != nil {
+		return expr{}, err
+	}
+	fn, ok := functions[name.text]
+	if !ok {
+		return expr{}, t.errorf("unknown function %q", name.text)
+	}
+	if len(args) < len(fn.args) || len(args) > len(fn.args)+len(fn.optional) {
+		return expr{}, t.errorf("%s expects %d argument(s)", name.text, len(fn.args))
+	}
+	kinds := slices.Concat(fn.args, fn.optional)
+	for i, a := range args {
+		want := kinds[i]
+		if a.kind != kNull && a.kind != want && !(want == kNumber && a.kind == kInt) {
+			return expr{}, t.errorf("argument %d of %s must be %s", i+1, name.text, want)
+		}
+	}
+	if len(args) > len(fn.args) {
+		return apply(fn.result, fn.long, args), nil
+	}
+	return apply(fn.result, fn.sql, args), nil
+}
+
+type function struct {
+	args, optional []kind
+	result         kind
+	// sql memakai {0}, {1}, ... untuk argumen; argumen boleh muncul lebih dari sekali.
+	sql, long string
+}
+
+func datePart(format string) function {
+	return function{args: []kind{kDateTime}, result: kInt, sql: "CAST(strftime('" + format + "', {0}) AS INTEGER)"}
+}
+
+var functions = map[string]function{
+	"contains":   {args: []kind{kString, kString}, result: kBool, sql: "(instr({0}, {1}) > 0)"},
+	"startswith": {args: []kind{kString, kString}, result: kBool, sql: "(instr({0}, {1}) = 1)"},
+	"endswith":   {args: []kind{kString, kString}, result: kBool, sql: "(substr({0}, length({0}) - length({1}) + 1) = {1})"},
+	"indexof":    {args: []kind{kString, kString}, result: kInt, sql: "(instr({0}, {1}) - 1)"},
+	"length":     {args: []kind{kString}, result: kInt, sql: "length({0})"},
+	"tolower":    {args: []kind{kString}, result: kString, sql: "lower({0})"},
+	"toupper":    {args: []kind{kString}, result: kString, sql: "upper({0})"},
+	"trim":       {args: []kind{kString}, result: kString, sql: "trim({0})"},
+	"concat":     {args: []kind{kString, kString}, result: kString, sql: "({0} || {1})"},
+	"substring": {args: []kind{kString, kInt}, optional: []kind{kInt}, result: kString,
+		sql: "substr({0}, {1} + 1)", long: "substr({0}, {1} + 1, {2})"},
+	"year":    datePart("%Y"),
+	"month":   datePart("%m"),
+	"day":     datePart("%d"),
+	"hour":    datePart("%H"),
+	"minute":  datePart("%M"),
+	"second":  datePart("%S"),
+	"date":    {args: []kind{kDateTime}, result: kDateTime, sql: "datetime(date({0}))"},
+	"now":     {result: kDateTime, sql: "datetime('now')"},
+	"round":   {args: []kind{kNumber}, result: kNumber, sql: "round({0})"},
+	"floor":   {args: []kind{kNumber}, result: kNumber, sql: "(CAST({0} AS INTEGER) - ({0} < CAST({0} AS INTEGER)))"},
+	"ceiling": {args: []kind{kNumber}, result: kNumber, sql: "(CAST({0} AS INTEGER) + ({0} > CAST({0} AS INTEGER)))"},
+}
+
+// apply mengisi template fungsi; argumen SQL disusun ulang sesuai urutan
+// kemunculan placeholder supaya "?" tetap cocok dengan args.
+func apply(k kind, template string, args []expr) expr {
+	out := expr{kind: k}
+	for {
+		i := strings.IndexByte(template, '{')
+		if i < 0 {
+			out.sql += template
+			return out
+		}
+		j := strings.IndexByte(template[i:], '}') + i
+		n, _ := strconv.Atoi(template[i+1 : j])
+		out.sql += template[:i] + args[n].sql
+		out.args = append(out.args, args[n].args...)
+		template = template[j+1:]
+	}
+}
+
+func param(k kind, v any) expr { return expr{sql: "?", args: []any{v}, kind: k} }
+
+// join merangkai string dan expr menjadi satu expr dengan kind k.
+func join(k kind, parts ...any) expr {
+	out := expr{kind: k}
+	for _, p := range parts {
+		switch v := p.(type) {
+		case string:
+			out.sql += v
+		case expr:
+			out.sql += v.sql
+			out.args = append(out.args, v.args...)
+		default:
+			panic(fmt.Sprintf("odata: cannot join %T", p))
+		}
+	}
+	return out
+}
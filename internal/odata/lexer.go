@@ -0,0 +1,116 @@
+package odata
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tEOF tokenKind = iota
+	tIdent
+	tString
+	tNumber
+	tDate     // 1997-01-01
+	tDateTime // 1997-01-01T10:00:00Z
+	tLParen
+	tRParen
+	tComma
+	tSlash
+	tColon
+	tMinus
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+var (
+	dateTimeLit = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}(:\d{2}(\.\d+)?)?(Z|[+-]\d{2}:\d{2})`)
+	dateLit     = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}`)
+	numberLit   = regexp.MustCompile(`^-?\d+(\.\d+)?([eE][+-]?\d+)?[mMdDfFlL]?`)
+)
+
+// lex memecah ekspresi $filter/$orderby menjadi token.
+func lex(src string) ([]token, error) {
+	var out []token
+	for i := 0; i < len(src); {
+		c := src[i]
+		rest := src[i:]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+			continue
+		case c == '(':
+			out = append(out, token{tLParen, "(", i})
+			i++
+		case c == ')':
+			out = append(out, token{tRParen, ")", i})
+			i++
+		case c == ',':
+			out = append(out, token{tComma, ",", i})
+			i++
+		case c == '/':
+			out = append(out, token{tSlash, "/", i})
+			i++
+		case c == ':':
+			out = append(out, token{tColon, ":", i})
+			i++
+		case c == '\'':
+			s, n, err := lexString(rest)
+			if err != nil {
+				return nil, fmt.Errorf("%v at position %d", err, i)
+			}
+			out = append(out, token{tString, s, i})
+			i += n
+		case dateTimeLit.MatchString(rest):
+			m := dateTimeLit.FindString(rest)
+			out = append(out, token{tDateTime, m, i})
+			i += len(m)
+		case dateLit.MatchString(rest):
+			m := dateLit.FindString(rest)
+			out = append(out, token{tDate, m, i})
+			i += len(m)
+		case numberLit.MatchString(rest):
+			m := numberLit.FindString(rest)
+			out = append(out, token{tNumber, m, i})
+			i += len(m)
+		case c == '-':
+			out = append(out, token{tMinus, "-", i})
+			i++
+		case c == '$' || c == '_' || unicode.IsLetter(rune(c)):
+			j := i + 1
+			for j < len(src) && (src[j] == '_' || src[j] == '.' || unicode.IsLetter(rune(src[j])) || unicode.IsDigit(rune(src[j]))) {
+				j++
+			}
+			out = append(out, token{tIdent, src[i:j], i})
+			i = j
+		default:
+			return nil, fmt.Errorf("unexpected character %q at position %d", c, i)
+		}
+	}
+	return append(out, token{tEOF, "", len(src)}), nil
+}
+
+// lexString membaca literal string; kutip di dalamnya ditulis dua kali.
+func lexString(src string) (string, int, error) {
+	var b strings.Builder
+	for i := 1; i < len(src); i++ {
+		if src[i] != '\'' {
+			b.WriteByte(src[i])
+			continue
+		}
+		if i+1 < len(src) && src[i+1] == '\'' {
+			b.WriteByte('\'')
+			i++
+			continue
+		}
+		return b.String(), i + 1, nil
+	}
+	return "", 0, fmt.Errorf("unterminated string literal")
+}
@@ -0,0 +1,112 @@
+package odata
+
+import "encoding/xml"
+
+// Struktur CSDL (OData v4 XML) untuk $metadata.
+
+type edmx struct {
+	XMLName  xml.Name `xml:"edmx:Edmx"`
+	Version  string   `xml:"Version,attr"`
+	XMLNS    string   `xml:"xmlns:edmx,attr"`
+	Services struct {
+		Schema edmSchema `xml:"Schema"`
+	} `xml:"edmx:DataServices"`
+}
+
+type edmSchema struct {
+	XMLNS     string          `xml:"xmlns,attr"`
+	Namespace string          `xml:"Namespace,attr"`
+	Types     []edmEntityType `xml:"EntityType"`
+	Container struct {
+		Name string         `xml:"Name,attr"`
+		Sets []edmEntitySet `xml:"EntitySet"`
+	} `xml:"EntityContainer"`
+}
+
+type edmEntityType struct {
+	Name string `xml:"Name,attr"`
+	Key  struct {
+		Refs []edmPropertyRef `xml:"PropertyRef"`
+	} `xml:"Key"`
+	Properties []edmProperty `xml:"Property"`
+	Navs       []edmNav      `xml:"NavigationProperty"`
+}
+
+type edmPropertyRef struct {
+	Name string `xml:"Name,attr"`
+}
+
+type edmProperty struct {
+	Name      string `xml:"Name,attr"`
+	Type      string `xml:"Type,attr"`
+	Nullable  string `xml:"Nullable,attr,omitempty"`
+	MaxLength int    `xml:"MaxLength,attr,omitempty"`
+	Precision int    `xml:"Precision,attr,omitempty"`
+	Scale     int    `xml:"Scale,attr,omitempty"`
+}
+
+type edmNav struct {
+	Name        string             `xml:"Name,attr"`
+	Type        string             `xml:"Type,attr"`
+	Constraints []edmRefConstraint `xml:"ReferentialConstraint"`
+}
+
+type edmRefConstraint struct {
+	Property           string `xml:"Property,attr"`
+	ReferencedProperty string `xml:"ReferencedProperty,attr"`
+}
+
+type edmEntitySet struct {
+	Name     string          `xml:"Name,attr"`
+	Type     string          `xml:"EntityType,attr"`
+	Bindings []edmNavBinding `xml:"NavigationPropertyBinding"`
+}
+
+type edmNavBinding struct {
+	Path   string `xml:"Path,attr"`
+	Target string `xml:"Target,attr"`
+}
+
+// Metadata menghasilkan dokumen CSDL untuk model.
+func (m *Model) Metadata() ([]byte, error) {
+	doc := edmx{Version: "4.0", XMLNS: "http://docs.oasis-open.org/odata/ns/edmx"}
+	schema := &doc.Services.Schema
+	schema.XMLNS = "http://docs.oasis-open.org/odata/ns/edm"
+	schema.Namespace = Namespace
+	schema.Container.Name = Container
+
+	for _, s := range m.Sets {
+		t := edmEntityType{Name: s.EntityType}
+		for _, k := range s.Key {
+			t.Key.Refs = append(t.Key.Refs, edmPropertyRef{Name: k})
+		}
+		for _, p := range s.Properties {
+			ep := edmProperty{Name: p.Name, Type: p.Type, MaxLength: p.MaxLength}
+			if !p.Nullable {
+				ep.Nullable = "false"
+			}
+			if p.Type == EdmDecimal {
+				// CSDL v4 memakai Scale 0 kalau tidak disebut; harga butuh pecahan.
+				ep.Precision, ep.Scale = 19, 4
+			}
+			t.Properties = append(t.Properties, ep)
+		}
+		set := edmEntitySet{Name: s.Name, Type: Namespace + "." + s.EntityType}
+		for _, n := range s.Navs {
+			nav := edmNav{Name: n.Name, Type: n.Type()}
+			if !n.Many {
+				nav.Constraints = []edmRefConstraint{{Property: n.Local, ReferencedProperty: n.Remote}}
+			}
+			t.Navs = append(t.Navs, nav)
+			set.Bindings = append(set.Bindings, edmNavBinding{Path: n.Name, Target: n.Target.Name})
+		}
+		schema.Types = append(schema.Types, t)
+		schema.Container.Sets = append(schema.Container.Sets, set)
+	}
+
+	out, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), out...), nil
+}
@@ -0,0 +1,279 @@
+package odata
+
+import (
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"northwind-api/internal/apperr"
+)
+
+// Query adalah system query option yang sudah divalidasi terhadap model dan
+// diterjemahkan ke potongan SQL.
+type Query struct {
+	Set *EntitySet
+	// Select nil berarti semua properti.
+	Select  []*Property
+	Filter  *expr
+	OrderBy []orderBy
+	// Top -1 berarti tidak dibatasi client.
+	Top    int
+	Skip   int
+	Count  bool
+	Expand []*Expand
+
+	includeDeleted bool
+	hidden         Hidden
+	aliases        int
+}
+
+type orderBy struct {
+	expr
+	desc bool
+}
+
+// Hidden melaporkan properti yang tidak boleh dilihat caller. Nilainya
+// dikirim sebagai null dan properti tersebut tidak bisa dipakai di $filter
+// atau $orderby (hasilnya bisa dipakai menebak nilai).
+type Hidden func(set *EntitySet, p *Property) bool
+
+// Expand adalah satu item $expand beserta opsi bersarangnya.
+type Expand struct {
+	Nav *NavProperty
+	*Query
+}
+
+// ParseQuery membaca system query option ($filter, $select, dst.) dari values
+// untuk entity set. Option tanpa awalan $ (mis. include_deleted) diabaikan.
+// hidden boleh nil.
+func ParseQuery(set *EntitySet, values url.Values, includeDeleted bool, hidden Hidden) (*Query, error) {
+	opts := map[string]string{}
+	for k, v := range values {
+		if !strings.HasPrefix(k, "$") {
+			continue
+		}
+		if len(v) > 1 {
+			return nil, apperr.Validation(k, "must not be specified more than once")
+		}
+		opts[k] = v[0]
+	}
+	if f, ok := opts["$format"]; ok {
+		if f != "json" && !strings.HasPrefix(f, "application/json") {
+			return nil, apperr.Validation("$format", "only json is supported")
+		}
+		delete(opts, "$format")
+	}
+	return parseOptions(set, opts, includeDeleted, hidden)
+}
+
+func parseOptions(set *EntitySet, opts map[string]string, includeDeleted bool, hidden Hidden) (*Query, error) {
+	q := &Query{Set: set, Top: -1, includeDeleted: includeDeleted, hidden: hidden}
+	for name, value := range opts {
+		var err error
+		switch name {
+		case "$select":
+			q.Select, err = parseSelect(set, value)
+		case "$filter":
+			var e expr
+			if e, err = q.translate(name, value); err == nil {
+				if e.kind != kBool {
+					err = apperr.Validation(name, "must be a boolean expression")
+				}
+				q.Filter = &e
+			}
+		case "$orderby":
+			q.OrderBy, err = q.parseOrderBy(value)
+		case "$top":
+			q.Top, err = nonNegative(name, value)
+		case "$skip":
+			q.Skip, err = nonNegative(name, value)
+		case "$count":
+			q.Count, err = strconv.ParseBool(value)
+			if err != nil || (value != "true" && value != "false") {
+				err = apperr.Validation(name, "must be true or false")
+			}
+		case "$expand":
+			q.Expand, err = parseExpand(set, value, includeDeleted, hidden)
+		default:
+			err = apperr.Validation(name, "query option is not supported")
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+// translate menerjemahkan ekspresi; alias subquery dibagi antar option
+// supaya $filter dan $orderby di statement yang sama tidak bentrok.
+func (q *Query) translate(option, src string) (expr, error) {
+	t := newTranslator(q.Set, q.includeDeleted)
+	t.hidden = q.hidden
+	t.aliases = q.aliases
+	e, err := t.parse(option, src)
+	q.aliases = t.aliases
+	return e, err
+}
+
+func nonNegative(option, value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, apperr.Validation(option, "must be a non-negative integer")
+	}
+	return n, nil
+}
+
+func parseSelect(set *EntitySet, value string) ([]*Property, error) {
+	var out []*Property
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "*" {
+			return nil, nil
+		}
+		p := set.Property(name)
+		if p == nil {
+			if set.Nav(name) != nil {
+				return nil, apperr.Validation("$select", "%s is a navigation property; use $expand", name)
+			}
+			return nil, apperr.Validation("$select", "unknown property %q on %s", name, set.EntityType)
+		}
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+func (q *Query) parseOrderBy(value string) ([]orderBy, error) {
+	var out []orderBy
+	for _, item := range splitTop(value, ',') {
+		item = strings.TrimSpace(item)
+		desc := false
+		if s, ok := strings.CutSuffix(item, " desc"); ok {
+			item, desc = s, true
+		} else if s, ok := strings.CutSuffix(item, " asc"); ok {
+			item = s
+		}
+		e, err := q.translate("$orderby", item)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, orderBy{expr: e, desc: desc})
+	}
+	return out, nil
+}
+
+// parseExpand membaca "Nav1,Nav2($select=...;$filter=...;$expand=...)".
+func parseExpand(set *EntitySet, value string, includeDeleted bool, hidden Hidden) ([]*Expand, error) {
+	var out []*Expand
+	for _, item := range splitTop(value, ',') {
+		item = strings.TrimSpace(item)
+		name, nested := item, ""
+		if i := strings.IndexByte(item, '('); i >= 0 {
+			if !strings.HasSuffix(item, ")") {
+				return nil, apperr.Validation("$expand", "missing ) in %q", item)
+			}
+			name, nested = item[:i], item[i+1:len(item)-1]
+		}
+		n := set.Nav(name)
+		if n == nil {
+			return nil, apperr.Validation("$expand", "unknown navigation property %q on %s", name, set.EntityType)
+		}
+		opts := map[string]string{}
+		for _, o := range splitTop(nested, ';') {
+			if o = strings.TrimSpace(o); o == "" {
+				continue
+			}
+			k, v, ok := strings.Cut(o, "=")
+			if !ok {
+				return nil, apperr.Validation("$expand", "invalid option %q for %s", o, name)
+			}
+			opts[k] = v
+		}
+		if !n.Many {
+			for k := range opts {
+				if k != "$select" && k != "$expand" {
+					return nil, apperr.Validation("$expand", "%s is not allowed on single-valued %s", k, name)
+				}
+			}
+		}
+		q, err := parseOptions(n.Target, opts, includeDeleted, hidden)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, &Expand{Nav: n, Query: q})
+	}
+	return out, nil
+}
+
+// splitTop memecah s pada sep yang tidak berada di dalam kurung atau string.
+func splitTop(s string, sep byte) []string {
+	var out []string
+	depth, quoted, start := 0, false, 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\'':
+			quoted = !quoted
+		case quoted:
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == sep && depth == 0:
+			out = append(out, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(out, s[start:])
+}
+
+// ParseKey membaca kunci entity dari "('ALFKI')", "(10248)" atau
+// "(OrderID=10248,ProductID=11)" dan mengembalikannya sesuai urutan Key.
+func ParseKey(set *EntitySet, src string) ([]any, error) {
+	inner, open := strings.CutPrefix(src, "(")
+	inner, closed := strings.CutSuffix(inner, ")")
+	if !open || !closed || inner == "" {
+		return nil, apperr.Validation("key", "invalid key %q", src)
+	}
+	parts := splitTop(inner, ',')
+	if len(parts) != len(set.Key) {
+		return nil, apperr.Validation("key", "%s has %d key properties", set.EntityType, len(set.Key))
+	}
+	values := make([]any, len(set.Key))
+	for i, part := range parts {
+		name := set.Key[i]
+		if k, v, named := strings.Cut(part, "="); named && !strings.HasPrefix(part, "'") {
+			if set.Property(k) == nil || !slices.Contains(set.Key, k) {
+				return nil, apperr.Validation("key", "%q is not a key property of %s", k, set.EntityType)
+			}
+			name, part = k, v
+		} else if len(parts) > 1 {
+			return nil, apperr.Validation("key", "composite keys must be written as Name=value")
+		}
+		v, err := keyValue(set.Property(name), part)
+		if err != nil {
+			return nil, err
+		}
+		values[slices.Index(set.Key, name)] = v
+	}
+	for i, v := range values {
+		if v == nil {
+			return nil, apperr.Validation("key", "missing key property %s", set.Key[i])
+		}
+	}
+	return values, nil
+}
+
+func keyValue(p *Property, src string) (any, error) {
+	if kindOf(p) == kString {
+		s, n, err := lexString(src)
+		if err != nil || !strings.HasPrefix(src, "'") || n != len(src) {
+			return nil, apperr.Validation("key", "%s must be a quoted string", p.Name)
+		}
+		return s, nil
+	}
+	i, err := strconv.ParseInt(src, 10, 64)
+	if err != nil {
+		return nil, apperr.Validation("key", "%s must be an integer", p.Name)
+	}
+	return i, nil
+}
@@ -0,0 +1,106 @@
+package odata
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"northwind-api/internal/apperr"
+)
+
+func TestFilterTranslation(t *testing.T) {
+	m := Northwind()
+	tests := []struct {
+		set, filter string
+		wantSQL     string
+		wantArgs    []any
+	}{
+		{"Customers", "Country eq 'Germany'", "(t0.Country IS ?)", []any{"Germany"}},
+		{"Customers", "Region eq null", "(t0.Region IS NULL)", nil},
+		{"Products", "UnitPrice gt 20 and not Discontinued", "((t0.UnitPrice > ?) AND (NOT t0.Discontinued))", []any{int64(20)}},
+		{"Products", "CategoryID in (1, 2)", "(t0.CategoryID IN (?, ?))", []any{int64(1), int64(2)}},
+		{"Customers", "contains(tolower(CompanyName), 'fut')", "(instr(lower(t0.CompanyName), ?) > 0)", []any{"fut"}},
+		{"Orders", "year(OrderDate) eq 1996 and Freight add 1 ge 10.5",
+			"((CAST(strftime('%Y', datetime(t0.OrderDate)) AS INTEGER) IS ?) AND ((t0.Freight + ?) >= ?))", []any{int64(1996), int64(1), 10.5}},
+		{"Orders", "Customer/Country eq 'Germany'",
+			"((SELECT t1.Country FROM Customers t1 WHERE t1.CustomerID = t0.CustomerID) IS ?)", []any{"Germany"}},
+		{"Customers", "Orders/any(o: o/Freight gt 30)",
+			"EXISTS (SELECT 1 FROM Orders t1 WHERE t1.CustomerID = t0.CustomerID AND (t1.Freight > ?))", []any{int64(30)}},
+		{"Customers", "Orders/$count gt 1",
+			"((SELECT COUNT(*) FROM Orders t1 WHERE t1.CustomerID = t0.CustomerID) > ?)", []any{int64(1)}},
+		{"Employees", "Territories/all(t: startswith(t/TerritoryDescription, 'W'))",
+			"NOT EXISTS (SELECT 1 FROM Territories t1 JOIN EmployeeTerritories l1 ON l1.TerritoryID = t1.TerritoryID WHERE l1.EmployeeID = t0.EmployeeID AND NOT COALESCE((instr(TRIM(t1.TerritoryDescription), ?) = 1), 0))", []any{"W"}},
+	}
+	for _, tc := range tests {
+		q, err := ParseQuery(m.Set(tc.set), url.Values{"$filter": {tc.filter}}, false, nil)
+		if err != nil {
+			t.Errorf("%s: %v", tc.filter, err)
+			continue
+		}
+		if q.Filter.sql != tc.wantSQL {
+			t.Errorf("%s:\n got %s\nwant %s", tc.filter, q.Filter.sql, tc.wantSQL)
+		}
+		if !reflect.DeepEqual(q.Filter.args, tc.wantArgs) {
+			t.Errorf("%s: args = %#v, want %#v", tc.filter, q.Filter.args, tc.wantArgs)
+		}
+	}
+}
+
+func TestQueryErrors(t *testing.T) {
+	orders := Northwind().Set("Orders")
+	tests := []struct {
+		option, value, wantField, wantMsg string
+	}{
+		{"$filter", "Foo eq 1", "$filter", `unknown property "Foo"`},
+		{"$filter", "Freight eq 'x'", "$filter", "cannot compare"},
+		{"$filter", "Freight add 1", "$filter", "boolean"},
+		{"$filter", "ShipCity eq 'Reims", "$filter", "unterminated"},
+		{"$filter", "length(ShipCity, 1) eq 2", "$filter", "length"},
+		{"$select", "Customer", "$select", "use $expand"},
+		{"$orderby", "OrderDate sideways", "$orderby", ""},
+		{"$top", "-1", "$top", "non-negative"},
+		{"$count", "yes", "$count", "true or false"},
+		{"$expand", "Customer($top=1)", "$expand", "not allowed"},
+		{"$expand", "Details", "$expand", "unknown navigation"},
+		{"$expand", "OrderDetails($filter=Nope gt 1)", "$filter", "Nope"},
+		{"$search", "chai", "$search", "not supported"},
+	}
+	for _, tc := range tests {
+		_, err := ParseQuery(orders, url.Values{tc.option: {tc.value}}, false, nil)
+		e, ok := apperr.As(err)
+		if !ok || !errors.Is(err, apperr.ErrValidation) {
+			t.Errorf("%s=%s: err = %v, want validation error", tc.option, tc.value, err)
+			continue
+		}
+		if e.Field != tc.wantField || !strings.Contains(e.Message, tc.wantMsg) {
+			t.Errorf("%s=%s: got %s %q", tc.option, tc.value, e.Field, e.Message)
+		}
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	m := Northwind()
+	tests := []struct {
+		set, key string
+		want     []any
+		wantErr  bool
+	}{
+		{"Customers", "('ALFKI')", []any{"ALFKI"}, false},
+		{"Customers", "('O''BR')", []any{"O'BR"}, false},
+		{"Orders", "(10248)", []any{int64(10248)}, false},
+		{"OrderDetails", "(ProductID=11,OrderID=10248)", []any{int64(10248), int64(11)}, false},
+		{"Customers", "(ALFKI)", nil, true},
+		{"Orders", "('10248')", nil, true},
+		{"OrderDetails", "(10248,11)", nil, true},
+		{"OrderDetails", "(OrderID=10248,OrderID=11)", nil, true},
+		{"Orders", "10248", nil, true},
+	}
+	for _, tc := range tests {
+		got, err := ParseKey(m.Set(tc.set), tc.key)
+		if (err != nil) != tc.wantErr || !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s%s = %#v, %v", tc.set, tc.key, got, err)
+		}
+	}
+}
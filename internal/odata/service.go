@@ -0,0 +1,490 @@
+package odata
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"northwind-api/internal/apperr"
+)
+
+// DefaultPageSize adalah jumlah baris per response sebelum server mengirim
+// @odata.nextLink (server-driven paging).
+const DefaultPageSize = 1000
+
+// maxBatch membatasi jumlah kunci per IN (...) saat memuat $expand, sama
+// seperti loader GraphQL, supaya tidak melewati batas parameter SQLite.
+const maxBatch = 500
+
+// Statement adalah SQL berparameter hasil terjemahan query OData.
+type Statement struct {
+	SQL  string
+	Args []any
+}
+
+// Store menjalankan statement atas database. Diimplementasikan oleh
+// repositories.ODataRepository.
+type Store interface {
+	QueryOData(ctx context.Context, s Statement) ([][]any, error)
+	CountOData(ctx context.Context, s Statement) (int64, error)
+}
+
+// Service menjalankan Query terhadap Store dan membentuk hasilnya.
+type Service struct {
+	Model    *Model
+	Store    Store
+	PageSize int
+}
+
+func NewService(store Store) *Service {
+	return &Service{Model: Northwind(), Store: store, PageSize: DefaultPageSize}
+}
+
+// Field adalah satu pasangan nama/nilai di Record.
+type Field struct {
+	Name  string
+	Value any
+}
+
+// Record adalah satu entity dalam urutan properti $metadata; JSON-nya
+// mempertahankan urutan tersebut.
+type Record []Field
+
+func (r Record) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, f := range r {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		name, _ := json.Marshal(f.Name)
+		value, err := json.Marshal(f.Value)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(name)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// Page adalah hasil query collection.
+type Page struct {
+	Records []Record
+	// Count diisi kalau $count=true: jumlah baris yang lolos $filter.
+	Count *int64
+	// More menandakan masih ada baris setelah halaman ini (perlu nextLink).
+	More bool
+}
+
+// entity adalah satu baris hasil query: nilai properti per nama, plus hasil
+// $expand yang ditempel setelahnya.
+type entity struct {
+	values   map[string]any
+	expanded []Field
+}
+
+// List menjalankan query collection. Jumlah baris dibatasi PageSize; kalau
+// $top lebih besar (atau kosong) dan masih ada sisa, More bernilai true.
+func (s *Service) List(ctx context.Context, q *Query) (Page, error) {
+	var page Page
+	where, args := q.where(nil)
+	if q.Count {
+		n, err := s.Store.CountOData(ctx, Statement{
+			SQL:  "SELECT COUNT(*) FROM " + q.Set.Table + " t0" + where,
+			Args: args,
+		})
+		if err != nil {
+			return page, err
+		}
+		page.Count = &n
+	}
+
+	limit := s.PageSize
+	if q.Top >= 0 && q.Top <= limit {
+		limit = q.Top
+	} else {
+		limit++ // satu baris ekstra untuk mendeteksi halaman berikutnya
+	}
+	cols := q.columns()
+	stmt := q.selectFrom(cols, "", "")
+	stmt.SQL += where + q.order()
+	stmt.Args = append(append(stmt.Args, args...), q.orderArgs()...)
+	stmt.SQL += " LIMIT ? OFFSET ?"
+	stmt.Args = append(stmt.Args, limit, q.Skip)
+
+	rows, err := s.query(ctx, stmt, cols, nil)
+	if err != nil {
+		return page, err
+	}
+	if len(rows) > s.PageSize {
+		rows, page.More = rows[:s.PageSize], true
+	}
+	if err := s.expand(ctx, q, rows); err != nil {
+		return page, err
+	}
+	page.Records = make([]Record, len(rows))
+	for i, e := range rows {
+		page.Records[i] = q.record(e)
+	}
+	return page, nil
+}
+
+// Get memuat satu entity berdasarkan kunci (hasil ParseKey).
+func (s *Service) Get(ctx context.Context, q *Query, key []any) (Record, error) {
+	var conds []string
+	for _, k := range q.Set.Key {
+		conds = append(conds, "t0."+k+" = ?")
+	}
+	where, args := q.where(conds)
+	cols := q.columns()
+	stmt := q.selectFrom(cols, "", "")
+	stmt.SQL += where
+	stmt.Args = append(append(stmt.Args, key...), args...)
+
+	rows, err := s.query(ctx, stmt, cols, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, apperr.NotFound("no %s found with key %s", q.Set.EntityType, formatKey(key))
+	}
+	if err := s.expand(ctx, q, rows); err != nil {
+		return nil, err
+	}
+	return q.record(rows[0]), nil
+}
+
+// Count menghitung baris yang lolos $filter (untuk /{set}/$count).
+func (s *Service) Count(ctx context.Context, q *Query) (int64, error) {
+	where, args := q.where(nil)
+	return s.Store.CountOData(ctx, Statement{SQL: "SELECT COUNT(*) FROM " + q.Set.Table + " t0" + where, Args: args})
+}
+
+func formatKey(key []any) string {
+	parts := make([]string, len(key))
+	for i, k := range key {
+		parts[i] = fmt.Sprint(k)
+	}
+	return strings.Join(parts, ",")
+}
+
+// where menyusun klausa WHERE: kondisi tambahan (argumennya ditambahkan
+// pemanggil lebih dulu), filter soft delete, lalu $filter.
+func (q *Query) where(conds []string) (string, []any) {
+	var args []any
+	if live := q.Set.liveSQL("t0", q.includeDeleted); live != "" {
+		conds = append(conds, live)
+	}
+	if q.Filter != nil {
+		conds = append(conds, q.Filter.sql)
+		args = q.Filter.args
+	}
+	if len(conds) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conds, " AND "), args
+}
+
+// order menyusun ORDER BY dari $orderby, diakhiri kunci supaya paging stabil.
+func (q *Query) order() string {
+	var items []string
+	for _, o := range q.OrderBy {
+		item := o.sql
+		if o.desc {
+			item += " DESC"
+		}
+		items = append(items, item)
+	}
+	for _, k := range q.Set.Key {
+		items = append(items, "t0."+k)
+	}
+	return " ORDER BY " + strings.Join(items, ", ")
+}
+
+func (q *Query) orderArgs() []any {
+	var args []any
+	for _, o := range q.OrderBy {
+		args = append(args, o.args...)
+	}
+	return args
+}
+
+// columns adalah properti yang di-SELECT: hasil $select ditambah kunci dan
+// kolom yang dibutuhkan untuk menyambung $expand.
+func (q *Query) columns(extra ...string) []*Property {
+	if q.Select == nil {
+		return q.Set.Properties
+	}
+	need := slices.Clone(q.Set.Key)
+	need = append(need, extra...)
+	for _, e := range q.Expand {
+		need = append(need, e.Nav.Local)
+	}
+	var cols []*Property
+	for _, p := range q.Set.Properties {
+		if slices.Contains(q.Select, p) || slices.Contains(need, p.Name) {
+			cols = append(cols, p)
+		}
+	}
+	return cols
+}
+
+// selectFrom menyusun "SELECT kolom FROM tabel t0"; extraCol dan join dipakai
+// saat memuat relasi lewat tabel penghubung.
+func (q *Query) selectFrom(cols []*Property, extraCol, join string) Statement {
+	list := make([]string, 0, len(cols)+1)
+	for _, p := range cols {
+		list = append(list, p.selectSQL("t0"))
+	}
+	if extraCol != "" {
+		list = append(list, extraCol)
+	}
+	return Statement{SQL: "SELECT " + strings.Join(list, ", ") + " FROM " + q.Set.Table + " t0" + join}
+}
+
+// record membentuk output entity sesuai $select, diikuti hasil $expand.
+func (q *Query) record(e entity) Record {
+	props := q.Select
+	if props == nil {
+		props = q.Set.Properties
+	}
+	rec := make(Record, 0, len(props)+len(e.expanded))
+	for _, p := range q.Set.Properties {
+		if !slices.Contains(props, p) {
+			continue
+		}
+		if q.hidden != nil && q.hidden(q.Set, p) {
+			rec = append(rec, Field{p.Name, nil})
+		} else {
+			rec = append(rec, Field{p.Name, e.values[p.Name]})
+		}
+	}
+	return append(rec, e.expanded...)
+}
+
+// query menjalankan stmt dan mengubah nilai mentah sesuai tipe properti.
+// Kalau parent tidak nil, kolom terakhir adalah kunci induk (tabel penghubung)
+// dan disimpan di values[linkKey].
+func (s *Service) query(ctx context.Context, stmt Statement, cols []*Property, parent *Property) ([]entity, error) {
+	raw, err := s.Store.QueryOData(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]entity, len(raw))
+	for i, row := range raw {
+		values := make(map[string]any, len(cols)+1)
+		for j, p := range cols {
+			values[p.Name] = convert(p, row[j])
+		}
+		if parent != nil {
+			values[linkKey] = convert(parent, row[len(cols)])
+		}
+		out[i] = entity{values: values}
+	}
+	return out, nil
+}
+
+// linkKey adalah nama internal kolom kunci induk pada relasi many-to-many.
+const linkKey = "@link"
+
+// expand memuat setiap relasi $expand untuk semua baris sekaligus: satu
+// query IN (...) per relasi (per 500 kunci), lalu dibagikan ke tiap baris.
+func (s *Service) expand(ctx context.Context, q *Query, rows []entity) error {
+	for _, e := range q.Expand {
+		n := e.Nav
+		var keys []any
+		seen := map[any]bool{}
+		for _, r := range rows {
+			if k := r.values[n.Local]; k != nil && !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+
+		groups := map[any][]entity{}
+		for start := 0; start < len(keys); start += maxBatch {
+			chunk := keys[start:min(start+maxBatch, len(keys))]
+			children, err := s.loadRelated(ctx, e, chunk)
+			if err != nil {
+				return err
+			}
+			for _, c := range children {
+				k := c.values[n.Remote]
+				if n.Link != nil {
+					k = c.values[linkKey]
+				}
+				groups[k] = append(groups[k], c)
+			}
+		}
+
+		for i := range rows {
+			var group []entity
+			if k := rows[i].values[n.Local]; k != nil {
+				group = groups[k]
+			}
+			rows[i].expanded = append(rows[i].expanded, e.fields(group)...)
+		}
+	}
+	return nil
+}
+
+// loadRelated memuat baris target relasi untuk kunci induk keys, termasuk
+// $expand bersarang di dalamnya.
+func (s *Service) loadRelated(ctx context.Context, e *Expand, keys []any) ([]entity, error) {
+	n, q := e.Nav, e.Query
+	match, extraCol, join := "t0."+n.Remote, "", ""
+	var parent *Property
+	if n.Link != nil {
+		match = "l0." + n.Link.From
+		extraCol = match
+		join = " JOIN " + n.Link.Table + " l0 ON l0." + n.Link.To + " = t0." + n.Remote
+		parent = &Property{Name: n.Link.From, Type: keyType(keys[0])}
+	}
+
+	cols := q.columns(n.Remote)
+	stmt := q.selectFrom(cols, extraCol, join)
+	conds := []string{match + " IN " + placeholders(len(keys))}
+	if !n.Many {
+		// relasi tunggal (mis. Order.Customer) tetap menampilkan data yang
+		// sudah dihapus, sama seperti order lama tetap merujuk customer-nya.
+		q = &Query{Set: q.Set, Select: q.Select, Expand: q.Expand, Top: -1, includeDeleted: true, hidden: q.hidden}
+	}
+	where, args := q.where(conds)
+	stmt.SQL += where + q.order()
+	stmt.Args = append(append(append(stmt.Args, keys...), args...), q.orderArgs()...)
+
+	rows, err := s.query(ctx, stmt, cols, parent)
+	if err != nil {
+		return nil, err
+	}
+	return rows, s.expand(ctx, q, rows)
+}
+
+// fields membentuk nilai relasi untuk satu baris induk: objek (atau null)
+// untuk relasi tunggal, array untuk koleksi dengan $count/$skip/$top per induk.
+func (e *Expand) fields(group []entity) []Field {
+	if !e.Nav.Many {
+		if len(group) == 0 {
+			return []Field{{e.Nav.Name, nil}}
+		}
+		return []Field{{e.Nav.Name, e.record(group[0])}}
+	}
+	var out []Field
+	if e.Count {
+		out = append(out, Field{e.Nav.Name + "@odata.count", len(group)})
+	}
+	group = group[min(e.Skip, len(group)):]
+	if e.Top >= 0 && e.Top < len(group) {
+		group = group[:e.Top]
+	}
+	records := make([]Record, len(group))
+	for i, c := range group {
+		records[i] = e.record(c)
+	}
+	return append(out, Field{e.Nav.Name, records})
+}
+
+func placeholders(n int) string {
+	return "(" + strings.TrimSuffix(strings.Repeat("?, ", n), ", ") + ")"
+}
+
+// keyType menebak tipe EDM kolom penghubung dari kunci induk yang sudah dikonversi.
+func keyType(v any) string {
+	if _, ok := v.(int64); ok {
+		return EdmInt32
+	}
+	return EdmString
+}
+
+// convert mengubah nilai mentah dari driver SQLite ke nilai JSON sesuai tipe
+// properti. Nilai yang tidak bisa dibaca menjadi null.
+func convert(p *Property, raw any) any {
+	if raw == nil {
+		return nil
+	}
+	if b, ok := raw.([]byte); ok {
+		raw = string(b)
+	}
+	switch p.Type {
+	case EdmInt32:
+		switch v := raw.(type) {
+		case int64:
+			return v
+		case float64:
+			return int64(v)
+		case string:
+			if i, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64); err == nil {
+				return i
+			}
+		}
+		return nil
+	case EdmDecimal, EdmSingle:
+		switch v := raw.(type) {
+		case float64:
+			return v
+		case int64:
+			return float64(v)
+		case string:
+			if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+				return f
+			}
+		}
+		return nil
+	case EdmBoolean:
+		switch v := raw.(type) {
+		case bool:
+			return v
+		case int64:
+			return v != 0
+		case string:
+			b, err := strconv.ParseBool(strings.TrimSpace(v))
+			if err != nil {
+				return nil
+			}
+			return b
+		}
+		return nil
+	case EdmDateTimeOffset:
+		switch v := raw.(type) {
+		case time.Time:
+			return v.UTC().Format(time.RFC3339)
+		case string:
+			if t, ok := parseTime(v); ok {
+				return t.UTC().Format(time.RFC3339)
+			}
+		}
+		return nil
+	}
+	switch v := raw.(type) {
+	case string:
+		return v
+	case time.Time:
+		return v.UTC().Format(time.RFC3339)
+	}
+	return fmt.Sprint(raw)
+}
+
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+}
+
+func parseTime(s string) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
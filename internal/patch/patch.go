@@ -0,0 +1,200 @@
+// Package patch menerapkan JSON Merge Patch (RFC 7396) dan JSON Patch
+// (RFC 6902) ke model, lalu memetakan field yang disentuh ke kolom database
+// supaya UPDATE hanya menulis kolom yang dikirim client.
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"mime"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"northwind-api/internal/apperr"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+)
+
+const (
+	ContentTypeMergePatch = "application/merge-patch+json"
+	ContentTypeJSONPatch  = "application/json-patch+json"
+)
+
+type Kind int
+
+const (
+	MergePatch Kind = iota
+	JSONPatch
+)
+
+// Patch adalah body PATCH yang belum diterapkan.
+type Patch struct {
+	Kind Kind
+	Body []byte
+}
+
+// Parse memilih jenis patch dari Content-Type. application/json diperlakukan
+// sebagai merge patch.
+func Parse(contentType string, body []byte) (Patch, error) {
+	mt, _, _ := mime.ParseMediaType(contentType)
+	switch mt {
+	case ContentTypeJSONPatch:
+		return Patch{Kind: JSONPatch, Body: body}, nil
+	case ContentTypeMergePatch, "application/json", "":
+		return Patch{Kind: MergePatch, Body: body}, nil
+	}
+	return Patch{}, apperr.UnsupportedMediaType("PATCH accepts %s or %s", ContentTypeMergePatch, ContentTypeJSONPatch)
+}
+
+// Apply menerapkan patch ke original dan menulis hasilnya ke dst (pointer ke
+// tipe yang sama). Hasilnya adalah nama field JSON top-level yang disentuh
+// patch; field di readOnly boleh muncul asal nilainya tidak berubah.
+func (p Patch) Apply(original, dst any, readOnly ...string) ([]string, error) {
+	doc, err := json.Marshal(original)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		patched []byte
+		touched []string
+	)
+	switch p.Kind {
+	case JSONPatch:
+		ops, err := jsonpatch.DecodePatch(p.Body)
+		if err != nil {
+			return nil, apperr.Validation("", "invalid JSON Patch document").Wrap(err)
+		}
+		if touched, err = opFields(ops); err != nil {
+			return nil, err
+		}
+		patched, err = ops.Apply(doc)
+		if errors.Is(err, jsonpatch.ErrTestFailed) {
+			return nil, apperr.Conflict("JSON Patch test operation failed").Wrap(err)
+		}
+		if err != nil {
+			return nil, apperr.Validation("", "JSON Patch could not be applied").Wrap(err)
+		}
+	default:
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(p.Body, &fields); err != nil {
+			return nil, apperr.Validation("", "merge patch must be a JSON object").Wrap(err)
+		}
+		for f := range fields {
+			touched = append(touched, f)
+		}
+		if patched, err = jsonpatch.MergePatch(doc, p.Body); err != nil {
+			return nil, apperr.Validation("", "merge patch could not be applied").Wrap(err)
+		}
+	}
+	sort.Strings(touched)
+
+	var before, after map[string]json.RawMessage
+	_ = json.Unmarshal(doc, &before)
+	if err := json.Unmarshal(patched, &after); err != nil {
+		return nil, apperr.Validation("", "patched document must be a JSON object").Wrap(err)
+	}
+
+	t := reflect.TypeOf(dst).Elem()
+	var fields []string
+	var invalid []apperr.FieldError
+	for _, name := range touched {
+		sf, ok := fieldByJSON(t, name)
+		switch {
+		case !ok:
+			invalid = append(invalid, apperr.FieldError{Field: name, Message: "is not a known field"})
+		case slices.Contains(readOnly, name):
+			if !bytes.Equal(before[name], after[name]) {
+				invalid = append(invalid, apperr.FieldError{Field: name, Message: "is read-only"})
+			}
+		case isNull(after[name]) && !nullable(sf.Type):
+			invalid = append(invalid, apperr.FieldError{Field: name, Message: "must not be null"})
+		default:
+			fields = append(fields, name)
+		}
+	}
+	if len(invalid) > 0 {
+		return nil, apperr.Invalid(invalid...)
+	}
+
+	// Decode ke nilai nol supaya field yang dihapus patch tidak mewarisi nilai lama.
+	reflect.ValueOf(dst).Elem().Set(reflect.Zero(t))
+	if err := json.Unmarshal(patched, dst); err != nil {
+		return nil, apperr.Validation("", "patched document does not match the resource").Wrap(err)
+	}
+	return fields, nil
+}
+
+// Columns memetakan field JSON ke kolom database (tag db) beserta nilainya di model.
+func Columns(model any, fields []string) map[string]any {
+	v := reflect.ValueOf(model)
+	for v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	cols := make(map[string]any, len(fields))
+	for _, name := range fields {
+		sf, ok := fieldByJSON(v.Type(), name)
+		if !ok {
+			continue
+		}
+		col, _, _ := strings.Cut(sf.Tag.Get("db"), ",")
+		if col == "" || col == "-" {
+			continue
+		}
+		cols[col] = v.FieldByIndex(sf.Index).Interface()
+	}
+	return cols
+}
+
+// opFields mengambil field top-level dari path (dan from) tiap operasi.
+func opFields(ops jsonpatch.Patch) ([]string, error) {
+	seen := map[string]bool{}
+	var fields []string
+	for _, op := range ops {
+		paths := []string{}
+		if path, err := op.Path(); err == nil {
+			paths = append(paths, path)
+		}
+		if from, err := op.From(); err == nil {
+			paths = append(paths, from)
+		}
+		for _, path := range paths {
+			segment, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+			if !strings.HasPrefix(path, "/") || segment == "" {
+				return nil, apperr.Validation("", "JSON Patch path %q must target a field", path)
+			}
+			segment = strings.NewReplacer("~1", "/", "~0", "~").Replace(segment)
+			if !seen[segment] {
+				seen[segment] = true
+				fields = append(fields, segment)
+			}
+		}
+	}
+	return fields, nil
+}
+
+func fieldByJSON(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if tag == name && tag != "-" {
+			return sf, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+func nullable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+		return true
+	}
+	return false
+}
+
+func isNull(raw json.RawMessage) bool {
+	return raw == nil || string(raw) == "null"
+}
@@ -0,0 +1,73 @@
+package patch_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/models"
+	"northwind-api/internal/patch"
+)
+
+func strPtr(s string) *string { return &s }
+
+func TestApply(t *testing.T) {
+	freight := 32.38
+	before := models.Order{OrderID: 10248, CustomerID: strPtr("ALFKI"), ShipCity: strPtr("Reims"), Freight: &freight}
+
+	tests := []struct {
+		name     string
+		kind     patch.Kind
+		body     string
+		wantCols []string
+		wantErr  error
+	}{
+		{"merge", patch.MergePatch, `{"freight":10,"ship_city":null}`, []string{"Freight", "ShipCity"}, nil},
+		{"merge same id", patch.MergePatch, `{"order_id":10248,"ship_name":"Vins"}`, []string{"ShipName"}, nil},
+		{"merge changed id", patch.MergePatch, `{"order_id":1}`, nil, apperr.ErrValidation},
+		{"json patch", patch.JSONPatch, `[{"op":"replace","path":"/freight","value":10},{"op":"move","from":"/ship_city","path":"/ship_region"}]`, []string{"Freight", "ShipCity", "ShipRegion"}, nil},
+		{"json patch root", patch.JSONPatch, `[{"op":"replace","path":"","value":{}}]`, nil, apperr.ErrValidation},
+		{"json patch test failed", patch.JSONPatch, `[{"op":"test","path":"/customer_id","value":"ANATR"}]`, nil, apperr.ErrConflict},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var after models.Order
+			fields, err := patch.Patch{Kind: tc.kind, Body: []byte(tc.body)}.Apply(before, &after, "order_id")
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			cols := patch.Columns(&after, fields)
+			var got []string
+			for _, c := range tc.wantCols {
+				if _, ok := cols[c]; ok {
+					got = append(got, c)
+				}
+			}
+			if len(cols) != len(tc.wantCols) || !reflect.DeepEqual(got, tc.wantCols) {
+				t.Fatalf("columns = %v, want %v", cols, tc.wantCols)
+			}
+			if *after.CustomerID != "ALFKI" {
+				t.Fatalf("untouched field changed: %+v", after)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	if p, err := patch.Parse("application/json-patch+json; charset=utf-8", nil); err != nil || p.Kind != patch.JSONPatch {
+		t.Fatalf("json patch: %v %v", p.Kind, err)
+	}
+	if p, err := patch.Parse("application/json", nil); err != nil || p.Kind != patch.MergePatch {
+		t.Fatalf("json: %v %v", p.Kind, err)
+	}
+	if _, err := patch.Parse("text/plain", nil); !errors.Is(err, apperr.ErrUnsupportedMediaType) {
+		t.Fatalf("text/plain: err = %v", err)
+	}
+}
@@ -0,0 +1,587 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: northwind/v1/customers.proto
+
+package northwindv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Customer struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId   string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	CompanyName  string                 `protobuf:"bytes,2,opt,name=company_name,json=companyName,proto3" json:"company_name,omitempty"`
+	ContactName  string                 `protobuf:"bytes,3,opt,name=contact_name,json=contactName,proto3" json:"contact_name,omitempty"`
+	ContactTitle string                 `protobuf:"bytes,4,opt,name=contact_title,json=contactTitle,proto3" json:"contact_title,omitempty"`
+	Address      string                 `protobuf:"bytes,5,opt,name=address,proto3" json:"address,omitempty"`
+	City         string                 `protobuf:"bytes,6,opt,name=city,proto3" json:"city,omitempty"`
+	Region       string                 `protobuf:"bytes,7,opt,name=region,proto3" json:"region,omitempty"`
+	PostalCode   string                 `protobuf:"bytes,8,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	Country      string                 `protobuf:"bytes,9,opt,name=country,proto3" json:"country,omitempty"`
+	Phone        string                 `protobuf:"bytes,10,opt,name=phone,proto3" json:"phone,omitempty"`
+	Fax          string                 `protobuf:"bytes,11,opt,name=fax,proto3" json:"fax,omitempty"`
+	DeletedAt    *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	// Versi resource; kirim balik di request update/delete untuk optimistic concurrency.
+	Etag          string `protobuf:"bytes,13,opt,name=etag,proto3" json:"etag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Customer) Reset() {
+	*x = Customer{}
+	mi := &file_northwind_v1_customers_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Customer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Customer) ProtoMessage() {}
+
+func (x *Customer) ProtoReflect() protoreflect.Message {
+	mi := &file_northwind_v1_customers_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Customer.ProtoReflect.Descriptor instead.
+func (*Customer) Descriptor() ([]byte, []int) {
+	return file_northwind_v1_customers_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Customer) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *Customer) GetCompanyName() string {
+	if x != nil {
+		return x.CompanyName
+	}
+	return ""
+}
+
+func (x *Customer) GetContactName() string {
+	if x != nil {
+		return x.ContactName
+	}
+	return ""
+}
+
+func (x *Customer) GetContactTitle() string {
+	if x != nil {
+		return x.ContactTitle
+	}
+	return ""
+}
+
+func (x *Customer) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Customer) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *Customer) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *Customer) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *Customer) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Customer) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *Customer) GetFax() string {
+	if x != nil {
+		return x.Fax
+	}
+	return ""
+}
+
+func (x *Customer) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *Customer) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+type ListCustomersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Page  *PageRequest           `protobuf:"bytes,1,opt,name=page,proto3" json:"page,omitempty"`
+	// Filter opsional, tidak peka huruf besar/kecil.
+	Country       string `protobuf:"bytes,2,opt,name=country,proto3" json:"country,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCustomersRequest) Reset() {
+	*x = ListCustomersRequest{}
+	mi := &file_northwind_v1_customers_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCustomersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCustomersRequest) ProtoMessage() {}
+
+func (x *ListCustomersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_northwind_v1_customers_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCustomersRequest.ProtoReflect.Descriptor instead.
+func (*ListCustomersRequest) Descriptor() ([]byte, []int) {
+	return file_northwind_v1_customers_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListCustomersRequest) GetPage() *PageRequest {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+func (x *ListCustomersRequest) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+type ListCustomersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Customers     []*Customer            `protobuf:"bytes,1,rep,name=customers,proto3" json:"customers,omitempty"`
+	Page          *PageResponse          `protobuf:"bytes,2,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCustomersResponse) Reset() {
+	*x = ListCustomersResponse{}
+	mi := &file_northwind_v1_customers_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCustomersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCustomersResponse) ProtoMessage() {}
+
+func (x *ListCustomersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_northwind_v1_customers_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCustomersResponse.ProtoReflect.Descriptor instead.
+func (*ListCustomersResponse) Descriptor() ([]byte, []int) {
+	return file_northwind_v1_customers_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListCustomersResponse) GetCustomers() []*Customer {
+	if x != nil {
+		return x.Customers
+	}
+	return nil
+}
+
+func (x *ListCustomersResponse) GetPage() *PageResponse {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+type GetCustomerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCustomerRequest) Reset() {
+	*x = GetCustomerRequest{}
+	mi := &file_northwind_v1_customers_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCustomerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCustomerRequest) ProtoMessage() {}
+
+func (x *GetCustomerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_northwind_v1_customers_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCustomerRequest.ProtoReflect.Descriptor instead.
+func (*GetCustomerRequest) Descriptor() ([]byte, []int) {
+	return file_northwind_v1_customers_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetCustomerRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+type CreateCustomerRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// customer_id dan etag diabaikan.
+	Customer      *Customer `protobuf:"bytes,1,opt,name=customer,proto3" json:"customer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCustomerRequest) Reset() {
+	*x = CreateCustomerRequest{}
+	mi := &file_northwind_v1_customers_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCustomerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCustomerRequest) ProtoMessage() {}
+
+func (x *CreateCustomerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_northwind_v1_customers_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCustomerRequest.ProtoReflect.Descriptor instead.
+func (*CreateCustomerRequest) Descriptor() ([]byte, []int) {
+	return file_northwind_v1_customers_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateCustomerRequest) GetCustomer() *Customer {
+	if x != nil {
+		return x.Customer
+	}
+	return nil
+}
+
+type UpdateCustomerRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Customer *Customer              `protobuf:"bytes,1,opt,name=customer,proto3" json:"customer,omitempty"`
+	// Opsional; kalau diisi harus sama dengan etag saat ini.
+	Etag          string `protobuf:"bytes,2,opt,name=etag,proto3" json:"etag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCustomerRequest) Reset() {
+	*x = UpdateCustomerRequest{}
+	mi := &file_northwind_v1_customers_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCustomerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCustomerRequest) ProtoMessage() {}
+
+func (x *UpdateCustomerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_northwind_v1_customers_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCustomerRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCustomerRequest) Descriptor() ([]byte, []int) {
+	return file_northwind_v1_customers_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateCustomerRequest) GetCustomer() *Customer {
+	if x != nil {
+		return x.Customer
+	}
+	return nil
+}
+
+func (x *UpdateCustomerRequest) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+type DeleteCustomerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Etag          string                 `protobuf:"bytes,2,opt,name=etag,proto3" json:"etag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCustomerRequest) Reset() {
+	*x = DeleteCustomerRequest{}
+	mi := &file_northwind_v1_customers_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCustomerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCustomerRequest) ProtoMessage() {}
+
+func (x *DeleteCustomerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_northwind_v1_customers_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCustomerRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCustomerRequest) Descriptor() ([]byte, []int) {
+	return file_northwind_v1_customers_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeleteCustomerRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *DeleteCustomerRequest) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+var File_northwind_v1_customers_proto protoreflect.FileDescriptor
+
+const file_northwind_v1_customers_proto_rawDesc = "" +
+	"\n" +
+	"\x1cnorthwind/v1/customers.proto\x12\fnorthwind.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x19northwind/v1/common.proto\"\x8e\x03\n" +
+	"\bCustomer\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x12!\n" +
+	"\fcompany_name\x18\x02 \x01(\tR\vcompanyName\x12!\n" +
+	"\fcontact_name\x18\x03 \x01(\tR\vcontactName\x12#\n" +
+	"\rcontact_title\x18\x04 \x01(\tR\fcontactTitle\x12\x18\n" +
+	"\aaddress\x18\x05 \x01(\tR\aaddress\x12\x12\n" +
+	"\x04city\x18\x06 \x01(\tR\x04city\x12\x16\n" +
+	"\x06region\x18\a \x01(\tR\x06region\x12\x1f\n" +
+	"\vpostal_code\x18\b \x01(\tR\n" +
+	"postalCode\x12\x18\n" +
+	"\acountry\x18\t \x01(\tR\acountry\x12\x14\n" +
+	"\x05phone\x18\n" +
+	" \x01(\tR\x05phone\x12\x10\n" +
+	"\x03fax\x18\v \x01(\tR\x03fax\x129\n" +
+	"\n" +
+	"deleted_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x12\x12\n" +
+	"\x04etag\x18\r \x01(\tR\x04etag\"_\n" +
+	"\x14ListCustomersRequest\x12-\n" +
+	"\x04page\x18\x01 \x01(\v2\x19.northwind.v1.PageRequestR\x04page\x12\x18\n" +
+	"\acountry\x18\x02 \x01(\tR\acountry\"}\n" +
+	"\x15ListCustomersResponse\x124\n" +
+	"\tcustomers\x18\x01 \x03(\v2\x16.northwind.v1.CustomerR\tcustomers\x12.\n" +
+	"\x04page\x18\x02 \x01(\v2\x1a.northwind.v1.PageResponseR\x04page\"5\n" +
+	"\x12GetCustomerRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\"K\n" +
+	"\x15CreateCustomerRequest\x122\n" +
+	"\bcustomer\x18\x01 \x01(\v2\x16.northwind.v1.CustomerR\bcustomer\"_\n" +
+	"\x15UpdateCustomerRequest\x122\n" +
+	"\bcustomer\x18\x01 \x01(\v2\x16.northwind.v1.CustomerR\bcustomer\x12\x12\n" +
+	"\x04etag\x18\x02 \x01(\tR\x04etag\"L\n" +
+	"\x15DeleteCustomerRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x12\x12\n" +
+	"\x04etag\x18\x02 \x01(\tR\x04etag2\xa1\x03\n" +
+	"\x0fCustomerService\x12X\n" +
+	"\rListCustomers\x12\".northwind.v1.ListCustomersRequest\x1a#.northwind.v1.ListCustomersResponse\x12G\n" +
+	"\vGetCustomer\x12 .northwind.v1.GetCustomerRequest\x1a\x16.northwind.v1.Customer\x12M\n" +
+	"\x0eCreateCustomer\x12#.northwind.v1.CreateCustomerRequest\x1a\x16.northwind.v1.Customer\x12M\n" +
+	"\x0eUpdateCustomer\x12#.northwind.v1.UpdateCustomerRequest\x1a\x16.northwind.v1.Customer\x12M\n" +
+	"\x0eDeleteCustomer\x12#.northwind.v1.DeleteCustomerRequest\x1a\x16.google.protobuf.EmptyB3Z1northwind-api/internal/pb/northwindv1;northwindv1b\x06proto3"
+
+var (
+	file_northwind_v1_customers_proto_rawDescOnce sync.Once
+	file_northwind_v1_customers_proto_rawDescData []byte
+)
+
+func file_northwind_v1_customers_proto_rawDescGZIP() []byte {
+	file_northwind_v1_customers_proto_rawDescOnce.Do(func() {
+		file_northwind_v1_customers_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_northwind_v1_customers_proto_rawDesc), len(file_northwind_v1_customers_proto_rawDesc)))
+	})
+	return file_northwind_v1_customers_proto_rawDescData
+}
+
+var file_northwind_v1_customers_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_northwind_v1_customers_proto_goTypes = []any{
+	(*Customer)(nil),              // 0: northwind.v1.Customer
+	(*ListCustomersRequest)(nil),  // 1: northwind.v1.ListCustomersRequest
+	(*ListCustomersResponse)(nil), // 2: northwind.v1.ListCustomersResponse
+	(*GetCustomerRequest)(nil),    // 3: northwind.v1.GetCustomerRequest
+	(*CreateCustomerRequest)(nil), // 4: northwind.v1.CreateCustomerRequest
+	(*UpdateCustomerRequest)(nil), // 5: northwind.v1.UpdateCustomerRequest
+	(*DeleteCustomerRequest)(nil), // 6: northwind.v1.DeleteCustomerRequest
+	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
+	(*PageRequest)(nil),           // 8: northwind.v1.PageRequest
+	(*PageResponse)(nil),          // 9: northwind.v1.PageResponse
+	(*emptypb.Empty)(nil),         // 10: google.protobuf.Empty
+}
+var file_northwind_v1_customers_proto_depIdxs = []int32{
+	7,  // 0: northwind.v1.Customer.deleted_at:type_name -> google.protobuf.Timestamp
+	8,  // 1: northwind.v1.ListCustomersRequest.page:type_name -> northwind.v1.PageRequest
+	0,  // 2: northwind.v1.ListCustomersResponse.customers:type_name -> northwind.v1.Customer
+	9,  // 3: northwind.v1.ListCustomersResponse.page:type_name -> northwind.v1.PageResponse
+	0,  // 4: northwind.v1.CreateCustomerRequest.customer:type_name -> northwind.v1.Customer
+	0,  // 5: northwind.v1.UpdateCustomerRequest.customer:type_name -> northwind.v1.Customer
+	1,  // 6: northwind.v1.CustomerService.ListCustomers:input_type -> northwind.v1.ListCustomersRequest
+	3,  // 7: northwind.v1.CustomerService.GetCustomer:input_type -> northwind.v1.GetCustomerRequest
+	4,  // 8: northwind.v1.CustomerService.CreateCustomer:input_type -> northwind.v1.CreateCustomerRequest
+	5,  // 9: northwind.v1.CustomerService.UpdateCustomer:input_type -> northwind.v1.UpdateCustomerRequest
+	6,  // 10: northwind.v1.CustomerService.DeleteCustomer:input_type -> northwind.v1.DeleteCustomerRequest
+	2,  // 11: northwind.v1.CustomerService.ListCustomers:output_type -> northwind.v1.ListCustomersResponse
+	0,  // 12: northwind.v1.CustomerService.GetCustomer:output_type -> northwind.v1.Customer
+	0,  // 13: northwind.v1.CustomerService.CreateCustomer:output_type -> northwind.v1.Customer
+	0,  // 14: northwind.v1.CustomerService.UpdateCustomer:output_type -> northwind.v1.Customer
+	10, // 15: northwind.v1.CustomerService.DeleteCustomer:output_type -> google.protobuf.Empty
+	11, // [11:16] is the sub-list for method output_type
+	6,  // [6:11] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_northwind_v1_customers_proto_init() }
+func file_northwind_v1_customers_proto_init() {
+	if File_northwind_v1_customers_proto != nil {
+		return
+	}
+	file_northwind_v1_common_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_northwind_v1_customers_proto_rawDesc), len(file_northwind_v1_customers_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_northwind_v1_customers_proto_goTypes,
+		DependencyIndexes: file_northwind_v1_customers_proto_depIdxs,
+		MessageInfos:      file_northwind_v1_customers_proto_msgTypes,
+	}.Build()
+	File_northwind_v1_customers_proto = out.File
+	file_northwind_v1_customers_proto_goTypes = nil
+	file_northwind_v1_customers_proto_depIdxs = nil
+}
@@ -0,0 +1,748 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: northwind/v1/orders.proto
+
+package northwindv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Order struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	OrderId    int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	CustomerId *string                `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3,oneof" json:"customer_id,omitempty"`
+	EmployeeId *int64                 `protobuf:"varint,3,opt,name=employee_id,json=employeeId,proto3,oneof" json:"employee_id,omitempty"`
+	// Tanggal ISO (YYYY-MM-DD atau RFC 3339).
+	OrderDate      *string  `protobuf:"bytes,4,opt,name=order_date,json=orderDate,proto3,oneof" json:"order_date,omitempty"`
+	RequiredDate   *string  `protobuf:"bytes,5,opt,name=required_date,json=requiredDate,proto3,oneof" json:"required_date,omitempty"`
+	ShippedDate    *string  `protobuf:"bytes,6,opt,name=shipped_date,json=shippedDate,proto3,oneof" json:"shipped_date,omitempty"`
+	ShipVia        *int64   `protobuf:"varint,7,opt,name=ship_via,json=shipVia,proto3,oneof" json:"ship_via,omitempty"`
+	Freight        *float64 `protobuf:"fixed64,8,opt,name=freight,proto3,oneof" json:"freight,omitempty"`
+	ShipName       *string  `protobuf:"bytes,9,opt,name=ship_name,json=shipName,proto3,oneof" json:"ship_name,omitempty"`
+	ShipAddress    *string  `protobuf:"bytes,10,opt,name=ship_address,json=shipAddress,proto3,oneof" json:"ship_address,omitempty"`
+	ShipCity       *string  `protobuf:"bytes,11,opt,name=ship_city,json=shipCity,proto3,oneof" json:"ship_city,omitempty"`
+	ShipRegion     *string  `protobuf:"bytes,12,opt,name=ship_region,json=shipRegion,proto3,oneof" json:"ship_region,omitempty"`
+	ShipPostalCode *string  `protobuf:"bytes,13,opt,name=ship_postal_code,json=shipPostalCode,proto3,oneof" json:"ship_postal_code,omitempty"`
+	ShipCountry    *string  `protobuf:"bytes,14,opt,name=ship_country,json=shipCountry,proto3,oneof" json:"ship_country,omitempty"`
+	// Hanya diisi oleh GetOrder.
+	Details       []*OrderDetail `protobuf:"bytes,15,rep,name=details,proto3" json:"details,omitempty"`
+	Etag          string         `protobuf:"bytes,16,opt,name=etag,proto3" json:"etag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Order) Reset() {
+	*x = Order{}
+	mi := &file_northwind_v1_orders_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Order) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Order) ProtoMessage() {}
+
+func (x *Order) ProtoReflect() protoreflect.Message {
+	mi := &file_northwind_v1_orders_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Order.ProtoReflect.Descriptor instead.
+func (*Order) Descriptor() ([]byte, []int) {
+	return file_northwind_v1_orders_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Order) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *Order) GetCustomerId() string {
+	if x != nil && x.CustomerId != nil {
+		return *x.CustomerId
+	}
+	return ""
+}
+
+func (x *Order) GetEmployeeId() int64 {
+	if x != nil && x.EmployeeId != nil {
+		return *x.EmployeeId
+	}
+	return 0
+}
+
+func (x *Order) GetOrderDate() string {
+	if x != nil && x.OrderDate != nil {
+		return *x.OrderDate
+	}
+	return ""
+}
+
+func (x *Order) GetRequiredDate() string {
+	if x != nil && x.RequiredDate != nil {
+		return *x.RequiredDate
+	}
+	return ""
+}
+
+func (x *Order) GetShippedDate() string {
+	if x != nil && x.ShippedDate != nil {
+		return *x.ShippedDate
+	}
+	return ""
+}
+
+func (x *Order) GetShipVia() int64 {
+	if x != nil && x.ShipVia != nil {
+		return *x.ShipVia
+	}
+	return 0
+}
+
+func (x *Order) GetFreight() float64 {
+	if x != nil && x.Freight != nil {
+		return *x.Freight
+	}
+	return 0
+}
+
+func (x *Order) GetShipName() string {
+	if x != nil && x.ShipName != nil {
+		return *x.ShipName
+	}
+	return ""
+}
+
+func (x *Order) GetShipAddress() string {
+	if x != nil && x.ShipAddress != nil {
+		return *x.ShipAddress
+	}
+	return ""
+}
+
+func (x *Order) GetShipCity() string {
+	if x != nil && x.ShipCity != nil {
+		return *x.ShipCity
+	}
+	return ""
+}
+
+func (x *Order) GetShipRegion() string {
+	if x != nil && x.ShipRegion != nil {
+		return *x.ShipRegion
+	}
+	return ""
+}
+
+func (x *Order) GetShipPostalCode() string {
+	if x != nil && x.ShipPostalCode != nil {
+		return *x.ShipPostalCode
+	}
+	return ""
+}
+
+func (x *Order) GetShipCountry() string {
+	if x != nil && x.ShipCountry != nil {
+		return *x.ShipCountry
+	}
+	return ""
+}
+
+func (x *Order) GetDetails() []*OrderDetail {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+func (x *Order) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+type OrderDetail struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ProductId     int64                  `protobuf:"varint,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	UnitPrice     float64                `protobuf:"fixed64,3,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Discount      float32                `protobuf:"fixed32,5,opt,name=discount,proto3" json:"discount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderDetail) Reset() {
+	*x = OrderDetail{}
+	mi := &file_northwind_v1_orders_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderDetail) ProtoMessage() {}
+
+func (x *OrderDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_northwind_v1_orders_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderDetail.ProtoReflect.Descriptor instead.
+func (*OrderDetail) Descriptor() ([]byte, []int) {
+	return file_northwind_v1_orders_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *OrderDetail) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *OrderDetail) GetProductId() int64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *OrderDetail) GetUnitPrice() float64 {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return 0
+}
+
+func (x *OrderDetail) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *OrderDetail) GetDiscount() float32 {
+	if x != nil {
+		return x.Discount
+	}
+	return 0
+}
+
+type ListOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          *PageRequest           `protobuf:"bytes,1,opt,name=page,proto3" json:"page,omitempty"`
+	CustomerId    string                 `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	EmployeeId    *int64                 `protobuf:"varint,3,opt,name=employee_id,json=employeeId,proto3,oneof" json:"employee_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrdersRequest) Reset() {
+	*x = ListOrdersRequest{}
+	mi := &file_northwind_v1_orders_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersRequest) ProtoMessage() {}
+
+func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_northwind_v1_orders_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrdersRequest.ProtoReflect.Descriptor instead.
+func (*ListOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_northwind_v1_orders_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListOrdersRequest) GetPage() *PageRequest {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+func (x *ListOrdersRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetEmployeeId() int64 {
+	if x != nil && x.EmployeeId != nil {
+		return *x.EmployeeId
+	}
+	return 0
+}
+
+type ListOrdersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	Page          *PageResponse          `protobuf:"bytes,2,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrdersResponse) Reset() {
+	*x = ListOrdersResponse{}
+	mi := &file_northwind_v1_orders_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersResponse) ProtoMessage() {}
+
+func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_northwind_v1_orders_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrdersResponse.ProtoReflect.Descriptor instead.
+func (*ListOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_northwind_v1_orders_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListOrdersResponse) GetOrders() []*Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+func (x *ListOrdersResponse) GetPage() *PageResponse {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+type GetOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderRequest) Reset() {
+	*x = GetOrderRequest{}
+	mi := &file_northwind_v1_orders_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderRequest) ProtoMessage() {}
+
+func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_northwind_v1_orders_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderRequest) Descriptor() ([]byte, []int) {
+	return file_northwind_v1_orders_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetOrderRequest) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+type CreateOrderRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// order_id, details dan etag diabaikan.
+	Order         *Order `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrderRequest) Reset() {
+	*x = CreateOrderRequest{}
+	mi := &file_northwind_v1_orders_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrderRequest) ProtoMessage() {}
+
+func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_northwind_v1_orders_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrderRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrderRequest) Descriptor() ([]byte, []int) {
+	return file_northwind_v1_orders_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateOrderRequest) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type UpdateOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	Etag          string                 `protobuf:"bytes,2,opt,name=etag,proto3" json:"etag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOrderRequest) Reset() {
+	*x = UpdateOrderRequest{}
+	mi := &file_northwind_v1_orders_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrderRequest) ProtoMessage() {}
+
+func (x *UpdateOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_northwind_v1_orders_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrderRequest.ProtoReflect.Descriptor instead.
+func (*UpdateOrderRequest) Descriptor() ([]byte, []int) {
+	return file_northwind_v1_orders_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateOrderRequest) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *UpdateOrderRequest) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+type DeleteOrderRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	OrderId int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Etag    string                 `protobuf:"bytes,2,opt,name=etag,proto3" json:"etag,omitempty"`
+	// Ikut menghapus baris detail; tanpa ini order yang masih punya detail ditolak.
+	Cascade bool `protobuf:"varint,3,opt,name=cascade,proto3" json:"cascade,omitempty"`
+	// Pindahkan baris detail ke order ini sebelum menghapus.
+	ReassignTo    int64 `protobuf:"varint,4,opt,name=reassign_to,json=reassignTo,proto3" json:"reassign_to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteOrderRequest) Reset() {
+	*x = DeleteOrderRequest{}
+	mi := &file_northwind_v1_orders_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteOrderRequest) ProtoMessage() {}
+
+func (x *DeleteOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_northwind_v1_orders_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteOrderRequest.ProtoReflect.Descriptor instead.
+func (*DeleteOrderRequest) Descriptor() ([]byte, []int) {
+	return file_northwind_v1_orders_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteOrderRequest) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *DeleteOrderRequest) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+func (x *DeleteOrderRequest) GetCascade() bool {
+	if x != nil {
+		return x.Cascade
+	}
+	return false
+}
+
+func (x *DeleteOrderRequest) GetReassignTo() int64 {
+	if x != nil {
+		return x.ReassignTo
+	}
+	return 0
+}
+
+var File_northwind_v1_orders_proto protoreflect.FileDescriptor
+
+const file_northwind_v1_orders_proto_rawDesc = "" +
+	"\n" +
+	"\x19northwind/v1/orders.proto\x12\fnorthwind.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x19northwind/v1/common.proto\"\xa3\x06\n" +
+	"\x05Order\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x03R\aorderId\x12$\n" +
+	"\vcustomer_id\x18\x02 \x01(\tH\x00R\n" +
+	"customerId\x88\x01\x01\x12$\n" +
+	"\vemployee_id\x18\x03 \x01(\x03H\x01R\n" +
+	"employeeId\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"order_date\x18\x04 \x01(\tH\x02R\torderDate\x88\x01\x01\x12(\n" +
+	"\rrequired_date\x18\x05 \x01(\tH\x03R\frequiredDate\x88\x01\x01\x12&\n" +
+	"\fshipped_date\x18\x06 \x01(\tH\x04R\vshippedDate\x88\x01\x01\x12\x1e\n" +
+	"\bship_via\x18\a \x01(\x03H\x05R\ashipVia\x88\x01\x01\x12\x1d\n" +
+	"\afreight\x18\b \x01(\x01H\x06R\afreight\x88\x01\x01\x12 \n" +
+	"\tship_name\x18\t \x01(\tH\aR\bshipName\x88\x01\x01\x12&\n" +
+	"\fship_address\x18\n" +
+	" \x01(\tH\bR\vshipAddress\x88\x01\x01\x12 \n" +
+	"\tship_city\x18\v \x01(\tH\tR\bshipCity\x88\x01\x01\x12$\n" +
+	"\vship_region\x18\f \x01(\tH\n" +
+	"R\n" +
+	"shipRegion\x88\x01\x01\x12-\n" +
+	"\x10ship_postal_code\x18\r \x01(\tH\vR\x0eshipPostalCode\x88\x01\x01\x12&\n" +
+	"\fship_country\x18\x0e \x01(\tH\fR\vshipCountry\x88\x01\x01\x123\n" +
+	"\adetails\x18\x0f \x03(\v2\x19.northwind.v1.OrderDetailR\adetails\x12\x12\n" +
+	"\x04etag\x18\x10 \x01(\tR\x04etagB\x0e\n" +
+	"\f_customer_idB\x0e\n" +
+	"\f_employee_idB\r\n" +
+	"\v_order_dateB\x10\n" +
+	"\x0e_required_dateB\x0f\n" +
+	"\r_shipped_dateB\v\n" +
+	"\t_ship_viaB\n" +
+	"\n" +
+	"\b_freightB\f\n" +
+	"\n" +
+	"_ship_nameB\x0f\n" +
+	"\r_ship_addressB\f\n" +
+	"\n" +
+	"_ship_cityB\x0e\n" +
+	"\f_ship_regionB\x13\n" +
+	"\x11_ship_postal_codeB\x0f\n" +
+	"\r_ship_country\"\x9e\x01\n" +
+	"\vOrderDetail\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x03R\aorderId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\x03R\tproductId\x12\x1d\n" +
+	"\n" +
+	"unit_price\x18\x03 \x01(\x01R\tunitPrice\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\x12\x1a\n" +
+	"\bdiscount\x18\x05 \x01(\x02R\bdiscount\"\x99\x01\n" +
+	"\x11ListOrdersRequest\x12-\n" +
+	"\x04page\x18\x01 \x01(\v2\x19.northwind.v1.PageRequestR\x04page\x12\x1f\n" +
+	"\vcustomer_id\x18\x02 \x01(\tR\n" +
+	"customerId\x12$\n" +
+	"\vemployee_id\x18\x03 \x01(\x03H\x00R\n" +
+	"employeeId\x88\x01\x01B\x0e\n" +
+	"\f_employee_id\"q\n" +
+	"\x12ListOrdersResponse\x12+\n" +
+	"\x06orders\x18\x01 \x03(\v2\x13.northwind.v1.OrderR\x06orders\x12.\n" +
+	"\x04page\x18\x02 \x01(\v2\x1a.northwind.v1.PageResponseR\x04page\",\n" +
+	"\x0fGetOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x03R\aorderId\"?\n" +
+	"\x12CreateOrderRequest\x12)\n" +
+	"\x05order\x18\x01 \x01(\v2\x13.northwind.v1.OrderR\x05order\"S\n" +
+	"\x12UpdateOrderRequest\x12)\n" +
+	"\x05order\x18\x01 \x01(\v2\x13.northwind.v1.OrderR\x05order\x12\x12\n" +
+	"\x04etag\x18\x02 \x01(\tR\x04etag\"~\n" +
+	"\x12DeleteOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x03R\aorderId\x12\x12\n" +
+	"\x04etag\x18\x02 \x01(\tR\x04etag\x12\x18\n" +
+	"\acascade\x18\x03 \x01(\bR\acascade\x12\x1f\n" +
+	"\vreassign_to\x18\x04 \x01(\x03R\n" +
+	"reassignTo2\xf4\x02\n" +
+	"\fOrderService\x12O\n" +
+	"\n" +
+	"ListOrders\x12\x1f.northwind.v1.ListOrdersRequest\x1a .northwind.v1.ListOrdersResponse\x12>\n" +
+	"\bGetOrder\x12\x1d.northwind.v1.GetOrderRequest\x1a\x13.northwind.v1.Order\x12D\n" +
+	"\vCreateOrder\x12 .northwind.v1.CreateOrderRequest\x1a\x13.northwind.v1.Order\x12D\n" +
+	"\vUpdateOrder\x12 .northwind.v1.UpdateOrderRequest\x1a\x13.northwind.v1.Order\x12G\n" +
+	"\vDeleteOrder\x12 .northwind.v1.DeleteOrderRequest\x1a\x16.google.protobuf.EmptyB3Z1northwind-api/internal/pb/northwindv1;northwindv1b\x06proto3"
+
+var (
+	file_northwind_v1_orders_proto_rawDescOnce sync.Once
+	file_northwind_v1_orders_proto_rawDescData []byte
+)
+
+func file_northwind_v1_orders_proto_rawDescGZIP() []byte {
+	file_northwind_v1_orders_proto_rawDescOnce.Do(func() {
+		file_northwind_v1_orders_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_northwind_v1_orders_proto_rawDesc), len(file_northwind_v1_orders_proto_rawDesc)))
+	})
+	return file_northwind_v1_orders_proto_rawDescData
+}
+
+var file_northwind_v1_orders_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_northwind_v1_orders_proto_goTypes = []any{
+	(*Order)(nil),              // 0: northwind.v1.Order
+	(*OrderDetail)(nil),        // 1: northwind.v1.OrderDetail
+	(*ListOrdersRequest)(nil),  // 2: northwind.v1.ListOrdersRequest
+	(*ListOrdersResponse)(nil), // 3: northwind.v1.ListOrdersResponse
+	(*GetOrderRequest)(nil),    // 4: northwind.v1.GetOrderRequest
+	(*CreateOrderRequest)(nil), // 5: northwind.v1.CreateOrderRequest
+	(*UpdateOrderRequest)(nil), // 6: northwind.v1.UpdateOrderRequest
+	(*DeleteOrderRequest)(nil), // 7: northwind.v1.DeleteOrderRequest
+	(*PageRequest)(nil),        // 8: northwind.v1.PageRequest
+	(*PageResponse)(nil),       // 9: northwind.v1.PageResponse
+	(*emptypb.Empty)(nil),      // 10: google.protobuf.Empty
+}
+var file_northwind_v1_orders_proto_depIdxs = []int32{
+	1,  // 0: northwind.v1.Order.details:type_name -> northwind.v1.OrderDetail
+	8,  // 1: northwind.v1.ListOrdersRequest.page:type_name -> northwind.v1.PageRequest
+	0,  // 2: northwind.v1.ListOrdersResponse.orders:type_name -> northwind.v1.Order
+	9,  // 3: northwind.v1.ListOrdersResponse.page:type_name -> northwind.v1.PageResponse
+	0,  // 4: northwind.v1.CreateOrderRequest.order:type_name -> northwind.v1.Order
+	0,  // 5: northwind.v1.UpdateOrderRequest.order:type_name -> northwind.v1.Order
+	2,  // 6: northwind.v1.OrderService.ListOrders:input_type -> northwind.v1.ListOrdersRequest
+	4,  // 7: northwind.v1.OrderService.GetOrder:input_type -> northwind.v1.GetOrderRequest
+	5,  // 8: northwind.v1.OrderService.CreateOrder:input_type -> northwind.v1.CreateOrderRequest
+	6,  // 9: northwind.v1.OrderService.UpdateOrder:input_type -> northwind.v1.UpdateOrderRequest
+	7,  // 10: northwind.v1.OrderService.DeleteOrder:input_type -> northwind.v1.DeleteOrderRequest
+	3,  // 11: northwind.v1.OrderService.ListOrders:output_type -> northwind.v1.ListOrdersResponse
+	0,  // 12: northwind.v1.OrderService.GetOrder:output_type -> northwind.v1.Order
+	0,  // 13: northwind.v1.OrderService.CreateOrder:output_type -> northwind.v1.Order
+	0,  // 14: northwind.v1.OrderService.UpdateOrder:output_type -> northwind.v1.Order
+	10, // 15: northwind.v1.OrderService.DeleteOrder:output_type -> google.protobuf.Empty
+	11, // [11:16] is the sub-list for method output_type
+	6,  // [6:11] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_northwind_v1_orders_proto_init() }
+func file_northwind_v1_orders_proto_init() {
+	if File_northwind_v1_orders_proto != nil {
+		return
+	}
+	file_northwind_v1_common_proto_init()
+	file_northwind_v1_orders_proto_msgTypes[0].OneofWrappers = []any{}
+	file_northwind_v1_orders_proto_msgTypes[2].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_northwind_v1_orders_proto_rawDesc), len(file_northwind_v1_orders_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_northwind_v1_orders_proto_goTypes,
+		DependencyIndexes: file_northwind_v1_orders_proto_depIdxs,
+		MessageInfos:      file_northwind_v1_orders_proto_msgTypes,
+	}.Build()
+	File_northwind_v1_orders_proto = out.File
+	file_northwind_v1_orders_proto_goTypes = nil
+	file_northwind_v1_orders_proto_depIdxs = nil
+}
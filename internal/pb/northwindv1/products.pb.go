@@ -0,0 +1,606 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: northwind/v1/products.proto
+
+package northwindv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Product struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ProductId       int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ProductName     string                 `protobuf:"bytes,2,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
+	SupplierId      *int32                 `protobuf:"varint,3,opt,name=supplier_id,json=supplierId,proto3,oneof" json:"supplier_id,omitempty"`
+	CategoryId      *int32                 `protobuf:"varint,4,opt,name=category_id,json=categoryId,proto3,oneof" json:"category_id,omitempty"`
+	QuantityPerUnit *string                `protobuf:"bytes,5,opt,name=quantity_per_unit,json=quantityPerUnit,proto3,oneof" json:"quantity_per_unit,omitempty"`
+	UnitPrice       float64                `protobuf:"fixed64,6,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	UnitsInStock    int32                  `protobuf:"varint,7,opt,name=units_in_stock,json=unitsInStock,proto3" json:"units_in_stock,omitempty"`
+	UnitsOnOrder    int32                  `protobuf:"varint,8,opt,name=units_on_order,json=unitsOnOrder,proto3" json:"units_on_order,omitempty"`
+	ReorderLevel    int32                  `protobuf:"varint,9,opt,name=reorder_level,json=reorderLevel,proto3" json:"reorder_level,omitempty"`
+	Discontinued    bool                   `protobuf:"varint,10,opt,name=discontinued,proto3" json:"discontinued,omitempty"`
+	DeletedAt       *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	Etag            string                 `protobuf:"bytes,12,opt,name=etag,proto3" json:"etag,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Product) Reset() {
+	*x = Product{}
+	mi := &file_northwind_v1_products_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Product) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Product) ProtoMessage() {}
+
+func (x *Product) ProtoReflect() protoreflect.Message {
+	mi := &file_northwind_v1_products_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Product.ProtoReflect.Descriptor instead.
+func (*Product) Descriptor() ([]byte, []int) {
+	return file_northwind_v1_products_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Product) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *Product) GetProductName() string {
+	if x != nil {
+		return x.ProductName
+	}
+	return ""
+}
+
+func (x *Product) GetSupplierId() int32 {
+	if x != nil && x.SupplierId != nil {
+		return *x.SupplierId
+	}
+	return 0
+}
+
+func (x *Product) GetCategoryId() int32 {
+	if x != nil && x.CategoryId != nil {
+		return *x.CategoryId
+	}
+	return 0
+}
+
+func (x *Product) GetQuantityPerUnit() string {
+	if x != nil && x.QuantityPerUnit != nil {
+		return *x.QuantityPerUnit
+	}
+	return ""
+}
+
+func (x *Product) GetUnitPrice() float64 {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return 0
+}
+
+func (x *Product) GetUnitsInStock() int32 {
+	if x != nil {
+		return x.UnitsInStock
+	}
+	return 0
+}
+
+func (x *Product) GetUnitsOnOrder() int32 {
+	if x != nil {
+		return x.UnitsOnOrder
+	}
+	return 0
+}
+
+func (x *Product) GetReorderLevel() int32 {
+	if x != nil {
+		return x.ReorderLevel
+	}
+	return 0
+}
+
+func (x *Product) GetDiscontinued() bool {
+	if x != nil {
+		return x.Discontinued
+	}
+	return false
+}
+
+func (x *Product) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *Product) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+type ListProductsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          *PageRequest           `protobuf:"bytes,1,opt,name=page,proto3" json:"page,omitempty"`
+	CategoryId    *int32                 `protobuf:"varint,2,opt,name=category_id,json=categoryId,proto3,oneof" json:"category_id,omitempty"`
+	SupplierId    *int32                 `protobuf:"varint,3,opt,name=supplier_id,json=supplierId,proto3,oneof" json:"supplier_id,omitempty"`
+	Discontinued  *bool                  `protobuf:"varint,4,opt,name=discontinued,proto3,oneof" json:"discontinued,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductsRequest) Reset() {
+	*x = ListProductsRequest{}
+	mi := &file_northwind_v1_products_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsRequest) ProtoMessage() {}
+
+func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_northwind_v1_products_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsRequest.ProtoReflect.Descriptor instead.
+func (*ListProductsRequest) Descriptor() ([]byte, []int) {
+	return file_northwind_v1_products_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListProductsRequest) GetPage() *PageRequest {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+func (x *ListProductsRequest) GetCategoryId() int32 {
+	if x != nil && x.CategoryId != nil {
+		return *x.CategoryId
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetSupplierId() int32 {
+	if x != nil && x.SupplierId != nil {
+		return *x.SupplierId
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetDiscontinued() bool {
+	if x != nil && x.Discontinued != nil {
+		return *x.Discontinued
+	}
+	return false
+}
+
+type ListProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	Page          *PageResponse          `protobuf:"bytes,2,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductsResponse) Reset() {
+	*x = ListProductsResponse{}
+	mi := &file_northwind_v1_products_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsResponse) ProtoMessage() {}
+
+func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_northwind_v1_products_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsResponse.ProtoReflect.Descriptor instead.
+func (*ListProductsResponse) Descriptor() ([]byte, []int) {
+	return file_northwind_v1_products_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListProductsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *ListProductsResponse) GetPage() *PageResponse {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+type GetProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductRequest) Reset() {
+	*x = GetProductRequest{}
+	mi := &file_northwind_v1_products_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductRequest) ProtoMessage() {}
+
+func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_northwind_v1_products_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductRequest.ProtoReflect.Descriptor instead.
+func (*GetProductRequest) Descriptor() ([]byte, []int) {
+	return file_northwind_v1_products_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetProductRequest) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+type CreateProductRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// product_id dan etag diabaikan.
+	Product       *Product `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProductRequest) Reset() {
+	*x = CreateProductRequest{}
+	mi := &file_northwind_v1_products_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProductRequest) ProtoMessage() {}
+
+func (x *CreateProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_northwind_v1_products_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProductRequest.ProtoReflect.Descriptor instead.
+func (*CreateProductRequest) Descriptor() ([]byte, []int) {
+	return file_northwind_v1_products_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateProductRequest) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type UpdateProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	Etag          string                 `protobuf:"bytes,2,opt,name=etag,proto3" json:"etag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProductRequest) Reset() {
+	*x = UpdateProductRequest{}
+	mi := &file_northwind_v1_products_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProductRequest) ProtoMessage() {}
+
+func (x *UpdateProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_northwind_v1_products_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProductRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProductRequest) Descriptor() ([]byte, []int) {
+	return file_northwind_v1_products_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateProductRequest) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+func (x *UpdateProductRequest) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+type DeleteProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Etag          string                 `protobuf:"bytes,2,opt,name=etag,proto3" json:"etag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProductRequest) Reset() {
+	*x = DeleteProductRequest{}
+	mi := &file_northwind_v1_products_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductRequest) ProtoMessage() {}
+
+func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_northwind_v1_products_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProductRequest) Descriptor() ([]byte, []int) {
+	return file_northwind_v1_products_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeleteProductRequest) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *DeleteProductRequest) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+var File_northwind_v1_products_proto protoreflect.FileDescriptor
+
+const file_northwind_v1_products_proto_rawDesc = "" +
+	"\n" +
+	"\x1bnorthwind/v1/products.proto\x12\fnorthwind.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x19northwind/v1/common.proto\"\x81\x04\n" +
+	"\aProduct\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x05R\tproductId\x12!\n" +
+	"\fproduct_name\x18\x02 \x01(\tR\vproductName\x12$\n" +
+	"\vsupplier_id\x18\x03 \x01(\x05H\x00R\n" +
+	"supplierId\x88\x01\x01\x12$\n" +
+	"\vcategory_id\x18\x04 \x01(\x05H\x01R\n" +
+	"categoryId\x88\x01\x01\x12/\n" +
+	"\x11quantity_per_unit\x18\x05 \x01(\tH\x02R\x0fquantityPerUnit\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"unit_price\x18\x06 \x01(\x01R\tunitPrice\x12$\n" +
+	"\x0eunits_in_stock\x18\a \x01(\x05R\funitsInStock\x12$\n" +
+	"\x0eunits_on_order\x18\b \x01(\x05R\funitsOnOrder\x12#\n" +
+	"\rreorder_level\x18\t \x01(\x05R\freorderLevel\x12\"\n" +
+	"\fdiscontinued\x18\n" +
+	" \x01(\bR\fdiscontinued\x129\n" +
+	"\n" +
+	"deleted_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x12\x12\n" +
+	"\x04etag\x18\f \x01(\tR\x04etagB\x0e\n" +
+	"\f_supplier_idB\x0e\n" +
+	"\f_category_idB\x14\n" +
+	"\x12_quantity_per_unit\"\xea\x01\n" +
+	"\x13ListProductsRequest\x12-\n" +
+	"\x04page\x18\x01 \x01(\v2\x19.northwind.v1.PageRequestR\x04page\x12$\n" +
+	"\vcategory_id\x18\x02 \x01(\x05H\x00R\n" +
+	"categoryId\x88\x01\x01\x12$\n" +
+	"\vsupplier_id\x18\x03 \x01(\x05H\x01R\n" +
+	"supplierId\x88\x01\x01\x12'\n" +
+	"\fdiscontinued\x18\x04 \x01(\bH\x02R\fdiscontinued\x88\x01\x01B\x0e\n" +
+	"\f_category_idB\x0e\n" +
+	"\f_supplier_idB\x0f\n" +
+	"\r_discontinued\"y\n" +
+	"\x14ListProductsResponse\x121\n" +
+	"\bproducts\x18\x01 \x03(\v2\x15.northwind.v1.ProductR\bproducts\x12.\n" +
+	"\x04page\x18\x02 \x01(\v2\x1a.northwind.v1.PageResponseR\x04page\"2\n" +
+	"\x11GetProductRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x05R\tproductId\"G\n" +
+	"\x14CreateProductRequest\x12/\n" +
+	"\aproduct\x18\x01 \x01(\v2\x15.northwind.v1.ProductR\aproduct\"[\n" +
+	"\x14UpdateProductRequest\x12/\n" +
+	"\aproduct\x18\x01 \x01(\v2\x15.northwind.v1.ProductR\aproduct\x12\x12\n" +
+	"\x04etag\x18\x02 \x01(\tR\x04etag\"I\n" +
+	"\x14DeleteProductRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x05R\tproductId\x12\x12\n" +
+	"\x04etag\x18\x02 \x01(\tR\x04etag2\x92\x03\n" +
+	"\x0eProductService\x12U\n" +
+	"\fListProducts\x12!.northwind.v1.ListProductsRequest\x1a\".northwind.v1.ListProductsResponse\x12D\n" +
+	"\n" +
+	"GetProduct\x12\x1f.northwind.v1.GetProductRequest\x1a\x15.northwind.v1.Product\x12J\n" +
+	"\rCreateProduct\x12\".northwind.v1.CreateProductRequest\x1a\x15.northwind.v1.Product\x12J\n" +
+	"\rUpdateProduct\x12\".northwind.v1.UpdateProductRequest\x1a\x15.northwind.v1.Product\x12K\n" +
+	"\rDeleteProduct\x12\".northwind.v1.DeleteProductRequest\x1a\x16.google.protobuf.EmptyB3Z1northwind-api/internal/pb/northwindv1;northwindv1b\x06proto3"
+
+var (
+	file_northwind_v1_products_proto_rawDescOnce sync.Once
+	file_northwind_v1_products_proto_rawDescData []byte
+)
+
+func file_northwind_v1_products_proto_rawDescGZIP() []byte {
+	file_northwind_v1_products_proto_rawDescOnce.Do(func() {
+		file_northwind_v1_products_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_northwind_v1_products_proto_rawDesc), len(file_northwind_v1_products_proto_rawDesc)))
+	})
+	return file_northwind_v1_products_proto_rawDescData
+}
+
+var file_northwind_v1_products_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_northwind_v1_products_proto_goTypes = []any{
+	(*Product)(nil),               // 0: northwind.v1.Product
+	(*ListProductsRequest)(nil),   // 1: northwind.v1.ListProductsRequest
+	(*ListProductsResponse)(nil),  // 2: northwind.v1.ListProductsResponse
+	(*GetProductRequest)(nil),     // 3: northwind.v1.GetProductRequest
+	(*CreateProductRequest)(nil),  // 4: northwind.v1.CreateProductRequest
+	(*UpdateProductRequest)(nil),  // 5: northwind.v1.UpdateProductRequest
+	(*DeleteProductRequest)(nil),  // 6: northwind.v1.DeleteProductRequest
+	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
+	(*PageRequest)(nil),           // 8: northwind.v1.PageRequest
+	(*PageResponse)(nil),          // 9: northwind.v1.PageResponse
+	(*emptypb.Empty)(nil),         // 10: google.protobuf.Empty
+}
+var file_northwind_v1_products_proto_depIdxs = []int32{
+	7,  // 0: northwind.v1.Product.deleted_at:type_name -> google.protobuf.Timestamp
+	8,  // 1: northwind.v1.ListProductsRequest.page:type_name -> northwind.v1.PageRequest
+	0,  // 2: northwind.v1.ListProductsResponse.products:type_name -> northwind.v1.Product
+	9,  // 3: northwind.v1.ListProductsResponse.page:type_name -> northwind.v1.PageResponse
+	0,  // 4: northwind.v1.CreateProductRequest.product:type_name -> northwind.v1.Product
+	0,  // 5: northwind.v1.UpdateProductRequest.product:type_name -> northwind.v1.Product
+	1,  // 6: northwind.v1.ProductService.ListProducts:input_type -> northwind.v1.ListProductsRequest
+	3,  // 7: northwind.v1.ProductService.GetProduct:input_type -> northwind.v1.GetProductRequest
+	4,  // 8: northwind.v1.ProductService.CreateProduct:input_type -> northwind.v1.CreateProductRequest
+	5,  // 9: northwind.v1.ProductService.UpdateProduct:input_type -> northwind.v1.UpdateProductRequest
+	6,  // 10: northwind.v1.ProductService.DeleteProduct:input_type -> northwind.v1.DeleteProductRequest
+	2,  // 11: northwind.v1.ProductService.ListProducts:output_type -> northwind.v1.ListProductsResponse
+	0,  // 12: northwind.v1.ProductService.GetProduct:output_type -> northwind.v1.Product
+	0,  // 13: northwind.v1.ProductService.CreateProduct:output_type -> northwind.v1.Product
+	0,  // 14: northwind.v1.ProductService.UpdateProduct:output_type -> northwind.v1.Product
+	10, // 15: northwind.v1.ProductService.DeleteProduct:output_type -> google.protobuf.Empty
+	11, // [11:16] is the sub-list for method output_type
+	6,  // [6:11] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_northwind_v1_products_proto_init() }
+func file_northwind_v1_products_proto_init() {
+	if File_northwind_v1_products_proto != nil {
+		return
+	}
+	file_northwind_v1_common_proto_init()
+	file_northwind_v1_products_proto_msgTypes[0].OneofWrappers = []any{}
+	file_northwind_v1_products_proto_msgTypes[1].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_northwind_v1_products_proto_rawDesc), len(file_northwind_v1_products_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_northwind_v1_products_proto_goTypes,
+		DependencyIndexes: file_northwind_v1_products_proto_depIdxs,
+		MessageInfos:      file_northwind_v1_products_proto_msgTypes,
+	}.Build()
+	File_northwind_v1_products_proto = out.File
+	file_northwind_v1_products_proto_goTypes = nil
+	file_northwind_v1_products_proto_depIdxs = nil
+}
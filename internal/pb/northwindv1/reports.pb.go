@@ -0,0 +1,1019 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: northwind/v1/reports.proto
+
+package northwindv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetSalesSummaryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSalesSummaryRequest) Reset() {
+	*x = GetSalesSummaryRequest{}
+	mi := &file_northwind_v1_reports_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSalesSummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSalesSummaryRequest) ProtoMessage() {}
+
+func (x *GetSalesSummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_northwind_v1_reports_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSalesSummaryRequest.ProtoReflect.Descriptor instead.
+func (*GetSalesSummaryRequest) Descriptor() ([]byte, []int) {
+	return file_northwind_v1_reports_proto_rawDescGZIP(), []int{0}
+}
+
+type SalesSummary struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	TotalRevenue      float64                `protobuf:"fixed64,1,opt,name=total_revenue,json=totalRevenue,proto3" json:"total_revenue,omitempty"`
+	TotalOrders       int64                  `protobuf:"varint,2,opt,name=total_orders,json=totalOrders,proto3" json:"total_orders,omitempty"`
+	TotalCustomers    int64                  `protobuf:"varint,3,opt,name=total_customers,json=totalCustomers,proto3" json:"total_customers,omitempty"`
+	AverageOrderValue float64                `protobuf:"fixed64,4,opt,name=average_order_value,json=averageOrderValue,proto3" json:"average_order_value,omitempty"`
+	FirstOrderDate    string                 `protobuf:"bytes,5,opt,name=first_order_date,json=firstOrderDate,proto3" json:"first_order_date,omitempty"`
+	LastOrderDate     string                 `protobuf:"bytes,6,opt,name=last_order_date,json=lastOrderDate,proto3" json:"last_order_date,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SalesSummary) Reset() {
+	*x = SalesSummary{}
+	mi := &file_northwind_v1_reports_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SalesSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SalesSummary) ProtoMessage() {}
+
+func (x *SalesSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_northwind_v1_reports_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SalesSummary.ProtoReflect.Descriptor instead.
+func (*SalesSummary) Descriptor() ([]byte, []int) {
+	return file_northwind_v1_reports_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SalesSummary) GetTotalRevenue() float64 {
+	if x != nil {
+		return x.TotalRevenue
+	}
+	return 0
+}
+
+func (x *SalesSummary) GetTotalOrders() int64 {
+	if x != nil {
+		return x.TotalOrders
+	}
+	return 0
+}
+
+func (x *SalesSummary) GetTotalCustomers() int64 {
+	if x != nil {
+		return x.TotalCustomers
+	}
+	return 0
+}
+
+func (x *SalesSummary) GetAverageOrderValue() float64 {
+	if x != nil {
+		return x.AverageOrderValue
+	}
+	return 0
+}
+
+func (x *SalesSummary) GetFirstOrderDate() string {
+	if x != nil {
+		return x.FirstOrderDate
+	}
+	return ""
+}
+
+func (x *SalesSummary) GetLastOrderDate() string {
+	if x != nil {
+		return x.LastOrderDate
+	}
+	return ""
+}
+
+type ListTopCustomersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTopCustomersRequest) Reset() {
+	*x = ListTopCustomersRequest{}
+	mi := &file_northwind_v1_reports_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTopCustomersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTopCustomersRequest) ProtoMessage() {}
+
+func (x *ListTopCustomersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_northwind_v1_reports_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTopCustomersRequest.ProtoReflect.Descriptor instead.
+func (*ListTopCustomersRequest) Descriptor() ([]byte, []int) {
+	return file_northwind_v1_reports_proto_rawDescGZIP(), []int{2}
+}
+
+type TopCustomer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	CompanyName   string                 `protobuf:"bytes,2,opt,name=company_name,json=companyName,proto3" json:"company_name,omitempty"`
+	TotalPurchase float64                `protobuf:"fixed64,3,opt,name=total_purchase,json=totalPurchase,proto3" json:"total_purchase,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopCustomer) Reset() {
+	*x = TopCustomer{}
+	mi := &file_northwind_v1_reports_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopCustomer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopCustomer) ProtoMessage() {}
+
+func (x *TopCustomer) ProtoReflect() protoreflect.Message {
+	mi := &file_northwind_v1_reports_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopCustomer.ProtoReflect.Descriptor instead.
+func (*TopCustomer) Descriptor() ([]byte, []int) {
+	return file_northwind_v1_reports_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TopCustomer) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *TopCustomer) GetCompanyName() string {
+	if x != nil {
+		return x.CompanyName
+	}
+	return ""
+}
+
+func (x *TopCustomer) GetTotalPurchase() float64 {
+	if x != nil {
+		return x.TotalPurchase
+	}
+	return 0
+}
+
+type ListTopCustomersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Customers     []*TopCustomer         `protobuf:"bytes,1,rep,name=customers,proto3" json:"customers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTopCustomersResponse) Reset() {
+	*x = ListTopCustomersResponse{}
+	mi := &file_northwind_v1_reports_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTopCustomersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTopCustomersResponse) ProtoMessage() {}
+
+func (x *ListTopCustomersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_northwind_v1_reports_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTopCustomersResponse.ProtoReflect.Descriptor instead.
+func (*ListTopCustomersResponse) Descriptor() ([]byte, []int) {
+	return file_northwind_v1_reports_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListTopCustomersResponse) GetCustomers() []*TopCustomer {
+	if x != nil {
+		return x.Customers
+	}
+	return nil
+}
+
+type ListTopProductsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTopProductsRequest) Reset() {
+	*x = ListTopProductsRequest{}
+	mi := &file_northwind_v1_reports_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTopProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTopProductsRequest) ProtoMessage() {}
+
+func (x *ListTopProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_northwind_v1_reports_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTopProductsRequest.ProtoReflect.Descriptor instead.
+func (*ListTopProductsRequest) Descriptor() ([]byte, []int) {
+	return file_northwind_v1_reports_proto_rawDescGZIP(), []int{5}
+}
+
+type TopProduct struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ProductName   string                 `protobuf:"bytes,2,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
+	TotalSold     int32                  `protobuf:"varint,3,opt,name=total_sold,json=totalSold,proto3" json:"total_sold,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopProduct) Reset() {
+	*x = TopProduct{}
+	mi := &file_northwind_v1_reports_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopProduct) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopProduct) ProtoMessage() {}
+
+func (x *TopProduct) ProtoReflect() protoreflect.Message {
+	mi := &file_northwind_v1_reports_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopProduct.ProtoReflect.Descriptor instead.
+func (*TopProduct) Descriptor() ([]byte, []int) {
+	return file_northwind_v1_reports_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TopProduct) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *TopProduct) GetProductName() string {
+	if x != nil {
+		return x.ProductName
+	}
+	return ""
+}
+
+func (x *TopProduct) GetTotalSold() int32 {
+	if x != nil {
+		return x.TotalSold
+	}
+	return 0
+}
+
+type ListTopProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*TopProduct          `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTopProductsResponse) Reset() {
+	*x = ListTopProductsResponse{}
+	mi := &file_northwind_v1_reports_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTopProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTopProductsResponse) ProtoMessage() {}
+
+func (x *ListTopProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_northwind_v1_reports_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTopProductsResponse.ProtoReflect.Descriptor instead.
+func (*ListTopProductsResponse) Descriptor() ([]byte, []int) {
+	return file_northwind_v1_reports_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListTopProductsResponse) GetProducts() []*TopProduct {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+type ListSalesByCategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSalesByCategoryRequest) Reset() {
+	*x = ListSalesByCategoryRequest{}
+	mi := &file_northwind_v1_reports_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSalesByCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSalesByCategoryRequest) ProtoMessage() {}
+
+func (x *ListSalesByCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_northwind_v1_reports_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSalesByCategoryRequest.ProtoReflect.Descriptor instead.
+func (*ListSalesByCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_northwind_v1_reports_proto_rawDescGZIP(), []int{8}
+}
+
+type CategorySales struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CategoryId    int32                  `protobuf:"varint,1,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	CategoryName  string                 `protobuf:"bytes,2,opt,name=category_name,json=categoryName,proto3" json:"category_name,omitempty"`
+	TotalSales    float64                `protobuf:"fixed64,3,opt,name=total_sales,json=totalSales,proto3" json:"total_sales,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategorySales) Reset() {
+	*x = CategorySales{}
+	mi := &file_northwind_v1_reports_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategorySales) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategorySales) ProtoMessage() {}
+
+func (x *CategorySales) ProtoReflect() protoreflect.Message {
+	mi := &file_northwind_v1_reports_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategorySales.ProtoReflect.Descriptor instead.
+func (*CategorySales) Descriptor() ([]byte, []int) {
+	return file_northwind_v1_reports_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CategorySales) GetCategoryId() int32 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *CategorySales) GetCategoryName() string {
+	if x != nil {
+		return x.CategoryName
+	}
+	return ""
+}
+
+func (x *CategorySales) GetTotalSales() float64 {
+	if x != nil {
+		return x.TotalSales
+	}
+	return 0
+}
+
+type ListSalesByCategoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Categories    []*CategorySales       `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSalesByCategoryResponse) Reset() {
+	*x = ListSalesByCategoryResponse{}
+	mi := &file_northwind_v1_reports_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSalesByCategoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSalesByCategoryResponse) ProtoMessage() {}
+
+func (x *ListSalesByCategoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_northwind_v1_reports_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSalesByCategoryResponse.ProtoReflect.Descriptor instead.
+func (*ListSalesByCategoryResponse) Descriptor() ([]byte, []int) {
+	return file_northwind_v1_reports_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListSalesByCategoryResponse) GetCategories() []*CategorySales {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+type ListMonthlySalesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMonthlySalesRequest) Reset() {
+	*x = ListMonthlySalesRequest{}
+	mi := &file_northwind_v1_reports_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMonthlySalesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMonthlySalesRequest) ProtoMessage() {}
+
+func (x *ListMonthlySalesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_northwind_v1_reports_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMonthlySalesRequest.ProtoReflect.Descriptor instead.
+func (*ListMonthlySalesRequest) Descriptor() ([]byte, []int) {
+	return file_northwind_v1_reports_proto_rawDescGZIP(), []int{11}
+}
+
+type MonthlySales struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Format YYYY-MM.
+	YearMonth     string  `protobuf:"bytes,1,opt,name=year_month,json=yearMonth,proto3" json:"year_month,omitempty"`
+	TotalSales    float64 `protobuf:"fixed64,2,opt,name=total_sales,json=totalSales,proto3" json:"total_sales,omitempty"`
+	Orders        int64   `protobuf:"varint,3,opt,name=orders,proto3" json:"orders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MonthlySales) Reset() {
+	*x = MonthlySales{}
+	mi := &file_northwind_v1_reports_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MonthlySales) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MonthlySales) ProtoMessage() {}
+
+func (x *MonthlySales) ProtoReflect() protoreflect.Message {
+	mi := &file_northwind_v1_reports_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MonthlySales.ProtoReflect.Descriptor instead.
+func (*MonthlySales) Descriptor() ([]byte, []int) {
+	return file_northwind_v1_reports_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *MonthlySales) GetYearMonth() string {
+	if x != nil {
+		return x.YearMonth
+	}
+	return ""
+}
+
+func (x *MonthlySales) GetTotalSales() float64 {
+	if x != nil {
+		return x.TotalSales
+	}
+	return 0
+}
+
+func (x *MonthlySales) GetOrders() int64 {
+	if x != nil {
+		return x.Orders
+	}
+	return 0
+}
+
+type ListMonthlySalesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Months        []*MonthlySales        `protobuf:"bytes,1,rep,name=months,proto3" json:"months,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMonthlySalesResponse) Reset() {
+	*x = ListMonthlySalesResponse{}
+	mi := &file_northwind_v1_reports_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMonthlySalesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMonthlySalesResponse) ProtoMessage() {}
+
+func (x *ListMonthlySalesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_northwind_v1_reports_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMonthlySalesResponse.ProtoReflect.Descriptor instead.
+func (*ListMonthlySalesResponse) Descriptor() ([]byte, []int) {
+	return file_northwind_v1_reports_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListMonthlySalesResponse) GetMonths() []*MonthlySales {
+	if x != nil {
+		return x.Months
+	}
+	return nil
+}
+
+type ListInventoryStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInventoryStatusRequest) Reset() {
+	*x = ListInventoryStatusRequest{}
+	mi := &file_northwind_v1_reports_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInventoryStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInventoryStatusRequest) ProtoMessage() {}
+
+func (x *ListInventoryStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_northwind_v1_reports_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInventoryStatusRequest.ProtoReflect.Descriptor instead.
+func (*ListInventoryStatusRequest) Descriptor() ([]byte, []int) {
+	return file_northwind_v1_reports_proto_rawDescGZIP(), []int{14}
+}
+
+type InventoryStatus struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ProductId    int64                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ProductName  string                 `protobuf:"bytes,2,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
+	UnitsInStock int64                  `protobuf:"varint,3,opt,name=units_in_stock,json=unitsInStock,proto3" json:"units_in_stock,omitempty"`
+	UnitsOnOrder int64                  `protobuf:"varint,4,opt,name=units_on_order,json=unitsOnOrder,proto3" json:"units_on_order,omitempty"`
+	ReorderLevel int64                  `protobuf:"varint,5,opt,name=reorder_level,json=reorderLevel,proto3" json:"reorder_level,omitempty"`
+	// OK, LOW atau OUT.
+	Status        string `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InventoryStatus) Reset() {
+	*x = InventoryStatus{}
+	mi := &file_northwind_v1_reports_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InventoryStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InventoryStatus) ProtoMessage() {}
+
+func (x *InventoryStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_northwind_v1_reports_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InventoryStatus.ProtoReflect.Descriptor instead.
+func (*InventoryStatus) Descriptor() ([]byte, []int) {
+	return file_northwind_v1_reports_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *InventoryStatus) GetProductId() int64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *InventoryStatus) GetProductName() string {
+	if x != nil {
+		return x.ProductName
+	}
+	return ""
+}
+
+func (x *InventoryStatus) GetUnitsInStock() int64 {
+	if x != nil {
+		return x.UnitsInStock
+	}
+	return 0
+}
+
+func (x *InventoryStatus) GetUnitsOnOrder() int64 {
+	if x != nil {
+		return x.UnitsOnOrder
+	}
+	return 0
+}
+
+func (x *InventoryStatus) GetReorderLevel() int64 {
+	if x != nil {
+		return x.ReorderLevel
+	}
+	return 0
+}
+
+func (x *InventoryStatus) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type ListInventoryStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*InventoryStatus     `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInventoryStatusResponse) Reset() {
+	*x = ListInventoryStatusResponse{}
+	mi := &file_northwind_v1_reports_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInventoryStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInventoryStatusResponse) ProtoMessage() {}
+
+func (x *ListInventoryStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_northwind_v1_reports_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInventoryStatusResponse.ProtoReflect.Descriptor instead.
+func (*ListInventoryStatusResponse) Descriptor() ([]byte, []int) {
+	return file_northwind_v1_reports_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListInventoryStatusResponse) GetProducts() []*InventoryStatus {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+var File_northwind_v1_reports_proto protoreflect.FileDescriptor
+
+const file_northwind_v1_reports_proto_rawDesc = "" +
+	"\n" +
+	"\x1anorthwind/v1/reports.proto\x12\fnorthwind.v1\"\x18\n" +
+	"\x16GetSalesSummaryRequest\"\x81\x02\n" +
+	"\fSalesSummary\x12#\n" +
+	"\rtotal_revenue\x18\x01 \x01(\x01R\ftotalRevenue\x12!\n" +
+	"\ftotal_orders\x18\x02 \x01(\x03R\vtotalOrders\x12'\n" +
+	"\x0ftotal_customers\x18\x03 \x01(\x03R\x0etotalCustomers\x12.\n" +
+	"\x13average_order_value\x18\x04 \x01(\x01R\x11averageOrderValue\x12(\n" +
+	"\x10first_order_date\x18\x05 \x01(\tR\x0efirstOrderDate\x12&\n" +
+	"\x0flast_order_date\x18\x06 \x01(\tR\rlastOrderDate\"\x19\n" +
+	"\x17ListTopCustomersRequest\"x\n" +
+	"\vTopCustomer\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x12!\n" +
+	"\fcompany_name\x18\x02 \x01(\tR\vcompanyName\x12%\n" +
+	"\x0etotal_purchase\x18\x03 \x01(\x01R\rtotalPurchase\"S\n" +
+	"\x18ListTopCustomersResponse\x127\n" +
+	"\tcustomers\x18\x01 \x03(\v2\x19.northwind.v1.TopCustomerR\tcustomers\"\x18\n" +
+	"\x16ListTopProductsRequest\"m\n" +
+	"\n" +
+	"TopProduct\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x05R\tproductId\x12!\n" +
+	"\fproduct_name\x18\x02 \x01(\tR\vproductName\x12\x1d\n" +
+	"\n" +
+	"total_sold\x18\x03 \x01(\x05R\ttotalSold\"O\n" +
+	"\x17ListTopProductsResponse\x124\n" +
+	"\bproducts\x18\x01 \x03(\v2\x18.northwind.v1.TopProductR\bproducts\"\x1c\n" +
+	"\x1aListSalesByCategoryRequest\"v\n" +
+	"\rCategorySales\x12\x1f\n" +
+	"\vcategory_id\x18\x01 \x01(\x05R\n" +
+	"categoryId\x12#\n" +
+	"\rcategory_name\x18\x02 \x01(\tR\fcategoryName\x12\x1f\n" +
+	"\vtotal_sales\x18\x03 \x01(\x01R\n" +
+	"totalSales\"Z\n" +
+	"\x1bListSalesByCategoryResponse\x12;\n" +
+	"\n" +
+	"categories\x18\x01 \x03(\v2\x1b.northwind.v1.CategorySalesR\n" +
+	"categories\"\x19\n" +
+	"\x17ListMonthlySalesRequest\"f\n" +
+	"\fMonthlySales\x12\x1d\n" +
+	"\n" +
+	"year_month\x18\x01 \x01(\tR\tyearMonth\x12\x1f\n" +
+	"\vtotal_sales\x18\x02 \x01(\x01R\n" +
+	"totalSales\x12\x16\n" +
+	"\x06orders\x18\x03 \x01(\x03R\x06orders\"N\n" +
+	"\x18ListMonthlySalesResponse\x122\n" +
+	"\x06months\x18\x01 \x03(\v2\x1a.northwind.v1.MonthlySalesR\x06months\"\x1c\n" +
+	"\x1aListInventoryStatusRequest\"\xdc\x01\n" +
+	"\x0fInventoryStatus\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x03R\tproductId\x12!\n" +
+	"\fproduct_name\x18\x02 \x01(\tR\vproductName\x12$\n" +
+	"\x0eunits_in_stock\x18\x03 \x01(\x03R\funitsInStock\x12$\n" +
+	"\x0eunits_on_order\x18\x04 \x01(\x03R\funitsOnOrder\x12#\n" +
+	"\rreorder_level\x18\x05 \x01(\x03R\freorderLevel\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\"X\n" +
+	"\x1bListInventoryStatusResponse\x129\n" +
+	"\bproducts\x18\x01 \x03(\v2\x1d.northwind.v1.InventoryStatusR\bproducts2\xe2\x04\n" +
+	"\rReportService\x12S\n" +
+	"\x0fGetSalesSummary\x12$.northwind.v1.GetSalesSummaryRequest\x1a\x1a.northwind.v1.SalesSummary\x12a\n" +
+	"\x10ListTopCustomers\x12%.northwind.v1.ListTopCustomersRequest\x1a&.northwind.v1.ListTopCustomersResponse\x12^\n" +
+	"\x0fListTopProducts\x12$.northwind.v1.ListTopProductsRequest\x1a%.northwind.v1.ListTopProductsResponse\x12j\n" +
+	"\x13ListSalesByCategory\x12(.northwind.v1.ListSalesByCategoryRequest\x1a).northwind.v1.ListSalesByCategoryResponse\x12a\n" +
+	"\x10ListMonthlySales\x12%.northwind.v1.ListMonthlySalesRequest\x1a&.northwind.v1.ListMonthlySalesResponse\x12j\n" +
+	"\x13ListInventoryStatus\x12(.northwind.v1.ListInventoryStatusRequest\x1a).northwind.v1.ListInventoryStatusResponseB3Z1northwind-api/internal/pb/northwindv1;northwindv1b\x06proto3"
+
+var (
+	file_northwind_v1_reports_proto_rawDescOnce sync.Once
+	file_northwind_v1_reports_proto_rawDescData []byte
+)
+
+func file_northwind_v1_reports_proto_rawDescGZIP() []byte {
+	file_northwind_v1_reports_proto_rawDescOnce.Do(func() {
+		file_northwind_v1_reports_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_northwind_v1_reports_proto_rawDesc), len(file_northwind_v1_reports_proto_rawDesc)))
+	})
+	return file_northwind_v1_reports_proto_rawDescData
+}
+
+var file_northwind_v1_reports_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_northwind_v1_reports_proto_goTypes = []any{
+	(*GetSalesSummaryRequest)(nil),      // 0: northwind.v1.GetSalesSummaryRequest
+	(*SalesSummary)(nil),                // 1: northwind.v1.SalesSummary
+	(*ListTopCustomersRequest)(nil),     // 2: northwind.v1.ListTopCustomersRequest
+	(*TopCustomer)(nil),                 // 3: northwind.v1.TopCustomer
+	(*ListTopCustomersResponse)(nil),    // 4: northwind.v1.ListTopCustomersResponse
+	(*ListTopProductsRequest)(nil),      // 5: northwind.v1.ListTopProductsRequest
+	(*TopProduct)(nil),                  // 6: northwind.v1.TopProduct
+	(*ListTopProductsResponse)(nil),     // 7: northwind.v1.ListTopProductsResponse
+	(*ListSalesByCategoryRequest)(nil),  // 8: northwind.v1.ListSalesByCategoryRequest
+	(*CategorySales)(nil),               // 9: northwind.v1.CategorySales
+	(*ListSalesByCategoryResponse)(nil), // 10: northwind.v1.ListSalesByCategoryResponse
+	(*ListMonthlySalesRequest)(nil),     // 11: northwind.v1.ListMonthlySalesRequest
+	(*MonthlySales)(nil),                // 12: northwind.v1.MonthlySales
+	(*ListMonthlySalesResponse)(nil),    // 13: northwind.v1.ListMonthlySalesResponse
+	(*ListInventoryStatusRequest)(nil),  // 14: northwind.v1.ListInventoryStatusRequest
+	(*InventoryStatus)(nil),             // 15: northwind.v1.InventoryStatus
+	(*ListInventoryStatusResponse)(nil), // 16: northwind.v1.ListInventoryStatusResponse
+}
+var file_northwind_v1_reports_proto_depIdxs = []int32{
+	3,  // 0: northwind.v1.ListTopCustomersResponse.customers:type_name -> northwind.v1.TopCustomer
+	6,  // 1: northwind.v1.ListTopProductsResponse.products:type_name -> northwind.v1.TopProduct
+	9,  // 2: northwind.v1.ListSalesByCategoryResponse.categories:type_name -> northwind.v1.CategorySales
+	12, // 3: northwind.v1.ListMonthlySalesResponse.months:type_name -> northwind.v1.MonthlySales
+	15, // 4: northwind.v1.ListInventoryStatusResponse.products:type_name -> northwind.v1.InventoryStatus
+	0,  // 5: northwind.v1.ReportService.GetSalesSummary:input_type -> northwind.v1.GetSalesSummaryRequest
+	2,  // 6: northwind.v1.ReportService.ListTopCustomers:input_type -> northwind.v1.ListTopCustomersRequest
+	5,  // 7: northwind.v1.ReportService.ListTopProducts:input_type -> northwind.v1.ListTopProductsRequest
+	8,  // 8: northwind.v1.ReportService.ListSalesByCategory:input_type -> northwind.v1.ListSalesByCategoryRequest
+	11, // 9: northwind.v1.ReportService.ListMonthlySales:input_type -> northwind.v1.ListMonthlySalesRequest
+	14, // 10: northwind.v1.ReportService.ListInventoryStatus:input_type -> northwind.v1.ListInventoryStatusRequest
+	1,  // 11: northwind.v1.ReportService.GetSalesSummary:output_type -> northwind.v1.SalesSummary
+	4,  // 12: northwind.v1.ReportService.ListTopCustomers:output_type -> northwind.v1.ListTopCustomersResponse
+	7,  // 13: northwind.v1.ReportService.ListTopProducts:output_type -> northwind.v1.ListTopProductsResponse
+	10, // 14: northwind.v1.ReportService.ListSalesByCategory:output_type -> northwind.v1.ListSalesByCategoryResponse
+	13, // 15: northwind.v1.ReportService.ListMonthlySales:output_type -> northwind.v1.ListMonthlySalesResponse
+	16, // 16: northwind.v1.ReportService.ListInventoryStatus:output_type -> northwind.v1.ListInventoryStatusResponse
+	11, // [11:17] is the sub-list for method output_type
+	5,  // [5:11] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_northwind_v1_reports_proto_init() }
+func file_northwind_v1_reports_proto_init() {
+	if File_northwind_v1_reports_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_northwind_v1_reports_proto_rawDesc), len(file_northwind_v1_reports_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   17,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_northwind_v1_reports_proto_goTypes,
+		DependencyIndexes: file_northwind_v1_reports_proto_depIdxs,
+		MessageInfos:      file_northwind_v1_reports_proto_msgTypes,
+	}.Build()
+	File_northwind_v1_reports_proto = out.File
+	file_northwind_v1_reports_proto_goTypes = nil
+	file_northwind_v1_reports_proto_depIdxs = nil
+}
@@ -0,0 +1,315 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: northwind/v1/reports.proto
+
+package northwindv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ReportService_GetSalesSummary_FullMethodName     = "/northwind.v1.ReportService/GetSalesSummary"
+	ReportService_ListTopCustomers_FullMethodName    = "/northwind.v1.ReportService/ListTopCustomers"
+	ReportService_ListTopProducts_FullMethodName     = "/northwind.v1.ReportService/ListTopProducts"
+	ReportService_ListSalesByCategory_FullMethodName = "/northwind.v1.ReportService/ListSalesByCategory"
+	ReportService_ListMonthlySales_FullMethodName    = "/northwind.v1.ReportService/ListMonthlySales"
+	ReportService_ListInventoryStatus_FullMethodName = "/northwind.v1.ReportService/ListInventoryStatus"
+)
+
+// ReportServiceClient is the client API for ReportService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Laporan read-only, isinya sama dengan endpoint /reports.
+type ReportServiceClient interface {
+	GetSalesSummary(ctx context.Context, in *GetSalesSummaryRequest, opts ...grpc.CallOption) (*SalesSummary, error)
+	ListTopCustomers(ctx context.Context, in *ListTopCustomersRequest, opts ...grpc.CallOption) (*ListTopCustomersResponse, error)
+	ListTopProducts(ctx context.Context, in *ListTopProductsRequest, opts ...grpc.CallOption) (*ListTopProductsResponse, error)
+	ListSalesByCategory(ctx context.Context, in *ListSalesByCategoryRequest, opts ...grpc.CallOption) (*ListSalesByCategoryResponse, error)
+	ListMonthlySales(ctx context.Context, in *ListMonthlySalesRequest, opts ...grpc.CallOption) (*ListMonthlySalesResponse, error)
+	ListInventoryStatus(ctx context.Context, in *ListInventoryStatusRequest, opts ...grpc.CallOption) (*ListInventoryStatusResponse, error)
+}
+
+type reportServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReportServiceClient(cc grpc.ClientConnInterface) ReportServiceClient {
+	return &reportServiceClient{cc}
+}
+
+func (c *reportServiceClient) GetSalesSummary(ctx context.Context, in *GetSalesSummaryRequest, opts ...grpc.CallOption) (*SalesSummary, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SalesSummary)
+	err := c.cc.Invoke(ctx, ReportService_GetSalesSummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reportServiceClient) ListTopCustomers(ctx context.Context, in *ListTopCustomersRequest, opts ...grpc.CallOption) (*ListTopCustomersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTopCustomersResponse)
+	err := c.cc.Invoke(ctx, ReportService_ListTopCustomers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reportServiceClient) ListTopProducts(ctx context.Context, in *ListTopProductsRequest, opts ...grpc.CallOption) (*ListTopProductsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTopProductsResponse)
+	err := c.cc.Invoke(ctx, ReportService_ListTopProducts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reportServiceClient) ListSalesByCategory(ctx context.Context, in *ListSalesByCategoryRequest, opts ...grpc.CallOption) (*ListSalesByCategoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSalesByCategoryResponse)
+	err := c.cc.Invoke(ctx, ReportService_ListSalesByCategory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reportServiceClient) ListMonthlySales(ctx context.Context, in *ListMonthlySalesRequest, opts ...grpc.CallOption) (*ListMonthlySalesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMonthlySalesResponse)
+	err := c.cc.Invoke(ctx, ReportService_ListMonthlySales_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reportServiceClient) ListInventoryStatus(ctx context.Context, in *ListInventoryStatusRequest, opts ...grpc.CallOption) (*ListInventoryStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListInventoryStatusResponse)
+	err := c.cc.Invoke(ctx, ReportService_ListInventoryStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReportServiceServer is the server API for ReportService service.
+// All implementations must embed UnimplementedReportServiceServer
+// for forward compatibility.
+//
+// Laporan read-only, isinya sama dengan endpoint /reports.
+type ReportServiceServer interface {
+	GetSalesSummary(context.Context, *GetSalesSummaryRequest) (*SalesSummary, error)
+	ListTopCustomers(context.Context, *ListTopCustomersRequest) (*ListTopCustomersResponse, error)
+	ListTopProducts(context.Context, *ListTopProductsRequest) (*ListTopProductsResponse, error)
+	ListSalesByCategory(context.Context, *ListSalesByCategoryRequest) (*ListSalesByCategoryResponse, error)
+	ListMonthlySales(context.Context, *ListMonthlySalesRequest) (*ListMonthlySalesResponse, error)
+	ListInventoryStatus(context.Context, *ListInventoryStatusRequest) (*ListInventoryStatusResponse, error)
+	mustEmbedUnimplementedReportServiceServer()
+}
+
+// UnimplementedReportServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedReportServiceServer struct{}
+
+func (UnimplementedReportServiceServer) GetSalesSummary(context.Context, *GetSalesSummaryRequest) (*SalesSummary, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetSalesSummary not implemented")
+}
+func (UnimplementedReportServiceServer) ListTopCustomers(context.Context, *ListTopCustomersRequest) (*ListTopCustomersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListTopCustomers not implemented")
+}
+func (UnimplementedReportServiceServer) ListTopProducts(context.Context, *ListTopProductsRequest) (*ListTopProductsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListTopProducts not implemented")
+}
+func (UnimplementedReportServiceServer) ListSalesByCategory(context.Context, *ListSalesByCategoryRequest) (*ListSalesByCategoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListSalesByCategory not implemented")
+}
+func (UnimplementedReportServiceServer) ListMonthlySales(context.Context, *ListMonthlySalesRequest) (*ListMonthlySalesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListMonthlySales not implemented")
+}
+func (UnimplementedReportServiceServer) ListInventoryStatus(context.Context, *ListInventoryStatusRequest) (*ListInventoryStatusResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListInventoryStatus not implemented")
+}
+func (UnimplementedReportServiceServer) mustEmbedUnimplementedReportServiceServer() {}
+func (UnimplementedReportServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeReportServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReportServiceServer will
+// result in compilation errors.
+type UnsafeReportServiceServer interface {
+	mustEmbedUnimplementedReportServiceServer()
+}
+
+func RegisterReportServiceServer(s grpc.ServiceRegistrar, srv ReportServiceServer) {
+	// If the following call panics, it indicates UnimplementedReportServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ReportService_ServiceDesc, srv)
+}
+
+func _ReportService_GetSalesSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSalesSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReportServiceServer).GetSalesSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReportService_GetSalesSummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReportServiceServer).GetSalesSummary(ctx, req.(*GetSalesSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReportService_ListTopCustomers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTopCustomersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReportServiceServer).ListTopCustomers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReportService_ListTopCustomers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReportServiceServer).ListTopCustomers(ctx, req.(*ListTopCustomersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReportService_ListTopProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTopProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReportServiceServer).ListTopProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReportService_ListTopProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReportServiceServer).ListTopProducts(ctx, req.(*ListTopProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReportService_ListSalesByCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSalesByCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReportServiceServer).ListSalesByCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReportService_ListSalesByCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReportServiceServer).ListSalesByCategory(ctx, req.(*ListSalesByCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReportService_ListMonthlySales_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMonthlySalesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReportServiceServer).ListMonthlySales(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReportService_ListMonthlySales_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReportServiceServer).ListMonthlySales(ctx, req.(*ListMonthlySalesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReportService_ListInventoryStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInventoryStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReportServiceServer).ListInventoryStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReportService_ListInventoryStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReportServiceServer).ListInventoryStatus(ctx, req.(*ListInventoryStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ReportService_ServiceDesc is the grpc.ServiceDesc for ReportService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ReportService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "northwind.v1.ReportService",
+	HandlerType: (*ReportServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetSalesSummary",
+			Handler:    _ReportService_GetSalesSummary_Handler,
+		},
+		{
+			MethodName: "ListTopCustomers",
+			Handler:    _ReportService_ListTopCustomers_Handler,
+		},
+		{
+			MethodName: "ListTopProducts",
+			Handler:    _ReportService_ListTopProducts_Handler,
+		},
+		{
+			MethodName: "ListSalesByCategory",
+			Handler:    _ReportService_ListSalesByCategory_Handler,
+		},
+		{
+			MethodName: "ListMonthlySales",
+			Handler:    _ReportService_ListMonthlySales_Handler,
+		},
+		{
+			MethodName: "ListInventoryStatus",
+			Handler:    _ReportService_ListInventoryStatus_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "northwind/v1/reports.proto",
+}
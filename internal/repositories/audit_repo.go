@@ -0,0 +1,131 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"northwind-api/internal/models"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// AuditFilter membatasi hasil ListAudit. Entity dan EntityID kosong berarti
+// semua; Limit 0 berarti tanpa batas.
+type AuditFilter struct {
+	Entity   string
+	EntityID string
+	Limit    int
+}
+
+// AuditRepository menulis audit log di transaksi yang sama dengan perubahannya.
+type AuditRepository struct {
+	DB DBTX
+}
+
+func (r *AuditRepository) RecordAudit(ctx context.Context, entries ...models.AuditEntry) error {
+	for _, e := range entries {
+		changes, err := json.Marshal(e.Changes)
+		if err != nil {
+			return fmt.Errorf("error encoding audit changes: %w", err)
+		}
+		if _, err := r.DB.ExecContext(ctx, `
+			INSERT INTO AuditLog (Entity, EntityID, Action, Actor, RequestID, Changes, OccurredAt)
+			VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			e.Entity, e.EntityID, e.Action, e.Actor, e.RequestID, string(changes),
+			e.OccurredAt.UTC().Format(time.RFC3339Nano),
+		); err != nil {
+			log.Error().Err(err).Str("entity", e.Entity).Str("id", e.EntityID).Msg("error writing audit log")
+			return dbError(err, "error writing audit log")
+		}
+	}
+	return nil
+}
+
+// ListAudit mengembalikan entry terbaru lebih dulu.
+func (r *AuditRepository) ListAudit(ctx context.Context, f AuditFilter) ([]models.AuditEntry, error) {
+	query := `SELECT AuditID, Entity, EntityID, Action, Actor, COALESCE(RequestID, ''), Changes, OccurredAt
+		FROM AuditLog WHERE 1 = 1`
+	var args []any
+	if f.Entity != "" {
+		query += ` AND Entity = ?`
+		args = append(args, f.Entity)
+	}
+	if f.EntityID != "" {
+		query += ` AND EntityID = ?`
+		args = append(args, f.EntityID)
+	}
+	query += ` ORDER BY AuditID DESC`
+	if f.Limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, f.Limit)
+	}
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to query audit log")
+		return nil, fmt.Errorf("error fetching audit log: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []models.AuditEntry{}
+	for rows.Next() {
+		var (
+			e                   models.AuditEntry
+			changes, occurredAt string
+		)
+		if err := rows.Scan(&e.AuditID, &e.Entity, &e.EntityID, &e.Action, &e.Actor, &e.RequestID, &changes, &occurredAt); err != nil {
+			return nil, fmt.Errorf("error scanning audit entry: %w", err)
+		}
+		if err := json.Unmarshal([]byte(changes), &e.Changes); err != nil {
+			return nil, fmt.Errorf("error decoding audit changes: %w", err)
+		}
+		e.OccurredAt, _ = time.Parse(time.RFC3339Nano, occurredAt)
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+// RedactAudit mengganti nilai fields di semua entry entity/id dengan Erased.
+func (r *AuditRepository) RedactAudit(ctx context.Context, entity, id string, fields []string) error {
+	rows, err := r.DB.QueryContext(ctx, `SELECT AuditID, Changes FROM AuditLog WHERE Entity = ? AND EntityID = ?`, entity, id)
+	if err != nil {
+		log.Error().Err(err).Str("entity", entity).Str("id", id).Msg("failed to query audit log")
+		return fmt.Errorf("error fetching audit log: %w", err)
+	}
+	redacted := map[int64]string{}
+	for rows.Next() {
+		var (
+			auditID int64
+			raw     string
+			changes map[string]models.AuditChange
+		)
+		if err := rows.Scan(&auditID, &raw); err != nil {
+			rows.Close()
+			return fmt.Errorf("error scanning audit entry: %w", err)
+		}
+		if err := json.Unmarshal([]byte(raw), &changes); err != nil {
+			rows.Close()
+			return fmt.Errorf("error decoding audit changes: %w", err)
+		}
+		if RedactChanges(changes, fields) {
+			out, err := json.Marshal(changes)
+			if err != nil {
+				rows.Close()
+				return fmt.Errorf("error encoding audit changes: %w", err)
+			}
+			redacted[auditID] = string(out)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for auditID, changes := range redacted {
+		if _, err := r.DB.ExecContext(ctx, `UPDATE AuditLog SET Changes = ? WHERE AuditID = ?`, changes, auditID); err != nil {
+			log.Error().Err(err).Int64("audit_id", auditID).Msg("error redacting audit log")
+			return dbError(err, "error redacting audit log")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package repositories
+
+import "strings"
+
+// Query batch (dipakai loader GraphQL) memuat banyak baris sekaligus dengan
+// "WHERE kolom IN (...)" supaya relasi tidak menjadi query N+1.
+
+// placeholders menghasilkan "(?, ?, ...)" untuk n nilai.
+func placeholders(n int) string {
+	return "(" + strings.TrimSuffix(strings.Repeat("?, ", n), ", ") + ")"
+}
+
+// inArgs mengubah daftar kunci menjadi argumen query.
+func inArgs[K any](keys []K) []any {
+	args := make([]any, len(keys))
+	for i, k := range keys {
+		args[i] = k
+	}
+	return args
+}
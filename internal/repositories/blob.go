@@ -0,0 +1,44 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"northwind-api/internal/apperr"
+
+	"github.com/rs/zerolog/log"
+)
+
+// getBlob membaca satu kolom BLOB (Photo, Picture). Kolom ini tidak dimuat
+// oleh query resource biasa. Baris tanpa isi menghasilkan nil tanpa error.
+func getBlob(ctx context.Context, db DBTX, table, key, column, entity string, id any) ([]byte, error) {
+	var data []byte
+	err := db.QueryRowContext(ctx,
+		fmt.Sprintf("SELECT %s FROM %s WHERE %s = ? AND %s", column, table, key, liveOnly(ctx, "DeletedAt")), id,
+	).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperr.NotFound("no %s found with ID %v", entity, id)
+	}
+	if err != nil {
+		log.Error().Err(err).Str("table", table).Msg("error fetching blob")
+		return nil, fmt.Errorf("error fetching %s %s: %w", entity, column, err)
+	}
+	return data, nil
+}
+
+// setBlob mengganti isi kolom BLOB; data kosong menyimpan NULL.
+func setBlob(ctx context.Context, db DBTX, table, key, column, entity string, id any, data []byte) error {
+	var value any
+	if len(data) > 0 {
+		value = data
+	}
+	result, err := db.ExecContext(ctx,
+		fmt.Sprintf("UPDATE %s SET %s = ? WHERE %s = ? AND %s", table, column, key, liveOnly(ctx, "DeletedAt")), value, id,
+	)
+	if err != nil {
+		log.Error().Err(err).Str("table", table).Msg("error updating blob")
+		return dbError(err, "error updating "+entity+" "+column)
+	}
+	return ensureAffected(result, apperr.NotFound("no %s found with ID %v", entity, id))
+}
@@ -4,13 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"northwind-api/internal/apperr"
 	"northwind-api/internal/models"
 
 	"github.com/rs/zerolog/log"
 )
 
 type CategoryRepository struct {
-	DB *sql.DB
+	DB DBTX
 }
 
 func (r *CategoryRepository) CreateCategory(ctx context.Context, c *models.Category) (int64, error) {
@@ -20,7 +21,7 @@ func (r *CategoryRepository) CreateCategory(ctx context.Context, c *models.Categ
 	`, c.CategoryName, c.Description, c.Picture)
 	if err != nil {
 		log.Error().Err(err).Msg("error creating category")
-		return 0, fmt.Errorf("error creating category: %w", err)
+		return 0, dbError(err, "error creating category")
 	}
 	id, err := result.LastInsertId()
 	if err != nil {
@@ -31,10 +32,24 @@ func (r *CategoryRepository) CreateCategory(ctx context.Context, c *models.Categ
 }
 
 func (r *CategoryRepository) GetAllCategories(ctx context.Context) ([]models.Category, error) {
+	return r.listCategories(ctx, liveOnly(ctx, "DeletedAt"))
+}
+
+// GetCategoriesByIDs memuat beberapa category sekaligus, termasuk yang sudah
+// dihapus supaya rujukan dari data lama tetap bisa di-resolve.
+func (r *CategoryRepository) GetCategoriesByIDs(ctx context.Context, ids []int) ([]models.Category, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return r.listCategories(ctx, "CategoryID IN "+placeholders(len(ids)), inArgs(ids)...)
+}
+
+// listCategories menjalankan SELECT daftar dengan kondisi WHERE where.
+func (r *CategoryRepository) listCategories(ctx context.Context, where string, args ...any) ([]models.Category, error) {
 	rows, err := r.DB.QueryContext(ctx, `
-		SELECT CategoryID, CategoryName, Description
+		SELECT CategoryID, CategoryName, Description, DeletedAt
 		FROM Categories
-	`)
+		WHERE `+where, args...)
 	if err != nil {
 		log.Error().Err(err).Msg("error fetching categories")
 		return nil, fmt.Errorf("error fetching categories: %w", err)
@@ -43,11 +58,15 @@ func (r *CategoryRepository) GetAllCategories(ctx context.Context) ([]models.Cat
 
 	var categories []models.Category
 	for rows.Next() {
-		var category models.Category
-		if err := rows.Scan(&category.CategoryID, &category.CategoryName, &category.Description); err != nil {
+		var (
+			category models.Category
+			deleted  sql.NullString
+		)
+		if err := rows.Scan(&category.CategoryID, &category.CategoryName, &category.Description, &deleted); err != nil {
 			log.Error().Err(err).Msg("error scanning category row")
 			return nil, fmt.Errorf("error scanning category row: %w", err)
 		}
+		category.DeletedAt = deletedAt(deleted)
 		categories = append(categories, category)
 	}
 	if err := rows.Err(); err != nil {
@@ -58,32 +77,35 @@ func (r *CategoryRepository) GetAllCategories(ctx context.Context) ([]models.Cat
 }
 
 func (r *CategoryRepository) GetCategoryByID(ctx context.Context, id int) (models.Category, error) {
-	var category models.Category
+	var (
+		category models.Category
+		deleted  sql.NullString
+	)
 	err := r.DB.QueryRowContext(ctx, `
-		SELECT CategoryID, CategoryName, Description
+		SELECT CategoryID, CategoryName, Description, DeletedAt
 		FROM Categories
-		WHERE CategoryID = ?
-	`, id).Scan(&category.CategoryID, &category.CategoryName, &category.Description)
+		WHERE CategoryID = ? AND `+liveOnly(ctx, "DeletedAt"), id).Scan(&category.CategoryID, &category.CategoryName, &category.Description, &deleted)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			log.Warn().Int("id", id).Msg("category not found")
-			return category, fmt.Errorf("category not found")
+			return category, apperr.NotFound("category not found")
 		}
 		log.Error().Err(err).Int("id", id).Msg("error fetching category by ID")
 		return category, fmt.Errorf("error fetching category by ID: %w", err)
 	}
+	category.DeletedAt = deletedAt(deleted)
 	return category, nil
 }
 
 func (r *CategoryRepository) UpdateCategory(ctx context.Context, c *models.Category) error {
 	result, err := r.DB.ExecContext(ctx, `
 		UPDATE Categories
-		SET CategoryName = ?, Description = ?, Picture = ?
-		WHERE CategoryID = ?
-	`, c.CategoryName, c.Description, c.Picture, c.CategoryID)
+		SET CategoryName = ?, Description = ?
+		WHERE CategoryID = ? AND DeletedAt IS NULL
+	`, c.CategoryName, c.Description, c.CategoryID)
 	if err != nil {
 		log.Error().Err(err).Int64("id", c.CategoryID).Msg("error updating category")
-		return fmt.Errorf("error updating category: %w", err)
+		return dbError(err, "error updating category")
 	}
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
@@ -92,25 +114,41 @@ func (r *CategoryRepository) UpdateCategory(ctx context.Context, c *models.Categ
 	}
 	if rowsAffected == 0 {
 		log.Warn().Int64("id", c.CategoryID).Msg("no category found to update")
-		return fmt.Errorf("category not found")
+		return apperr.NotFound("category not found")
 	}
 	return nil
 }
 
+// DeleteCategory melakukan soft delete; product lama tetap merujuk category ini.
 func (r *CategoryRepository) DeleteCategory(ctx context.Context, id int) error {
-	result, err := r.DB.ExecContext(ctx, `DELETE FROM Categories WHERE CategoryID = ?`, id)
-	if err != nil {
-		log.Error().Err(err).Int("id", id).Msg("error deleting category")
-		return fmt.Errorf("error deleting category: %w", err)
-	}
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		log.Error().Err(err).Int("id", id).Msg("error getting rows affected for category delete")
-		return fmt.Errorf("error getting rows affected: %w", err)
-	}
-	if rowsAffected == 0 {
-		log.Warn().Int("id", id).Msg("no category found to delete")
-		return fmt.Errorf("category not found")
-	}
-	return nil
+	return softDeleteRow(ctx, r.DB, "Categories", "CategoryID", "category", id)
+}
+
+func (r *CategoryRepository) RestoreCategory(ctx context.Context, id int) error {
+	return restoreRow(ctx, r.DB, "Categories", "CategoryID", "category", id)
+}
+
+var categoryPatch = patchTarget{
+	table:  "Categories",
+	key:    "CategoryID",
+	entity: "category",
+	columns: []string{
+		"CategoryName", "Description",
+	},
+	softDelete: true,
+}
+
+// PatchCategory hanya meng-update kolom yang ada di changes.
+func (r *CategoryRepository) PatchCategory(ctx context.Context, id int, changes Changes) error {
+	return patchRow(ctx, r.DB, categoryPatch, id, changes)
+}
+
+// GetCategoryPicture membaca Picture apa adanya (bisa masih dengan header OLE).
+func (r *CategoryRepository) GetCategoryPicture(ctx context.Context, id int) ([]byte, error) {
+	return getBlob(ctx, r.DB, "Categories", "CategoryID", "Picture", "category", id)
+}
+
+// SetCategoryPicture mengganti Picture; nil menghapusnya.
+func (r *CategoryRepository) SetCategoryPicture(ctx context.Context, id int, picture []byte) error {
+	return setBlob(ctx, r.DB, "Categories", "CategoryID", "Picture", "category", id, picture)
 }
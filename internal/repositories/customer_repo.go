@@ -4,17 +4,65 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"northwind-api/internal/apperr"
 	"northwind-api/internal/models"
-	"northwind-api/internal/utils"
 
 	"github.com/rs/zerolog/log"
 )
 
+// CustomerFilter membatasi hasil ListCustomers. Field kosong berarti semua;
+// Country dan City tidak peka huruf besar/kecil, Search mencocokkan potongan
+// CompanyName. Limit 0 berarti tanpa batas.
+type CustomerFilter struct {
+	Country string
+	City    string
+	Search  string
+	Limit   int
+	Offset  int
+}
+
 type CustomerRepository struct {
-	DB *sql.DB
+	DB DBTX
 }
 
 func (r *CustomerRepository) GetAllCustomers(ctx context.Context) ([]models.Customer, error) {
+	return r.listCustomers(ctx, liveOnly(ctx, "DeletedAt"))
+}
+
+// ListCustomers mengembalikan satu halaman customer aktif yang cocok dengan f,
+// urut CustomerID, beserta jumlah seluruh customer yang cocok.
+func (r *CustomerRepository) ListCustomers(ctx context.Context, f CustomerFilter) ([]models.Customer, int, error) {
+	var c conditions
+	c.add(liveOnly(ctx, "DeletedAt"))
+	if f.Country != "" {
+		c.add("Country = ? COLLATE NOCASE", f.Country)
+	}
+	if f.City != "" {
+		c.add("City = ? COLLATE NOCASE", f.City)
+	}
+	if f.Search != "" {
+		c.contains("CompanyName", f.Search)
+	}
+	total, err := c.count(ctx, r.DB, "Customers")
+	if err != nil {
+		return nil, 0, err
+	}
+	where, args := c.page("CustomerID", f.Limit, f.Offset)
+	rows, err := r.listCustomers(ctx, where, args...)
+	return rows, total, err
+}
+
+// GetCustomersByIDs memuat beberapa customer sekaligus, termasuk yang sudah
+// dihapus supaya rujukan dari data lama tetap bisa di-resolve.
+func (r *CustomerRepository) GetCustomersByIDs(ctx context.Context, ids []string) ([]models.Customer, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return r.listCustomers(ctx, "CustomerID IN "+placeholders(len(ids)), inArgs(ids)...)
+}
+
+// listCustomers menjalankan SELECT daftar dengan kondisi WHERE where.
+func (r *CustomerRepository) listCustomers(ctx context.Context, where string, args ...any) ([]models.Customer, error) {
 	rows, err := r.DB.QueryContext(ctx, `
 		SELECT
 			COALESCE(CustomerID, '') AS CustomerID,
@@ -27,9 +75,10 @@ func (r *CustomerRepository) GetAllCustomers(ctx context.Context) ([]models.Cust
 			COALESCE(PostalCode, '') AS PostalCode,
 			COALESCE(Country, '') AS Country,
 			COALESCE(Phone, '') AS Phone,
-			COALESCE(Fax, '') AS Fax
+			COALESCE(Fax, '') AS Fax,
+			DeletedAt
 		FROM Customers
-	`)
+		WHERE `+where, args...)
 	if err != nil {
 		log.Error().Err(err).Msg("failed to query customers")
 		return nil, fmt.Errorf("error fetching customers: %w", err)
@@ -38,7 +87,10 @@ func (r *CustomerRepository) GetAllCustomers(ctx context.Context) ([]models.Cust
 
 	var customers []models.Customer
 	for rows.Next() {
-		var customer models.Customer
+		var (
+			customer models.Customer
+			deleted  sql.NullString
+		)
 		if err := rows.Scan(
 			&customer.CustomerID,
 			&customer.CompanyName,
@@ -51,10 +103,12 @@ func (r *CustomerRepository) GetAllCustomers(ctx context.Context) ([]models.Cust
 			&customer.Country,
 			&customer.Phone,
 			&customer.Fax,
+			&deleted,
 		); err != nil {
 			log.Error().Err(err).Msg("failed to scan customer")
 			return nil, fmt.Errorf("error scanning customer: %w", err)
 		}
+		customer.DeletedAt = deletedAt(deleted)
 		customers = append(customers, customer)
 	}
 	if err := rows.Err(); err != nil {
@@ -65,7 +119,10 @@ func (r *CustomerRepository) GetAllCustomers(ctx context.Context) ([]models.Cust
 }
 
 func (r *CustomerRepository) GetCustomerByID(ctx context.Context, id string) (models.Customer, error) {
-	var customer models.Customer
+	var (
+		customer models.Customer
+		deleted  sql.NullString
+	)
 	err := r.DB.QueryRowContext(ctx, `
 		SELECT
 			COALESCE(CustomerID, '') AS CustomerID,
@@ -78,10 +135,10 @@ func (r *CustomerRepository) GetCustomerByID(ctx context.Context, id string) (mo
 			COALESCE(PostalCode, '') AS PostalCode,
 			COALESCE(Country, '') AS Country,
 			COALESCE(Phone, '') AS Phone,
-			COALESCE(Fax, '') AS Fax
+			COALESCE(Fax, '') AS Fax,
+			DeletedAt
 		FROM Customers
-		WHERE CustomerID = ?
-	`, id).Scan(
+		WHERE CustomerID = ? AND `+liveOnly(ctx, "DeletedAt"), id).Scan(
 		&customer.CustomerID,
 		&customer.CompanyName,
 		&customer.ContactName,
@@ -93,21 +150,21 @@ func (r *CustomerRepository) GetCustomerByID(ctx context.Context, id string) (mo
 		&customer.Country,
 		&customer.Phone,
 		&customer.Fax,
+		&deleted,
 	)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return models.Customer{}, fmt.Errorf("customer with ID %s not found", id)
+			return models.Customer{}, apperr.NotFound("customer with ID %s not found", id)
 		}
 		log.Error().Err(err).Msg("failed to query customer by ID")
 		return models.Customer{}, fmt.Errorf("error fetching customer by ID: %w", err)
 	}
+	customer.DeletedAt = deletedAt(deleted)
 	return customer, nil
 }
 
 func (h *CustomerRepository) CreateCustomer(ctx context.Context, customer *models.Customer) (string, error) {
-	// generate ID
-	customer.CustomerID = utils.GenerateCustomerID()
 	log.Debug().
 		Str("customer_id", customer.CustomerID).
 		Str("company_name", customer.CompanyName).
@@ -135,7 +192,7 @@ func (h *CustomerRepository) CreateCustomer(ctx context.Context, customer *model
 		log.Error().Err(err).
 			Str("customer_id", customer.CustomerID).
 			Msg("Error creating customer")
-		return "", fmt.Errorf("error creating customer: %w", err)
+		return "", dbError(err, "error creating customer")
 	}
 
 	log.Info().
@@ -156,7 +213,7 @@ func (r *CustomerRepository) UpdateCustomer(ctx context.Context, customer *model
 		`UPDATE Customers SET
 			CompanyName = ?, ContactName = ?, ContactTitle = ?, Address = ?, City = ?,
 			Region = ?, PostalCode = ?, Country = ?, Phone = ?, Fax = ?
-		 WHERE CustomerID = ?`,
+		 WHERE CustomerID = ? AND DeletedAt IS NULL`,
 		customer.CompanyName,
 		customer.ContactName,
 		customer.ContactTitle,
@@ -173,7 +230,7 @@ func (r *CustomerRepository) UpdateCustomer(ctx context.Context, customer *model
 		log.Error().Err(err).
 			Str("customer_id", customer.CustomerID).
 			Msg("Error updating customer")
-		return fmt.Errorf("error updating customer: %w", err)
+		return dbError(err, "error updating customer")
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -187,7 +244,7 @@ func (r *CustomerRepository) UpdateCustomer(ctx context.Context, customer *model
 		log.Warn().
 			Str("customer_id", customer.CustomerID).
 			Msg("No customer found to update")
-		return fmt.Errorf("no customer found with ID %s", customer.CustomerID)
+		return apperr.NotFound("no customer found with ID %s", customer.CustomerID)
 	}
 
 	log.Info().
@@ -196,23 +253,35 @@ func (r *CustomerRepository) UpdateCustomer(ctx context.Context, customer *model
 	return nil
 }
 
+// DeleteCustomer melakukan soft delete; order lama tetap merujuk customer ini.
 func (r *CustomerRepository) DeleteCustomer(ctx context.Context, id string) error {
-	result, err := r.DB.ExecContext(ctx, "DELETE FROM Customers WHERE CustomerID = ?", id)
-	if err != nil {
-		log.Error().Err(err).Str("customer_id", id).Msg("Error deleting customer")
-		return fmt.Errorf("error deleting customer: %w", err)
+	if err := softDeleteRow(ctx, r.DB, "Customers", "CustomerID", "customer", id); err != nil {
+		return err
 	}
+	log.Info().Str("customer_id", id).Msg("Customer deleted")
+	return nil
+}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		log.Error().Err(err).Str("customer_id", id).Msg("Error fetching rows affected for customer delete")
-		return fmt.Errorf("error fetching rows affected: %w", err)
+func (r *CustomerRepository) RestoreCustomer(ctx context.Context, id string) error {
+	if err := restoreRow(ctx, r.DB, "Customers", "CustomerID", "customer", id); err != nil {
+		return err
 	}
-	if rowsAffected == 0 {
-		log.Warn().Str("customer_id", id).Msg("No customer found to delete")
-		return fmt.Errorf("no customer found with ID %s", id)
-	}
-
-	log.Info().Str("customer_id", id).Msg("Customer deleted")
+	log.Info().Str("customer_id", id).Msg("Customer restored")
 	return nil
 }
+
+var customerPatch = patchTarget{
+	table:  "Customers",
+	key:    "CustomerID",
+	entity: "customer",
+	columns: []string{
+		"CompanyName", "ContactName", "ContactTitle", "Address", "City", "Region",
+		"PostalCode", "Country", "Phone", "Fax",
+	},
+	softDelete: true,
+}
+
+// PatchCustomer hanya meng-update kolom yang ada di changes.
+func (r *CustomerRepository) PatchCustomer(ctx context.Context, id string, changes Changes) error {
+	return patchRow(ctx, r.DB, customerPatch, id, changes)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"northwind-api/internal/apperr"
 	"northwind-api/internal/models"
 
 	// "northwind-api/internal/utils"
@@ -12,7 +13,7 @@ import (
 )
 
 type EmployeeRepository struct {
-	DB *sql.DB
+	DB DBTX
 }
 
 func (r *EmployeeRepository) CreateEmployee(ctx context.Context, emp models.Employee) (int64, error) {
@@ -27,18 +28,32 @@ func (r *EmployeeRepository) CreateEmployee(ctx context.Context, emp models.Empl
 	)
 	if err != nil {
 		log.Error().Err(err).Msg("Error creating employee")
-		return 0, fmt.Errorf("error creating employee: %w", err)
+		return 0, dbError(err, "error creating employee")
 	}
 	return result.LastInsertId()
 }
 
 func (r *EmployeeRepository) GetAllEmployees(ctx context.Context) ([]models.Employee, error) {
+	return r.listEmployees(ctx, liveOnly(ctx, "DeletedAt"))
+}
+
+// GetEmployeesByIDs memuat beberapa employee sekaligus, termasuk yang sudah
+// dihapus supaya rujukan dari data lama tetap bisa di-resolve.
+func (r *EmployeeRepository) GetEmployeesByIDs(ctx context.Context, ids []int) ([]models.Employee, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return r.listEmployees(ctx, "EmployeeID IN "+placeholders(len(ids)), inArgs(ids)...)
+}
+
+// listEmployees menjalankan SELECT daftar dengan kondisi WHERE where.
+func (r *EmployeeRepository) listEmployees(ctx context.Context, where string, args ...any) ([]models.Employee, error) {
 	rows, err := r.DB.QueryContext(ctx, `
 		SELECT
 			EmployeeID, LastName, FirstName, Title, TitleOfCourtesy, BirthDate, HireDate,
-			Address, City, Region, PostalCode, Country, HomePhone, Extension, Notes, ReportsTo, PhotoPath
+			Address, City, Region, PostalCode, Country, HomePhone, Extension, Notes, ReportsTo, PhotoPath, DeletedAt
 		FROM Employees
-	`)
+		WHERE `+where, args...)
 	if err != nil {
 		log.Error().Err(err).Msg("failed to query employees")
 		return nil, fmt.Errorf("error fetching employees: %w", err)
@@ -47,7 +62,10 @@ func (r *EmployeeRepository) GetAllEmployees(ctx context.Context) ([]models.Empl
 
 	var employees []models.Employee
 	for rows.Next() {
-		var employee models.Employee
+		var (
+			employee models.Employee
+			deleted  sql.NullString
+		)
 		if err := rows.Scan(
 			&employee.EmployeeID,
 			&employee.LastName,
@@ -67,10 +85,12 @@ func (r *EmployeeRepository) GetAllEmployees(ctx context.Context) ([]models.Empl
 			&employee.Notes,
 			&employee.ReportsTo,
 			&employee.PhotoPath,
+			&deleted,
 		); err != nil {
 			log.Error().Err(err).Msg("failed to scan employee")
 			return nil, fmt.Errorf("error scanning employee: %w", err)
 		}
+		employee.DeletedAt = deletedAt(deleted)
 		employees = append(employees, employee)
 	}
 	if err := rows.Err(); err != nil {
@@ -81,14 +101,16 @@ func (r *EmployeeRepository) GetAllEmployees(ctx context.Context) ([]models.Empl
 }
 
 func (r *EmployeeRepository) GetEmployeeByID(ctx context.Context, id int) (models.Employee, error) {
-	var employee models.Employee
+	var (
+		employee models.Employee
+		deleted  sql.NullString
+	)
 	err := r.DB.QueryRowContext(ctx, `
 		SELECT
 			EmployeeID, LastName, FirstName, Title, TitleOfCourtesy, BirthDate, HireDate,
-			Address, City, Region, PostalCode, Country, HomePhone, Extension, Notes, ReportsTo, PhotoPath
+			Address, City, Region, PostalCode, Country, HomePhone, Extension, Notes, ReportsTo, PhotoPath, DeletedAt
 		FROM Employees
-		WHERE EmployeeID = ?
-	`, id).Scan(
+		WHERE EmployeeID = ? AND `+liveOnly(ctx, "DeletedAt"), id).Scan(
 		&employee.EmployeeID,
 		&employee.LastName,
 		&employee.FirstName,
@@ -107,54 +129,75 @@ func (r *EmployeeRepository) GetEmployeeByID(ctx context.Context, id int) (model
 		&employee.Notes,
 		&employee.ReportsTo,
 		&employee.PhotoPath,
+		&deleted,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return models.Employee{}, fmt.Errorf("employee with ID %d not found", id)
+			return models.Employee{}, apperr.NotFound("employee with ID %d not found", id)
 		}
 		log.Error().Err(err).Msg("failed to query employee by ID")
 		return models.Employee{}, fmt.Errorf("error fetching employee by ID: %w", err)
 	}
+	employee.DeletedAt = deletedAt(deleted)
 	return employee, nil
 }
 
 func (r *EmployeeRepository) UpdateEmployee(ctx context.Context, emp *models.Employee) error {
-	_, err := r.DB.ExecContext(
+	result, err := r.DB.ExecContext(
 		ctx,
 		`UPDATE Employees SET
 			LastName = ?, FirstName = ?, Title = ?, TitleOfCourtesy = ?, BirthDate = ?, HireDate = ?,
 			Address = ?, City = ?, Region = ?, PostalCode = ?, Country = ?, HomePhone = ?, Extension = ?,
-			Photo = ?, Notes = ?, ReportsTo = ?, PhotoPath = ?
-		WHERE EmployeeID = ?`,
+			Notes = ?, ReportsTo = ?, PhotoPath = ?
+		WHERE EmployeeID = ? AND DeletedAt IS NULL`,
 		emp.LastName, emp.FirstName, emp.Title, emp.TitleOfCourtesy, emp.BirthDate, emp.HireDate,
 		emp.Address, emp.City, emp.Region, emp.PostalCode, emp.Country, emp.HomePhone,
-		emp.Extension, emp.Photo, emp.Notes, emp.ReportsTo, emp.PhotoPath, emp.EmployeeID,
+		emp.Extension, emp.Notes, emp.ReportsTo, emp.PhotoPath, emp.EmployeeID,
 	)
 	if err != nil {
 		log.Error().Err(err).Msg("Error updating employee")
-		return fmt.Errorf("error updating employee: %w", err)
+		return dbError(err, "error updating employee")
 	}
 
-	return nil
+	return ensureAffected(result, apperr.NotFound("no employee found with ID %d", emp.EmployeeID))
 }
 
+// DeleteEmployee melakukan soft delete; order lama tetap merujuk employee ini.
 func (r *EmployeeRepository) DeleteEmployee(ctx context.Context, id int) error {
-	result, err := r.DB.ExecContext(ctx, "DELETE FROM Employees WHERE EmployeeID = ?", id)
-	if err != nil {
-		log.Error().Err(err).Int("employee_id", id).Msg("Error deleting employee")
-		return fmt.Errorf("error deleting employee: %w", err)
-	}
-
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		log.Error().Err(err).Int("employee_id", id).Msg("Error fetching rows affected for employee delete")
-		return fmt.Errorf("error fetching rows affected: %w", err)
+	if err := softDeleteRow(ctx, r.DB, "Employees", "EmployeeID", "employee", id); err != nil {
+		return err
 	}
-	if rowsAffected == 0 {
-		log.Warn().Int("employee_id", id).Msg("No employee found to delete")
-		return fmt.Errorf("no employee found with ID %d", id)
-	}
-
 	log.Info().Int("employee_id", id).Msg("Employee deleted")
 	return nil
 }
+
+func (r *EmployeeRepository) RestoreEmployee(ctx context.Context, id int) error {
+	return restoreRow(ctx, r.DB, "Employees", "EmployeeID", "employee", id)
+}
+
+var employeePatch = patchTarget{
+	table:  "Employees",
+	key:    "EmployeeID",
+	entity: "employee",
+	columns: []string{
+		"LastName", "FirstName", "Title", "TitleOfCourtesy", "BirthDate", "HireDate",
+		"Address", "City", "Region", "PostalCode", "Country", "HomePhone", "Extension",
+		"Notes", "ReportsTo", "PhotoPath",
+	},
+	softDelete: true,
+}
+
+// PatchEmployee hanya meng-update kolom yang ada di changes.
+func (r *EmployeeRepository) PatchEmployee(ctx context.Context, id int, changes Changes) error {
+	return patchRow(ctx, r.DB, employeePatch, id, changes)
+}
+
+// GetEmployeePhoto membaca Photo apa adanya (bisa masih dengan header OLE).
+func (r *EmployeeRepository) GetEmployeePhoto(ctx context.Context, id int) ([]byte, error) {
+	return getBlob(ctx, r.DB, "Employees", "EmployeeID", "Photo", "employee", id)
+}
+
+// SetEmployeePhoto mengganti Photo; nil menghapusnya.
+func (r *EmployeeRepository) SetEmployeePhoto(ctx context.Context, id int, photo []byte) error {
+	return setBlob(ctx, r.DB, "Employees", "EmployeeID", "Photo", "employee", id, photo)
+}
@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"northwind-api/internal/apperr"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// dbError menerjemahkan error constraint SQLite ke apperr; error lain
+// dibungkus apa adanya (dan akan menjadi 500 di layer HTTP).
+func dbError(err error, msg string) error {
+	var se *sqlite.Error
+	if errors.As(err, &se) {
+		switch se.Code() {
+		case sqlite3.SQLITE_CONSTRAINT_UNIQUE, sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY:
+			return apperr.Conflict("%s: record already exists", msg).Wrap(err)
+		case sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY:
+			return apperr.ForeignKey("%s: referenced record is missing or still in use", msg).Wrap(err)
+		case sqlite3.SQLITE_CONSTRAINT_NOTNULL, sqlite3.SQLITE_CONSTRAINT_CHECK:
+			return apperr.Validation("", "%s: constraint violated", msg).Wrap(err)
+		}
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
+
+// ensureAffected mengubah update/delete yang tidak menyentuh baris apa pun menjadi NotFound.
+func ensureAffected(res interface{ RowsAffected() (int64, error) }, notFound *apperr.Error) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error fetching rows affected: %w", err)
+	}
+	if n == 0 {
+		return notFound
+	}
+	return nil
+}
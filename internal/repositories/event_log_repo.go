@@ -0,0 +1,146 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"northwind-api/internal/models"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// EventFilter membatasi hasil ListEvents: event dengan EventID > AfterID,
+// opsional hanya untuk Entities tertentu, urut dari yang terlama.
+type EventFilter struct {
+	AfterID  int64
+	Entities []string
+	Limit    int
+}
+
+// EventLogRepository menulis event log di transaksi yang sama dengan perubahannya.
+type EventLogRepository struct {
+	DB DBTX
+}
+
+func (r *EventLogRepository) AppendEvents(ctx context.Context, events ...models.ChangeEvent) error {
+	for _, e := range events {
+		if _, err := r.DB.ExecContext(ctx, `
+			INSERT INTO EventLog (Type, Entity, EntityID, Action, Before, After, OccurredAt)
+			VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			e.Type, e.Entity, e.EntityID, e.Action, nullJSON(e.Before), nullJSON(e.After),
+			e.OccurredAt.UTC().Format(time.RFC3339Nano),
+		); err != nil {
+			log.Error().Err(err).Str("type", e.Type).Str("id", e.EntityID).Msg("error writing event log")
+			return dbError(err, "error writing event log")
+		}
+	}
+	return nil
+}
+
+func (r *EventLogRepository) ListEvents(ctx context.Context, f EventFilter) ([]models.ChangeEvent, error) {
+	query := `SELECT EventID, Type, Entity, EntityID, Action, Before, After, OccurredAt
+		FROM EventLog WHERE EventID > ?`
+	args := []any{f.AfterID}
+	if len(f.Entities) > 0 {
+		query += ` AND Entity IN (?` + strings.Repeat(`, ?`, len(f.Entities)-1) + `)`
+		for _, e := range f.Entities {
+			args = append(args, e)
+		}
+	}
+	query += ` ORDER BY EventID LIMIT ?`
+	args = append(args, f.Limit)
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to query event log")
+		return nil, fmt.Errorf("error fetching event log: %w", err)
+	}
+	defer rows.Close()
+
+	events := []models.ChangeEvent{}
+	for rows.Next() {
+		var (
+			e             models.ChangeEvent
+			before, after sql.NullString
+			occurredAt    string
+		)
+		if err := rows.Scan(&e.EventID, &e.Type, &e.Entity, &e.EntityID, &e.Action, &before, &after, &occurredAt); err != nil {
+			return nil, fmt.Errorf("error scanning event: %w", err)
+		}
+		if before.Valid {
+			e.Before = []byte(before.String)
+		}
+		if after.Valid {
+			e.After = []byte(after.String)
+		}
+		e.OccurredAt, _ = time.Parse(time.RFC3339Nano, occurredAt)
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+// LatestEventID mengembalikan EventID terakhir, 0 kalau log masih kosong.
+func (r *EventLogRepository) LatestEventID(ctx context.Context) (int64, error) {
+	var id int64
+	if err := r.DB.QueryRowContext(ctx, `SELECT COALESCE(MAX(EventID), 0) FROM EventLog`).Scan(&id); err != nil {
+		log.Error().Err(err).Msg("failed to query latest event id")
+		return 0, fmt.Errorf("error fetching latest event id: %w", err)
+	}
+	return id, nil
+}
+
+// RedactEvents mengganti nilai fields di snapshot before/after semua event
+// entity/id dengan Erased.
+func (r *EventLogRepository) RedactEvents(ctx context.Context, entity, id string, fields []string) error {
+	rows, err := r.DB.QueryContext(ctx, `SELECT EventID, Before, After FROM EventLog WHERE Entity = ? AND EntityID = ?`, entity, id)
+	if err != nil {
+		log.Error().Err(err).Str("entity", entity).Str("id", id).Msg("failed to query event log")
+		return fmt.Errorf("error fetching event log: %w", err)
+	}
+	type snapshot struct{ before, after []byte }
+	redacted := map[int64]snapshot{}
+	for rows.Next() {
+		var (
+			eventID       int64
+			before, after sql.NullString
+		)
+		if err := rows.Scan(&eventID, &before, &after); err != nil {
+			rows.Close()
+			return fmt.Errorf("error scanning event: %w", err)
+		}
+		b, bChanged, err := RedactJSON([]byte(before.String), fields)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+		a, aChanged, err := RedactJSON([]byte(after.String), fields)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+		if bChanged || aChanged {
+			redacted[eventID] = snapshot{b, a}
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for eventID, s := range redacted {
+		if _, err := r.DB.ExecContext(ctx, `UPDATE EventLog SET Before = ?, After = ? WHERE EventID = ?`,
+			nullJSON(s.before), nullJSON(s.after), eventID); err != nil {
+			log.Error().Err(err).Int64("event_id", eventID).Msg("error redacting event log")
+			return dbError(err, "error redacting event log")
+		}
+	}
+	return nil
+}
+
+func nullJSON(raw []byte) any {
+	if len(raw) == 0 {
+		return nil
+	}
+	return string(raw)
+}
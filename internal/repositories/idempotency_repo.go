@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/models"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// IdempotencyRepository dipakai middleware di luar transaksi services, supaya
+// key tetap tersimpan walaupun transaksi request-nya rollback.
+type IdempotencyRepository struct {
+	DB DBTX
+}
+
+// ReserveIdempotencyKey menyimpan key baru dalam status "sedang diproses".
+// Key yang sudah kedaluwarsa dihapus dulu; key yang masih aktif menghasilkan Conflict.
+func (r *IdempotencyRepository) ReserveIdempotencyKey(ctx context.Context, rec models.IdempotencyRecord) error {
+	if _, err := r.DB.ExecContext(ctx,
+		`DELETE FROM IdempotencyKeys WHERE ExpiresAt <= ?`, rec.CreatedAt.UTC().Format(time.RFC3339Nano),
+	); err != nil {
+		log.Error().Err(err).Msg("error purging idempotency keys")
+		return fmt.Errorf("error purging idempotency keys: %w", err)
+	}
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO IdempotencyKeys (IdempotencyKey, Fingerprint, StatusCode, CreatedAt, ExpiresAt)
+		VALUES (?, ?, 0, ?, ?)`,
+		rec.Key, rec.Fingerprint,
+		rec.CreatedAt.UTC().Format(time.RFC3339Nano), rec.ExpiresAt.UTC().Format(time.RFC3339Nano),
+	)
+	if err != nil {
+		return dbError(err, "error reserving idempotency key")
+	}
+	return nil
+}
+
+func (r *IdempotencyRepository) GetIdempotencyKey(ctx context.Context, key string) (models.IdempotencyRecord, error) {
+	var (
+		rec                  models.IdempotencyRecord
+		contentType          sql.NullString
+		createdAt, expiresAt string
+	)
+	err := r.DB.QueryRowContext(ctx, `
+		SELECT IdempotencyKey, Fingerprint, StatusCode, ContentType, ResponseBody, CreatedAt, ExpiresAt
+		FROM IdempotencyKeys
+		WHERE IdempotencyKey = ?`, key,
+	).Scan(&rec.Key, &rec.Fingerprint, &rec.StatusCode, &contentType, &rec.Body, &createdAt, &expiresAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return rec, apperr.NotFound("idempotency key %s not found", key)
+		}
+		log.Error().Err(err).Msg("failed to query idempotency key")
+		return rec, fmt.Errorf("error fetching idempotency key: %w", err)
+	}
+	rec.ContentType = contentType.String
+	rec.CreatedAt, _ = time.Parse(time.RFC3339Nano, createdAt)
+	rec.ExpiresAt, _ = time.Parse(time.RFC3339Nano, expiresAt)
+	return rec, nil
+}
+
+// CompleteIdempotencyKey menyimpan response final untuk key.
+func (r *IdempotencyRepository) CompleteIdempotencyKey(ctx context.Context, key string, status int, contentType string, body []byte) error {
+	result, err := r.DB.ExecContext(ctx, `
+		UPDATE IdempotencyKeys SET StatusCode = ?, ContentType = ?, ResponseBody = ?
+		WHERE IdempotencyKey = ?`, status, contentType, body, key)
+	if err != nil {
+		log.Error().Err(err).Msg("error completing idempotency key")
+		return dbError(err, "error completing idempotency key")
+	}
+	return ensureAffected(result, apperr.NotFound("idempotency key %s not found", key))
+}
+
+// ReleaseIdempotencyKey menghapus key supaya request boleh diulang (dipakai saat request gagal).
+func (r *IdempotencyRepository) ReleaseIdempotencyKey(ctx context.Context, key string) error {
+	if _, err := r.DB.ExecContext(ctx, `DELETE FROM IdempotencyKeys WHERE IdempotencyKey = ?`, key); err != nil {
+		log.Error().Err(err).Msg("error releasing idempotency key")
+		return dbError(err, "error releasing idempotency key")
+	}
+	return nil
+}
+
+func (r *IdempotencyRepository) PurgeIdempotencyResponses(ctx context.Context, contains ...string) error {
+	for _, text := range contains {
+		if _, err := r.DB.ExecContext(ctx,
+			`DELETE FROM IdempotencyKeys WHERE instr(ResponseBody, ?) > 0`, []byte(text),
+		); err != nil {
+			log.Error().Err(err).Msg("error purging idempotency responses")
+			return dbError(err, "error purging idempotency responses")
+		}
+	}
+	return nil
+}
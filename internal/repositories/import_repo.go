@@ -0,0 +1,148 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/models"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ImportJobRepository menyimpan job import CSV. Dipakai di luar transaksi
+// services supaya status job tetap tercatat walaupun import-nya rollback.
+type ImportJobRepository struct {
+	DB DBTX
+}
+
+const importJobColumns = `JobID, Entity, Status, FileName, Mapping, TotalRows, ValidRows,
+	InvalidRows, ImportedRows, Errors, Message, CreatedAt, UpdatedAt`
+
+func (r *ImportJobRepository) CreateImportJob(ctx context.Context, job *models.ImportJob) error {
+	mapping, _ := json.Marshal(job.Mapping)
+	errs, _ := json.Marshal(job.Errors)
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO ImportJobs (`+importJobColumns+`, Data)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		job.JobID, job.Entity, job.Status, job.FileName, string(mapping),
+		job.TotalRows, job.ValidRows, job.InvalidRows, job.ImportedRows, string(errs), job.Message,
+		job.CreatedAt.UTC().Format(time.RFC3339Nano), job.UpdatedAt.UTC().Format(time.RFC3339Nano),
+		job.Data,
+	)
+	if err != nil {
+		log.Error().Err(err).Msg("error creating import job")
+		return dbError(err, "error creating import job")
+	}
+	return nil
+}
+
+// GetImportJob mengembalikan job beserta isi file-nya.
+func (r *ImportJobRepository) GetImportJob(ctx context.Context, id string) (models.ImportJob, error) {
+	row := r.DB.QueryRowContext(ctx, `SELECT `+importJobColumns+`, Data FROM ImportJobs WHERE JobID = ?`, id)
+	job, err := scanImportJob(row.Scan, true)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return job, apperr.NotFound("import job %s not found", id)
+		}
+		log.Error().Err(err).Str("job_id", id).Msg("failed to query import job")
+		return job, fmt.Errorf("error fetching import job: %w", err)
+	}
+	return job, nil
+}
+
+// ListImportJobs mengembalikan job terbaru lebih dulu, tanpa isi file.
+func (r *ImportJobRepository) ListImportJobs(ctx context.Context) ([]models.ImportJob, error) {
+	rows, err := r.DB.QueryContext(ctx, `SELECT `+importJobColumns+` FROM ImportJobs ORDER BY CreatedAt DESC`)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to query import jobs")
+		return nil, fmt.Errorf("error fetching import jobs: %w", err)
+	}
+	defer rows.Close()
+
+	jobs := []models.ImportJob{}
+	for rows.Next() {
+		job, err := scanImportJob(rows.Scan, false)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning import job: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+// UpdateImportJob menyimpan status, hitungan baris dan laporan error job.
+// File-nya dibuang begitu job selesai; isinya bisa berisi data pribadi.
+func (r *ImportJobRepository) UpdateImportJob(ctx context.Context, job *models.ImportJob) error {
+	errs, _ := json.Marshal(job.Errors)
+	result, err := r.DB.ExecContext(ctx, `
+		UPDATE ImportJobs SET Status = ?, TotalRows = ?, ValidRows = ?, InvalidRows = ?,
+			ImportedRows = ?, Errors = ?, Message = ?, UpdatedAt = ?,
+			Data = CASE WHEN ? THEN x'' ELSE Data END
+		WHERE JobID = ?`,
+		job.Status, job.TotalRows, job.ValidRows, job.InvalidRows, job.ImportedRows,
+		string(errs), job.Message, job.UpdatedAt.UTC().Format(time.RFC3339Nano), job.Finished(), job.JobID,
+	)
+	if err != nil {
+		log.Error().Err(err).Msg("error updating import job")
+		return dbError(err, "error updating import job")
+	}
+	return ensureAffected(result, apperr.NotFound("import job %s not found", job.JobID))
+}
+
+// TransitionImportJob memindahkan status from -> to secara atomik; kalau job
+// sudah tidak berstatus from (mis. commit dobel), hasilnya Conflict.
+func (r *ImportJobRepository) TransitionImportJob(ctx context.Context, id, from, to string) error {
+	result, err := r.DB.ExecContext(ctx, `
+		UPDATE ImportJobs SET Status = ?, UpdatedAt = ? WHERE JobID = ? AND Status = ?`,
+		to, time.Now().UTC().Format(time.RFC3339Nano), id, from,
+	)
+	if err != nil {
+		log.Error().Err(err).Msg("error updating import job status")
+		return dbError(err, "error updating import job status")
+	}
+	return ensureAffected(result, apperr.Conflict("import job %s is not %s", id, from))
+}
+
+// FailRunningImportJobs dipakai saat start: job yang masih berjalan di sini
+// ditinggal proses sebelumnya.
+func (r *ImportJobRepository) FailRunningImportJobs(ctx context.Context, message string) (int, error) {
+	result, err := r.DB.ExecContext(ctx, `
+		UPDATE ImportJobs SET Status = ?, Message = ?, UpdatedAt = ?, Data = x''
+		WHERE Status IN (?, ?)`,
+		models.ImportFailed, message, time.Now().UTC().Format(time.RFC3339Nano),
+		models.ImportValidating, models.ImportCommitting,
+	)
+	if err != nil {
+		log.Error().Err(err).Msg("error failing running import jobs")
+		return 0, dbError(err, "error failing running import jobs")
+	}
+	n, err := result.RowsAffected()
+	return int(n), err
+}
+
+func scanImportJob(scan func(dest ...any) error, withData bool) (models.ImportJob, error) {
+	var (
+		job                  models.ImportJob
+		fileName, message    sql.NullString
+		mapping, errs        string
+		createdAt, updatedAt string
+	)
+	dest := []any{&job.JobID, &job.Entity, &job.Status, &fileName, &mapping,
+		&job.TotalRows, &job.ValidRows, &job.InvalidRows, &job.ImportedRows, &errs, &message,
+		&createdAt, &updatedAt}
+	if withData {
+		dest = append(dest, &job.Data)
+	}
+	if err := scan(dest...); err != nil {
+		return job, err
+	}
+	job.FileName, job.Message = fileName.String, message.String
+	_ = json.Unmarshal([]byte(mapping), &job.Mapping)
+	_ = json.Unmarshal([]byte(errs), &job.Errors)
+	job.CreatedAt, _ = time.Parse(time.RFC3339Nano, createdAt)
+	job.UpdatedAt, _ = time.Parse(time.RFC3339Nano, updatedAt)
+	return job, nil
+}
@@ -0,0 +1,292 @@
+package repositories
+
+import (
+	"context"
+	"northwind-api/internal/models"
+	"northwind-api/internal/odata"
+	"time"
+)
+
+// Interfaces yang dipakai oleh handlers. Implementasi SQL ada di file *_repo.go,
+// sedangkan fake in-memory untuk test ada di package repositories/memory.
+
+type CustomerStore interface {
+	GetAllCustomers(ctx context.Context) ([]models.Customer, error)
+	ListCustomers(ctx context.Context, f CustomerFilter) ([]models.Customer, int, error)
+	GetCustomerByID(ctx context.Context, id string) (models.Customer, error)
+	CreateCustomer(ctx context.Context, customer *models.Customer) (string, error)
+	UpdateCustomer(ctx context.Context, customer *models.Customer) error
+	PatchCustomer(ctx context.Context, id string, changes Changes) error
+	DeleteCustomer(ctx context.Context, id string) error
+	RestoreCustomer(ctx context.Context, id string) error
+	GetCustomersByIDs(ctx context.Context, ids []string) ([]models.Customer, error)
+}
+
+type EmployeeStore interface {
+	GetAllEmployees(ctx context.Context) ([]models.Employee, error)
+	GetEmployeeByID(ctx context.Context, id int) (models.Employee, error)
+	CreateEmployee(ctx context.Context, emp models.Employee) (int64, error)
+	UpdateEmployee(ctx context.Context, emp *models.Employee) error
+	PatchEmployee(ctx context.Context, id int, changes Changes) error
+	DeleteEmployee(ctx context.Context, id int) error
+	RestoreEmployee(ctx context.Context, id int) error
+	GetEmployeesByIDs(ctx context.Context, ids []int) ([]models.Employee, error)
+	GetEmployeePhoto(ctx context.Context, id int) ([]byte, error)
+	SetEmployeePhoto(ctx context.Context, id int, photo []byte) error
+}
+
+type ShipperStore interface {
+	GetAllShippers(ctx context.Context) ([]models.Shipper, error)
+	GetShipperByID(ctx context.Context, id int) (models.Shipper, error)
+	CreateShipper(ctx context.Context, shipper models.Shipper) (int64, error)
+	UpdateShipper(ctx context.Context, shipper *models.Shipper) error
+	PatchShipper(ctx context.Context, id int, changes Changes) error
+	DeleteShipper(ctx context.Context, id int) error
+	RestoreShipper(ctx context.Context, id int) error
+	GetShippersByIDs(ctx context.Context, ids []int) ([]models.Shipper, error)
+}
+
+type ProductStore interface {
+	GetAllProducts(ctx context.Context) ([]models.Product, error)
+	ListProducts(ctx context.Context, f ProductFilter) ([]models.Product, int, error)
+	GetProductByID(ctx context.Context, id int) (models.Product, error)
+	CreateProduct(ctx context.Context, p models.Product) (int64, error)
+	UpdateProduct(ctx context.Context, p *models.Product) error
+	PatchProduct(ctx context.Context, id int, changes Changes) error
+	DeleteProduct(ctx context.Context, id int) error
+	RestoreProduct(ctx context.Context, id int) error
+	GetSupplierByProductID(ctx context.Context, productID int) (models.ProductSupplier, error)
+	GetCategoryByProductID(ctx context.Context, productID int) (models.ProductCategory, error)
+	GetProductIDsBySupplierID(ctx context.Context, supplierID int) ([]int, error)
+	GetProductIDsByCategoryID(ctx context.Context, categoryID int) ([]int, error)
+	GetProductsByIDs(ctx context.Context, ids []int) ([]models.Product, error)
+	GetProductsBySupplierIDs(ctx context.Context, supplierIDs []int) ([]models.Product, error)
+	GetProductsByCategoryIDs(ctx context.Context, categoryIDs []int) ([]models.Product, error)
+}
+
+type CategoryStore interface {
+	GetAllCategories(ctx context.Context) ([]models.Category, error)
+	GetCategoryByID(ctx context.Context, id int) (models.Category, error)
+	CreateCategory(ctx context.Context, c *models.Category) (int64, error)
+	UpdateCategory(ctx context.Context, c *models.Category) error
+	PatchCategory(ctx context.Context, id int, changes Changes) error
+	DeleteCategory(ctx context.Context, id int) error
+	RestoreCategory(ctx context.Context, id int) error
+	GetCategoriesByIDs(ctx context.Context, ids []int) ([]models.Category, error)
+	GetCategoryPicture(ctx context.Context, id int) ([]byte, error)
+	SetCategoryPicture(ctx context.Context, id int, picture []byte) error
+}
+
+type SupplierStore interface {
+	GetAllSuppliers(ctx context.Context) ([]models.Supplier, error)
+	GetSupplierByID(ctx context.Context, id int) (models.Supplier, error)
+	CreateSupplier(ctx context.Context, s *models.Supplier) error
+	UpdateSupplier(ctx context.Context, s *models.Supplier) error
+	PatchSupplier(ctx context.Context, id int, changes Changes) error
+	DeleteSupplier(ctx context.Context, id int) error
+	RestoreSupplier(ctx context.Context, id int) error
+	GetSuppliersByIDs(ctx context.Context, ids []int) ([]models.Supplier, error)
+}
+
+type OrderStore interface {
+	GetAllOrders(ctx context.Context) ([]models.Order, error)
+	GetOrdersPage(ctx context.Context, page, pageSize int) (*models.Paginated[models.Order], error)
+	ListOrders(ctx context.Context, f OrderFilter) ([]models.Order, int, error)
+	GetOrderByID(ctx context.Context, id int) (models.Order, error)
+	CreateOrder(ctx context.Context, o *models.Order) (int64, error)
+	UpdateOrder(ctx context.Context, o *models.Order) error
+	PatchOrder(ctx context.Context, id int, changes Changes) error
+	DeleteOrder(ctx context.Context, id int) error
+	GetOrderDetailsByOrderID(ctx context.Context, orderID int) ([]models.OrderDetail, error)
+	DeleteOrderDetails(ctx context.Context, orderID int) error
+	MoveOrderDetails(ctx context.Context, fromOrderID, toOrderID int) error
+	GetOrderDetailsByOrderIDs(ctx context.Context, orderIDs []int) ([]models.OrderDetail, error)
+	GetOrdersByCustomerIDs(ctx context.Context, customerIDs []string) ([]models.Order, error)
+}
+
+type RegionStore interface {
+	GetAllRegions(ctx context.Context) ([]models.Region, error)
+	GetRegionsByID(ctx context.Context, id int) (*models.Region, error)
+	GetEmployeesByTerritoryID(ctx context.Context, id string) ([]models.Employee, error)
+	GetAllTerritories(ctx context.Context) ([]models.Territory, error)
+	GetTerritoriesByIDs(ctx context.Context, ids []string) ([]models.Territory, error)
+	GetEmployeeTerritoriesByEmployeeIDs(ctx context.Context, employeeIDs []int) ([]models.EmployeeTerritory, error)
+	GetEmployeeTerritoriesByTerritoryIDs(ctx context.Context, territoryIDs []string) ([]models.EmployeeTerritory, error)
+}
+
+type ReportStore interface {
+	GetTopCustomers(ctx context.Context) ([]models.TopCustomer, error)
+	GetTopProducts(ctx context.Context) ([]models.TopProduct, error)
+	GetSalesByCategory(ctx context.Context) ([]models.SalesByCategory, error)
+	GetSalesByEmployee(ctx context.Context) ([]models.SalesByEmployee, error)
+	GetSalesSummary(ctx context.Context) (models.SalesSummary, error)
+	GetMonthlySales(ctx context.Context) ([]models.MonthlySales, error)
+	GetInventoryStatus(ctx context.Context) ([]models.InventoryStatus, error)
+	GetTopSuppliers(ctx context.Context) ([]models.TopSupplier, error)
+	GetCustomerGrowth(ctx context.Context) ([]models.CustomerGrowth, error)
+	GetOrderStatusSummary(ctx context.Context) ([]models.OrderStatusSummary, error)
+	GetRegionSales(ctx context.Context) ([]models.RegionSales, error)
+	GetEmployeePerformance(ctx context.Context) ([]models.EmployeePerformance, error)
+	GetProductProfitability(ctx context.Context) ([]models.ProductProfitability, error)
+	GetAverageOrderValue(ctx context.Context) (models.AverageOrderValue, error)
+}
+
+// ImportJobStore menyimpan job import CSV beserta file dan laporannya.
+type ImportJobStore interface {
+	CreateImportJob(ctx context.Context, job *models.ImportJob) error
+	GetImportJob(ctx context.Context, id string) (models.ImportJob, error)
+	ListImportJobs(ctx context.Context) ([]models.ImportJob, error)
+	UpdateImportJob(ctx context.Context, job *models.ImportJob) error
+	TransitionImportJob(ctx context.Context, id, from, to string) error
+	// FailRunningImportJobs menandai job validating/committing sebagai failed
+	// dengan message, dan mengembalikan jumlahnya.
+	FailRunningImportJobs(ctx context.Context, message string) (int, error)
+}
+
+// AuditStore menyimpan audit log perubahan data.
+type AuditStore interface {
+	RecordAudit(ctx context.Context, entries ...models.AuditEntry) error
+	ListAudit(ctx context.Context, f AuditFilter) ([]models.AuditEntry, error)
+	// RedactAudit menghapus nilai fields dari riwayat entity/id (lihat Erased).
+	RedactAudit(ctx context.Context, entity, id string, fields []string) error
+}
+
+// EventLogStore menyimpan domain event yang sudah di-commit untuk stream dan resume.
+type EventLogStore interface {
+	AppendEvents(ctx context.Context, events ...models.ChangeEvent) error
+	ListEvents(ctx context.Context, f EventFilter) ([]models.ChangeEvent, error)
+	LatestEventID(ctx context.Context) (int64, error)
+	RedactEvents(ctx context.Context, entity, id string, fields []string) error
+}
+
+// WebhookStore menyimpan subscription webhook, outbox pengiriman dan log percobaannya.
+type WebhookStore interface {
+	CreateWebhook(ctx context.Context, w *models.Webhook) error
+	GetWebhook(ctx context.Context, id int) (models.Webhook, error)
+	ListWebhooks(ctx context.Context) ([]models.Webhook, error)
+	UpdateWebhook(ctx context.Context, w *models.Webhook) error
+	DeleteWebhook(ctx context.Context, id int) error
+
+	EnqueueDeliveries(ctx context.Context, deliveries ...models.WebhookDelivery) error
+	DueDeliveries(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, f DeliveryFilter) ([]models.WebhookDelivery, error)
+	GetDelivery(ctx context.Context, id int64) (models.WebhookDelivery, error)
+	RecordAttempt(ctx context.Context, d models.WebhookDelivery, a models.WebhookAttempt) error
+	RequeueDelivery(ctx context.Context, id int64, at time.Time) error
+	RedactDeliveries(ctx context.Context, entity, id string, fields []string) error
+}
+
+// IdempotencyStore menyimpan response POST per Idempotency-Key.
+type IdempotencyStore interface {
+	ReserveIdempotencyKey(ctx context.Context, rec models.IdempotencyRecord) error
+	GetIdempotencyKey(ctx context.Context, key string) (models.IdempotencyRecord, error)
+	CompleteIdempotencyKey(ctx context.Context, key string, status int, contentType string, body []byte) error
+	ReleaseIdempotencyKey(ctx context.Context, key string) error
+	// PurgeIdempotencyResponses menghapus record yang response-nya memuat
+	// salah satu potongan teks (dipakai saat data pribadi di-erase).
+	PurgeIdempotencyResponses(ctx context.Context, contains ...string) error
+}
+
+// ODataStore menjalankan statement SQL hasil terjemahan query OData.
+type ODataStore interface {
+	QueryOData(ctx context.Context, s odata.Statement) ([][]any, error)
+	CountOData(ctx context.Context, s odata.Statement) (int64, error)
+}
+
+// SearchTerm adalah satu kata pencarian; Prefix true juga mencocokkan kata
+// yang diawali Word.
+type SearchTerm struct {
+	Word   string
+	Prefix bool
+}
+
+// SearchQuery: setiap elemen Terms harus cocok (AND), dengan salah satu
+// alternatifnya (OR, mis. ejaan yang mirip). Entities kosong berarti semua.
+type SearchQuery struct {
+	Terms    [][]SearchTerm
+	Entities []string
+	Limit    int
+}
+
+// SearchStore menyimpan index full-text customers, products dan suppliers.
+type SearchStore interface {
+	IndexDocuments(ctx context.Context, docs ...models.SearchDocument) error
+	RemoveDocument(ctx context.Context, entity, id string) error
+	// Search mengembalikan hit terurut dari yang paling relevan.
+	Search(ctx context.Context, q SearchQuery) ([]models.SearchHit, error)
+	// SearchTerms mengembalikan kata di index yang panjangnya minimal minLen.
+	SearchTerms(ctx context.Context, minLen int) ([]string, error)
+}
+
+// Repositories mengumpulkan semua store supaya bisa di-inject sekaligus
+// (SQL di production, memory di test).
+type Repositories struct {
+	Customers  CustomerStore
+	Employees  EmployeeStore
+	Shippers   ShipperStore
+	Products   ProductStore
+	Categories CategoryStore
+	Suppliers  SupplierStore
+	Orders     OrderStore
+	Regions    RegionStore
+	Reports    ReportStore
+	// Audit ditulis services di dalam transaksi; boleh nil (audit dimatikan).
+	Audit AuditStore
+	// EventLog ditulis services di dalam transaksi; boleh nil (stream dimatikan).
+	EventLog EventLogStore
+	// Webhooks menerima outbox delivery di dalam transaksi; boleh nil (webhook dimatikan).
+	Webhooks WebhookStore
+	// Search diperbarui services di dalam transaksi; boleh nil (pencarian dimatikan).
+	Search SearchStore
+	// Idempotency dipakai middleware, di luar transaksi services.
+	Idempotency IdempotencyStore
+	// Imports dipakai job import, di luar transaksi services.
+	Imports ImportJobStore
+	// OData menjalankan query /odata langsung di SQL; nil di memory store
+	// (endpoint /odata tidak dipasang).
+	OData ODataStore
+}
+
+// NewSQLRepositories membangun semua repository berbasis database/sql.
+func NewSQLRepositories(db DBTX) Repositories {
+	return Repositories{
+		Customers:  &CustomerRepository{DB: db},
+		Employees:  &EmployeeRepository{DB: db},
+		Shippers:   &ShipperRepository{DB: db},
+		Products:   &ProductRepository{DB: db},
+		Categories: &CategoryRepository{DB: db},
+		Suppliers:  &SupplierRepository{DB: db},
+		Orders:     &OrderRepository{DB: db},
+		Regions:    &RegionRepository{DB: db},
+		Reports:    &ReportRepository{DB: db},
+		Audit:      &AuditRepository{DB: db},
+		EventLog:   &EventLogRepository{DB: db},
+		Webhooks:   &WebhookRepository{DB: db},
+		Search:     &SearchRepository{DB: db},
+
+		Idempotency: &IdempotencyRepository{DB: db},
+		Imports:     &ImportJobRepository{DB: db},
+		OData:       &ODataRepository{DB: db},
+	}
+}
+
+var (
+	_ CustomerStore = (*CustomerRepository)(nil)
+	_ EmployeeStore = (*EmployeeRepository)(nil)
+	_ ShipperStore  = (*ShipperRepository)(nil)
+	_ ProductStore  = (*ProductRepository)(nil)
+	_ CategoryStore = (*CategoryRepository)(nil)
+	_ SupplierStore = (*SupplierRepository)(nil)
+	_ OrderStore    = (*OrderRepository)(nil)
+	_ RegionStore   = (*RegionRepository)(nil)
+	_ AuditStore    = (*AuditRepository)(nil)
+	_ EventLogStore = (*EventLogRepository)(nil)
+	_ WebhookStore  = (*WebhookRepository)(nil)
+	_ ReportStore   = (*ReportRepository)(nil)
+	_ SearchStore   = (*SearchRepository)(nil)
+
+	_ IdempotencyStore = (*IdempotencyRepository)(nil)
+	_ ImportJobStore   = (*ImportJobRepository)(nil)
+	_ ODataStore       = (*ODataRepository)(nil)
+	_ TxRunner         = (*SQLTxRunner)(nil)
+)
@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// conditions menyusun klausa WHERE dari argumen filter list (semua harus
+// terpenuhi), supaya filter dan paginasi dijalankan database, bukan di memori.
+type conditions struct {
+	clauses []string
+	args    []any
+}
+
+func (c *conditions) add(clause string, args ...any) {
+	c.clauses = append(c.clauses, clause)
+	c.args = append(c.args, args...)
+}
+
+// contains menambahkan pencocokan potongan teks tanpa memperhatikan huruf
+// besar/kecil; % dan _ di substr dicocokkan apa adanya.
+func (c *conditions) contains(column, substr string) {
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(substr)
+	c.add(column+` LIKE ? ESCAPE '\'`, "%"+escaped+"%")
+}
+
+func (c *conditions) where() string {
+	if len(c.clauses) == 0 {
+		return "1 = 1"
+	}
+	return strings.Join(c.clauses, " AND ")
+}
+
+// page mengembalikan kondisi WHERE beserta ORDER BY orderBy dan LIMIT/OFFSET,
+// siap dipakai fungsi list*. Limit 0 berarti tanpa batas.
+func (c *conditions) page(orderBy string, limit, offset int) (string, []any) {
+	if limit <= 0 {
+		limit = -1
+	}
+	args := append(append([]any(nil), c.args...), limit, max(offset, 0))
+	return c.where() + " ORDER BY " + orderBy + " LIMIT ? OFFSET ?", args
+}
+
+// count menghitung baris table yang memenuhi kondisi.
+func (c *conditions) count(ctx context.Context, db DBTX, table string) (int, error) {
+	var total int
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+table+" WHERE "+c.where(), c.args...).Scan(&total); err != nil {
+		log.Error().Err(err).Str("table", table).Msg("error counting rows")
+		return 0, fmt.Errorf("error counting %s: %w", strings.ToLower(table), err)
+	}
+	return total, nil
+}
@@ -0,0 +1,51 @@
+package memory
+
+import (
+	"context"
+	"northwind-api/internal/models"
+	"northwind-api/internal/repositories"
+	"sync"
+)
+
+type AuditRepository struct {
+	mu      sync.Mutex
+	entries []models.AuditEntry
+}
+
+func NewAuditRepository() *AuditRepository {
+	return &AuditRepository{}
+}
+
+func (r *AuditRepository) RecordAudit(ctx context.Context, entries ...models.AuditEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, e := range entries {
+		e.AuditID = int64(len(r.entries) + 1)
+		r.entries = append(r.entries, e)
+	}
+	return nil
+}
+
+func (r *AuditRepository) ListAudit(ctx context.Context, f repositories.AuditFilter) ([]models.AuditEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := []models.AuditEntry{}
+	for i := len(r.entries) - 1; i >= 0 && (f.Limit <= 0 || len(out) < f.Limit); i-- {
+		e := r.entries[i]
+		if (f.Entity == "" || e.Entity == f.Entity) && (f.EntityID == "" || e.EntityID == f.EntityID) {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func (r *AuditRepository) RedactAudit(ctx context.Context, entity, id string, fields []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, e := range r.entries {
+		if e.Entity == entity && e.EntityID == id {
+			repositories.RedactChanges(e.Changes, fields)
+		}
+	}
+	return nil
+}
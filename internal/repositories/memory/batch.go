@@ -0,0 +1,27 @@
+package memory
+
+import "sort"
+
+// pick mengambil baris untuk setiap kunci yang ada (termasuk yang sudah
+// dihapus), meniru query "WHERE kolom IN (...)" versi SQL.
+func pick[K comparable, V any](rows map[K]V, ids []K, less func(a, b V) bool) []V {
+	var out []V
+	seen := map[K]bool{}
+	for _, id := range ids {
+		if v, ok := rows[id]; ok && !seen[id] {
+			seen[id] = true
+			out = append(out, v)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return less(out[i], out[j]) })
+	return out
+}
+
+// widen mengubah daftar ID int menjadi int64 untuk map yang dikunci int64.
+func widen(ids []int) []int64 {
+	out := make([]int64, len(ids))
+	for i, id := range ids {
+		out[i] = int64(id)
+	}
+	return out
+}
@@ -0,0 +1,143 @@
+package memory
+
+import (
+	"context"
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/models"
+	"northwind-api/internal/repositories"
+	"sort"
+	"sync"
+)
+
+type CategoryRepository struct {
+	mu     sync.RWMutex
+	rows   map[int64]models.Category
+	nextID int64
+}
+
+func NewCategoryRepository() *CategoryRepository {
+	return &CategoryRepository{rows: map[int64]models.Category{}, nextID: 1}
+}
+
+func (r *CategoryRepository) Seed(categories ...models.Category) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, c := range categories {
+		r.rows[c.CategoryID] = c
+		if c.CategoryID >= r.nextID {
+			r.nextID = c.CategoryID + 1
+		}
+	}
+}
+
+func (r *CategoryRepository) GetAllCategories(ctx context.Context) ([]models.Category, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]models.Category, 0, len(r.rows))
+	for _, c := range r.rows {
+		if !visible(ctx, c.DeletedAt) {
+			continue
+		}
+		c.Picture = nil
+		out = append(out, c)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CategoryID < out[j].CategoryID })
+	return out, nil
+}
+
+func (r *CategoryRepository) GetCategoryByID(ctx context.Context, id int) (models.Category, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	c, ok := r.rows[int64(id)]
+	if !ok || !visible(ctx, c.DeletedAt) {
+		return models.Category{}, apperr.NotFound("category not found")
+	}
+	c.Picture = nil
+	return c, nil
+}
+
+func (r *CategoryRepository) CreateCategory(ctx context.Context, c *models.Category) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c.CategoryID = r.nextID
+	r.nextID++
+	r.rows[c.CategoryID] = *c
+	return c.CategoryID, nil
+}
+
+func (r *CategoryRepository) UpdateCategory(ctx context.Context, c *models.Category) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cur, ok := r.rows[c.CategoryID]
+	if !ok || cur.DeletedAt != nil {
+		return apperr.NotFound("category not found")
+	}
+	row := *c
+	row.Picture = cur.Picture // sama seperti versi SQL: Picture hanya lewat SetCategoryPicture
+	r.rows[c.CategoryID] = row
+	return nil
+}
+
+func (r *CategoryRepository) DeleteCategory(ctx context.Context, id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	row, ok := r.rows[int64(id)]
+	if !ok || row.DeletedAt != nil {
+		return apperr.NotFound("category not found")
+	}
+	row.DeletedAt = deletedNow()
+	r.rows[int64(id)] = row
+	return nil
+}
+
+func (r *CategoryRepository) PatchCategory(ctx context.Context, id int, changes repositories.Changes) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	row, ok := r.rows[int64(id)]
+	if !ok || !visible(ctx, row.DeletedAt) {
+		return apperr.NotFound("no category found with ID %d", id)
+	}
+	applyChanges(&row, changes)
+	r.rows[int64(id)] = row
+	return nil
+}
+
+func (r *CategoryRepository) RestoreCategory(ctx context.Context, id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	row, ok := r.rows[int64(id)]
+	if !ok || row.DeletedAt == nil {
+		return apperr.NotFound("no deleted category found with ID %d", id)
+	}
+	row.DeletedAt = nil
+	r.rows[int64(id)] = row
+	return nil
+}
+
+func (r *CategoryRepository) GetCategoriesByIDs(ctx context.Context, ids []int) ([]models.Category, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return pick(r.rows, widen(ids), func(a, b models.Category) bool { return a.CategoryID < b.CategoryID }), nil
+}
+
+func (r *CategoryRepository) GetCategoryPicture(ctx context.Context, id int) ([]byte, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	c, ok := r.rows[int64(id)]
+	if !ok || !visible(ctx, c.DeletedAt) {
+		return nil, apperr.NotFound("no category found with ID %d", id)
+	}
+	return c.Picture, nil
+}
+
+func (r *CategoryRepository) SetCategoryPicture(ctx context.Context, id int, picture []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.rows[int64(id)]
+	if !ok || !visible(ctx, c.DeletedAt) {
+		return apperr.NotFound("no category found with ID %d", id)
+	}
+	c.Picture = picture
+	r.rows[int64(id)] = c
+	return nil
+}
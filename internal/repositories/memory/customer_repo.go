@@ -0,0 +1,129 @@
+package memory
+
+import (
+	"context"
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/models"
+	"northwind-api/internal/repositories"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type CustomerRepository struct {
+	mu   sync.RWMutex
+	rows map[string]models.Customer
+}
+
+func NewCustomerRepository() *CustomerRepository {
+	return &CustomerRepository{rows: map[string]models.Customer{}}
+}
+
+// Seed menaruh data awal apa adanya (ID tidak di-generate).
+func (r *CustomerRepository) Seed(customers ...models.Customer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, c := range customers {
+		r.rows[c.CustomerID] = c
+	}
+}
+
+func (r *CustomerRepository) GetAllCustomers(ctx context.Context) ([]models.Customer, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]models.Customer, 0, len(r.rows))
+	for _, c := range r.rows {
+		if !visible(ctx, c.DeletedAt) {
+			continue
+		}
+		out = append(out, c)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CustomerID < out[j].CustomerID })
+	return out, nil
+}
+
+func (r *CustomerRepository) ListCustomers(ctx context.Context, f repositories.CustomerFilter) ([]models.Customer, int, error) {
+	all, _ := r.GetAllCustomers(ctx)
+	out := all[:0]
+	for _, c := range all {
+		if (f.Country == "" || strings.EqualFold(c.Country, f.Country)) &&
+			(f.City == "" || strings.EqualFold(c.City, f.City)) &&
+			(f.Search == "" || containsFold(c.CompanyName, f.Search)) {
+			out = append(out, c)
+		}
+	}
+	rows, total := page(out, f.Limit, f.Offset)
+	return rows, total, nil
+}
+
+func (r *CustomerRepository) GetCustomerByID(ctx context.Context, id string) (models.Customer, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	c, ok := r.rows[id]
+	if !ok || !visible(ctx, c.DeletedAt) {
+		return models.Customer{}, apperr.NotFound("customer with ID %s not found", id)
+	}
+	return c, nil
+}
+
+func (r *CustomerRepository) CreateCustomer(ctx context.Context, customer *models.Customer) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.rows[customer.CustomerID]; ok {
+		return "", apperr.Conflict("error creating customer: record already exists")
+	}
+	r.rows[customer.CustomerID] = *customer
+	return customer.CustomerID, nil
+}
+
+func (r *CustomerRepository) UpdateCustomer(ctx context.Context, customer *models.Customer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if cur, ok := r.rows[customer.CustomerID]; !ok || cur.DeletedAt != nil {
+		return apperr.NotFound("no customer found with ID %s", customer.CustomerID)
+	}
+	r.rows[customer.CustomerID] = *customer
+	return nil
+}
+
+func (r *CustomerRepository) DeleteCustomer(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	row, ok := r.rows[id]
+	if !ok || row.DeletedAt != nil {
+		return apperr.NotFound("no customer found with ID %s", id)
+	}
+	row.DeletedAt = deletedNow()
+	r.rows[id] = row
+	return nil
+}
+
+func (r *CustomerRepository) PatchCustomer(ctx context.Context, id string, changes repositories.Changes) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	row, ok := r.rows[id]
+	if !ok || !visible(ctx, row.DeletedAt) {
+		return apperr.NotFound("no customer found with ID %s", id)
+	}
+	applyChanges(&row, changes)
+	r.rows[id] = row
+	return nil
+}
+
+func (r *CustomerRepository) RestoreCustomer(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	row, ok := r.rows[id]
+	if !ok || row.DeletedAt == nil {
+		return apperr.NotFound("no deleted customer found with ID %s", id)
+	}
+	row.DeletedAt = nil
+	r.rows[id] = row
+	return nil
+}
+
+func (r *CustomerRepository) GetCustomersByIDs(ctx context.Context, ids []string) ([]models.Customer, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return pick(r.rows, ids, func(a, b models.Customer) bool { return a.CustomerID < b.CustomerID }), nil
+}
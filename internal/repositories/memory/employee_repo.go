@@ -0,0 +1,143 @@
+package memory
+
+import (
+	"context"
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/models"
+	"northwind-api/internal/repositories"
+	"sort"
+	"sync"
+)
+
+type EmployeeRepository struct {
+	mu     sync.RWMutex
+	rows   map[int]models.Employee
+	nextID int
+}
+
+func NewEmployeeRepository() *EmployeeRepository {
+	return &EmployeeRepository{rows: map[int]models.Employee{}, nextID: 1}
+}
+
+func (r *EmployeeRepository) Seed(employees ...models.Employee) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, e := range employees {
+		r.rows[e.EmployeeID] = e
+		if e.EmployeeID >= r.nextID {
+			r.nextID = e.EmployeeID + 1
+		}
+	}
+}
+
+func (r *EmployeeRepository) GetAllEmployees(ctx context.Context) ([]models.Employee, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]models.Employee, 0, len(r.rows))
+	for _, e := range r.rows {
+		if !visible(ctx, e.DeletedAt) {
+			continue
+		}
+		e.Photo = nil // sama seperti versi SQL: list tidak memuat Photo
+		out = append(out, e)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].EmployeeID < out[j].EmployeeID })
+	return out, nil
+}
+
+func (r *EmployeeRepository) GetEmployeeByID(ctx context.Context, id int) (models.Employee, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	e, ok := r.rows[id]
+	if !ok || !visible(ctx, e.DeletedAt) {
+		return models.Employee{}, apperr.NotFound("employee with ID %d not found", id)
+	}
+	e.Photo = nil
+	return e, nil
+}
+
+func (r *EmployeeRepository) CreateEmployee(ctx context.Context, emp models.Employee) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	emp.EmployeeID = r.nextID
+	r.nextID++
+	r.rows[emp.EmployeeID] = emp
+	return int64(emp.EmployeeID), nil
+}
+
+func (r *EmployeeRepository) UpdateEmployee(ctx context.Context, emp *models.Employee) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cur, ok := r.rows[emp.EmployeeID]
+	if !ok || cur.DeletedAt != nil {
+		return apperr.NotFound("no employee found with ID %d", emp.EmployeeID)
+	}
+	row := *emp
+	row.Photo = cur.Photo // sama seperti versi SQL: Photo hanya lewat SetEmployeePhoto
+	r.rows[emp.EmployeeID] = row
+	return nil
+}
+
+func (r *EmployeeRepository) DeleteEmployee(ctx context.Context, id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	row, ok := r.rows[id]
+	if !ok || row.DeletedAt != nil {
+		return apperr.NotFound("no employee found with ID %d", id)
+	}
+	row.DeletedAt = deletedNow()
+	r.rows[id] = row
+	return nil
+}
+
+func (r *EmployeeRepository) PatchEmployee(ctx context.Context, id int, changes repositories.Changes) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	row, ok := r.rows[id]
+	if !ok || !visible(ctx, row.DeletedAt) {
+		return apperr.NotFound("no employee found with ID %d", id)
+	}
+	applyChanges(&row, changes)
+	r.rows[id] = row
+	return nil
+}
+
+func (r *EmployeeRepository) RestoreEmployee(ctx context.Context, id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	row, ok := r.rows[id]
+	if !ok || row.DeletedAt == nil {
+		return apperr.NotFound("no deleted employee found with ID %d", id)
+	}
+	row.DeletedAt = nil
+	r.rows[id] = row
+	return nil
+}
+
+func (r *EmployeeRepository) GetEmployeesByIDs(ctx context.Context, ids []int) ([]models.Employee, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return pick(r.rows, ids, func(a, b models.Employee) bool { return a.EmployeeID < b.EmployeeID }), nil
+}
+
+func (r *EmployeeRepository) GetEmployeePhoto(ctx context.Context, id int) ([]byte, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	e, ok := r.rows[id]
+	if !ok || !visible(ctx, e.DeletedAt) {
+		return nil, apperr.NotFound("no employee found with ID %d", id)
+	}
+	return e.Photo, nil
+}
+
+func (r *EmployeeRepository) SetEmployeePhoto(ctx context.Context, id int, photo []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	e, ok := r.rows[id]
+	if !ok || !visible(ctx, e.DeletedAt) {
+		return apperr.NotFound("no employee found with ID %d", id)
+	}
+	e.Photo = photo
+	r.rows[id] = e
+	return nil
+}
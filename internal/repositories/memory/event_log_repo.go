@@ -0,0 +1,69 @@
+package memory
+
+import (
+	"context"
+	"northwind-api/internal/models"
+	"northwind-api/internal/repositories"
+	"slices"
+	"sync"
+)
+
+type EventLogRepository struct {
+	mu     sync.Mutex
+	events []models.ChangeEvent
+}
+
+func NewEventLogRepository() *EventLogRepository {
+	return &EventLogRepository{}
+}
+
+func (r *EventLogRepository) AppendEvents(ctx context.Context, events ...models.ChangeEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, e := range events {
+		e.EventID = int64(len(r.events) + 1)
+		r.events = append(r.events, e)
+	}
+	return nil
+}
+
+func (r *EventLogRepository) ListEvents(ctx context.Context, f repositories.EventFilter) ([]models.ChangeEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := []models.ChangeEvent{}
+	for _, e := range r.events {
+		if len(out) == f.Limit {
+			break
+		}
+		if e.EventID > f.AfterID && (len(f.Entities) == 0 || slices.Contains(f.Entities, e.Entity)) {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func (r *EventLogRepository) LatestEventID(ctx context.Context) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return int64(len(r.events)), nil
+}
+
+func (r *EventLogRepository) RedactEvents(ctx context.Context, entity, id string, fields []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, e := range r.events {
+		if e.Entity != entity || e.EntityID != id {
+			continue
+		}
+		before, _, err := repositories.RedactJSON(e.Before, fields)
+		if err != nil {
+			return err
+		}
+		after, _, err := repositories.RedactJSON(e.After, fields)
+		if err != nil {
+			return err
+		}
+		r.events[i].Before, r.events[i].After = before, after
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/models"
+	"sync"
+)
+
+type IdempotencyRepository struct {
+	mu   sync.Mutex
+	rows map[string]models.IdempotencyRecord
+}
+
+func NewIdempotencyRepository() *IdempotencyRepository {
+	return &IdempotencyRepository{rows: map[string]models.IdempotencyRecord{}}
+}
+
+func (r *IdempotencyRepository) ReserveIdempotencyKey(ctx context.Context, rec models.IdempotencyRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for k, existing := range r.rows {
+		if !existing.ExpiresAt.After(rec.CreatedAt) {
+			delete(r.rows, k)
+		}
+	}
+	if _, ok := r.rows[rec.Key]; ok {
+		return apperr.Conflict("error reserving idempotency key: record already exists")
+	}
+	rec.StatusCode = 0
+	r.rows[rec.Key] = rec
+	return nil
+}
+
+func (r *IdempotencyRepository) GetIdempotencyKey(ctx context.Context, key string) (models.IdempotencyRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rec, ok := r.rows[key]
+	if !ok {
+		return rec, apperr.NotFound("idempotency key %s not found", key)
+	}
+	return rec, nil
+}
+
+func (r *IdempotencyRepository) CompleteIdempotencyKey(ctx context.Context, key string, status int, contentType string, body []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rec, ok := r.rows[key]
+	if !ok {
+		return apperr.NotFound("idempotency key %s not found", key)
+	}
+	rec.StatusCode, rec.ContentType, rec.Body = status, contentType, body
+	r.rows[key] = rec
+	return nil
+}
+
+func (r *IdempotencyRepository) ReleaseIdempotencyKey(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.rows, key)
+	return nil
+}
+
+func (r *IdempotencyRepository) PurgeIdempotencyResponses(ctx context.Context, contains ...string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for k, rec := range r.rows {
+		for _, text := range contains {
+			if bytes.Contains(rec.Body, []byte(text)) {
+				delete(r.rows, k)
+				break
+			}
+		}
+	}
+	return nil
+}
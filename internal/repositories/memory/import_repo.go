@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"context"
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/models"
+	"sort"
+	"sync"
+	"time"
+)
+
+type ImportJobRepository struct {
+	mu   sync.Mutex
+	rows map[string]models.ImportJob
+}
+
+func NewImportJobRepository() *ImportJobRepository {
+	return &ImportJobRepository{rows: map[string]models.ImportJob{}}
+}
+
+func (r *ImportJobRepository) CreateImportJob(ctx context.Context, job *models.ImportJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.rows[job.JobID]; ok {
+		return apperr.Conflict("error creating import job: record already exists")
+	}
+	r.rows[job.JobID] = *job
+	return nil
+}
+
+func (r *ImportJobRepository) GetImportJob(ctx context.Context, id string) (models.ImportJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job, ok := r.rows[id]
+	if !ok {
+		return job, apperr.NotFound("import job %s not found", id)
+	}
+	return job, nil
+}
+
+func (r *ImportJobRepository) ListImportJobs(ctx context.Context) ([]models.ImportJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	jobs := make([]models.ImportJob, 0, len(r.rows))
+	for _, job := range r.rows {
+		job.Data = nil
+		jobs = append(jobs, job)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].CreatedAt.After(jobs[j].CreatedAt) })
+	return jobs, nil
+}
+
+func (r *ImportJobRepository) UpdateImportJob(ctx context.Context, job *models.ImportJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	existing, ok := r.rows[job.JobID]
+	if !ok {
+		return apperr.NotFound("import job %s not found", job.JobID)
+	}
+	updated := *job
+	updated.Entity, updated.FileName, updated.Mapping, updated.Data = existing.Entity, existing.FileName, existing.Mapping, existing.Data
+	if updated.Finished() {
+		updated.Data = nil
+	}
+	updated.CreatedAt = existing.CreatedAt
+	r.rows[job.JobID] = updated
+	return nil
+}
+
+func (r *ImportJobRepository) TransitionImportJob(ctx context.Context, id, from, to string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job, ok := r.rows[id]
+	if !ok {
+		return apperr.NotFound("import job %s not found", id)
+	}
+	if job.Status != from {
+		return apperr.Conflict("import job %s is not %s", id, from)
+	}
+	job.Status, job.UpdatedAt = to, time.Now().UTC()
+	r.rows[id] = job
+	return nil
+}
+
+func (r *ImportJobRepository) FailRunningImportJobs(ctx context.Context, message string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for id, job := range r.rows {
+		if job.Status == models.ImportValidating || job.Status == models.ImportCommitting {
+			job.Status, job.Message, job.UpdatedAt = models.ImportFailed, message, time.Now().UTC()
+			job.Data = nil
+			r.rows[id] = job
+			n++
+		}
+	}
+	return n, nil
+}
@@ -0,0 +1,18 @@
+package memory
+
+import "strings"
+
+// page memotong rows sesuai limit/offset seperti LIMIT ... OFFSET ... di SQL
+// (limit 0 berarti tanpa batas) dan mengembalikan jumlah rows sebelum dipotong.
+func page[T any](rows []T, limit, offset int) ([]T, int) {
+	start := min(max(offset, 0), len(rows))
+	end := len(rows)
+	if limit > 0 {
+		end = min(start+limit, end)
+	}
+	return rows[start:end], len(rows)
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
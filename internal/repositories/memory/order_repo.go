@@ -0,0 +1,214 @@
+package memory
+
+import (
+	"context"
+	"math"
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/models"
+	"northwind-api/internal/repositories"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type OrderRepository struct {
+	mu      sync.RWMutex
+	rows    map[int64]models.Order
+	details map[int64][]models.OrderDetail
+	nextID  int64
+}
+
+func NewOrderRepository() *OrderRepository {
+	return &OrderRepository{
+		rows:    map[int64]models.Order{},
+		details: map[int64][]models.OrderDetail{},
+		nextID:  1,
+	}
+}
+
+func (r *OrderRepository) Seed(orders ...models.Order) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, o := range orders {
+		r.rows[o.OrderID] = o
+		if o.OrderID >= r.nextID {
+			r.nextID = o.OrderID + 1
+		}
+	}
+}
+
+func (r *OrderRepository) SeedDetails(details ...models.OrderDetail) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, d := range details {
+		r.details[d.OrderID] = append(r.details[d.OrderID], d)
+	}
+}
+
+func (r *OrderRepository) sorted() []models.Order {
+	out := make([]models.Order, 0, len(r.rows))
+	for _, o := range r.rows {
+		out = append(out, o)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].OrderID < out[j].OrderID })
+	return out
+}
+
+func (r *OrderRepository) GetAllOrders(ctx context.Context) ([]models.Order, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.sorted(), nil
+}
+
+func (r *OrderRepository) ListOrders(ctx context.Context, f repositories.OrderFilter) ([]models.Order, int, error) {
+	r.mu.RLock()
+	all := r.sorted()
+	r.mu.RUnlock()
+	out := all[:0]
+	for _, o := range all {
+		if (f.CustomerID == "" || o.CustomerID != nil && *o.CustomerID == f.CustomerID) &&
+			(f.EmployeeID == 0 || o.EmployeeID != nil && *o.EmployeeID == f.EmployeeID) &&
+			(f.ShipperID == 0 || o.ShipVia != nil && *o.ShipVia == f.ShipperID) &&
+			(f.ShipCountry == "" || o.ShipCountry != nil && strings.EqualFold(*o.ShipCountry, f.ShipCountry)) &&
+			(f.Shipped == nil || (o.ShippedDate != nil && *o.ShippedDate != "") == *f.Shipped) {
+			out = append(out, o)
+		}
+	}
+	rows, total := page(out, f.Limit, f.Offset)
+	return rows, total, nil
+}
+
+func (r *OrderRepository) GetOrdersPage(ctx context.Context, page, pageSize int) (*models.Paginated[models.Order], error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	r.mu.RLock()
+	all := r.sorted()
+	r.mu.RUnlock()
+
+	total := len(all)
+	start := min((page-1)*pageSize, total)
+	end := min(start+pageSize, total)
+	totalPages := int(math.Ceil(float64(total) / float64(pageSize)))
+	return &models.Paginated[models.Order]{
+		Items:      all[start:end],
+		Page:       page,
+		PageSize:   pageSize,
+		TotalItems: total,
+		TotalPages: totalPages,
+		HasNext:    page < totalPages,
+		HasPrev:    page > 1,
+	}, nil
+}
+
+func (r *OrderRepository) GetOrderByID(ctx context.Context, id int) (models.Order, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	o, ok := r.rows[int64(id)]
+	if !ok {
+		return models.Order{}, apperr.NotFound("order not found")
+	}
+	return o, nil
+}
+
+func (r *OrderRepository) CreateOrder(ctx context.Context, o *models.Order) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	o.OrderID = r.nextID
+	r.nextID++
+	r.rows[o.OrderID] = *o
+	return o.OrderID, nil
+}
+
+func (r *OrderRepository) UpdateOrder(ctx context.Context, o *models.Order) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.rows[o.OrderID]; !ok {
+		return apperr.NotFound("order with ID %d not found", o.OrderID)
+	}
+	r.rows[o.OrderID] = *o
+	return nil
+}
+
+func (r *OrderRepository) DeleteOrder(ctx context.Context, id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.rows[int64(id)]; !ok {
+		return apperr.NotFound("order with ID %d not found", id)
+	}
+	delete(r.rows, int64(id))
+	return nil
+}
+
+func (r *OrderRepository) GetOrderDetailsByOrderID(ctx context.Context, orderID int) ([]models.OrderDetail, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]models.OrderDetail(nil), r.details[int64(orderID)]...), nil
+}
+
+func (r *OrderRepository) PatchOrder(ctx context.Context, id int, changes repositories.Changes) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	row, ok := r.rows[int64(id)]
+	if !ok {
+		return apperr.NotFound("no order found with ID %d", id)
+	}
+	applyChanges(&row, changes)
+	r.rows[int64(id)] = row
+	return nil
+}
+
+func (r *OrderRepository) DeleteOrderDetails(ctx context.Context, orderID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.details, int64(orderID))
+	return nil
+}
+
+func (r *OrderRepository) MoveOrderDetails(ctx context.Context, fromOrderID, toOrderID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	from, to := int64(fromOrderID), int64(toOrderID)
+	for _, d := range r.details[from] {
+		for _, existing := range r.details[to] {
+			if existing.ProductID == d.ProductID {
+				return apperr.Conflict("error moving order details: record already exists")
+			}
+		}
+	}
+	for _, d := range r.details[from] {
+		d.OrderID = to
+		r.details[to] = append(r.details[to], d)
+	}
+	delete(r.details, from)
+	return nil
+}
+
+func (r *OrderRepository) GetOrderDetailsByOrderIDs(ctx context.Context, orderIDs []int) ([]models.OrderDetail, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var out []models.OrderDetail
+	for _, o := range pick(r.rows, widen(orderIDs), func(a, b models.Order) bool { return a.OrderID < b.OrderID }) {
+		out = append(out, r.details[o.OrderID]...)
+	}
+	return out, nil
+}
+
+func (r *OrderRepository) GetOrdersByCustomerIDs(ctx context.Context, customerIDs []string) ([]models.Order, error) {
+	want := map[string]bool{}
+	for _, id := range customerIDs {
+		want[id] = true
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var out []models.Order
+	for _, o := range r.sorted() {
+		if o.CustomerID != nil && want[*o.CustomerID] {
+			out = append(out, o)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,28 @@
+package memory
+
+import (
+	"reflect"
+	"strings"
+
+	"northwind-api/internal/repositories"
+)
+
+// applyChanges menyalin nilai di changes ke field dst yang tag db-nya cocok,
+// meniru UPDATE ... SET kolom = ? pada repository SQL.
+func applyChanges(dst any, changes repositories.Changes) {
+	v := reflect.ValueOf(dst).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		col, _, _ := strings.Cut(t.Field(i).Tag.Get("db"), ",")
+		val, ok := changes[col]
+		if !ok {
+			continue
+		}
+		f := v.Field(i)
+		if val == nil {
+			f.Set(reflect.Zero(f.Type()))
+			continue
+		}
+		f.Set(reflect.ValueOf(val).Convert(f.Type()))
+	}
+}
@@ -0,0 +1,215 @@
+package memory
+
+import (
+	"context"
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/models"
+	"northwind-api/internal/repositories"
+	"sort"
+	"sync"
+)
+
+type ProductRepository struct {
+	mu     sync.RWMutex
+	rows   map[int]models.Product
+	nextID int
+
+	// dipakai untuk lookup relasi /products/:id/supplier dan /category
+	suppliers  *SupplierRepository
+	categories *CategoryRepository
+}
+
+func NewProductRepository(suppliers *SupplierRepository, categories *CategoryRepository) *ProductRepository {
+	return &ProductRepository{
+		rows:       map[int]models.Product{},
+		nextID:     1,
+		suppliers:  suppliers,
+		categories: categories,
+	}
+}
+
+func (r *ProductRepository) Seed(products ...models.Product) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, p := range products {
+		r.rows[p.ProductID] = p
+		if p.ProductID >= r.nextID {
+			r.nextID = p.ProductID + 1
+		}
+	}
+}
+
+func (r *ProductRepository) GetAllProducts(ctx context.Context) ([]models.Product, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]models.Product, 0, len(r.rows))
+	for _, p := range r.rows {
+		if !visible(ctx, p.DeletedAt) {
+			continue
+		}
+		out = append(out, p)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ProductID < out[j].ProductID })
+	return out, nil
+}
+
+func (r *ProductRepository) ListProducts(ctx context.Context, f repositories.ProductFilter) ([]models.Product, int, error) {
+	all, _ := r.GetAllProducts(ctx)
+	out := all[:0]
+	for _, p := range all {
+		if (f.CategoryID == 0 || p.CategoryID != nil && *p.CategoryID == f.CategoryID) &&
+			(f.SupplierID == 0 || p.SupplierID != nil && *p.SupplierID == f.SupplierID) &&
+			(f.Discontinued == nil || p.Discontinued == *f.Discontinued) &&
+			(f.Search == "" || containsFold(p.ProductName, f.Search)) {
+			out = append(out, p)
+		}
+	}
+	rows, total := page(out, f.Limit, f.Offset)
+	return rows, total, nil
+}
+
+func (r *ProductRepository) GetProductByID(ctx context.Context, id int) (models.Product, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	p, ok := r.rows[id]
+	if !ok || !visible(ctx, p.DeletedAt) {
+		return models.Product{}, apperr.NotFound("product with ID %d not found", id)
+	}
+	return p, nil
+}
+
+func (r *ProductRepository) CreateProduct(ctx context.Context, p models.Product) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p.ProductID = r.nextID
+	r.nextID++
+	r.rows[p.ProductID] = p
+	return int64(p.ProductID), nil
+}
+
+func (r *ProductRepository) UpdateProduct(ctx context.Context, p *models.Product) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if cur, ok := r.rows[p.ProductID]; !ok || cur.DeletedAt != nil {
+		return apperr.NotFound("product with ID %d not found", p.ProductID)
+	}
+	r.rows[p.ProductID] = *p
+	return nil
+}
+
+func (r *ProductRepository) DeleteProduct(ctx context.Context, id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	row, ok := r.rows[id]
+	if !ok || row.DeletedAt != nil {
+		return apperr.NotFound("no product found with ID %d", id)
+	}
+	row.DeletedAt = deletedNow()
+	r.rows[id] = row
+	return nil
+}
+
+func (r *ProductRepository) GetSupplierByProductID(ctx context.Context, productID int) (models.ProductSupplier, error) {
+	p, err := r.GetProductByID(ctx, productID)
+	if err != nil || p.SupplierID == nil {
+		return models.ProductSupplier{}, apperr.NotFound("supplier for product %d not found", productID)
+	}
+	s, err := r.suppliers.GetSupplierByID(ctx, *p.SupplierID)
+	if err != nil {
+		return models.ProductSupplier{}, apperr.NotFound("supplier for product %d not found", productID)
+	}
+	return models.ProductSupplier{SupplierID: int(s.SupplierID), CompanyName: s.CompanyName}, nil
+}
+
+func (r *ProductRepository) GetCategoryByProductID(ctx context.Context, productID int) (models.ProductCategory, error) {
+	p, err := r.GetProductByID(ctx, productID)
+	if err != nil || p.CategoryID == nil {
+		return models.ProductCategory{}, apperr.NotFound("category for product %d not found", productID)
+	}
+	c, err := r.categories.GetCategoryByID(ctx, *p.CategoryID)
+	if err != nil {
+		return models.ProductCategory{}, apperr.NotFound("category for product %d not found", productID)
+	}
+	out := models.ProductCategory{CategoryID: int(c.CategoryID)}
+	if c.CategoryName != nil {
+		out.CategoryName = *c.CategoryName
+	}
+	return out, nil
+}
+
+func (r *ProductRepository) PatchProduct(ctx context.Context, id int, changes repositories.Changes) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	row, ok := r.rows[id]
+	if !ok || !visible(ctx, row.DeletedAt) {
+		return apperr.NotFound("no product found with ID %d", id)
+	}
+	applyChanges(&row, changes)
+	r.rows[id] = row
+	return nil
+}
+
+func (r *ProductRepository) RestoreProduct(ctx context.Context, id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	row, ok := r.rows[id]
+	if !ok || row.DeletedAt == nil {
+		return apperr.NotFound("no deleted product found with ID %d", id)
+	}
+	row.DeletedAt = nil
+	r.rows[id] = row
+	return nil
+}
+
+func (r *ProductRepository) GetProductIDsBySupplierID(ctx context.Context, supplierID int) ([]int, error) {
+	return r.liveIDsWhere(func(p models.Product) *int { return p.SupplierID }, supplierID), nil
+}
+
+func (r *ProductRepository) GetProductIDsByCategoryID(ctx context.Context, categoryID int) ([]int, error) {
+	return r.liveIDsWhere(func(p models.Product) *int { return p.CategoryID }, categoryID), nil
+}
+
+func (r *ProductRepository) liveIDsWhere(ref func(models.Product) *int, id int) []int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var ids []int
+	for _, p := range r.rows {
+		if p.DeletedAt == nil && ref(p) != nil && *ref(p) == id {
+			ids = append(ids, p.ProductID)
+		}
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+func (r *ProductRepository) GetProductsByIDs(ctx context.Context, ids []int) ([]models.Product, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return pick(r.rows, ids, func(a, b models.Product) bool { return a.ProductID < b.ProductID }), nil
+}
+
+func (r *ProductRepository) GetProductsBySupplierIDs(ctx context.Context, supplierIDs []int) ([]models.Product, error) {
+	return r.productsWhere(ctx, supplierIDs, func(p models.Product) *int { return p.SupplierID }), nil
+}
+
+func (r *ProductRepository) GetProductsByCategoryIDs(ctx context.Context, categoryIDs []int) ([]models.Product, error) {
+	return r.productsWhere(ctx, categoryIDs, func(p models.Product) *int { return p.CategoryID }), nil
+}
+
+// productsWhere mengembalikan produk aktif yang kolom relasinya ada di ids.
+func (r *ProductRepository) productsWhere(ctx context.Context, ids []int, column func(models.Product) *int) []models.Product {
+	want := map[int]bool{}
+	for _, id := range ids {
+		want[id] = true
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var out []models.Product
+	for _, p := range r.rows {
+		if ref := column(p); ref != nil && want[*ref] && visible(ctx, p.DeletedAt) {
+			out = append(out, p)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ProductID < out[j].ProductID })
+	return out
+}
@@ -0,0 +1,149 @@
+package memory
+
+import (
+	"context"
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/models"
+	"sort"
+	"sync"
+)
+
+type RegionRepository struct {
+	mu          sync.RWMutex
+	rows        map[int]models.Region
+	territories map[string][]int // TerritoryID -> EmployeeID
+	terrRows    map[string]models.Territory
+	employees   *EmployeeRepository
+}
+
+func NewRegionRepository(employees *EmployeeRepository) *RegionRepository {
+	return &RegionRepository{
+		rows:        map[int]models.Region{},
+		territories: map[string][]int{},
+		terrRows:    map[string]models.Territory{},
+		employees:   employees,
+	}
+}
+
+func (r *RegionRepository) Seed(regions ...models.Region) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, reg := range regions {
+		r.rows[reg.RegionID] = reg
+	}
+}
+
+func (r *RegionRepository) SeedTerritories(territories ...models.Territory) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, t := range territories {
+		r.terrRows[t.TerritoryID] = t
+	}
+}
+
+// AssignTerritory mensimulasikan baris di tabel EmployeeTerritories.
+func (r *RegionRepository) AssignTerritory(territoryID string, employeeIDs ...int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.territories[territoryID] = append(r.territories[territoryID], employeeIDs...)
+}
+
+func (r *RegionRepository) GetAllRegions(ctx context.Context) ([]models.Region, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]models.Region, 0, len(r.rows))
+	for _, reg := range r.rows {
+		out = append(out, reg)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].RegionID < out[j].RegionID })
+	return out, nil
+}
+
+func (r *RegionRepository) GetRegionsByID(ctx context.Context, id int) (*models.Region, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	reg, ok := r.rows[id]
+	if !ok {
+		return nil, apperr.NotFound("region with ID %d not found", id)
+	}
+	return &reg, nil
+}
+
+func (r *RegionRepository) GetEmployeesByTerritoryID(ctx context.Context, id string) ([]models.Employee, error) {
+	r.mu.RLock()
+	ids := append([]int(nil), r.territories[id]...)
+	r.mu.RUnlock()
+
+	var employees []models.Employee
+	for _, empID := range ids {
+		emp, err := r.employees.GetEmployeeByID(ctx, empID)
+		if err != nil {
+			continue
+		}
+		employees = append(employees, emp)
+	}
+	return employees, nil
+}
+
+func (r *RegionRepository) GetAllTerritories(ctx context.Context) ([]models.Territory, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]models.Territory, 0, len(r.terrRows))
+	for _, t := range r.terrRows {
+		out = append(out, t)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].TerritoryID < out[j].TerritoryID })
+	return out, nil
+}
+
+func (r *RegionRepository) GetTerritoriesByIDs(ctx context.Context, ids []string) ([]models.Territory, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return pick(r.terrRows, ids, func(a, b models.Territory) bool { return a.TerritoryID < b.TerritoryID }), nil
+}
+
+func (r *RegionRepository) GetEmployeeTerritoriesByEmployeeIDs(ctx context.Context, employeeIDs []int) ([]models.EmployeeTerritory, error) {
+	want := map[int]bool{}
+	for _, id := range employeeIDs {
+		want[id] = true
+	}
+	return r.links(func(l models.EmployeeTerritory) bool { return want[l.EmployeeID] }), nil
+}
+
+func (r *RegionRepository) GetEmployeeTerritoriesByTerritoryIDs(ctx context.Context, territoryIDs []string) ([]models.EmployeeTerritory, error) {
+	want := map[string]bool{}
+	for _, id := range territoryIDs {
+		want[id] = true
+	}
+	links := r.links(func(l models.EmployeeTerritory) bool { return want[l.TerritoryID] })
+	out := links[:0]
+	for _, l := range links {
+		// sama seperti versi SQL: employee yang sudah dihapus tidak ikut
+		if _, err := r.employees.GetEmployeeByID(ctx, l.EmployeeID); err == nil {
+			out = append(out, l)
+		}
+	}
+	return out, nil
+}
+
+// links mengembalikan baris EmployeeTerritories yang lolos filter, urut employee lalu territory.
+func (r *RegionRepository) links(keep func(models.EmployeeTerritory) bool) []models.EmployeeTerritory {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var out []models.EmployeeTerritory
+	for territoryID, employeeIDs := range r.territories {
+		for _, employeeID := range employeeIDs {
+			l := models.EmployeeTerritory{EmployeeID: employeeID, TerritoryID: territoryID}
+			if keep(l) {
+				out = append(out, l)
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].EmployeeID != out[j].EmployeeID {
+			return out[i].EmployeeID < out[j].EmployeeID
+		}
+		return out[i].TerritoryID < out[j].TerritoryID
+	})
+	return out
+}
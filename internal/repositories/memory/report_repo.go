@@ -0,0 +1,82 @@
+package memory
+
+import (
+	"context"
+	"northwind-api/internal/models"
+)
+
+// ReportRepository mengembalikan data kalengan; isi field-nya langsung dari test.
+// Err, kalau di-set, dikembalikan oleh semua method.
+type ReportRepository struct {
+	TopCustomers         []models.TopCustomer
+	TopProducts          []models.TopProduct
+	SalesByCategory      []models.SalesByCategory
+	SalesByEmployee      []models.SalesByEmployee
+	SalesSummary         models.SalesSummary
+	MonthlySales         []models.MonthlySales
+	InventoryStatus      []models.InventoryStatus
+	TopSuppliers         []models.TopSupplier
+	CustomerGrowth       []models.CustomerGrowth
+	OrderStatusSummary   []models.OrderStatusSummary
+	RegionSales          []models.RegionSales
+	EmployeePerformance  []models.EmployeePerformance
+	ProductProfitability []models.ProductProfitability
+	AverageOrderValue    models.AverageOrderValue
+	Err                  error
+}
+
+func (r *ReportRepository) GetTopCustomers(ctx context.Context) ([]models.TopCustomer, error) {
+	return r.TopCustomers, r.Err
+}
+
+func (r *ReportRepository) GetTopProducts(ctx context.Context) ([]models.TopProduct, error) {
+	return r.TopProducts, r.Err
+}
+
+func (r *ReportRepository) GetSalesByCategory(ctx context.Context) ([]models.SalesByCategory, error) {
+	return r.SalesByCategory, r.Err
+}
+
+func (r *ReportRepository) GetSalesByEmployee(ctx context.Context) ([]models.SalesByEmployee, error) {
+	return r.SalesByEmployee, r.Err
+}
+
+func (r *ReportRepository) GetSalesSummary(ctx context.Context) (models.SalesSummary, error) {
+	return r.SalesSummary, r.Err
+}
+
+func (r *ReportRepository) GetMonthlySales(ctx context.Context) ([]models.MonthlySales, error) {
+	return r.MonthlySales, r.Err
+}
+
+func (r *ReportRepository) GetInventoryStatus(ctx context.Context) ([]models.InventoryStatus, error) {
+	return r.InventoryStatus, r.Err
+}
+
+func (r *ReportRepository) GetTopSuppliers(ctx context.Context) ([]models.TopSupplier, error) {
+	return r.TopSuppliers, r.Err
+}
+
+func (r *ReportRepository) GetCustomerGrowth(ctx context.Context) ([]models.CustomerGrowth, error) {
+	return r.CustomerGrowth, r.Err
+}
+
+func (r *ReportRepository) GetOrderStatusSummary(ctx context.Context) ([]models.OrderStatusSummary, error) {
+	return r.OrderStatusSummary, r.Err
+}
+
+func (r *ReportRepository) GetRegionSales(ctx context.Context) ([]models.RegionSales, error) {
+	return r.RegionSales, r.Err
+}
+
+func (r *ReportRepository) GetEmployeePerformance(ctx context.Context) ([]models.EmployeePerformance, error) {
+	return r.EmployeePerformance, r.Err
+}
+
+func (r *ReportRepository) GetProductProfitability(ctx context.Context) ([]models.ProductProfitability, error) {
+	return r.ProductProfitability, r.Err
+}
+
+func (r *ReportRepository) GetAverageOrderValue(ctx context.Context) (models.AverageOrderValue, error) {
+	return r.AverageOrderValue, r.Err
+}
@@ -0,0 +1,93 @@
+package memory
+
+import (
+	"context"
+	"northwind-api/internal/models"
+	"northwind-api/internal/repositories"
+	"slices"
+	"sort"
+	"sync"
+)
+
+// SearchRepository versi fake: pencocokan kata/awalan sederhana tanpa FTS5.
+// Score = jumlah kata yang cocok, kata di Title dihitung 10x.
+type SearchRepository struct {
+	mu   sync.Mutex
+	docs []models.SearchDocument
+}
+
+func NewSearchRepository() *SearchRepository {
+	return &SearchRepository{}
+}
+
+func (r *SearchRepository) IndexDocuments(ctx context.Context, docs ...models.SearchDocument) error {
+	for _, d := range docs {
+		_ = r.RemoveDocument(ctx, d.Entity, d.EntityID)
+		r.mu.Lock()
+		r.docs = append(r.docs, d)
+		r.mu.Unlock()
+	}
+	return nil
+}
+
+func (r *SearchRepository) RemoveDocument(ctx context.Context, entity, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.docs = slices.DeleteFunc(r.docs, func(d models.SearchDocument) bool {
+		return d.Entity == entity && d.EntityID == id
+	})
+	return nil
+}
+
+func (r *SearchRepository) Search(ctx context.Context, q repositories.SearchQuery) ([]models.SearchHit, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	hits := []models.SearchHit{}
+	for _, d := range r.docs {
+		if len(q.Entities) > 0 && !slices.Contains(q.Entities, d.Entity) {
+			continue
+		}
+		title, inTitle := repositories.Mark(d.Title, q.Terms)
+		body, inBody := repositories.Mark(d.Body, q.Terms)
+		matched := true
+		for i := range q.Terms {
+			if !inTitle[i] && !inBody[i] {
+				matched = false
+			}
+		}
+		if !matched {
+			continue
+		}
+		score := 0
+		for i := range q.Terms {
+			if inTitle[i] {
+				score += 10
+			}
+			if inBody[i] {
+				score++
+			}
+		}
+		hits = append(hits, models.SearchHit{Type: d.Entity, ID: d.EntityID, Title: title, Snippet: body, Score: float64(score)})
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].Score > hits[j].Score })
+	if len(hits) > q.Limit {
+		hits = hits[:q.Limit]
+	}
+	return hits, nil
+}
+
+func (r *SearchRepository) SearchTerms(ctx context.Context, minLen int) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	seen := map[string]bool{}
+	var terms []string
+	for _, d := range r.docs {
+		for _, w := range repositories.SearchWords(d.Title + " " + d.Body) {
+			if len([]rune(w)) >= minLen && !seen[w] {
+				seen[w] = true
+				terms = append(terms, w)
+			}
+		}
+	}
+	return terms, nil
+}
@@ -0,0 +1,116 @@
+package memory
+
+import (
+	"context"
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/models"
+	"northwind-api/internal/repositories"
+	"sort"
+	"sync"
+)
+
+type ShipperRepository struct {
+	mu     sync.RWMutex
+	rows   map[int]models.Shipper
+	nextID int
+}
+
+func NewShipperRepository() *ShipperRepository {
+	return &ShipperRepository{rows: map[int]models.Shipper{}, nextID: 1}
+}
+
+func (r *ShipperRepository) Seed(shippers ...models.Shipper) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, s := range shippers {
+		r.rows[s.ShipperID] = s
+		if s.ShipperID >= r.nextID {
+			r.nextID = s.ShipperID + 1
+		}
+	}
+}
+
+func (r *ShipperRepository) GetAllShippers(ctx context.Context) ([]models.Shipper, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]models.Shipper, 0, len(r.rows))
+	for _, s := range r.rows {
+		if !visible(ctx, s.DeletedAt) {
+			continue
+		}
+		out = append(out, s)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ShipperID < out[j].ShipperID })
+	return out, nil
+}
+
+func (r *ShipperRepository) GetShipperByID(ctx context.Context, id int) (models.Shipper, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.rows[id]
+	if !ok || !visible(ctx, s.DeletedAt) {
+		return models.Shipper{}, apperr.NotFound("shipper with ID %d not found", id)
+	}
+	return s, nil
+}
+
+func (r *ShipperRepository) CreateShipper(ctx context.Context, shipper models.Shipper) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	shipper.ShipperID = r.nextID
+	r.nextID++
+	r.rows[shipper.ShipperID] = shipper
+	return int64(shipper.ShipperID), nil
+}
+
+func (r *ShipperRepository) UpdateShipper(ctx context.Context, shipper *models.Shipper) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if cur, ok := r.rows[shipper.ShipperID]; !ok || cur.DeletedAt != nil {
+		return apperr.NotFound("shipper with ID %d not found", shipper.ShipperID)
+	}
+	r.rows[shipper.ShipperID] = *shipper
+	return nil
+}
+
+func (r *ShipperRepository) DeleteShipper(ctx context.Context, id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	row, ok := r.rows[id]
+	if !ok || row.DeletedAt != nil {
+		return apperr.NotFound("shipper with ID %d not found", id)
+	}
+	row.DeletedAt = deletedNow()
+	r.rows[id] = row
+	return nil
+}
+
+func (r *ShipperRepository) PatchShipper(ctx context.Context, id int, changes repositories.Changes) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	row, ok := r.rows[id]
+	if !ok || !visible(ctx, row.DeletedAt) {
+		return apperr.NotFound("no shipper found with ID %d", id)
+	}
+	applyChanges(&row, changes)
+	r.rows[id] = row
+	return nil
+}
+
+func (r *ShipperRepository) RestoreShipper(ctx context.Context, id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	row, ok := r.rows[id]
+	if !ok || row.DeletedAt == nil {
+		return apperr.NotFound("no deleted shipper found with ID %d", id)
+	}
+	row.DeletedAt = nil
+	r.rows[id] = row
+	return nil
+}
+
+func (r *ShipperRepository) GetShippersByIDs(ctx context.Context, ids []int) ([]models.Shipper, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return pick(r.rows, ids, func(a, b models.Shipper) bool { return a.ShipperID < b.ShipperID }), nil
+}
@@ -0,0 +1,18 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"northwind-api/internal/repositories"
+)
+
+// visible meniru filter DeletedAt IS NULL pada repository SQL.
+func visible(ctx context.Context, deletedAt *time.Time) bool {
+	return deletedAt == nil || repositories.IncludesDeleted(ctx)
+}
+
+func deletedNow() *time.Time {
+	t := time.Now().UTC().Truncate(time.Second)
+	return &t
+}
@@ -0,0 +1,92 @@
+// Package memory berisi implementasi in-memory dari interface di package
+// repositories. Dipakai untuk test handler tanpa database sungguhan.
+package memory
+
+import (
+	"northwind-api/internal/repositories"
+)
+
+// Store menyatukan semua fake repository yang saling berelasi
+// (mis. product -> supplier/category, territory -> employee).
+type Store struct {
+	Customers  *CustomerRepository
+	Employees  *EmployeeRepository
+	Shippers   *ShipperRepository
+	Products   *ProductRepository
+	Categories *CategoryRepository
+	Suppliers  *SupplierRepository
+	Orders     *OrderRepository
+	Regions    *RegionRepository
+	Reports    *ReportRepository
+	Audit      *AuditRepository
+	EventLog   *EventLogRepository
+	Webhooks   *WebhookRepository
+	Search     *SearchRepository
+
+	Idempotency *IdempotencyRepository
+	Imports     *ImportJobRepository
+}
+
+func NewStore() *Store {
+	s := &Store{
+		Customers:  NewCustomerRepository(),
+		Employees:  NewEmployeeRepository(),
+		Shippers:   NewShipperRepository(),
+		Categories: NewCategoryRepository(),
+		Suppliers:  NewSupplierRepository(),
+		Orders:     NewOrderRepository(),
+		Reports:    &ReportRepository{},
+		Audit:      NewAuditRepository(),
+		EventLog:   NewEventLogRepository(),
+		Webhooks:   NewWebhookRepository(),
+		Search:     NewSearchRepository(),
+
+		Idempotency: NewIdempotencyRepository(),
+		Imports:     NewImportJobRepository(),
+	}
+	s.Products = NewProductRepository(s.Suppliers, s.Categories)
+	s.Regions = NewRegionRepository(s.Employees)
+	return s
+}
+
+// Repositories mengembalikan bundle yang bisa langsung dipasang ke routes.Deps.
+func (s *Store) Repositories() repositories.Repositories {
+	return repositories.Repositories{
+		Customers:  s.Customers,
+		Employees:  s.Employees,
+		Shippers:   s.Shippers,
+		Products:   s.Products,
+		Categories: s.Categories,
+		Suppliers:  s.Suppliers,
+		Orders:     s.Orders,
+		Regions:    s.Regions,
+		Reports:    s.Reports,
+		Audit:      s.Audit,
+		EventLog:   s.EventLog,
+		Webhooks:   s.Webhooks,
+		Search:     s.Search,
+
+		Idempotency: s.Idempotency,
+		Imports:     s.Imports,
+	}
+}
+
+var (
+	_ repositories.CustomerStore = (*CustomerRepository)(nil)
+	_ repositories.EmployeeStore = (*EmployeeRepository)(nil)
+	_ repositories.ShipperStore  = (*ShipperRepository)(nil)
+	_ repositories.ProductStore  = (*ProductRepository)(nil)
+	_ repositories.CategoryStore = (*CategoryRepository)(nil)
+	_ repositories.SupplierStore = (*SupplierRepository)(nil)
+	_ repositories.OrderStore    = (*OrderRepository)(nil)
+	_ repositories.RegionStore   = (*RegionRepository)(nil)
+	_ repositories.ReportStore   = (*ReportRepository)(nil)
+	_ repositories.AuditStore    = (*AuditRepository)(nil)
+	_ repositories.SearchStore   = (*SearchRepository)(nil)
+	_ repositories.EventLogStore = (*EventLogRepository)(nil)
+	_ repositories.WebhookStore  = (*WebhookRepository)(nil)
+	_ repositories.TxRunner      = (*TxRunner)(nil)
+
+	_ repositories.IdempotencyStore = (*IdempotencyRepository)(nil)
+	_ repositories.ImportJobStore   = (*ImportJobRepository)(nil)
+)
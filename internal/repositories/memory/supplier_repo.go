@@ -0,0 +1,116 @@
+package memory
+
+import (
+	"context"
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/models"
+	"northwind-api/internal/repositories"
+	"sort"
+	"sync"
+)
+
+type SupplierRepository struct {
+	mu     sync.RWMutex
+	rows   map[int64]models.Supplier
+	nextID int64
+}
+
+func NewSupplierRepository() *SupplierRepository {
+	return &SupplierRepository{rows: map[int64]models.Supplier{}, nextID: 1}
+}
+
+func (r *SupplierRepository) Seed(suppliers ...models.Supplier) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, s := range suppliers {
+		r.rows[s.SupplierID] = s
+		if s.SupplierID >= r.nextID {
+			r.nextID = s.SupplierID + 1
+		}
+	}
+}
+
+func (r *SupplierRepository) GetAllSuppliers(ctx context.Context) ([]models.Supplier, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]models.Supplier, 0, len(r.rows))
+	for _, s := range r.rows {
+		if !visible(ctx, s.DeletedAt) {
+			continue
+		}
+		out = append(out, s)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].SupplierID < out[j].SupplierID })
+	return out, nil
+}
+
+func (r *SupplierRepository) GetSupplierByID(ctx context.Context, id int) (models.Supplier, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.rows[int64(id)]
+	if !ok || !visible(ctx, s.DeletedAt) {
+		return models.Supplier{}, apperr.NotFound("supplier with ID %d not found", id)
+	}
+	return s, nil
+}
+
+func (r *SupplierRepository) CreateSupplier(ctx context.Context, s *models.Supplier) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s.SupplierID = r.nextID
+	r.nextID++
+	r.rows[s.SupplierID] = *s
+	return nil
+}
+
+func (r *SupplierRepository) UpdateSupplier(ctx context.Context, s *models.Supplier) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if cur, ok := r.rows[s.SupplierID]; !ok || cur.DeletedAt != nil {
+		return apperr.NotFound("supplier with ID %d not found", s.SupplierID)
+	}
+	r.rows[s.SupplierID] = *s
+	return nil
+}
+
+func (r *SupplierRepository) DeleteSupplier(ctx context.Context, id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	row, ok := r.rows[int64(id)]
+	if !ok || row.DeletedAt != nil {
+		return apperr.NotFound("supplier with ID %d not found", id)
+	}
+	row.DeletedAt = deletedNow()
+	r.rows[int64(id)] = row
+	return nil
+}
+
+func (r *SupplierRepository) PatchSupplier(ctx context.Context, id int, changes repositories.Changes) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	row, ok := r.rows[int64(id)]
+	if !ok || !visible(ctx, row.DeletedAt) {
+		return apperr.NotFound("no supplier found with ID %d", id)
+	}
+	applyChanges(&row, changes)
+	r.rows[int64(id)] = row
+	return nil
+}
+
+func (r *SupplierRepository) RestoreSupplier(ctx context.Context, id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	row, ok := r.rows[int64(id)]
+	if !ok || row.DeletedAt == nil {
+		return apperr.NotFound("no deleted supplier found with ID %d", id)
+	}
+	row.DeletedAt = nil
+	r.rows[int64(id)] = row
+	return nil
+}
+
+func (r *SupplierRepository) GetSuppliersByIDs(ctx context.Context, ids []int) ([]models.Supplier, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return pick(r.rows, widen(ids), func(a, b models.Supplier) bool { return a.SupplierID < b.SupplierID }), nil
+}
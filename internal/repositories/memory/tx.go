@@ -0,0 +1,21 @@
+package memory
+
+import (
+	"context"
+	"northwind-api/internal/repositories"
+)
+
+// TxRunner versi fake: fn dijalankan langsung terhadap store yang sama,
+// tanpa isolasi maupun rollback.
+type TxRunner struct {
+	store *Store
+}
+
+func (s *Store) TxRunner() *TxRunner {
+	return &TxRunner{store: s}
+}
+
+func (t *TxRunner) WithTx(ctx context.Context, fn func(ctx context.Context, r repositories.Repositories) error) error {
+	repos := repositories.Scoped(ctx, t.store.Repositories())
+	return fn(repositories.WithScope(ctx, repos), repos)
+}
@@ -0,0 +1,197 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/models"
+	"northwind-api/internal/repositories"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+type WebhookRepository struct {
+	mu         sync.Mutex
+	webhooks   map[int]models.Webhook
+	deliveries []models.WebhookDelivery
+	attempts   map[int64][]models.WebhookAttempt
+	nextID     int
+	deliveryID int64
+	attemptID  int64
+}
+
+func NewWebhookRepository() *WebhookRepository {
+	return &WebhookRepository{webhooks: map[int]models.Webhook{}, attempts: map[int64][]models.WebhookAttempt{}}
+}
+
+func (r *WebhookRepository) CreateWebhook(ctx context.Context, w *models.Webhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextID++
+	w.WebhookID = r.nextID
+	r.webhooks[w.WebhookID] = cloneWebhook(*w)
+	return nil
+}
+
+func (r *WebhookRepository) GetWebhook(ctx context.Context, id int) (models.Webhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	w, ok := r.webhooks[id]
+	if !ok {
+		return w, apperr.NotFound("webhook %d not found", id)
+	}
+	return cloneWebhook(w), nil
+}
+
+func (r *WebhookRepository) ListWebhooks(ctx context.Context) ([]models.Webhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]models.Webhook, 0, len(r.webhooks))
+	for _, w := range r.webhooks {
+		out = append(out, cloneWebhook(w))
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].WebhookID < out[j].WebhookID })
+	return out, nil
+}
+
+func (r *WebhookRepository) UpdateWebhook(ctx context.Context, w *models.Webhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	old, ok := r.webhooks[w.WebhookID]
+	if !ok {
+		return apperr.NotFound("webhook %d not found", w.WebhookID)
+	}
+	updated := cloneWebhook(*w)
+	updated.CreatedAt = old.CreatedAt
+	r.webhooks[w.WebhookID] = updated
+	return nil
+}
+
+func (r *WebhookRepository) DeleteWebhook(ctx context.Context, id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.webhooks[id]; !ok {
+		return apperr.NotFound("webhook %d not found", id)
+	}
+	delete(r.webhooks, id)
+	r.deliveries = slices.DeleteFunc(r.deliveries, func(d models.WebhookDelivery) bool {
+		if d.WebhookID == id {
+			delete(r.attempts, d.DeliveryID)
+			return true
+		}
+		return false
+	})
+	return nil
+}
+
+func (r *WebhookRepository) EnqueueDeliveries(ctx context.Context, deliveries ...models.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, d := range deliveries {
+		if _, ok := r.webhooks[d.WebhookID]; !ok {
+			return apperr.ForeignKey("error enqueueing webhook delivery: webhook %d not found", d.WebhookID)
+		}
+		r.deliveryID++
+		d.DeliveryID = r.deliveryID
+		d.Attempts = 0
+		r.deliveries = append(r.deliveries, d)
+	}
+	return nil
+}
+
+func (r *WebhookRepository) DueDeliveries(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := []models.WebhookDelivery{}
+	for _, d := range r.deliveries {
+		if d.Status == models.DeliveryPending && d.NextAttemptAt != nil && !d.NextAttemptAt.After(now) && r.webhooks[d.WebhookID].Active {
+			out = append(out, d)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].NextAttemptAt.Before(*out[j].NextAttemptAt) })
+	return out[:min(len(out), limit)], nil
+}
+
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, f repositories.DeliveryFilter) ([]models.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := []models.WebhookDelivery{}
+	for i := len(r.deliveries) - 1; i >= 0 && len(out) < f.Limit; i-- {
+		d := r.deliveries[i]
+		if (f.WebhookID == 0 || d.WebhookID == f.WebhookID) && (f.Status == "" || d.Status == f.Status) {
+			out = append(out, d)
+		}
+	}
+	return out, nil
+}
+
+func (r *WebhookRepository) GetDelivery(ctx context.Context, id int64) (models.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := r.find(id)
+	if i < 0 {
+		return models.WebhookDelivery{}, apperr.NotFound("webhook delivery %d not found", id)
+	}
+	d := r.deliveries[i]
+	d.AttemptLog = slices.Clone(r.attempts[id])
+	return d, nil
+}
+
+func (r *WebhookRepository) RecordAttempt(ctx context.Context, d models.WebhookDelivery, a models.WebhookAttempt) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := r.find(d.DeliveryID)
+	if i < 0 {
+		return apperr.NotFound("webhook delivery %d not found", d.DeliveryID)
+	}
+	d.AttemptLog = nil
+	r.deliveries[i] = d
+	r.attemptID++
+	a.AttemptID = r.attemptID
+	r.attempts[d.DeliveryID] = append(r.attempts[d.DeliveryID], a)
+	return nil
+}
+
+func (r *WebhookRepository) RequeueDelivery(ctx context.Context, id int64, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := r.find(id)
+	if i < 0 || r.deliveries[i].Status != models.DeliveryDead {
+		return apperr.Conflict("webhook delivery %d is not dead", id)
+	}
+	d := &r.deliveries[i]
+	d.Status, d.Attempts, d.NextAttemptAt = models.DeliveryPending, 0, &at
+	return nil
+}
+
+func (r *WebhookRepository) RedactDeliveries(ctx context.Context, entity, id string, fields []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, d := range r.deliveries {
+		var ev struct {
+			Entity   string `json:"entity"`
+			EntityID string `json:"entity_id"`
+		}
+		if err := json.Unmarshal(d.Payload, &ev); err != nil || ev.Entity != entity || ev.EntityID != id {
+			continue
+		}
+		payload, _, err := repositories.RedactEventPayload(d.Payload, fields)
+		if err != nil {
+			return err
+		}
+		r.deliveries[i].Payload = payload
+	}
+	return nil
+}
+
+func (r *WebhookRepository) find(id int64) int {
+	return slices.IndexFunc(r.deliveries, func(d models.WebhookDelivery) bool { return d.DeliveryID == id })
+}
+
+func cloneWebhook(w models.Webhook) models.Webhook {
+	w.Events = slices.Clone(w.Events)
+	w.Roles = slices.Clone(w.Roles)
+	return w
+}
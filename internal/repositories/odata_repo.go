@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"northwind-api/internal/odata"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ODataRepository menjalankan SQL yang disusun package odata. Nilai kolom
+// dikembalikan mentah; konversi ke tipe EDM dilakukan package odata.
+type ODataRepository struct {
+	DB DBTX
+}
+
+func (r *ODataRepository) QueryOData(ctx context.Context, s odata.Statement) ([][]any, error) {
+	rows, err := r.DB.QueryContext(ctx, s.SQL, s.Args...)
+	if err != nil {
+		log.Error().Err(err).Str("sql", s.SQL).Msg("error running odata query")
+		return nil, fmt.Errorf("error running odata query: %w", err)
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("error reading odata columns: %w", err)
+	}
+	var out [][]any
+	for rows.Next() {
+		values := make([]any, len(cols))
+		ptrs := make([]any, len(cols))
+		for i := range values {
+			ptrs[i] = &values[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			log.Error().Err(err).Msg("error scanning odata row")
+			return nil, fmt.Errorf("error scanning odata row: %w", err)
+		}
+		out = append(out, values)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error().Err(err).Msg("error iterating odata rows")
+		return nil, fmt.Errorf("error iterating odata rows: %w", err)
+	}
+	return out, nil
+}
+
+func (r *ODataRepository) CountOData(ctx context.Context, s odata.Statement) (int64, error) {
+	var n int64
+	if err := r.DB.QueryRowContext(ctx, s.SQL, s.Args...).Scan(&n); err != nil {
+		log.Error().Err(err).Str("sql", s.SQL).Msg("error counting odata rows")
+		return 0, fmt.Errorf("error counting odata rows: %w", err)
+	}
+	return n, nil
+}
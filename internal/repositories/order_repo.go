@@ -5,13 +5,27 @@ import (
 	"database/sql"
 	"fmt"
 	"math"
+	"northwind-api/internal/apperr"
 	"northwind-api/internal/models"
 
 	"github.com/rs/zerolog/log"
 )
 
+// OrderFilter membatasi hasil ListOrders. Field kosong atau 0 berarti semua;
+// ShipCountry tidak peka huruf besar/kecil dan Shipped memilih order yang
+// sudah (true) atau belum (false) dikirim. Limit 0 berarti tanpa batas.
+type OrderFilter struct {
+	CustomerID  string
+	EmployeeID  int64
+	ShipperID   int64
+	ShipCountry string
+	Shipped     *bool
+	Limit       int
+	Offset      int
+}
+
 type OrderRepository struct {
-	DB *sql.DB
+	DB DBTX
 }
 
 func (r *OrderRepository) CreateOrder(ctx context.Context, o *models.Order) (int64, error) {
@@ -24,7 +38,7 @@ func (r *OrderRepository) CreateOrder(ctx context.Context, o *models.Order) (int
 		o.ShipRegion, o.ShipPostalCode, o.ShipCountry)
 	if err != nil {
 		log.Error().Err(err).Msg("error creating order")
-		return 0, fmt.Errorf("error creating order: %w", err)
+		return 0, dbError(err, "error creating order")
 	}
 	id, err := result.LastInsertId()
 	if err != nil {
@@ -98,11 +112,48 @@ func (r *OrderRepository) GetOrdersPage(ctx context.Context, page, pageSize int)
 }
 
 func (r *OrderRepository) GetAllOrders(ctx context.Context) ([]models.Order, error) {
+	return r.listOrders(ctx, "1 = 1")
+}
+
+// ListOrders mengembalikan satu halaman order yang cocok dengan f, urut
+// OrderID, beserta jumlah seluruh order yang cocok.
+func (r *OrderRepository) ListOrders(ctx context.Context, f OrderFilter) ([]models.Order, int, error) {
+	var c conditions
+	if f.CustomerID != "" {
+		c.add("CustomerID = ?", f.CustomerID)
+	}
+	if f.EmployeeID != 0 {
+		c.add("EmployeeID = ?", f.EmployeeID)
+	}
+	if f.ShipperID != 0 {
+		c.add("ShipVia = ?", f.ShipperID)
+	}
+	if f.ShipCountry != "" {
+		c.add("ShipCountry = ? COLLATE NOCASE", f.ShipCountry)
+	}
+	if f.Shipped != nil {
+		shipped := "COALESCE(ShippedDate, '') <> ''"
+		if !*f.Shipped {
+			shipped = "COALESCE(ShippedDate, '') = ''"
+		}
+		c.add(shipped)
+	}
+	total, err := c.count(ctx, r.DB, "Orders")
+	if err != nil {
+		return nil, 0, err
+	}
+	where, args := c.page("OrderID", f.Limit, f.Offset)
+	rows, err := r.listOrders(ctx, where, args...)
+	return rows, total, err
+}
+
+// listOrders menjalankan SELECT daftar order dengan kondisi WHERE where.
+func (r *OrderRepository) listOrders(ctx context.Context, where string, args ...any) ([]models.Order, error) {
 	rows, err := r.DB.QueryContext(ctx, `
 		SELECT OrderID, CustomerID, EmployeeID, OrderDate, RequiredDate, ShippedDate,
 			ShipVia, Freight, ShipName, ShipAddress, ShipCity, ShipRegion, ShipPostalCode, ShipCountry
 		FROM Orders
-	`)
+		WHERE `+where, args...)
 	if err != nil {
 		log.Error().Err(err).Msg("error fetching orders")
 		return nil, fmt.Errorf("error fetching orders: %w", err)
@@ -144,7 +195,7 @@ func (r *OrderRepository) GetOrderByID(ctx context.Context, id int) (models.Orde
 	if err != nil {
 		if err == sql.ErrNoRows {
 			log.Warn().Int("id", id).Msg("Order not found")
-			return order, fmt.Errorf("order not found")
+			return order, apperr.NotFound("order not found")
 		}
 		log.Error().Err(err).Int("id", id).Msg("error fetching order by ID")
 		return order, fmt.Errorf("error fetching order by ID: %w", err)
@@ -153,7 +204,7 @@ func (r *OrderRepository) GetOrderByID(ctx context.Context, id int) (models.Orde
 }
 
 func (r *OrderRepository) UpdateOrder(ctx context.Context, o *models.Order) error {
-	_, err := r.DB.ExecContext(ctx, `
+	result, err := r.DB.ExecContext(ctx, `
 		UPDATE Orders
 		SET CustomerID = ?, EmployeeID = ?, OrderDate = ?, RequiredDate = ?, ShippedDate = ?,
 			ShipVia = ?, Freight = ?, ShipName = ?, ShipAddress = ?, ShipCity = ?,
@@ -164,32 +215,53 @@ func (r *OrderRepository) UpdateOrder(ctx context.Context, o *models.Order) erro
 		o.ShipRegion, o.ShipPostalCode, o.ShipCountry, o.OrderID)
 	if err != nil {
 		log.Error().Err(err).Int64("id", o.OrderID).Msg("error updating order")
-		return fmt.Errorf("error updating order: %w", err)
+		return dbError(err, "error updating order")
 	}
-	return nil
+	return ensureAffected(result, apperr.NotFound("order with ID %d not found", o.OrderID))
 }
 
 func (r *OrderRepository) DeleteOrder(ctx context.Context, id int) error {
-	_, err := r.DB.ExecContext(ctx, `
+	result, err := r.DB.ExecContext(ctx, `
 		DELETE FROM Orders
 		WHERE OrderID = ?
 	`, id)
 	if err != nil {
 		log.Error().Err(err).Int("id", id).Msg("error deleting order")
-		return fmt.Errorf("error deleting order: %w", err)
+		return dbError(err, "error deleting order")
 	}
-	return nil
+	return ensureAffected(result, apperr.NotFound("order with ID %d not found", id))
 }
 
 // GET /orders/{id}/details → detail item yang dipesan
 func (r *OrderRepository) GetOrderDetailsByOrderID(ctx context.Context, orderID int) ([]models.OrderDetail, error) {
+	return r.listOrderDetails(ctx, "OrderID = ?", orderID)
+}
+
+// GetOrderDetailsByOrderIDs memuat detail beberapa order sekaligus.
+func (r *OrderRepository) GetOrderDetailsByOrderIDs(ctx context.Context, orderIDs []int) ([]models.OrderDetail, error) {
+	if len(orderIDs) == 0 {
+		return nil, nil
+	}
+	return r.listOrderDetails(ctx, "OrderID IN "+placeholders(len(orderIDs)), inArgs(orderIDs)...)
+}
+
+// GetOrdersByCustomerIDs memuat order milik beberapa customer sekaligus.
+func (r *OrderRepository) GetOrdersByCustomerIDs(ctx context.Context, customerIDs []string) ([]models.Order, error) {
+	if len(customerIDs) == 0 {
+		return nil, nil
+	}
+	return r.listOrders(ctx, "CustomerID IN "+placeholders(len(customerIDs)), inArgs(customerIDs)...)
+}
+
+// listOrderDetails menjalankan SELECT detail order dengan kondisi WHERE where.
+func (r *OrderRepository) listOrderDetails(ctx context.Context, where string, args ...any) ([]models.OrderDetail, error) {
 	rows, err := r.DB.QueryContext(ctx, `
 		SELECT OrderID, ProductID, UnitPrice, Quantity, Discount
 		FROM OrderDetails
-		WHERE OrderID = ?
-	`, orderID)
+		WHERE `+where+`
+		ORDER BY OrderID, ProductID`, args...)
 	if err != nil {
-		log.Error().Err(err).Int("orderID", orderID).Msg("error fetching order details")
+		log.Error().Err(err).Msg("error fetching order details")
 		return nil, fmt.Errorf("error fetching order details: %w", err)
 	}
 	defer rows.Close()
@@ -199,14 +271,49 @@ func (r *OrderRepository) GetOrderDetailsByOrderID(ctx context.Context, orderID
 		var detail models.OrderDetail
 		if err := rows.Scan(&detail.OrderID, &detail.ProductID,
 			&detail.UnitPrice, &detail.Quantity, &detail.Discount); err != nil {
-			log.Error().Err(err).Int("orderID", orderID).Msg("error scanning order detail row")
+			log.Error().Err(err).Msg("error scanning order detail row")
 			return nil, fmt.Errorf("error scanning order detail row: %w", err)
 		}
 		details = append(details, detail)
 	}
 	if err := rows.Err(); err != nil {
-		log.Error().Err(err).Int("orderID", orderID).Msg("error iterating over order detail rows")
+		log.Error().Err(err).Msg("error iterating over order detail rows")
 		return nil, fmt.Errorf("error iterating over order detail rows: %w", err)
 	}
 	return details, nil
 }
+
+// DeleteOrderDetails menghapus semua baris detail milik order.
+func (r *OrderRepository) DeleteOrderDetails(ctx context.Context, orderID int) error {
+	if _, err := r.DB.ExecContext(ctx, `DELETE FROM OrderDetails WHERE OrderID = ?`, orderID); err != nil {
+		log.Error().Err(err).Int("orderID", orderID).Msg("error deleting order details")
+		return dbError(err, "error deleting order details")
+	}
+	return nil
+}
+
+// MoveOrderDetails memindahkan baris detail ke order lain. Product yang sudah
+// ada di order tujuan membuat primary key bentrok dan dilaporkan sebagai Conflict.
+func (r *OrderRepository) MoveOrderDetails(ctx context.Context, fromOrderID, toOrderID int) error {
+	if _, err := r.DB.ExecContext(ctx, `UPDATE OrderDetails SET OrderID = ? WHERE OrderID = ?`, toOrderID, fromOrderID); err != nil {
+		log.Error().Err(err).Int("from", fromOrderID).Int("to", toOrderID).Msg("error moving order details")
+		return dbError(err, "error moving order details")
+	}
+	return nil
+}
+
+var orderPatch = patchTarget{
+	table:  "Orders",
+	key:    "OrderID",
+	entity: "order",
+	columns: []string{
+		"CustomerID", "EmployeeID", "OrderDate", "RequiredDate", "ShippedDate", "ShipVia",
+		"Freight", "ShipName", "ShipAddress", "ShipCity", "ShipRegion", "ShipPostalCode",
+		"ShipCountry",
+	},
+}
+
+// PatchOrder hanya meng-update kolom yang ada di changes.
+func (r *OrderRepository) PatchOrder(ctx context.Context, id int, changes Changes) error {
+	return patchRow(ctx, r.DB, orderPatch, id, changes)
+}
@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"northwind-api/internal/apperr"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Changes berisi kolom yang akan di-update (nama kolom database -> nilai baru).
+type Changes map[string]any
+
+// patchTarget mendeskripsikan tabel yang boleh di-PATCH dan kolom yang boleh diubah.
+type patchTarget struct {
+	table   string
+	key     string
+	entity  string
+	columns []string
+	// softDelete: baris yang sudah di-soft delete tidak boleh di-patch,
+	// kecuali ctx dibuat dengan WithDeleted (mis. penghapusan data pribadi).
+	softDelete bool
+}
+
+// patchRow meng-update hanya kolom di changes. Nama kolom berasal dari tag db
+// model, tapi tetap dicek terhadap t.columns supaya tidak bisa disisipi SQL.
+func patchRow(ctx context.Context, db DBTX, t patchTarget, id any, changes Changes) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	cols := make([]string, 0, len(changes))
+	for col := range changes {
+		if !slices.Contains(t.columns, col) {
+			return fmt.Errorf("column %q cannot be patched on %s", col, t.table)
+		}
+		cols = append(cols, col)
+	}
+	sort.Strings(cols)
+
+	set := make([]string, len(cols))
+	args := make([]any, 0, len(cols)+1)
+	for i, col := range cols {
+		set[i] = col + " = ?"
+		args = append(args, changes[col])
+	}
+	args = append(args, id)
+
+	query := fmt.Sprintf("UPDATE %s SET %s WHERE %s = ?", t.table, strings.Join(set, ", "), t.key)
+	if t.softDelete {
+		query += " AND " + liveOnly(ctx, "DeletedAt")
+	}
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		log.Error().Err(err).Str("table", t.table).Strs("columns", cols).Msg("error patching row")
+		return dbError(err, "error updating "+t.entity)
+	}
+	return ensureAffected(result, apperr.NotFound("no %s found with ID %v", t.entity, id))
+}
@@ -4,13 +4,26 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"northwind-api/internal/apperr"
 	"northwind-api/internal/models"
 
 	"github.com/rs/zerolog/log"
 )
 
+// ProductFilter membatasi hasil ListProducts. Field kosong atau 0 berarti
+// semua; Search mencocokkan potongan ProductName tanpa memperhatikan huruf
+// besar/kecil. Limit 0 berarti tanpa batas.
+type ProductFilter struct {
+	CategoryID   int
+	SupplierID   int
+	Discontinued *bool
+	Search       string
+	Limit        int
+	Offset       int
+}
+
 type ProductRepository struct {
-	DB *sql.DB
+	DB DBTX
 }
 
 func (r *ProductRepository) CreateProduct(ctx context.Context, p models.Product) (int64, error) {
@@ -28,11 +41,11 @@ func (r *ProductRepository) CreateProduct(ctx context.Context, p models.Product)
 		p.UnitsInStock,
 		p.UnitsOnOrder,
 		p.ReorderLevel,
-		p.Discontinued, // di Northwind klasik biasanya bool/int; di modelmu string
+		p.Discontinued, // bool disimpan sebagai 0/1, sama seperti data Northwind
 	)
 	if err != nil {
 		log.Error().Err(err).Msg("error creating product")
-		return 0, fmt.Errorf("error creating product: %w", err)
+		return 0, dbError(err, "error creating product")
 	}
 	id, err := result.LastInsertId()
 	if err != nil {
@@ -43,6 +56,62 @@ func (r *ProductRepository) CreateProduct(ctx context.Context, p models.Product)
 }
 
 func (r *ProductRepository) GetAllProducts(ctx context.Context) ([]models.Product, error) {
+	return r.listProducts(ctx, liveOnly(ctx, "DeletedAt"))
+}
+
+// ListProducts mengembalikan satu halaman produk aktif yang cocok dengan f,
+// urut ProductID, beserta jumlah seluruh produk yang cocok.
+func (r *ProductRepository) ListProducts(ctx context.Context, f ProductFilter) ([]models.Product, int, error) {
+	var c conditions
+	c.add(liveOnly(ctx, "DeletedAt"))
+	if f.CategoryID != 0 {
+		c.add("CategoryID = ?", f.CategoryID)
+	}
+	if f.SupplierID != 0 {
+		c.add("SupplierID = ?", f.SupplierID)
+	}
+	if f.Discontinued != nil {
+		c.add("Discontinued = ?", *f.Discontinued)
+	}
+	if f.Search != "" {
+		c.contains("ProductName", f.Search)
+	}
+	total, err := c.count(ctx, r.DB, "Products")
+	if err != nil {
+		return nil, 0, err
+	}
+	where, args := c.page("ProductID", f.Limit, f.Offset)
+	rows, err := r.listProducts(ctx, where, args...)
+	return rows, total, err
+}
+
+// GetProductsByIDs memuat beberapa product sekaligus, termasuk yang sudah
+// dihapus supaya rujukan dari data lama tetap bisa di-resolve.
+func (r *ProductRepository) GetProductsByIDs(ctx context.Context, ids []int) ([]models.Product, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return r.listProducts(ctx, "ProductID IN "+placeholders(len(ids)), inArgs(ids)...)
+}
+
+// GetProductsBySupplierIDs memuat produk aktif milik beberapa supplier sekaligus.
+func (r *ProductRepository) GetProductsBySupplierIDs(ctx context.Context, supplierIDs []int) ([]models.Product, error) {
+	if len(supplierIDs) == 0 {
+		return nil, nil
+	}
+	return r.listProducts(ctx, "SupplierID IN "+placeholders(len(supplierIDs))+" AND "+liveOnly(ctx, "DeletedAt"), inArgs(supplierIDs)...)
+}
+
+// GetProductsByCategoryIDs memuat produk aktif dari beberapa kategori sekaligus.
+func (r *ProductRepository) GetProductsByCategoryIDs(ctx context.Context, categoryIDs []int) ([]models.Product, error) {
+	if len(categoryIDs) == 0 {
+		return nil, nil
+	}
+	return r.listProducts(ctx, "CategoryID IN "+placeholders(len(categoryIDs))+" AND "+liveOnly(ctx, "DeletedAt"), inArgs(categoryIDs)...)
+}
+
+// listProducts menjalankan SELECT daftar dengan kondisi WHERE where.
+func (r *ProductRepository) listProducts(ctx context.Context, where string, args ...any) ([]models.Product, error) {
 	rows, err := r.DB.QueryContext(ctx, `
 		SELECT
 			ProductID,
@@ -54,9 +123,10 @@ func (r *ProductRepository) GetAllProducts(ctx context.Context) ([]models.Produc
 			UnitsInStock,
 			UnitsOnOrder,
 			ReorderLevel,
-			Discontinued
+			Discontinued,
+			DeletedAt
 		FROM Products
-	`)
+		WHERE `+where, args...)
 	if err != nil {
 		log.Error().Err(err).Msg("failed to query products")
 		return nil, fmt.Errorf("error fetching products: %w", err)
@@ -70,6 +140,7 @@ func (r *ProductRepository) GetAllProducts(ctx context.Context) ([]models.Produc
 			supplierID        sql.NullInt64
 			categoryID        sql.NullInt64
 			quantityPerUnitNS sql.NullString
+			deleted           sql.NullString
 		)
 		if err := rows.Scan(
 			&p.ProductID,
@@ -82,6 +153,7 @@ func (r *ProductRepository) GetAllProducts(ctx context.Context) ([]models.Produc
 			&p.UnitsOnOrder,
 			&p.ReorderLevel,
 			&p.Discontinued,
+			&deleted,
 		); err != nil {
 			log.Error().Err(err).Msg("failed to scan product")
 			return nil, fmt.Errorf("error scanning product: %w", err)
@@ -89,6 +161,7 @@ func (r *ProductRepository) GetAllProducts(ctx context.Context) ([]models.Produc
 		p.SupplierID = ptrInt64OrNil(supplierID)
 		p.CategoryID = ptrInt64OrNil(categoryID)
 		p.QuantityPerUnit = ptrStringOrNil(quantityPerUnitNS)
+		p.DeletedAt = deletedAt(deleted)
 
 		list = append(list, p)
 	}
@@ -105,6 +178,7 @@ func (r *ProductRepository) GetProductByID(ctx context.Context, id int) (models.
 		supplierID        sql.NullInt64
 		categoryID        sql.NullInt64
 		quantityPerUnitNS sql.NullString
+		deleted           sql.NullString
 	)
 	err := r.DB.QueryRowContext(ctx, `
 		SELECT
@@ -117,10 +191,10 @@ func (r *ProductRepository) GetProductByID(ctx context.Context, id int) (models.
 			UnitsInStock,
 			UnitsOnOrder,
 			ReorderLevel,
-			Discontinued
+			Discontinued,
+			DeletedAt
 		FROM Products
-		WHERE ProductID = ?
-	`, id).Scan(
+		WHERE ProductID = ? AND `+liveOnly(ctx, "DeletedAt"), id).Scan(
 		&p.ProductID,
 		&p.ProductName,
 		&supplierID,
@@ -131,10 +205,11 @@ func (r *ProductRepository) GetProductByID(ctx context.Context, id int) (models.
 		&p.UnitsOnOrder,
 		&p.ReorderLevel,
 		&p.Discontinued,
+		&deleted,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return models.Product{}, fmt.Errorf("product with ID %d not found", id)
+			return models.Product{}, apperr.NotFound("product with ID %d not found", id)
 		}
 		log.Error().Err(err).Int("product_id", id).Msg("failed to query product by id")
 		return models.Product{}, fmt.Errorf("error fetching product by id: %w", err)
@@ -143,11 +218,12 @@ func (r *ProductRepository) GetProductByID(ctx context.Context, id int) (models.
 	p.SupplierID = ptrInt64OrNil(supplierID)
 	p.CategoryID = ptrInt64OrNil(categoryID)
 	p.QuantityPerUnit = ptrStringOrNil(quantityPerUnitNS)
+	p.DeletedAt = deletedAt(deleted)
 	return p, nil
 }
 
 func (r *ProductRepository) UpdateProduct(ctx context.Context, p *models.Product) error {
-	_, err := r.DB.ExecContext(ctx, `
+	result, err := r.DB.ExecContext(ctx, `
 		UPDATE Products SET
 			ProductName = ?,
 			SupplierID = ?,
@@ -158,7 +234,7 @@ func (r *ProductRepository) UpdateProduct(ctx context.Context, p *models.Product
 			UnitsOnOrder = ?,
 			ReorderLevel = ?,
 			Discontinued = ?
-		WHERE ProductID = ?
+		WHERE ProductID = ? AND DeletedAt IS NULL
 	`,
 		p.ProductName,
 		p.SupplierID,      // *int, boleh nil
@@ -173,30 +249,28 @@ func (r *ProductRepository) UpdateProduct(ctx context.Context, p *models.Product
 	)
 	if err != nil {
 		log.Error().Err(err).Int("product_id", p.ProductID).Msg("error updating product")
-		return fmt.Errorf("error updating product: %w", err)
+		return dbError(err, "error updating product")
 	}
-	return nil
+	return ensureAffected(result, apperr.NotFound("product with ID %d not found", p.ProductID))
 }
 
+// DeleteProduct melakukan soft delete; order details lama tetap merujuk product ini.
 func (r *ProductRepository) DeleteProduct(ctx context.Context, id int) error {
-	result, err := r.DB.ExecContext(ctx, `DELETE FROM Products WHERE ProductID = ?`, id)
-	if err != nil {
-		log.Error().Err(err).Int("product_id", id).Msg("error deleting product")
-		return fmt.Errorf("error deleting product: %w", err)
-	}
-	aff, err := result.RowsAffected()
-	if err != nil {
-		log.Error().Err(err).Int("product_id", id).Msg("error fetching rows affected for product delete")
-		return fmt.Errorf("error fetching rows affected: %w", err)
-	}
-	if aff == 0 {
-		log.Warn().Int("product_id", id).Msg("no product found to delete")
-		return fmt.Errorf("no product found with ID %d", id)
+	if err := softDeleteRow(ctx, r.DB, "Products", "ProductID", "product", id); err != nil {
+		return err
 	}
 	log.Info().Int("product_id", id).Msg("product deleted")
 	return nil
 }
 
+func (r *ProductRepository) RestoreProduct(ctx context.Context, id int) error {
+	if err := restoreRow(ctx, r.DB, "Products", "ProductID", "product", id); err != nil {
+		return err
+	}
+	log.Info().Int("product_id", id).Msg("product restored")
+	return nil
+}
+
 // --- Lookup relasi: supplier & category ---
 
 func (r *ProductRepository) GetSupplierByProductID(ctx context.Context, productID int) (models.ProductSupplier, error) {
@@ -205,11 +279,10 @@ func (r *ProductRepository) GetSupplierByProductID(ctx context.Context, productI
 		SELECT s.SupplierID, s.CompanyName
 		FROM Products p
 		JOIN Suppliers s ON s.SupplierID = p.SupplierID
-		WHERE p.ProductID = ?
-	`, productID).Scan(&out.SupplierID, &out.CompanyName)
+		WHERE p.ProductID = ? AND `+liveOnly(ctx, "p.DeletedAt"), productID).Scan(&out.SupplierID, &out.CompanyName)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return models.ProductSupplier{}, fmt.Errorf("supplier for product %d not found", productID)
+			return models.ProductSupplier{}, apperr.NotFound("supplier for product %d not found", productID)
 		}
 		log.Error().Err(err).Int("product_id", productID).Msg("failed to query supplier by product id")
 		return models.ProductSupplier{}, fmt.Errorf("error fetching supplier for product: %w", err)
@@ -223,11 +296,10 @@ func (r *ProductRepository) GetCategoryByProductID(ctx context.Context, productI
 		SELECT c.CategoryID, c.CategoryName
 		FROM Products p
 		JOIN Categories c ON c.CategoryID = p.CategoryID
-		WHERE p.ProductID = ?
-	`, productID).Scan(&out.CategoryID, &out.CategoryName)
+		WHERE p.ProductID = ? AND `+liveOnly(ctx, "p.DeletedAt"), productID).Scan(&out.CategoryID, &out.CategoryName)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return models.ProductCategory{}, fmt.Errorf("category for product %d not found", productID)
+			return models.ProductCategory{}, apperr.NotFound("category for product %d not found", productID)
 		}
 		log.Error().Err(err).Int("product_id", productID).Msg("failed to query category by product id")
 		return models.ProductCategory{}, fmt.Errorf("error fetching category for product: %w", err)
@@ -252,3 +324,53 @@ func ptrStringOrNil(s sql.NullString) *string {
 	v := s.String
 	return &v
 }
+
+// GetProductIDsBySupplierID mengembalikan ID product live milik supplier; dipakai cek delete supplier.
+func (r *ProductRepository) GetProductIDsBySupplierID(ctx context.Context, supplierID int) ([]int, error) {
+	return r.liveIDsWhere(ctx, "SupplierID", supplierID)
+}
+
+// GetProductIDsByCategoryID mengembalikan ID product live di category; dipakai cek delete category.
+func (r *ProductRepository) GetProductIDsByCategoryID(ctx context.Context, categoryID int) ([]int, error) {
+	return r.liveIDsWhere(ctx, "CategoryID", categoryID)
+}
+
+func (r *ProductRepository) liveIDsWhere(ctx context.Context, column string, id int) ([]int, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT ProductID
+		FROM Products
+		WHERE `+column+` = ? AND DeletedAt IS NULL
+		ORDER BY ProductID
+	`, id)
+	if err != nil {
+		log.Error().Err(err).Str("column", column).Int("id", id).Msg("error fetching dependent products")
+		return nil, fmt.Errorf("error fetching dependent products: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var pid int
+		if err := rows.Scan(&pid); err != nil {
+			return nil, fmt.Errorf("error scanning product id: %w", err)
+		}
+		ids = append(ids, pid)
+	}
+	return ids, rows.Err()
+}
+
+var productPatch = patchTarget{
+	table:  "Products",
+	key:    "ProductID",
+	entity: "product",
+	columns: []string{
+		"ProductName", "SupplierID", "CategoryID", "QuantityPerUnit", "UnitPrice",
+		"UnitsInStock", "UnitsOnOrder", "ReorderLevel", "Discontinued",
+	},
+	softDelete: true,
+}
+
+// PatchProduct hanya meng-update kolom yang ada di changes.
+func (r *ProductRepository) PatchProduct(ctx context.Context, id int, changes Changes) error {
+	return patchRow(ctx, r.DB, productPatch, id, changes)
+}
@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"encoding/json"
+	"fmt"
+	"northwind-api/internal/models"
+)
+
+// Erased menggantikan nilai data pribadi di audit log, event log dan payload
+// webhook setelah subject-nya meminta penghapusan.
+const Erased = "[erased]"
+
+// RedactJSON mengganti nilai field top-level objek JSON raw dengan Erased.
+// Field yang tidak ada atau bernilai null dibiarkan. changed false berarti
+// raw tidak perlu ditulis ulang.
+func RedactJSON(raw []byte, fields []string) (out []byte, changed bool, err error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return raw, false, nil
+	}
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil, false, fmt.Errorf("error decoding snapshot: %w", err)
+	}
+	erased, _ := json.Marshal(Erased)
+	for _, f := range fields {
+		if v, ok := obj[f]; ok && string(v) != "null" && string(v) != string(erased) {
+			obj[f] = erased
+			changed = true
+		}
+	}
+	if !changed {
+		return raw, false, nil
+	}
+	out, err = json.Marshal(obj)
+	return out, true, err
+}
+
+// RedactEventPayload menjalankan RedactJSON pada before dan after payload
+// event (format webhook).
+func RedactEventPayload(raw []byte, fields []string) ([]byte, bool, error) {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil, false, fmt.Errorf("error decoding event payload: %w", err)
+	}
+	changed := false
+	for _, key := range []string{"before", "after"} {
+		v, ok, err := RedactJSON(obj[key], fields)
+		if err != nil {
+			return nil, false, err
+		}
+		if ok {
+			obj[key], changed = v, true
+		}
+	}
+	if !changed {
+		return raw, false, nil
+	}
+	out, err := json.Marshal(obj)
+	return out, true, err
+}
+
+// RedactChanges mengganti before/after field di diff audit dengan Erased.
+func RedactChanges(changes map[string]models.AuditChange, fields []string) bool {
+	changed := false
+	for _, f := range fields {
+		c, ok := changes[f]
+		if !ok {
+			continue
+		}
+		if c.Before != nil && c.Before != Erased {
+			c.Before, changed = Erased, true
+		}
+		if c.After != nil && c.After != Erased {
+			c.After, changed = Erased, true
+		}
+		changes[f] = c
+	}
+	return changed
+}
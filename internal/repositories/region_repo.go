@@ -4,13 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"northwind-api/internal/apperr"
 	"northwind-api/internal/models"
 
 	"github.com/rs/zerolog/log"
 )
 
 type RegionRepository struct {
-	DB *sql.DB
+	DB DBTX
 }
 
 func (r *RegionRepository) GetAllRegions(ctx context.Context) ([]models.Region, error) {
@@ -48,7 +49,7 @@ func (r *RegionRepository) GetRegionsByID(ctx context.Context, id int) (*models.
 		WHERE RegionID = ?`, id).Scan(&region.RegionID, &region.RegionDescription)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("region with ID %d not found", id)
+			return nil, apperr.NotFound("region with ID %d not found", id)
 		}
 		log.Error().Err(err).Msg("error querying region by ID")
 		return nil, fmt.Errorf("error querying region by ID: %w", err)
@@ -62,11 +63,11 @@ func (r *RegionRepository) GetEmployeesByTerritoryID(ctx context.Context, id str
 	log.Info().Msgf("Fetching employees for territory ID: %s", id)
 	rows, err := r.DB.QueryContext(ctx, `
 		SELECT e.EmployeeID, e.LastName, e.FirstName, e.Title, e.TitleOfCourtesy, e.BirthDate, e.HireDate,
-		       e.Address, e.City, e.Region, e.PostalCode, e.Country, e.HomePhone, e.Extension, e.Photo,
+		       e.Address, e.City, e.Region, e.PostalCode, e.Country, e.HomePhone, e.Extension,
 		       e.Notes, e.ReportsTo, e.PhotoPath
 		FROM Employees e
 		JOIN EmployeeTerritories et ON e.EmployeeID = et.EmployeeID
-		WHERE et.TerritoryID = ?`, id)
+		WHERE et.TerritoryID = ? AND `+liveOnly(ctx, "e.DeletedAt"), id)
 	if err != nil {
 		log.Error().Err(err).Msg("error querying employees by territory ID")
 		return nil, fmt.Errorf("error querying employees by territory ID: %w", err)
@@ -78,7 +79,7 @@ func (r *RegionRepository) GetEmployeesByTerritoryID(ctx context.Context, id str
 		var emp models.Employee
 		if err := rows.Scan(&emp.EmployeeID, &emp.LastName, &emp.FirstName, &emp.Title, &emp.TitleOfCourtesy,
 			&emp.BirthDate, &emp.HireDate, &emp.Address, &emp.City, &emp.Region, &emp.PostalCode,
-			&emp.Country, &emp.HomePhone, &emp.Extension, &emp.Photo, &emp.Notes, &emp.ReportsTo,
+			&emp.Country, &emp.HomePhone, &emp.Extension, &emp.Notes, &emp.ReportsTo,
 			&emp.PhotoPath); err != nil {
 			log.Error().Err(err).Msg("error scanning employee row")
 			return nil, fmt.Errorf("error scanning employee row: %w", err)
@@ -91,3 +92,90 @@ func (r *RegionRepository) GetEmployeesByTerritoryID(ctx context.Context, id str
 	}
 	return employees, nil
 }
+
+func (r *RegionRepository) GetAllTerritories(ctx context.Context) ([]models.Territory, error) {
+	return r.listTerritories(ctx, "1 = 1")
+}
+
+// GetTerritoriesByIDs memuat beberapa territory sekaligus.
+func (r *RegionRepository) GetTerritoriesByIDs(ctx context.Context, ids []string) ([]models.Territory, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return r.listTerritories(ctx, "TerritoryID IN "+placeholders(len(ids)), inArgs(ids)...)
+}
+
+func (r *RegionRepository) listTerritories(ctx context.Context, where string, args ...any) ([]models.Territory, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT TerritoryID, TRIM(TerritoryDescription), RegionID
+		FROM Territories
+		WHERE `+where+`
+		ORDER BY TerritoryID ASC`, args...)
+	if err != nil {
+		log.Error().Err(err).Msg("error querying territories")
+		return nil, fmt.Errorf("error querying territories: %w", err)
+	}
+	defer rows.Close()
+
+	var territories []models.Territory
+	for rows.Next() {
+		var t models.Territory
+		if err := rows.Scan(&t.TerritoryID, &t.TerritoryDesc, &t.RegionID); err != nil {
+			log.Error().Err(err).Msg("error scanning territory row")
+			return nil, fmt.Errorf("error scanning territory row: %w", err)
+		}
+		territories = append(territories, t)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error().Err(err).Msg("error iterating territory rows")
+		return nil, fmt.Errorf("error iterating territory rows: %w", err)
+	}
+	return territories, nil
+}
+
+// GetEmployeeTerritoriesByEmployeeIDs memuat pasangan employee-territory untuk beberapa employee sekaligus.
+func (r *RegionRepository) GetEmployeeTerritoriesByEmployeeIDs(ctx context.Context, employeeIDs []int) ([]models.EmployeeTerritory, error) {
+	if len(employeeIDs) == 0 {
+		return nil, nil
+	}
+	return r.listEmployeeTerritories(ctx, "et.EmployeeID IN "+placeholders(len(employeeIDs)), inArgs(employeeIDs)...)
+}
+
+// GetEmployeeTerritoriesByTerritoryIDs memuat pasangan employee-territory untuk beberapa territory
+// sekaligus; employee yang sudah dihapus dilewati seperti di GetEmployeesByTerritoryID.
+func (r *RegionRepository) GetEmployeeTerritoriesByTerritoryIDs(ctx context.Context, territoryIDs []string) ([]models.EmployeeTerritory, error) {
+	if len(territoryIDs) == 0 {
+		return nil, nil
+	}
+	return r.listEmployeeTerritories(ctx,
+		"et.TerritoryID IN "+placeholders(len(territoryIDs))+" AND "+liveOnly(ctx, "e.DeletedAt"), inArgs(territoryIDs)...)
+}
+
+func (r *RegionRepository) listEmployeeTerritories(ctx context.Context, where string, args ...any) ([]models.EmployeeTerritory, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT et.EmployeeID, et.TerritoryID
+		FROM EmployeeTerritories et
+		JOIN Employees e ON e.EmployeeID = et.EmployeeID
+		WHERE `+where+`
+		ORDER BY et.EmployeeID, et.TerritoryID`, args...)
+	if err != nil {
+		log.Error().Err(err).Msg("error querying employee territories")
+		return nil, fmt.Errorf("error querying employee territories: %w", err)
+	}
+	defer rows.Close()
+
+	var links []models.EmployeeTerritory
+	for rows.Next() {
+		var l models.EmployeeTerritory
+		if err := rows.Scan(&l.EmployeeID, &l.TerritoryID); err != nil {
+			log.Error().Err(err).Msg("error scanning employee territory row")
+			return nil, fmt.Errorf("error scanning employee territory row: %w", err)
+		}
+		links = append(links, l)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error().Err(err).Msg("error iterating employee territory rows")
+		return nil, fmt.Errorf("error iterating employee territory rows: %w", err)
+	}
+	return links, nil
+}
@@ -2,12 +2,11 @@ package repositories
 
 import (
 	"context"
-	"database/sql"
 	"northwind-api/internal/models"
 )
 
 type ReportRepository struct {
-	DB *sql.DB
+	DB DBTX
 }
 
 func (r *ReportRepository) GetTopCustomers(ctx context.Context) ([]models.TopCustomer, error) {
@@ -0,0 +1,171 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"northwind-api/internal/models"
+	"strings"
+	"unicode"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/text/unicode/norm"
+)
+
+// SearchRepository memakai tabel FTS5 SearchIndex (lihat migration 0007).
+type SearchRepository struct {
+	DB DBTX
+}
+
+// IndexDocuments mengganti isi index untuk setiap dokumen (FTS5 tidak punya upsert).
+func (r *SearchRepository) IndexDocuments(ctx context.Context, docs ...models.SearchDocument) error {
+	for _, d := range docs {
+		if err := r.RemoveDocument(ctx, d.Entity, d.EntityID); err != nil {
+			return err
+		}
+		if _, err := r.DB.ExecContext(ctx,
+			`INSERT INTO SearchIndex (Entity, EntityID, Title, Body) VALUES (?, ?, ?, ?)`,
+			d.Entity, d.EntityID, d.Title, d.Body,
+		); err != nil {
+			log.Error().Err(err).Str("entity", d.Entity).Str("id", d.EntityID).Msg("error indexing document")
+			return dbError(err, "error updating search index")
+		}
+	}
+	return nil
+}
+
+func (r *SearchRepository) RemoveDocument(ctx context.Context, entity, id string) error {
+	if _, err := r.DB.ExecContext(ctx, `DELETE FROM SearchIndex WHERE Entity = ? AND EntityID = ?`, entity, id); err != nil {
+		log.Error().Err(err).Str("entity", entity).Str("id", id).Msg("error removing document from search index")
+		return dbError(err, "error updating search index")
+	}
+	return nil
+}
+
+// markOpen dan markClose menandai kata yang cocok di hasil highlight() dan
+// snippet() FTS5. Teksnya di-escape dulu, baru penanda diganti <mark>, supaya
+// markup di data (mis. nama produk) tidak ikut dirender client.
+const (
+	markOpen  = "\x02"
+	markClose = "\x03"
+)
+
+var marks = strings.NewReplacer(markOpen, "<mark>", markClose, "</mark>")
+
+// Search menjalankan MATCH dengan ranking bm25; Title diberi bobot 10x Body.
+func (r *SearchRepository) Search(ctx context.Context, q SearchQuery) ([]models.SearchHit, error) {
+	query := `SELECT Entity, EntityID,
+			highlight(SearchIndex, 2, ?, ?),
+			snippet(SearchIndex, 3, ?, ?, '…', 12),
+			bm25(SearchIndex, 0, 0, 10.0, 1.0) AS rank
+		FROM SearchIndex WHERE SearchIndex MATCH ?`
+	args := []any{markOpen, markClose, markOpen, markClose, matchExpr(q.Terms)}
+	if len(q.Entities) > 0 {
+		query += ` AND Entity IN (?` + strings.Repeat(`, ?`, len(q.Entities)-1) + `)`
+		for _, e := range q.Entities {
+			args = append(args, e)
+		}
+	}
+	query += ` ORDER BY rank LIMIT ?`
+	args = append(args, q.Limit)
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to query search index")
+		return nil, fmt.Errorf("error searching: %w", err)
+	}
+	defer rows.Close()
+
+	hits := []models.SearchHit{}
+	for rows.Next() {
+		var (
+			h    models.SearchHit
+			rank float64
+		)
+		if err := rows.Scan(&h.Type, &h.ID, &h.Title, &h.Snippet, &rank); err != nil {
+			return nil, fmt.Errorf("error scanning search hit: %w", err)
+		}
+		h.Title, h.Snippet = highlighted(h.Title), highlighted(h.Snippet)
+		h.Score = -rank // bm25 FTS5 negatif: makin kecil makin relevan
+		hits = append(hits, h)
+	}
+	return hits, rows.Err()
+}
+
+func highlighted(text string) string {
+	return marks.Replace(html.EscapeString(text))
+}
+
+// matchExpr menyusun ekspresi FTS5, mis. ("chai"* OR "chay") AND "tea"*.
+// Setiap kata dikutip supaya karakter khusus FTS5 tidak ditafsirkan.
+func matchExpr(terms [][]SearchTerm) string {
+	groups := make([]string, len(terms))
+	for i, alts := range terms {
+		parts := make([]string, len(alts))
+		for j, t := range alts {
+			parts[j] = `"` + strings.ReplaceAll(t.Word, `"`, `""`) + `"`
+			if t.Prefix {
+				parts[j] += "*"
+			}
+		}
+		groups[i] = "(" + strings.Join(parts, " OR ") + ")"
+	}
+	return strings.Join(groups, " AND ")
+}
+
+func (r *SearchRepository) SearchTerms(ctx context.Context, minLen int) ([]string, error) {
+	rows, err := r.DB.QueryContext(ctx, `SELECT term FROM SearchVocab WHERE length(term) >= ?`, minLen)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to query search vocabulary")
+		return nil, fmt.Errorf("error fetching search terms: %w", err)
+	}
+	defer rows.Close()
+	var terms []string
+	for rows.Next() {
+		var t string
+		if err := rows.Scan(&t); err != nil {
+			return nil, fmt.Errorf("error scanning search term: %w", err)
+		}
+		terms = append(terms, t)
+	}
+	return terms, rows.Err()
+}
+
+// Mark meng-escape text sebagai HTML, membungkus kata yang cocok dengan
+// <mark>, dan melaporkan term mana saja yang ditemukan di text.
+func Mark(text string, terms [][]SearchTerm) (string, []bool) {
+	found := make([]bool, len(terms))
+	parts := strings.Fields(text)
+	for p, part := range parts {
+		parts[p] = html.EscapeString(part)
+		hit := false
+		for _, w := range SearchWords(part) {
+			for i, alts := range terms {
+				for _, t := range alts {
+					if w == t.Word || (t.Prefix && strings.HasPrefix(w, t.Word)) {
+						found[i], hit = true, true
+					}
+				}
+			}
+		}
+		if hit {
+			parts[p] = "<mark>" + parts[p] + "</mark>"
+		}
+	}
+	return strings.Join(parts, " "), found
+}
+
+// SearchWords memecah teks menjadi kata dengan aturan yang sama seperti
+// tokenizer FTS5 unicode61 remove_diacritics: huruf kecil, tanpa aksen,
+// dipisah oleh karakter selain huruf dan angka.
+func SearchWords(text string) []string {
+	var b strings.Builder
+	for _, c := range norm.NFD.String(text) {
+		if !unicode.Is(unicode.Mn, c) {
+			b.WriteRune(unicode.ToLower(c))
+		}
+	}
+	return strings.FieldsFunc(b.String(), func(c rune) bool {
+		return !unicode.IsLetter(c) && !unicode.IsNumber(c)
+	})
+}
@@ -4,21 +4,36 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"northwind-api/internal/apperr"
 	"northwind-api/internal/models"
 
 	"github.com/rs/zerolog/log"
 )
 
 type ShipperRepository struct {
-	DB *sql.DB
+	DB DBTX
 }
 
 func (r *ShipperRepository) GetAllShippers(ctx context.Context) ([]models.Shipper, error) {
+	return r.listShippers(ctx, liveOnly(ctx, "DeletedAt"))
+}
+
+// GetShippersByIDs memuat beberapa shipper sekaligus, termasuk yang sudah
+// dihapus supaya rujukan dari data lama tetap bisa di-resolve.
+func (r *ShipperRepository) GetShippersByIDs(ctx context.Context, ids []int) ([]models.Shipper, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return r.listShippers(ctx, "ShipperID IN "+placeholders(len(ids)), inArgs(ids)...)
+}
+
+// listShippers menjalankan SELECT daftar dengan kondisi WHERE where.
+func (r *ShipperRepository) listShippers(ctx context.Context, where string, args ...any) ([]models.Shipper, error) {
 	rows, err := r.DB.QueryContext(ctx, `
 		SELECT
-			ShipperID, CompanyName, Phone
+			ShipperID, CompanyName, Phone, DeletedAt
 		FROM Shippers
-	`)
+		WHERE `+where, args...)
 	if err != nil {
 		log.Error().Err(err).Msg("failed to query shippers")
 		return nil, fmt.Errorf("error fetching shippers: %w", err)
@@ -27,11 +42,15 @@ func (r *ShipperRepository) GetAllShippers(ctx context.Context) ([]models.Shippe
 
 	var shippers []models.Shipper
 	for rows.Next() {
-		var shipper models.Shipper
-		if err := rows.Scan(&shipper.ShipperID, &shipper.CompanyName, &shipper.Phone); err != nil {
+		var (
+			shipper models.Shipper
+			deleted sql.NullString
+		)
+		if err := rows.Scan(&shipper.ShipperID, &shipper.CompanyName, &shipper.Phone, &deleted); err != nil {
 			log.Error().Err(err).Msg("failed to scan shipper")
 			return nil, fmt.Errorf("error scanning shipper: %w", err)
 		}
+		shipper.DeletedAt = deletedAt(deleted)
 		shippers = append(shippers, shipper)
 	}
 	if err := rows.Err(); err != nil {
@@ -42,20 +61,23 @@ func (r *ShipperRepository) GetAllShippers(ctx context.Context) ([]models.Shippe
 }
 
 func (r *ShipperRepository) GetShipperByID(ctx context.Context, id int) (models.Shipper, error) {
-	var shipper models.Shipper
+	var (
+		shipper models.Shipper
+		deleted sql.NullString
+	)
 	err := r.DB.QueryRowContext(ctx, `
 		SELECT
-			ShipperID, CompanyName, Phone
+			ShipperID, CompanyName, Phone, DeletedAt
 		FROM Shippers
-		WHERE ShipperID = ?
-	`, id).Scan(&shipper.ShipperID, &shipper.CompanyName, &shipper.Phone)
+		WHERE ShipperID = ? AND `+liveOnly(ctx, "DeletedAt"), id).Scan(&shipper.ShipperID, &shipper.CompanyName, &shipper.Phone, &deleted)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return shipper, fmt.Errorf("shipper with ID %d not found", id)
+			return shipper, apperr.NotFound("shipper with ID %d not found", id)
 		}
 		log.Error().Err(err).Msg("failed to query shipper by ID")
 		return shipper, fmt.Errorf("error fetching shipper by ID: %w", err)
 	}
+	shipper.DeletedAt = deletedAt(deleted)
 	return shipper, nil
 }
 
@@ -67,29 +89,44 @@ func (r *ShipperRepository) CreateShipper(ctx context.Context, shipper models.Sh
 	)
 	if err != nil {
 		log.Error().Err(err).Msg("Error creating shipper")
-		return 0, fmt.Errorf("error creating shipper: %w", err)
+		return 0, dbError(err, "error creating shipper")
 	}
 	return result.LastInsertId()
 }
 
 func (r *ShipperRepository) UpdateShipper(ctx context.Context, shipper *models.Shipper) error {
-	_, err := r.DB.ExecContext(
+	result, err := r.DB.ExecContext(
 		ctx,
-		"UPDATE Shippers SET CompanyName = ?, Phone = ? WHERE ShipperID = ?",
+		"UPDATE Shippers SET CompanyName = ?, Phone = ? WHERE ShipperID = ? AND DeletedAt IS NULL",
 		shipper.CompanyName, shipper.Phone, shipper.ShipperID,
 	)
 	if err != nil {
 		log.Error().Err(err).Msg("Error updating shipper")
-		return fmt.Errorf("error updating shipper: %w", err)
+		return dbError(err, "error updating shipper")
 	}
-	return nil
+	return ensureAffected(result, apperr.NotFound("shipper with ID %d not found", shipper.ShipperID))
 }
 
+// DeleteShipper melakukan soft delete; order lama tetap merujuk shipper ini.
 func (r *ShipperRepository) DeleteShipper(ctx context.Context, id int) error {
-	_, err := r.DB.ExecContext(ctx, "DELETE FROM Shippers WHERE ShipperID = ?", id)
-	if err != nil {
-		log.Error().Err(err).Msg("Error deleting shipper")
-		return fmt.Errorf("error deleting shipper: %w", err)
-	}
-	return nil
+	return softDeleteRow(ctx, r.DB, "Shippers", "ShipperID", "shipper", id)
+}
+
+func (r *ShipperRepository) RestoreShipper(ctx context.Context, id int) error {
+	return restoreRow(ctx, r.DB, "Shippers", "ShipperID", "shipper", id)
+}
+
+var shipperPatch = patchTarget{
+	table:  "Shippers",
+	key:    "ShipperID",
+	entity: "shipper",
+	columns: []string{
+		"CompanyName", "Phone",
+	},
+	softDelete: true,
+}
+
+// PatchShipper hanya meng-update kolom yang ada di changes.
+func (r *ShipperRepository) PatchShipper(ctx context.Context, id int, changes Changes) error {
+	return patchRow(ctx, r.DB, shipperPatch, id, changes)
 }
@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"northwind-api/internal/apperr"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Master data (customers, products, suppliers, employees, shippers,
+// categories) tidak pernah dihapus fisik karena masih dirujuk order lama;
+// DELETE hanya mengisi kolom DeletedAt. Query baca menyembunyikan baris
+// tersebut kecuali ctx dibuat dengan WithDeleted.
+
+type withDeletedKey struct{}
+
+// WithDeleted membuat query baca di ctx ikut mengembalikan baris yang sudah
+// dihapus, dan PATCH ikut mengubahnya.
+func WithDeleted(ctx context.Context) context.Context {
+	return context.WithValue(ctx, withDeletedKey{}, true)
+}
+
+// IncludesDeleted melaporkan apakah ctx dibuat dengan WithDeleted.
+func IncludesDeleted(ctx context.Context) bool {
+	v, _ := ctx.Value(withDeletedKey{}).(bool)
+	return v
+}
+
+// liveOnly mengembalikan kondisi WHERE untuk baris yang belum dihapus, atau
+// kondisi yang selalu benar kalau ctx meminta baris terhapus juga.
+func liveOnly(ctx context.Context, column string) string {
+	if IncludesDeleted(ctx) {
+		return "1 = 1"
+	}
+	return column + " IS NULL"
+}
+
+// softDeleteRow menandai baris sebagai terhapus; baris yang tidak ada atau
+// sudah terhapus menghasilkan NotFound.
+func softDeleteRow(ctx context.Context, db DBTX, table, key, entity string, id any) error {
+	result, err := db.ExecContext(ctx,
+		fmt.Sprintf("UPDATE %s SET DeletedAt = ? WHERE %s = ? AND DeletedAt IS NULL", table, key),
+		time.Now().UTC().Format(time.RFC3339), id,
+	)
+	if err != nil {
+		log.Error().Err(err).Str("table", table).Msg("error soft deleting row")
+		return dbError(err, "error deleting "+entity)
+	}
+	return ensureAffected(result, apperr.NotFound("no %s found with ID %v", entity, id))
+}
+
+// restoreRow mengosongkan DeletedAt; baris yang tidak sedang terhapus menghasilkan NotFound.
+func restoreRow(ctx context.Context, db DBTX, table, key, entity string, id any) error {
+	result, err := db.ExecContext(ctx,
+		fmt.Sprintf("UPDATE %s SET DeletedAt = NULL WHERE %s = ? AND DeletedAt IS NOT NULL", table, key), id,
+	)
+	if err != nil {
+		log.Error().Err(err).Str("table", table).Msg("error restoring row")
+		return dbError(err, "error restoring "+entity)
+	}
+	return ensureAffected(result, apperr.NotFound("no deleted %s found with ID %v", entity, id))
+}
+
+// deletedAt mengubah kolom DeletedAt hasil scan menjadi nilai model.
+func deletedAt(s sql.NullString) *time.Time {
+	if !s.Valid {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, s.String)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
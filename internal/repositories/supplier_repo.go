@@ -4,16 +4,31 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"northwind-api/internal/apperr"
 	"northwind-api/internal/models"
 
 	"github.com/rs/zerolog/log"
 )
 
 type SupplierRepository struct {
-	DB *sql.DB
+	DB DBTX
 }
 
 func (r *SupplierRepository) GetAllSuppliers(ctx context.Context) ([]models.Supplier, error) {
+	return r.listSuppliers(ctx, liveOnly(ctx, "DeletedAt"))
+}
+
+// GetSuppliersByIDs memuat beberapa supplier sekaligus, termasuk yang sudah
+// dihapus supaya rujukan dari data lama tetap bisa di-resolve.
+func (r *SupplierRepository) GetSuppliersByIDs(ctx context.Context, ids []int) ([]models.Supplier, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return r.listSuppliers(ctx, "SupplierID IN "+placeholders(len(ids)), inArgs(ids)...)
+}
+
+// listSuppliers menjalankan SELECT daftar dengan kondisi WHERE where.
+func (r *SupplierRepository) listSuppliers(ctx context.Context, where string, args ...any) ([]models.Supplier, error) {
 	rows, err := r.DB.QueryContext(ctx, `
 		SELECT
 			SupplierID,
@@ -27,9 +42,10 @@ func (r *SupplierRepository) GetAllSuppliers(ctx context.Context) ([]models.Supp
 			Country,
 			Phone,
 			Fax,
-			HomePage
+			HomePage,
+			DeletedAt
 		FROM Suppliers
-	`)
+		WHERE `+where, args...)
 	if err != nil {
 		log.Error().Err(err).Msg("error fetching suppliers")
 		return nil, fmt.Errorf("error fetching suppliers: %w", err)
@@ -38,7 +54,10 @@ func (r *SupplierRepository) GetAllSuppliers(ctx context.Context) ([]models.Supp
 
 	var suppliers []models.Supplier
 	for rows.Next() {
-		var supplier models.Supplier
+		var (
+			supplier models.Supplier
+			deleted  sql.NullString
+		)
 		if err := rows.Scan(
 			&supplier.SupplierID,
 			&supplier.CompanyName,
@@ -52,10 +71,12 @@ func (r *SupplierRepository) GetAllSuppliers(ctx context.Context) ([]models.Supp
 			&supplier.Phone,
 			&supplier.Fax,
 			&supplier.HomePage,
+			&deleted,
 		); err != nil {
 			log.Error().Err(err).Msg("error scanning supplier")
 			return nil, fmt.Errorf("error scanning supplier: %w", err)
 		}
+		supplier.DeletedAt = deletedAt(deleted)
 		suppliers = append(suppliers, supplier)
 	}
 	if err := rows.Err(); err != nil {
@@ -66,7 +87,10 @@ func (r *SupplierRepository) GetAllSuppliers(ctx context.Context) ([]models.Supp
 }
 
 func (r *SupplierRepository) GetSupplierByID(ctx context.Context, id int) (models.Supplier, error) {
-	var supplier models.Supplier
+	var (
+		supplier models.Supplier
+		deleted  sql.NullString
+	)
 	err := r.DB.QueryRowContext(ctx, `
 		SELECT
 			SupplierID,
@@ -80,10 +104,10 @@ func (r *SupplierRepository) GetSupplierByID(ctx context.Context, id int) (model
 			Country,
 			Phone,
 			Fax,
-			HomePage
+			HomePage,
+			DeletedAt
 		FROM Suppliers
-		WHERE SupplierID = ?
-	`, id).Scan(
+		WHERE SupplierID = ? AND `+liveOnly(ctx, "DeletedAt"), id).Scan(
 		&supplier.SupplierID,
 		&supplier.CompanyName,
 		&supplier.ContactName,
@@ -96,15 +120,17 @@ func (r *SupplierRepository) GetSupplierByID(ctx context.Context, id int) (model
 		&supplier.Phone,
 		&supplier.Fax,
 		&supplier.HomePage,
+		&deleted,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return supplier, fmt.Errorf("supplier with ID %d not found",
+			return supplier, apperr.NotFound("supplier with ID %d not found",
 				id)
 		}
 		log.Error().Err(err).Msg("error fetching supplier by ID")
 		return supplier, fmt.Errorf("error fetching supplier by ID: %w", err)
 	}
+	supplier.DeletedAt = deletedAt(deleted)
 	return supplier, nil
 }
 
@@ -125,8 +151,7 @@ func (r *SupplierRepository) GetSuppliersByProductID(ctx context.Context, produc
 			s.HomePage
 		FROM Suppliers s
 		JOIN Products p ON s.SupplierID = p.SupplierID
-		WHERE p.ProductID = ?
-	`, productID)
+		WHERE p.ProductID = ? AND `+liveOnly(ctx, "p.DeletedAt"), productID)
 	if err != nil {
 		log.Error().Err(err).Msg("error fetching suppliers by product ID")
 		return nil, fmt.Errorf("error fetching suppliers by product ID: %w", err)
@@ -192,7 +217,7 @@ func (r *SupplierRepository) CreateSupplier(ctx context.Context, s *models.Suppl
 	)
 	if err != nil {
 		log.Error().Err(err).Msg("error creating supplier")
-		return fmt.Errorf("error creating supplier: %w", err)
+		return dbError(err, "error creating supplier")
 	}
 	id, err := result.LastInsertId()
 	if err != nil {
@@ -204,7 +229,7 @@ func (r *SupplierRepository) CreateSupplier(ctx context.Context, s *models.Suppl
 }
 
 func (r *SupplierRepository) UpdateSupplier(ctx context.Context, s *models.Supplier) error {
-	_, err := r.DB.ExecContext(ctx, `
+	result, err := r.DB.ExecContext(ctx, `
 		UPDATE Suppliers SET
 			CompanyName = ?,
 			ContactName = ?,
@@ -217,7 +242,7 @@ func (r *SupplierRepository) UpdateSupplier(ctx context.Context, s *models.Suppl
 			Phone = ?,
 			Fax = ?,
 			HomePage = ?
-		WHERE SupplierID = ?
+		WHERE SupplierID = ? AND DeletedAt IS NULL
 	`,
 		s.CompanyName,
 		s.ContactName,
@@ -234,24 +259,32 @@ func (r *SupplierRepository) UpdateSupplier(ctx context.Context, s *models.Suppl
 	)
 	if err != nil {
 		log.Error().Err(err).Msg("error updating supplier")
-		return fmt.Errorf("error updating supplier: %w", err)
+		return dbError(err, "error updating supplier")
 	}
-	return nil
+	return ensureAffected(result, apperr.NotFound("supplier with ID %d not found", s.SupplierID))
 }
 
+// DeleteSupplier melakukan soft delete; product lama tetap merujuk supplier ini.
 func (r *SupplierRepository) DeleteSupplier(ctx context.Context, id int) error {
-	result, err := r.DB.ExecContext(ctx, `DELETE FROM Suppliers WHERE SupplierID = ?`, id)
-	if err != nil {
-		log.Error().Err(err).Int("supplier_id", id).Msg("error deleting supplier")
-		return fmt.Errorf("error deleting supplier: %w", err)
-	}
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		log.Error().Err(err).Int("supplier_id", id).Msg("error fetching rows affected for delete supplier")
-		return fmt.Errorf("error fetching rows affected: %w", err)
-	}
-	if rowsAffected == 0 {
-		return fmt.Errorf("supplier with ID %d not found", id)
-	}
-	return nil
+	return softDeleteRow(ctx, r.DB, "Suppliers", "SupplierID", "supplier", id)
+}
+
+func (r *SupplierRepository) RestoreSupplier(ctx context.Context, id int) error {
+	return restoreRow(ctx, r.DB, "Suppliers", "SupplierID", "supplier", id)
+}
+
+var supplierPatch = patchTarget{
+	table:  "Suppliers",
+	key:    "SupplierID",
+	entity: "supplier",
+	columns: []string{
+		"CompanyName", "ContactName", "ContactTitle", "Address", "City", "Region",
+		"PostalCode", "Country", "Phone", "Fax", "HomePage",
+	},
+	softDelete: true,
+}
+
+// PatchSupplier hanya meng-update kolom yang ada di changes.
+func (r *SupplierRepository) PatchSupplier(ctx context.Context, id int, changes Changes) error {
+	return patchRow(ctx, r.DB, supplierPatch, id, changes)
 }
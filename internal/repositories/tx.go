@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+)
+
+// DBTX dipenuhi oleh *sql.DB maupun *sql.Tx, jadi repository yang sama bisa
+// dipakai di dalam atau di luar transaksi.
+type DBTX interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// TxRunner menjalankan fn di dalam satu transaksi. Kalau ctx sudah membawa
+// transaksi (nested call), fn memakai transaksi yang sama; commit/rollback
+// hanya dilakukan oleh pemanggil terluar.
+type TxRunner interface {
+	WithTx(ctx context.Context, fn func(ctx context.Context, r Repositories) error) error
+}
+
+type scopeKey struct{}
+
+// WithScope menandai ctx dengan repositories milik transaksi yang sedang aktif.
+func WithScope(ctx context.Context, r Repositories) context.Context {
+	return context.WithValue(ctx, scopeKey{}, r)
+}
+
+// Scoped mengembalikan repositories transaksi aktif di ctx, atau fallback kalau tidak ada.
+func Scoped(ctx context.Context, fallback Repositories) Repositories {
+	if r, ok := ctx.Value(scopeKey{}).(Repositories); ok {
+		return r
+	}
+	return fallback
+}
+
+func inScope(ctx context.Context) (Repositories, bool) {
+	r, ok := ctx.Value(scopeKey{}).(Repositories)
+	return r, ok
+}
+
+type SQLTxRunner struct {
+	DB *sql.DB
+}
+
+func (t *SQLTxRunner) WithTx(ctx context.Context, fn func(ctx context.Context, r Repositories) error) error {
+	if r, ok := inScope(ctx); ok {
+		return fn(ctx, r)
+	}
+
+	tx, err := t.DB.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error().Err(err).Msg("error starting transaction")
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	repos := NewSQLRepositories(tx)
+	if err := fn(WithScope(ctx, repos), repos); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			log.Error().Err(rbErr).Msg("error rolling back transaction")
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		log.Error().Err(err).Msg("error committing transaction")
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+	return nil
+}
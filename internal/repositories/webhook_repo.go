@@ -0,0 +1,371 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/models"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// DeliveryFilter membatasi hasil ListDeliveries. Field kosong berarti semua.
+type DeliveryFilter struct {
+	WebhookID int
+	Status    string
+	Limit     int
+}
+
+// WebhookRepository menyimpan subscription webhook dan outbox pengirimannya.
+// Delivery di-enqueue di transaksi yang sama dengan perubahan datanya, jadi
+// tidak ada event yang hilang walaupun proses restart sebelum terkirim.
+type WebhookRepository struct {
+	DB DBTX
+}
+
+const webhookColumns = `WebhookID, URL, Events, Secret, Active, Roles, CreatedAt, UpdatedAt`
+
+func (r *WebhookRepository) CreateWebhook(ctx context.Context, w *models.Webhook) error {
+	events, _ := json.Marshal(w.Events)
+	result, err := r.DB.ExecContext(ctx, `
+		INSERT INTO Webhooks (URL, Events, Secret, Active, Roles, CreatedAt, UpdatedAt)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		w.URL, string(events), w.Secret, w.Active, webhookRoles(w.Roles),
+		w.CreatedAt.UTC().Format(time.RFC3339Nano), w.UpdatedAt.UTC().Format(time.RFC3339Nano),
+	)
+	if err != nil {
+		log.Error().Err(err).Msg("error creating webhook")
+		return dbError(err, "error creating webhook")
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("error reading webhook id: %w", err)
+	}
+	w.WebhookID = int(id)
+	return nil
+}
+
+func (r *WebhookRepository) GetWebhook(ctx context.Context, id int) (models.Webhook, error) {
+	row := r.DB.QueryRowContext(ctx, `SELECT `+webhookColumns+` FROM Webhooks WHERE WebhookID = ?`, id)
+	w, err := scanWebhook(row.Scan)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return w, apperr.NotFound("webhook %d not found", id)
+		}
+		log.Error().Err(err).Int("webhook_id", id).Msg("failed to query webhook")
+		return w, fmt.Errorf("error fetching webhook: %w", err)
+	}
+	return w, nil
+}
+
+func (r *WebhookRepository) ListWebhooks(ctx context.Context) ([]models.Webhook, error) {
+	rows, err := r.DB.QueryContext(ctx, `SELECT `+webhookColumns+` FROM Webhooks ORDER BY WebhookID`)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to query webhooks")
+		return nil, fmt.Errorf("error fetching webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	webhooks := []models.Webhook{}
+	for rows.Next() {
+		w, err := scanWebhook(rows.Scan)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning webhook: %w", err)
+		}
+		webhooks = append(webhooks, w)
+	}
+	return webhooks, rows.Err()
+}
+
+func (r *WebhookRepository) UpdateWebhook(ctx context.Context, w *models.Webhook) error {
+	events, _ := json.Marshal(w.Events)
+	result, err := r.DB.ExecContext(ctx, `
+		UPDATE Webhooks SET URL = ?, Events = ?, Secret = ?, Active = ?, Roles = ?, UpdatedAt = ?
+		WHERE WebhookID = ?`,
+		w.URL, string(events), w.Secret, w.Active, webhookRoles(w.Roles), w.UpdatedAt.UTC().Format(time.RFC3339Nano), w.WebhookID,
+	)
+	if err != nil {
+		log.Error().Err(err).Msg("error updating webhook")
+		return dbError(err, "error updating webhook")
+	}
+	return ensureAffected(result, apperr.NotFound("webhook %d not found", w.WebhookID))
+}
+
+// DeleteWebhook ikut menghapus delivery dan log percobaannya (ON DELETE CASCADE).
+func (r *WebhookRepository) DeleteWebhook(ctx context.Context, id int) error {
+	result, err := r.DB.ExecContext(ctx, `DELETE FROM Webhooks WHERE WebhookID = ?`, id)
+	if err != nil {
+		log.Error().Err(err).Msg("error deleting webhook")
+		return dbError(err, "error deleting webhook")
+	}
+	return ensureAffected(result, apperr.NotFound("webhook %d not found", id))
+}
+
+func (r *WebhookRepository) EnqueueDeliveries(ctx context.Context, deliveries ...models.WebhookDelivery) error {
+	for _, d := range deliveries {
+		if _, err := r.DB.ExecContext(ctx, `
+			INSERT INTO WebhookDeliveries (WebhookID, EventType, Payload, Status, Attempts, NextAttemptAt, CreatedAt)
+			VALUES (?, ?, ?, ?, 0, ?, ?)`,
+			d.WebhookID, d.EventType, string(d.Payload), d.Status, formatTime(d.NextAttemptAt),
+			d.CreatedAt.UTC().Format(time.RFC3339Nano),
+		); err != nil {
+			log.Error().Err(err).Int("webhook_id", d.WebhookID).Msg("error enqueueing webhook delivery")
+			return dbError(err, "error enqueueing webhook delivery")
+		}
+	}
+	return nil
+}
+
+const deliveryColumns = `DeliveryID, WebhookID, EventType, Payload, Status, Attempts, NextAttemptAt,
+	LastStatusCode, LastError, CreatedAt, DeliveredAt`
+
+// DueDeliveries mengembalikan delivery pending milik webhook aktif yang
+// jadwalnya sudah lewat, yang terlama lebih dulu.
+func (r *WebhookRepository) DueDeliveries(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error) {
+	return r.queryDeliveries(ctx, `
+		SELECT `+deliveryColumns+` FROM WebhookDeliveries
+		WHERE Status = ? AND NextAttemptAt <= ?
+			AND WebhookID IN (SELECT WebhookID FROM Webhooks WHERE Active = 1)
+		ORDER BY NextAttemptAt, DeliveryID LIMIT ?`,
+		models.DeliveryPending, formatTime(&now), limit,
+	)
+}
+
+// ListDeliveries mengembalikan delivery terbaru lebih dulu, tanpa log percobaan.
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, f DeliveryFilter) ([]models.WebhookDelivery, error) {
+	query := `SELECT ` + deliveryColumns + ` FROM WebhookDeliveries WHERE 1 = 1`
+	var args []any
+	if f.WebhookID != 0 {
+		query += ` AND WebhookID = ?`
+		args = append(args, f.WebhookID)
+	}
+	if f.Status != "" {
+		query += ` AND Status = ?`
+		args = append(args, f.Status)
+	}
+	query += ` ORDER BY DeliveryID DESC LIMIT ?`
+	args = append(args, f.Limit)
+	return r.queryDeliveries(ctx, query, args...)
+}
+
+// GetDelivery mengembalikan delivery beserta log percobaannya.
+func (r *WebhookRepository) GetDelivery(ctx context.Context, id int64) (models.WebhookDelivery, error) {
+	row := r.DB.QueryRowContext(ctx, `SELECT `+deliveryColumns+` FROM WebhookDeliveries WHERE DeliveryID = ?`, id)
+	d, err := scanDelivery(row.Scan)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return d, apperr.NotFound("webhook delivery %d not found", id)
+		}
+		log.Error().Err(err).Int64("delivery_id", id).Msg("failed to query webhook delivery")
+		return d, fmt.Errorf("error fetching webhook delivery: %w", err)
+	}
+
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT AttemptID, AttemptedAt, StatusCode, Error, DurationMs
+		FROM WebhookAttempts WHERE DeliveryID = ? ORDER BY AttemptID`, id)
+	if err != nil {
+		log.Error().Err(err).Int64("delivery_id", id).Msg("failed to query webhook attempts")
+		return d, fmt.Errorf("error fetching webhook attempts: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			a           models.WebhookAttempt
+			attemptedAt string
+			status      sql.NullInt64
+			msg         sql.NullString
+		)
+		if err := rows.Scan(&a.AttemptID, &attemptedAt, &status, &msg, &a.DurationMs); err != nil {
+			return d, fmt.Errorf("error scanning webhook attempt: %w", err)
+		}
+		a.AttemptedAt, _ = time.Parse(time.RFC3339Nano, attemptedAt)
+		a.StatusCode = nullInt(status)
+		a.Error = msg.String
+		d.AttemptLog = append(d.AttemptLog, a)
+	}
+	return d, rows.Err()
+}
+
+// RecordAttempt menyimpan hasil satu percobaan: state baru delivery dan satu baris log.
+func (r *WebhookRepository) RecordAttempt(ctx context.Context, d models.WebhookDelivery, a models.WebhookAttempt) error {
+	result, err := r.DB.ExecContext(ctx, `
+		UPDATE WebhookDeliveries SET Status = ?, Attempts = ?, NextAttemptAt = ?,
+			LastStatusCode = ?, LastError = ?, DeliveredAt = ?
+		WHERE DeliveryID = ?`,
+		d.Status, d.Attempts, formatTime(d.NextAttemptAt), d.LastStatusCode, d.LastError,
+		formatTime(d.DeliveredAt), d.DeliveryID,
+	)
+	if err != nil {
+		log.Error().Err(err).Int64("delivery_id", d.DeliveryID).Msg("error updating webhook delivery")
+		return dbError(err, "error updating webhook delivery")
+	}
+	if err := ensureAffected(result, apperr.NotFound("webhook delivery %d not found", d.DeliveryID)); err != nil {
+		return err
+	}
+	if _, err := r.DB.ExecContext(ctx, `
+		INSERT INTO WebhookAttempts (DeliveryID, AttemptedAt, StatusCode, Error, DurationMs)
+		VALUES (?, ?, ?, ?, ?)`,
+		d.DeliveryID, a.AttemptedAt.UTC().Format(time.RFC3339Nano), a.StatusCode, a.Error, a.DurationMs,
+	); err != nil {
+		log.Error().Err(err).Int64("delivery_id", d.DeliveryID).Msg("error writing webhook attempt")
+		return dbError(err, "error writing webhook attempt")
+	}
+	return nil
+}
+
+// RequeueDelivery menjadwalkan ulang delivery dead dengan jatah percobaan baru.
+func (r *WebhookRepository) RequeueDelivery(ctx context.Context, id int64, at time.Time) error {
+	result, err := r.DB.ExecContext(ctx, `
+		UPDATE WebhookDeliveries SET Status = ?, Attempts = 0, NextAttemptAt = ?
+		WHERE DeliveryID = ? AND Status = ?`,
+		models.DeliveryPending, formatTime(&at), id, models.DeliveryDead,
+	)
+	if err != nil {
+		log.Error().Err(err).Int64("delivery_id", id).Msg("error requeueing webhook delivery")
+		return dbError(err, "error requeueing webhook delivery")
+	}
+	return ensureAffected(result, apperr.Conflict("webhook delivery %d is not dead", id))
+}
+
+// RedactDeliveries mengganti nilai fields di payload semua delivery untuk
+// event entity/id dengan Erased, termasuk yang belum terkirim.
+func (r *WebhookRepository) RedactDeliveries(ctx context.Context, entity, id string, fields []string) error {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT DeliveryID, Payload FROM WebhookDeliveries
+		WHERE json_extract(Payload, '$.entity') = ? AND json_extract(Payload, '$.entity_id') = ?`, entity, id)
+	if err != nil {
+		log.Error().Err(err).Str("entity", entity).Str("id", id).Msg("failed to query webhook deliveries")
+		return fmt.Errorf("error fetching webhook deliveries: %w", err)
+	}
+	redacted := map[int64][]byte{}
+	for rows.Next() {
+		var (
+			deliveryID int64
+			payload    string
+		)
+		if err := rows.Scan(&deliveryID, &payload); err != nil {
+			rows.Close()
+			return fmt.Errorf("error scanning webhook delivery: %w", err)
+		}
+		out, changed, err := RedactEventPayload([]byte(payload), fields)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+		if changed {
+			redacted[deliveryID] = out
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for deliveryID, payload := range redacted {
+		if _, err := r.DB.ExecContext(ctx, `UPDATE WebhookDeliveries SET Payload = ? WHERE DeliveryID = ?`, string(payload), deliveryID); err != nil {
+			log.Error().Err(err).Int64("delivery_id", deliveryID).Msg("error redacting webhook delivery")
+			return dbError(err, "error redacting webhook delivery")
+		}
+	}
+	return nil
+}
+
+func (r *WebhookRepository) queryDeliveries(ctx context.Context, query string, args ...any) ([]models.WebhookDelivery, error) {
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to query webhook deliveries")
+		return nil, fmt.Errorf("error fetching webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []models.WebhookDelivery{}
+	for rows.Next() {
+		d, err := scanDelivery(rows.Scan)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, rows.Err()
+}
+
+func scanWebhook(scan func(dest ...any) error) (models.Webhook, error) {
+	var (
+		w                    models.Webhook
+		events               string
+		roles                sql.NullString
+		createdAt, updatedAt string
+	)
+	if err := scan(&w.WebhookID, &w.URL, &events, &w.Secret, &w.Active, &roles, &createdAt, &updatedAt); err != nil {
+		return w, err
+	}
+	_ = json.Unmarshal([]byte(events), &w.Events)
+	if roles.Valid {
+		w.Roles = []string{}
+		_ = json.Unmarshal([]byte(roles.String), &w.Roles)
+	}
+	w.CreatedAt, _ = time.Parse(time.RFC3339Nano, createdAt)
+	w.UpdatedAt, _ = time.Parse(time.RFC3339Nano, updatedAt)
+	return w, nil
+}
+
+// webhookRoles menyimpan nil sebagai NULL supaya tetap beda dengan role kosong.
+func webhookRoles(roles []string) any {
+	if roles == nil {
+		return nil
+	}
+	b, _ := json.Marshal(roles)
+	return string(b)
+}
+
+func scanDelivery(scan func(dest ...any) error) (models.WebhookDelivery, error) {
+	var (
+		d                        models.WebhookDelivery
+		payload, createdAt       string
+		nextAttempt, deliveredAt sql.NullString
+		lastStatus               sql.NullInt64
+		lastError                sql.NullString
+	)
+	if err := scan(&d.DeliveryID, &d.WebhookID, &d.EventType, &payload, &d.Status, &d.Attempts,
+		&nextAttempt, &lastStatus, &lastError, &createdAt, &deliveredAt); err != nil {
+		return d, err
+	}
+	d.Payload = json.RawMessage(payload)
+	d.NextAttemptAt = parseTime(nextAttempt)
+	d.LastStatusCode = nullInt(lastStatus)
+	d.LastError = lastError.String
+	d.CreatedAt, _ = time.Parse(time.RFC3339Nano, createdAt)
+	d.DeliveredAt = parseTime(deliveredAt)
+	return d, nil
+}
+
+// deliveryTime lebar tetap supaya NextAttemptAt bisa dibandingkan sebagai teks.
+const deliveryTime = "2006-01-02T15:04:05.000000000Z"
+
+func formatTime(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return t.UTC().Format(deliveryTime)
+}
+
+func parseTime(s sql.NullString) *time.Time {
+	if !s.Valid {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, s.String)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+func nullInt(n sql.NullInt64) *int {
+	if !n.Valid {
+		return nil
+	}
+	v := int(n.Int64)
+	return &v
+}
@@ -0,0 +1,52 @@
+// Package requestctx membawa info request HTTP (request ID dan actor dari JWT)
+// lewat context, supaya services bisa memakainya tanpa bergantung pada gin.
+package requestctx
+
+import "context"
+
+type (
+	idKey    struct{}
+	actorKey struct{}
+	rolesKey struct{}
+)
+
+// Anonymous dipakai sebagai actor kalau request tidak membawa token (mis. non-production).
+const Anonymous = "anonymous"
+
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, idKey{}, id)
+}
+
+// ID mengembalikan request ID, atau "" kalau bukan dari request HTTP.
+func ID(ctx context.Context) string {
+	id, _ := ctx.Value(idKey{}).(string)
+	return id
+}
+
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// Actor mengembalikan user yang melakukan request, atau Anonymous.
+func Actor(ctx context.Context) string {
+	if actor, _ := ctx.Value(actorKey{}).(string); actor != "" {
+		return actor
+	}
+	return Anonymous
+}
+
+// WithRoles menyimpan role dari token. Request yang terautentikasi selalu
+// membawa roles, walaupun kosong.
+func WithRoles(ctx context.Context, roles []string) context.Context {
+	if roles == nil {
+		roles = []string{}
+	}
+	return context.WithValue(ctx, rolesKey{}, roles)
+}
+
+// Roles mengembalikan role caller. ok false berarti ctx tidak membawa
+// identitas (auth mati atau bukan dari request), jadi tidak dibatasi role.
+func Roles(ctx context.Context) (roles []string, ok bool) {
+	roles, ok = ctx.Value(rolesKey{}).([]string)
+	return roles, ok
+}
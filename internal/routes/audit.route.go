@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"northwind-api/internal/handlers"
+	"northwind-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterAuditRoutes memasang /audit dan /{resource}/:id/history untuk semua resource yang diaudit.
+func RegisterAuditRoutes(rg *gin.RouterGroup, h *handlers.AuditHandler) {
+	rg.GET("/audit", h.GetAll)
+	for _, entity := range services.AuditEntities {
+		rg.GET("/"+entity+"/:id/history", h.History(entity))
+	}
+}
@@ -13,6 +13,11 @@ func RegisterCategoryRoutes(rg *gin.RouterGroup, h *handlers.CategoryHandler) {
 		categories.GET("/:id", h.GetOne)
 		categories.POST("", h.Create)
 		categories.PUT("/:id", h.Update)
+		categories.PATCH("/:id", h.Patch)
 		categories.DELETE("/:id", h.Delete)
+		categories.POST("/:id/restore", h.Restore)
+		categories.GET("/:id/picture", h.Picture)
+		categories.PUT("/:id/picture", h.UploadPicture)
+		categories.DELETE("/:id/picture", h.DeletePicture)
 	}
 }
@@ -11,9 +11,16 @@ func RegisterCustomerRoutes(rg *gin.RouterGroup, h *handlers.CustomerHandler) {
 	customers := rg.Group("/customers")
 	{
 		customers.GET("", h.GetAll)
+		customers.GET("/duplicates", h.Duplicates)
 		customers.GET("/:id", h.GetOne)
 		customers.POST("", h.Create)
+		customers.POST("/bulk", h.Bulk)
 		customers.PUT("/:id", h.Update)
+		customers.PATCH("/:id", h.Patch)
 		customers.DELETE("/:id", h.Delete)
+		customers.POST("/:id/restore", h.Restore)
+		customers.POST("/:id/merge", h.Merge)
+		customers.GET("/:id/data-export", h.ExportData)
+		customers.POST("/:id/erase", h.Erase)
 	}
 }
@@ -14,6 +14,13 @@ func RegisterEmployeeRoutes(rg *gin.RouterGroup, h *handlers.EmployeeHandler) {
 		employees.GET("/:id", h.GetOne)
 		employees.POST("", h.Create)
 		employees.PUT("/:id", h.Update)
+		employees.PATCH("/:id", h.Patch)
 		employees.DELETE("/:id", h.Delete)
+		employees.POST("/:id/restore", h.Restore)
+		employees.GET("/:id/data-export", h.ExportData)
+		employees.POST("/:id/erase", h.Erase)
+		employees.GET("/:id/photo", h.Photo)
+		employees.PUT("/:id/photo", h.UploadPhoto)
+		employees.DELETE("/:id/photo", h.DeletePhoto)
 	}
 }
@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"northwind-api/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterGraphQLRoutes memasang endpoint GraphQL (read-only).
+func RegisterGraphQLRoutes(rg *gin.RouterGroup, h *handlers.GraphQLHandler) {
+	rg.GET("/graphql", h.Query)
+	rg.POST("/graphql", h.Query)
+}
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"northwind-api/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterImportRoutes(rg *gin.RouterGroup, h *handlers.ImportHandler) {
+	imports := rg.Group("/imports")
+	{
+		imports.GET("", h.GetAll)
+		imports.GET("/:id", h.GetOne)
+		imports.POST("", h.Create)
+		imports.POST("/:id/commit", h.Commit)
+	}
+}
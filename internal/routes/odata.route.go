@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"northwind-api/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterODataRoutes memasang feed OData v4 (read-only) untuk tool BI.
+func RegisterODataRoutes(rg *gin.RouterGroup, h *handlers.ODataHandler) {
+	rg.GET("/odata", h.ServiceDocument)
+	rg.GET("/odata/*path", h.Serve)
+}
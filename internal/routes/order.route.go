@@ -13,7 +13,9 @@ func RegisterOrderRoutes(rg *gin.RouterGroup, h *handlers.OrderHandler) {
 		orders.GET("/paginated", h.GetPaginated) // <-- Add this line
 		orders.GET("/:id", h.GetOne)
 		orders.POST("", h.Create)
+		orders.POST("/bulk", h.Bulk)
 		orders.PUT("/:id", h.Update)
+		orders.PATCH("/:id", h.Patch)
 		orders.DELETE("/:id", h.Delete)
 		orders.GET("/:id/details", h.GetOrderDetails)
 	}
@@ -12,8 +12,11 @@ func RegisterProductRoutes(rg *gin.RouterGroup, h *handlers.ProductHandler) {
 		products.GET("", h.GetAll)
 		products.GET("/:id", h.GetOne)
 		products.POST("", h.Create)
+		products.POST("/bulk", h.Bulk)
 		products.PUT("/:id", h.Update)
+		products.PATCH("/:id", h.Patch)
 		products.DELETE("/:id", h.Delete)
+		products.POST("/:id/restore", h.Restore)
 		products.GET("/:id/supplier", h.GetSupplier)
 		products.GET("/:id/category", h.GetCategory)
 	}
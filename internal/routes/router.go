@@ -2,10 +2,16 @@
 package routes
 
 import (
+	"context"
 	"database/sql"
+	"time"
 
+	"northwind-api/internal/gql"
 	"northwind-api/internal/handlers"
+	"northwind-api/internal/middleware"
+	"northwind-api/internal/odata"
 	"northwind-api/internal/repositories"
+	"northwind-api/internal/services"
 	"northwind-api/internal/utils"
 
 	"github.com/gin-gonic/gin"
@@ -15,47 +21,85 @@ import (
 type ConfigView interface {
 	Env() string    // "production" | "staging" | "development"
 	APIVer() string // e.g. "v1"
+	IfMatchRequired() bool
+	IdempotencyWindow() time.Duration
 }
 
 type Deps struct {
 	DB     *sql.DB
 	Config ConfigView
+	// Repos & Tx optional; kalau nil dibangun dari DB (dipakai test untuk inject fake in-memory)
+	Repos *repositories.Repositories
+	Tx    repositories.TxRunner
+	// Events optional; subscriber (audit, webhook, dsb.) didaftarkan ke dispatcher ini
+	Events *services.Dispatcher
+	// Services optional; diisi kalau services dipakai bersama server lain
+	// (mis. gRPC) supaya stream dan webhook melihat semua perubahan.
+	Services *services.Services
+	// Background optional; kalau diisi, job import yang tertinggal dari proses
+	// sebelumnya dipulihkan dan job baru berjalan sampai ctx ini selesai. Worker
+	// webhook dijalankan pemanggil lewat Services.Webhooks.Start.
+	Background context.Context
+	// CustomerIDs dipakai kalau Services nil; kosong berarti classic.
+	CustomerIDs services.CustomerIDStrategy
 }
 
 func Register(e *gin.Engine, d Deps) {
-	// Build shared repos/handlers here (or inside each sub-registrar)
-	customerRepo := &repositories.CustomerRepository{DB: d.DB}
-	customerHandler := &handlers.CustomerHandler{Repo: customerRepo}
-
-	employeeRepo := &repositories.EmployeeRepository{DB: d.DB}
-	employeeHandler := &handlers.EmployeeHandler{Repo: employeeRepo}
-
-	shipperRepo := &repositories.ShipperRepository{DB: d.DB}
-	shipperHandler := &handlers.ShipperHandler{Repo: shipperRepo}
-
-	productRepo := &repositories.ProductRepository{DB: d.DB}
-	productHandler := &handlers.ProductHandler{Repo: productRepo}
-
-	categoryRepo := &repositories.CategoryRepository{DB: d.DB}
-	categoryHandler := &handlers.CategoryHandler{Repo: categoryRepo}
-
-	supplierRepo := &repositories.SupplierRepository{DB: d.DB}
-	supplierHandler := &handlers.SupplierHandler{Repo: supplierRepo}
-
-	orderRepo := &repositories.OrderRepository{DB: d.DB}
-	orderHandler := &handlers.OrderHandler{Repo: orderRepo}
-
-	regionRepo := &repositories.RegionRepository{DB: d.DB}
-	regionHandler := &handlers.RegionHandler{Repo: regionRepo}
+	repos := d.Repos
+	if repos == nil {
+		r := repositories.NewSQLRepositories(d.DB)
+		repos = &r
+	}
+	tx := d.Tx
+	if tx == nil {
+		tx = &repositories.SQLTxRunner{DB: d.DB}
+	}
+	svc := d.Services
+	if svc == nil {
+		svc = services.New(*repos, tx, d.Events)
+		svc.Customers.IDStrategy = d.CustomerIDs
+	}
+	if d.Background != nil {
+		svc.Imports.Recover(d.Background)
+	}
 
-	reportRepo := &repositories.ReportRepository{DB: d.DB}
-	reportHandler := &handlers.ReportHandler{Repo: reportRepo}
+	// Build shared handlers here (or inside each sub-registrar)
+	customerHandler := &handlers.CustomerHandler{Svc: svc.Customers, Includes: svc.Includes}
+	employeeHandler := &handlers.EmployeeHandler{Svc: svc.Employees, Includes: svc.Includes}
+	shipperHandler := &handlers.ShipperHandler{Svc: svc.Shippers, Includes: svc.Includes}
+	productHandler := &handlers.ProductHandler{Svc: svc.Products, Includes: svc.Includes}
+	categoryHandler := &handlers.CategoryHandler{Svc: svc.Categories, Includes: svc.Includes}
+	supplierHandler := &handlers.SupplierHandler{Svc: svc.Suppliers, Includes: svc.Includes}
+	orderHandler := &handlers.OrderHandler{Svc: svc.Orders, Includes: svc.Includes}
+	importHandler := &handlers.ImportHandler{Svc: svc.Imports}
+	auditHandler := &handlers.AuditHandler{Svc: svc.Audit}
+	streamHandler := &handlers.StreamHandler{Svc: svc.Stream}
+	webhookHandler := &handlers.WebhookHandler{Svc: svc.Webhooks}
+	searchHandler := &handlers.SearchHandler{Svc: svc.Search}
+	regionHandler := &handlers.RegionHandler{Repo: repos.Regions}
+	reportHandler := &handlers.ReportHandler{Repo: repos.Reports}
+	schema, err := gql.NewSchema(*repos)
+	if err != nil {
+		// schema dibangun dari kode, jadi error di sini adalah bug
+		panic("graphql schema: " + err.Error())
+	}
+	graphqlHandler := &handlers.GraphQLHandler{Schema: schema}
+	odataHandler := &handlers.ODataHandler{Svc: odata.NewService(repos.OData)}
 
 	// Swagger (only non-prod)
 	RegisterSwagger(e, d.Config)
 
 	// Versioned API group
-	api := e.Group("/api/" + d.Config.APIVer())
+	// ETag/If-None-Match untuk GET, If-Match untuk PUT/PATCH/DELETE,
+	// Idempotency-Key untuk POST, ?include_deleted untuk GET
+	api := e.Group("/api/"+d.Config.APIVer(),
+		middleware.ConditionalGET(),
+		middleware.Preconditions(d.Config.IfMatchRequired()),
+		middleware.IncludeDeleted(),
+	)
+	if repos.Idempotency != nil {
+		api.Use(middleware.Idempotency(repos.Idempotency, d.Config.IdempotencyWindow()))
+	}
 
 	// Protected toggle
 	var protected *gin.RouterGroup
@@ -73,7 +117,22 @@ func Register(e *gin.Engine, d Deps) {
 	RegisterCategoryRoutes(protected, categoryHandler)
 	RegisterSupplierRoutes(protected, supplierHandler)
 	RegisterOrderRoutes(protected, orderHandler)
+	RegisterImportRoutes(protected, importHandler)
+	RegisterAuditRoutes(protected, auditHandler)
+	if svc.Stream.Enabled() {
+		RegisterStreamRoutes(protected, streamHandler)
+	}
+	if svc.Webhooks.Enabled() {
+		RegisterWebhookRoutes(protected, webhookHandler)
+	}
+	if svc.Search.Enabled() {
+		RegisterSearchRoutes(protected, searchHandler)
+	}
 	RegisterRegionRoutes(protected, regionHandler)
 	RegisterTeritoryRoutes(protected, regionHandler)
 	RegisterReportRoutes(protected, reportHandler)
+	RegisterGraphQLRoutes(protected, graphqlHandler)
+	if repos.OData != nil {
+		RegisterODataRoutes(protected, odataHandler)
+	}
 }
@@ -0,0 +1,1888 @@
+package routes_test
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"image"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	_ "northwind-api/docs"
+
+	"northwind-api/internal/images"
+	"northwind-api/internal/migrations"
+	"northwind-api/internal/models"
+	"northwind-api/internal/repositories"
+	"northwind-api/internal/repositories/memory"
+	"northwind-api/internal/routes"
+	"northwind-api/internal/server"
+	"northwind-api/internal/services"
+	"northwind-api/internal/utils"
+	"northwind-api/internal/visibility"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"golang.org/x/image/bmp"
+	_ "modernc.org/sqlite"
+)
+
+type testConfig struct {
+	requireIfMatch bool
+	env            string
+}
+
+func (c testConfig) Env() string {
+	if c.env == "" {
+		return "development"
+	}
+	return c.env
+}
+func (testConfig) APIVer() string          { return "v1" }
+func (c testConfig) IfMatchRequired() bool { return c.requireIfMatch }
+func (testConfig) IdempotencyWindow() time.Duration {
+	return time.Hour
+}
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	gin.DefaultWriter = io.Discard
+	gin.DefaultErrorWriter = io.Discard
+	os.Exit(m.Run())
+}
+
+func strPtr(s string) *string { return &s }
+func intPtr(i int) *int       { return &i }
+func i64Ptr(i int64) *int64   { return &i }
+
+// seed mengisi store dengan potongan kecil data Northwind.
+func seed() *memory.Store {
+	s := memory.NewStore()
+	s.Customers.Seed(
+		models.Customer{CustomerID: "ALFKI", CompanyName: "Alfreds Futterkiste", Country: "Germany"},
+		models.Customer{CustomerID: "ANATR", CompanyName: "Ana Trujillo Emparedados y helados", Country: "Mexico"},
+	)
+	s.Employees.Seed(
+		models.Employee{EmployeeID: 1, LastName: "Davolio", FirstName: "Nancy"},
+		models.Employee{EmployeeID: 2, LastName: "Fuller", FirstName: "Andrew"},
+	)
+	s.Shippers.Seed(models.Shipper{ShipperID: 1, CompanyName: "Speedy Express", Phone: "(503) 555-9831"})
+	s.Suppliers.Seed(models.Supplier{SupplierID: 1, CompanyName: "Exotic Liquids"})
+	s.Categories.Seed(models.Category{CategoryID: 1, CategoryName: strPtr("Beverages")})
+	s.Products.Seed(
+		models.Product{ProductID: 1, ProductName: "Chai", SupplierID: intPtr(1), CategoryID: intPtr(1), UnitPrice: 18},
+		models.Product{ProductID: 2, ProductName: "Orphan", UnitPrice: 1},
+	)
+	s.Orders.Seed(models.Order{OrderID: 10248, CustomerID: strPtr("ALFKI"), EmployeeID: i64Ptr(1), ShipVia: i64Ptr(1)})
+	s.Orders.SeedDetails(models.OrderDetail{OrderID: 10248, ProductID: 1, UnitPrice: 14, Quantity: 12})
+	s.Regions.Seed(models.Region{RegionID: 1, RegionDescription: "Eastern"})
+	s.Regions.SeedTerritories(models.Territory{TerritoryID: "01581", TerritoryDesc: "Westboro", RegionID: 1})
+	s.Regions.AssignTerritory("01581", 1)
+	s.Reports.TopCustomers = []models.TopCustomer{{CustomerID: "ALFKI", CompanyName: "Alfreds Futterkiste", TotalPurchase: 168}}
+	s.Reports.SalesSummary = models.SalesSummary{TotalOrders: 1}
+	s.Reports.AverageOrderValue = models.AverageOrderValue{Average: 168}
+	_ = s.Search.IndexDocuments(context.Background(),
+		models.SearchDocument{Entity: "customers", EntityID: "ALFKI", Title: "Alfreds Futterkiste", Body: "Germany"},
+		models.SearchDocument{Entity: "customers", EntityID: "ANATR", Title: "Ana Trujillo Emparedados y helados", Body: "Mexico"},
+		models.SearchDocument{Entity: "suppliers", EntityID: "1", Title: "Exotic Liquids"},
+		models.SearchDocument{Entity: "products", EntityID: "1", Title: "Chai"},
+		models.SearchDocument{Entity: "products", EntityID: "2", Title: "Orphan"},
+	)
+	return s
+}
+
+func newEngine(s *memory.Store) *gin.Engine {
+	return newEngineWith(s, testConfig{})
+}
+
+func newEngineWith(s *memory.Store, cfg testConfig) *gin.Engine {
+	e := server.NewEngine()
+	repos := s.Repositories()
+	routes.Register(e, routes.Deps{Config: cfg, Repos: &repos, Tx: s.TxRunner()})
+	return e
+}
+
+// do menjalankan satu request dengan header tambahan (pasangan nama, nilai).
+func do(e *gin.Engine, method, path, body string, headers ...string) *httptest.ResponseRecorder {
+	var r io.Reader
+	if body != "" {
+		r = strings.NewReader(body)
+	}
+	req := httptest.NewRequest(method, path, r)
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+type routeCase struct {
+	name     string
+	method   string
+	route    string // pattern yang terdaftar di gin, untuk cek coverage
+	path     string
+	body     string
+	want     int
+	wantBody string
+}
+
+var cases = []routeCase{
+	{"swagger ui", "GET", "/swagger/*any", "/swagger/index.html", "", 200, ""},
+
+	{"list customers", "GET", "/api/v1/customers", "/api/v1/customers", "", 200, `"customer_id":"ALFKI"`},
+	{"get customer", "GET", "/api/v1/customers/:id", "/api/v1/customers/ALFKI", "", 200, `"company_name":"Alfreds Futterkiste"`},
+	{"get missing customer", "GET", "/api/v1/customers/:id", "/api/v1/customers/NOPE", "", 404, ""},
+	{"create customer", "POST", "/api/v1/customers", "/api/v1/customers", `{"company_name":"New Co"}`, 201, `"company_name":"New Co"`},
+	{"create customer bad json", "POST", "/api/v1/customers", "/api/v1/customers", `{`, 400, ""},
+	{"create customer without company", "POST", "/api/v1/customers", "/api/v1/customers", `{"contact_name":"Maria"}`, 400, `"errors":[{"field":"company_name","message":"is required"}]`},
+	{"create customer wrong type", "POST", "/api/v1/customers", "/api/v1/customers", `{"company_name":42}`, 400, `{"field":"company_name","message":"must be a string"}`},
+	{"create customer bad country", "POST", "/api/v1/customers", "/api/v1/customers", `{"company_name":"New Co","country":"Atlantis"}`, 400, `"field":"country"`},
+	{"create customer iso country", "POST", "/api/v1/customers", "/api/v1/customers", `{"company_name":"New Co","country":"DE"}`, 201, ""},
+	{"update customer", "PUT", "/api/v1/customers/:id", "/api/v1/customers/ALFKI", `{"company_name":"Renamed"}`, 200, ""},
+	{"update customer bad json", "PUT", "/api/v1/customers/:id", "/api/v1/customers/ALFKI", `[`, 400, ""},
+	{"patch customer", "PATCH", "/api/v1/customers/:id", "/api/v1/customers/ALFKI", `{"city":"Berlin"}`, 200, `"company_name":"Alfreds Futterkiste","contact_name":"","contact_title":"","address":"","city":"Berlin"`},
+	{"patch customer id", "PATCH", "/api/v1/customers/:id", "/api/v1/customers/ALFKI", `{"customer_id":"XXXXX"}`, 400, `{"field":"customer_id","message":"is read-only"}`},
+	{"delete customer", "DELETE", "/api/v1/customers/:id", "/api/v1/customers/ANATR", "", 200, ""},
+	{"bulk customers", "POST", "/api/v1/customers/bulk", "/api/v1/customers/bulk", `[{"op":"create","data":{"company_name":"Bulk Co"}},{"op":"patch","id":"ALFKI","data":{"city":"Berlin"}}]`, 200, `"succeeded":2,"failed":0`},
+	{"bulk customers unknown op", "POST", "/api/v1/customers/bulk", "/api/v1/customers/bulk", `[{"op":"upsert","id":"ALFKI"}]`, 400, `"errors":[{"field":"op","message":"must be one of: create, update, patch, delete"}]`},
+	{"delete missing customer", "DELETE", "/api/v1/customers/:id", "/api/v1/customers/NOPE", "", 404, ""},
+	{"customer history", "GET", "/api/v1/customers/:id/history", "/api/v1/customers/ALFKI/history", "", 200, "[]"},
+	{"restore live customer", "POST", "/api/v1/customers/:id/restore", "/api/v1/customers/ALFKI/restore", "", 409, "customer is not deleted"},
+	{"restore missing customer", "POST", "/api/v1/customers/:id/restore", "/api/v1/customers/NOPE/restore", "", 404, ""},
+	{"customer duplicates", "GET", "/api/v1/customers/duplicates", "/api/v1/customers/duplicates", "", 200, "[]"},
+	{"customer duplicates low score", "GET", "/api/v1/customers/duplicates", "/api/v1/customers/duplicates?min_score=0.1", "", 200, `"matches":{"company_name":`},
+	{"customer duplicates bad score", "GET", "/api/v1/customers/duplicates", "/api/v1/customers/duplicates?min_score=2", "", 400, `"field":"min_score"`},
+	{"merge customer", "POST", "/api/v1/customers/:id/merge", "/api/v1/customers/ALFKI/merge", `{"duplicate_ids":["ANATR"]}`, 200, `"merged_ids":["ANATR"],"moved_order_ids":[]`},
+	{"merge customer into itself", "POST", "/api/v1/customers/:id/merge", "/api/v1/customers/ALFKI/merge", `{"duplicate_ids":["ALFKI"]}`, 400, `"field":"duplicate_ids"`},
+	{"merge unknown customer", "POST", "/api/v1/customers/:id/merge", "/api/v1/customers/ALFKI/merge", `{"duplicate_ids":["NOPE1"]}`, 400, "references unknown customer NOPE1"},
+	{"merge into missing customer", "POST", "/api/v1/customers/:id/merge", "/api/v1/customers/NOPE1/merge", `{"duplicate_ids":["ANATR"]}`, 404, ""},
+	{"merge without duplicates", "POST", "/api/v1/customers/:id/merge", "/api/v1/customers/ALFKI/merge", `{}`, 400, `"field":"duplicate_ids"`},
+	{"export customer data", "GET", "/api/v1/customers/:id/data-export", "/api/v1/customers/ALFKI/data-export", "", 200, `"customer":{"customer_id":"ALFKI"`},
+	{"export missing customer data", "GET", "/api/v1/customers/:id/data-export", "/api/v1/customers/NOPE1/data-export", "", 404, ""},
+	{"export customer data bad format", "GET", "/api/v1/customers/:id/data-export", "/api/v1/customers/ALFKI/data-export?format=xml", "", 400, `"field":"format"`},
+	{"erase customer", "POST", "/api/v1/customers/:id/erase", "/api/v1/customers/ALFKI/erase", "", 200, `"pseudonym":"anon-`},
+	{"erase missing customer", "POST", "/api/v1/customers/:id/erase", "/api/v1/customers/NOPE1/erase", "", 404, ""},
+
+	{"list employees", "GET", "/api/v1/employees", "/api/v1/employees", "", 200, `"last_name":"Davolio"`},
+	{"get employee", "GET", "/api/v1/employees/:id", "/api/v1/employees/1", "", 200, `"first_name":"Nancy"`},
+	{"get missing employee", "GET", "/api/v1/employees/:id", "/api/v1/employees/99", "", 404, ""},
+	{"create employee", "POST", "/api/v1/employees", "/api/v1/employees", `{"last_name":"King","first_name":"Robert"}`, 201, ""},
+	{"create employee bad json", "POST", "/api/v1/employees", "/api/v1/employees", `{`, 400, ""},
+	{"create employee bad date", "POST", "/api/v1/employees", "/api/v1/employees", `{"last_name":"King","first_name":"Robert","hire_date":"12/01/1994"}`, 400, `{"field":"hire_date","message":"must be an ISO 8601 date (YYYY-MM-DD)"}`},
+	{"create employee unknown manager", "POST", "/api/v1/employees", "/api/v1/employees", `{"last_name":"King","first_name":"Robert","reports_to":99}`, 400, `{"field":"reports_to","message":"references unknown employee"}`},
+	{"update employee", "PUT", "/api/v1/employees/:id", "/api/v1/employees/1", `{"last_name":"Davolio","first_name":"Nan"}`, 200, ""},
+	{"patch employee", "PATCH", "/api/v1/employees/:id", "/api/v1/employees/2", `{"reports_to":1}`, 200, `"reports_to":1`},
+	{"patch employee self manager", "PATCH", "/api/v1/employees/:id", "/api/v1/employees/1", `{"reports_to":1}`, 400, "must not reference the employee itself"},
+	{"delete employee", "DELETE", "/api/v1/employees/:id", "/api/v1/employees/2", "", 200, ""},
+	{"delete missing employee", "DELETE", "/api/v1/employees/:id", "/api/v1/employees/99", "", 404, ""},
+	{"employee history", "GET", "/api/v1/employees/:id/history", "/api/v1/employees/1/history", "", 200, "[]"},
+	{"restore live employee", "POST", "/api/v1/employees/:id/restore", "/api/v1/employees/1/restore", "", 409, ""},
+	{"export employee data", "GET", "/api/v1/employees/:id/data-export", "/api/v1/employees/1/data-export", "", 200, `"employee":{"employee_id":1`},
+	{"export missing employee data", "GET", "/api/v1/employees/:id/data-export", "/api/v1/employees/99/data-export", "", 404, ""},
+	{"erase employee", "POST", "/api/v1/employees/:id/erase", "/api/v1/employees/1/erase", "", 200, `"entity":"employees","entity_id":"1"`},
+	{"erase missing employee", "POST", "/api/v1/employees/:id/erase", "/api/v1/employees/99/erase", "", 404, ""},
+	{"get missing photo", "GET", "/api/v1/employees/:id/photo", "/api/v1/employees/1/photo", "", 404, "employee has no photo"},
+	{"get photo bad size", "GET", "/api/v1/employees/:id/photo", "/api/v1/employees/1/photo?size=4", "", 400, `"field":"size"`},
+	{"upload photo without file", "PUT", "/api/v1/employees/:id/photo", "/api/v1/employees/1/photo", "", 400, `"field":"file"`},
+	{"delete missing photo", "DELETE", "/api/v1/employees/:id/photo", "/api/v1/employees/1/photo", "", 404, ""},
+
+	{"list shippers", "GET", "/api/v1/shippers", "/api/v1/shippers", "", 200, `"company_name":"Speedy Express"`},
+	{"get shipper", "GET", "/api/v1/shippers/:id", "/api/v1/shippers/1", "", 200, ""},
+	{"get missing shipper", "GET", "/api/v1/shippers/:id", "/api/v1/shippers/9", "", 404, ""},
+	{"create shipper", "POST", "/api/v1/shippers", "/api/v1/shippers", `{"company_name":"Federal Shipping"}`, 201, ""},
+	{"create shipper bad json", "POST", "/api/v1/shippers", "/api/v1/shippers", `{`, 400, ""},
+	{"update shipper", "PUT", "/api/v1/shippers/:id", "/api/v1/shippers/1", `{"company_name":"Speedy","phone":"1"}`, 200, ""},
+	{"patch shipper", "PATCH", "/api/v1/shippers/:id", "/api/v1/shippers/1", `{"phone":"(503) 555-0000"}`, 200, `"company_name":"Speedy Express"`},
+	{"patch missing shipper", "PATCH", "/api/v1/shippers/:id", "/api/v1/shippers/9", `{"phone":"1"}`, 404, ""},
+	{"update missing shipper", "PUT", "/api/v1/shippers/:id", "/api/v1/shippers/9", `{"company_name":"Speedy"}`, 404, ""},
+	{"delete shipper", "DELETE", "/api/v1/shippers/:id", "/api/v1/shippers/1", "", 200, ""},
+	{"delete missing shipper", "DELETE", "/api/v1/shippers/:id", "/api/v1/shippers/9", "", 404, ""},
+	{"shipper history", "GET", "/api/v1/shippers/:id/history", "/api/v1/shippers/1/history", "", 200, "[]"},
+	{"restore live shipper", "POST", "/api/v1/shippers/:id/restore", "/api/v1/shippers/1/restore", "", 409, ""},
+
+	{"list products", "GET", "/api/v1/products", "/api/v1/products", "", 200, `"product_name":"Chai"`},
+	{"get product", "GET", "/api/v1/products/:id", "/api/v1/products/1", "", 200, `"unit_price":18`},
+	{"get missing product", "GET", "/api/v1/products/:id", "/api/v1/products/99", "", 404, ""},
+	{"create product", "POST", "/api/v1/products", "/api/v1/products", `{"product_name":"Chang","unit_price":19}`, 201, ""},
+	{"create product bad json", "POST", "/api/v1/products", "/api/v1/products", `{`, 400, ""},
+	{"create product without name", "POST", "/api/v1/products", "/api/v1/products", `{"unit_price":19}`, 400, "product_name"},
+	{"create product negative stock", "POST", "/api/v1/products", "/api/v1/products", `{"product_name":"Tea","unit_price":-1,"units_in_stock":-5}`, 400, `{"field":"unit_price","message":"must be at least 0"},{"field":"units_in_stock","message":"must be at least 0"}`},
+	{"create product discontinued string", "POST", "/api/v1/products", "/api/v1/products", `{"product_name":"Tea","discontinued":"1"}`, 400, `{"field":"discontinued","message":"must be a boolean"}`},
+	{"create product unknown category", "POST", "/api/v1/products", "/api/v1/products", `{"product_name":"Tea","category_id":42,"discontinued":true}`, 400, `{"field":"category_id","message":"references unknown category"}`},
+	{"update product", "PUT", "/api/v1/products/:id", "/api/v1/products/1", `{"product_name":"Chai","unit_price":20}`, 200, ""},
+	{"patch product", "PATCH", "/api/v1/products/:id", "/api/v1/products/1", `{"discontinued":true}`, 200, `"supplier_id":1,"category_id":1,"unit_price":18,"units_in_stock":0,"units_on_order":0,"reorder_level":0,"discontinued":true`},
+	{"patch product null name", "PATCH", "/api/v1/products/:id", "/api/v1/products/1", `{"product_name":null}`, 400, `{"field":"product_name","message":"must not be null"}`},
+	{"patch product unknown field", "PATCH", "/api/v1/products/:id", "/api/v1/products/1", `{"colour":"red"}`, 400, `{"field":"colour","message":"is not a known field"}`},
+	{"delete product", "DELETE", "/api/v1/products/:id", "/api/v1/products/2", "", 200, ""},
+	{"delete missing product", "DELETE", "/api/v1/products/:id", "/api/v1/products/99", "", 404, ""},
+	{"product history", "GET", "/api/v1/products/:id/history", "/api/v1/products/1/history", "", 200, "[]"},
+	{"product history bad limit", "GET", "/api/v1/products/:id/history", "/api/v1/products/1/history?limit=0", "", 400, `"field":"limit"`},
+	{"restore live product", "POST", "/api/v1/products/:id/restore", "/api/v1/products/1/restore", "", 409, ""},
+	{"list products bad include_deleted", "GET", "/api/v1/products", "/api/v1/products?include_deleted=maybe", "", 400, `"field":"include_deleted"`},
+	{"bulk products", "POST", "/api/v1/products/bulk", "/api/v1/products/bulk", `[{"op":"update","id":1,"data":{"product_name":"Chai","unit_price":21}},{"op":"create","data":{"product_name":"Chang"}}]`, 200, `"results":[{"index":0,"op":"update","id":"1","status":200},{"index":1,"op":"create","id":"3","status":201}]`},
+	{"bulk products atomic failure", "POST", "/api/v1/products/bulk", "/api/v1/products/bulk", `[{"op":"update","id":1,"data":{"product_name":"Chai"}},{"op":"delete","id":99}]`, 404, `"status":424`},
+	{"bulk products best effort", "POST", "/api/v1/products/bulk", "/api/v1/products/bulk?mode=best_effort", `[{"op":"update","id":1,"data":{"product_name":"Chai"}},{"op":"delete","id":99}]`, 207, `"succeeded":1,"failed":1`},
+	{"bulk products bad mode", "POST", "/api/v1/products/bulk", "/api/v1/products/bulk?mode=sometimes", `[{"op":"delete","id":1}]`, 400, `"field":"mode"`},
+	{"bulk products empty", "POST", "/api/v1/products/bulk", "/api/v1/products/bulk", `[]`, 400, "at least one operation"},
+	{"bulk products not array", "POST", "/api/v1/products/bulk", "/api/v1/products/bulk", `{"op":"delete"}`, 400, "JSON array"},
+	{"product supplier", "GET", "/api/v1/products/:id/supplier", "/api/v1/products/1/supplier", "", 200, `"company_name":"Exotic Liquids"`},
+	{"product without supplier", "GET", "/api/v1/products/:id/supplier", "/api/v1/products/2/supplier", "", 404, ""},
+	{"product category", "GET", "/api/v1/products/:id/category", "/api/v1/products/1/category", "", 200, `"category_name":"Beverages"`},
+	{"product without category", "GET", "/api/v1/products/:id/category", "/api/v1/products/2/category", "", 404, ""},
+
+	{"list categories", "GET", "/api/v1/categories", "/api/v1/categories", "", 200, `"category_name":"Beverages"`},
+	{"get category", "GET", "/api/v1/categories/:id", "/api/v1/categories/1", "", 200, ""},
+	{"get missing category", "GET", "/api/v1/categories/:id", "/api/v1/categories/9", "", 404, ""},
+	{"create category", "POST", "/api/v1/categories", "/api/v1/categories", `{"category_name":"Condiments"}`, 201, ""},
+	{"create category bad json", "POST", "/api/v1/categories", "/api/v1/categories", `{`, 400, "malformed JSON body"},
+	{"create category empty name", "POST", "/api/v1/categories", "/api/v1/categories", `{"category_name":""}`, 400, `"field":"category_name"`},
+	{"update category", "PUT", "/api/v1/categories/:id", "/api/v1/categories/1", `{"category_name":"Drinks"}`, 200, ""},
+	{"patch category", "PATCH", "/api/v1/categories/:id", "/api/v1/categories/1", `{"description":"Soft drinks"}`, 200, `"category_name":"Beverages","description":"Soft drinks"`},
+	{"update missing category", "PUT", "/api/v1/categories/:id", "/api/v1/categories/9", `{"category_name":"Drinks"}`, 404, ""},
+	{"delete category with products", "DELETE", "/api/v1/categories/:id", "/api/v1/categories/1", "", 409, `"blockers":[{"entity":"products","count":1,"ids":["1"]}]`},
+	{"delete category cascade", "DELETE", "/api/v1/categories/:id", "/api/v1/categories/1?cascade=true", "", 200, ""},
+	{"get missing picture", "GET", "/api/v1/categories/:id/picture", "/api/v1/categories/1/picture", "", 404, "category has no picture"},
+	{"get picture of missing category", "GET", "/api/v1/categories/:id/picture", "/api/v1/categories/9/picture", "", 404, ""},
+	{"upload picture without file", "PUT", "/api/v1/categories/:id/picture", "/api/v1/categories/1/picture", "", 400, `"field":"file"`},
+	{"delete missing picture", "DELETE", "/api/v1/categories/:id/picture", "/api/v1/categories/1/picture", "", 404, ""},
+	{"delete category reassign to missing", "DELETE", "/api/v1/categories/:id", "/api/v1/categories/1?reassign_to=9", "", 400, `{"field":"reassign_to","message":"references unknown category"}`},
+	{"category history", "GET", "/api/v1/categories/:id/history", "/api/v1/categories/1/history", "", 200, "[]"},
+	{"restore live category", "POST", "/api/v1/categories/:id/restore", "/api/v1/categories/1/restore", "", 409, ""},
+
+	{"list suppliers", "GET", "/api/v1/suppliers", "/api/v1/suppliers", "", 200, `"company_name":"Exotic Liquids"`},
+	{"get supplier", "GET", "/api/v1/suppliers/:id", "/api/v1/suppliers/1", "", 200, ""},
+	{"get missing supplier", "GET", "/api/v1/suppliers/:id", "/api/v1/suppliers/9", "", 404, ""},
+	{"create supplier", "POST", "/api/v1/suppliers", "/api/v1/suppliers", `{"company_name":"Tokyo Traders"}`, 201, ""},
+	{"create supplier bad json", "POST", "/api/v1/suppliers", "/api/v1/suppliers", `{`, 400, ""},
+	{"update supplier", "PUT", "/api/v1/suppliers/:id", "/api/v1/suppliers/1", `{"company_name":"Exotic"}`, 200, ""},
+	{"patch supplier", "PATCH", "/api/v1/suppliers/:id", "/api/v1/suppliers/1", `{"city":"London"}`, 200, `"company_name":"Exotic Liquids"`},
+	{"delete supplier with products", "DELETE", "/api/v1/suppliers/:id", "/api/v1/suppliers/1", "", 409, `"blockers":[{"entity":"products","count":1,"ids":["1"]}]`},
+	{"delete supplier cascade", "DELETE", "/api/v1/suppliers/:id", "/api/v1/suppliers/1?cascade=true", "", 200, ""},
+	{"delete supplier cascade and reassign", "DELETE", "/api/v1/suppliers/:id", "/api/v1/suppliers/1?cascade=true&reassign_to=2", "", 400, `"field":"reassign_to"`},
+	{"delete missing supplier", "DELETE", "/api/v1/suppliers/:id", "/api/v1/suppliers/9", "", 404, ""},
+	{"supplier history", "GET", "/api/v1/suppliers/:id/history", "/api/v1/suppliers/1/history", "", 200, "[]"},
+	{"restore live supplier", "POST", "/api/v1/suppliers/:id/restore", "/api/v1/suppliers/1/restore", "", 409, ""},
+
+	{"list orders", "GET", "/api/v1/orders", "/api/v1/orders", "", 200, `"order_id":10248`},
+	{"paginated orders", "GET", "/api/v1/orders/paginated", "/api/v1/orders/paginated?page=1&page_size=5", "", 200, `"total_items":1`},
+	{"get order", "GET", "/api/v1/orders/:id", "/api/v1/orders/10248", "", 200, `"customer_id":"ALFKI"`},
+	{"get missing order", "GET", "/api/v1/orders/:id", "/api/v1/orders/1", "", 404, ""},
+	{"create order", "POST", "/api/v1/orders", "/api/v1/orders", `{"customer_id":"ALFKI","freight":1.5}`, 201, `"order_id":10249`},
+	{"create order bad json", "POST", "/api/v1/orders", "/api/v1/orders", `{`, 400, ""},
+	{"create order unknown customer", "POST", "/api/v1/orders", "/api/v1/orders", `{"customer_id":"NOONE","ship_via":9}`, 400, `"errors":[{"field":"customer_id","message":"references unknown customer"},{"field":"ship_via","message":"references unknown shipper"}]`},
+	{"update order", "PUT", "/api/v1/orders/:id", "/api/v1/orders/10248", `{"freight":32.38}`, 200, ""},
+	{"patch order", "PATCH", "/api/v1/orders/:id", "/api/v1/orders/10248", `{"freight":32.38}`, 200, `"customer_id":"ALFKI","employee_id":1`},
+	{"patch order remove customer", "PATCH", "/api/v1/orders/:id", "/api/v1/orders/10248", `{"customer_id":null}`, 200, `"customer_id":null`},
+	{"patch order not object", "PATCH", "/api/v1/orders/:id", "/api/v1/orders/10248", `[1]`, 400, "merge patch must be a JSON object"},
+	{"update order bad json", "PUT", "/api/v1/orders/:id", "/api/v1/orders/10248", `{`, 400, ""},
+	{"update order negative freight", "PUT", "/api/v1/orders/:id", "/api/v1/orders/10248", `{"freight":-1}`, 400, `"field":"freight"`},
+	{"update missing order", "PUT", "/api/v1/orders/:id", "/api/v1/orders/1", `{"freight":1}`, 404, ""},
+	{"update order invalid id", "PUT", "/api/v1/orders/:id", "/api/v1/orders/abc", `{"freight":1}`, 400, "id"},
+	{"order history", "GET", "/api/v1/orders/:id/history", "/api/v1/orders/10248/history", "", 200, "[]"},
+	{"audit log", "GET", "/api/v1/audit", "/api/v1/audit?entity=orders&id=10248", "", 200, "[]"},
+	{"audit log unknown entity", "GET", "/api/v1/audit", "/api/v1/audit?entity=users", "", 400, `"field":"entity"`},
+	{"event stream unknown resource", "GET", "/api/v1/events/stream", "/api/v1/events/stream?resources=orders,users", "", 400, `"field":"resources"`},
+	{"event stream bad last id", "GET", "/api/v1/events/stream", "/api/v1/events/stream?last_event_id=abc", "", 400, `"field":"last_event_id"`},
+	{"list webhooks", "GET", "/api/v1/webhooks", "/api/v1/webhooks", "", 200, "[]"},
+	{"get missing webhook", "GET", "/api/v1/webhooks/:id", "/api/v1/webhooks/1", "", 404, ""},
+	{"create webhook", "POST", "/api/v1/webhooks", "/api/v1/webhooks", `{"url":"https://partner.example.com/hook","events":["order.shipped"]}`, 201, `"secret":"whsec_`},
+	{"create webhook unknown event", "POST", "/api/v1/webhooks", "/api/v1/webhooks", `{"url":"https://partner.example.com/hook","events":["order.lost"]}`, 400, `"field":"events"`},
+	{"create webhook non-http url", "POST", "/api/v1/webhooks", "/api/v1/webhooks", `{"url":"ftp://partner.example.com/hook","events":["*"]}`, 400, `"field":"url"`},
+	{"update missing webhook", "PUT", "/api/v1/webhooks/:id", "/api/v1/webhooks/1", `{"url":"https://partner.example.com/hook","events":["*"]}`, 404, ""},
+	{"delete missing webhook", "DELETE", "/api/v1/webhooks/:id", "/api/v1/webhooks/1", "", 404, ""},
+
+	{"search", "GET", "/api/v1/search", "/api/v1/search?q=cha", "", 200, `"type":"products","id":"1"`},
+	{"search typo", "GET", "/api/v1/search", "/api/v1/search?q=futterkiste+germny", "", 200, `"fuzzy":true`},
+	{"search without q", "GET", "/api/v1/search", "/api/v1/search", "", 400, `"field":"q"`},
+	{"search unknown type", "GET", "/api/v1/search", "/api/v1/search?q=chai&type=orders", "", 400, `"field":"type"`},
+	{"search list", "GET", "/api/v1/products", "/api/v1/products?q=chai", "", 200, `"product_name":"Chai"`},
+	{"list webhook deliveries", "GET", "/api/v1/webhook-deliveries", "/api/v1/webhook-deliveries?status=dead", "", 200, "[]"},
+	{"list webhook deliveries bad status", "GET", "/api/v1/webhook-deliveries", "/api/v1/webhook-deliveries?status=lost", "", 400, `"field":"status"`},
+	{"list deliveries of missing webhook", "GET", "/api/v1/webhook-deliveries", "/api/v1/webhook-deliveries?webhook_id=9", "", 400, `"field":"webhook_id"`},
+	{"get missing webhook delivery", "GET", "/api/v1/webhook-deliveries/:id", "/api/v1/webhook-deliveries/1", "", 404, ""},
+	{"retry missing webhook delivery", "POST", "/api/v1/webhook-deliveries/:id/retry", "/api/v1/webhook-deliveries/1/retry", "", 404, ""},
+
+	{"graphql query", "POST", "/api/v1/graphql", "/api/v1/graphql", `{"query":"{ order(id: 10248) { customer { companyName } shipper { companyName } details { quantity product { productName category { categoryName } } } } }"}`, 200, `"product":{"category":{"categoryName":"Beverages"},"productName":"Chai"}`},
+	{"graphql variables", "POST", "/api/v1/graphql", "/api/v1/graphql", `{"query":"query($id: String!) { customer(id: $id) { customerId } }","variables":{"id":"NOPE"}}`, 200, `"customer":null`},
+	{"graphql bad limit", "POST", "/api/v1/graphql", "/api/v1/graphql", `{"query":"{ customers(limit: 1000) { totalCount } }"}`, 200, `limit: must be between 1 and 500`},
+	{"graphql syntax error", "POST", "/api/v1/graphql", "/api/v1/graphql", `{"query":"{ customers {"}`, 200, `"errors"`},
+	{"graphql too deep", "POST", "/api/v1/graphql", "/api/v1/graphql", `{"query":"{ employee(id: 1) { manager { manager { manager { manager { manager { manager { manager { lastName } } } } } } } } }"}`, 200, `"message":"query depth 9 exceeds the maximum of 8"`},
+	{"graphql too deep via fragment", "POST", "/api/v1/graphql", "/api/v1/graphql", `{"query":"{ employee(id: 1) { ...Chain } } fragment Chain on Employee { manager { manager { manager { ... on Employee { manager { manager { manager { manager { lastName } } } } } } } } }"}`, 200, `"message":"query depth 9 exceeds the maximum of 8"`},
+	{"graphql introspection not limited", "POST", "/api/v1/graphql", "/api/v1/graphql", `{"query":"{ __schema { types { fields { type { ofType { ofType { ofType { ofType { ofType { name } } } } } } } } } }"}`, 200, `"__schema"`},
+	{"graphql body too large", "POST", "/api/v1/graphql", "/api/v1/graphql", `{"query":"{ shippers { totalCount } }` + strings.Repeat(" ", 64<<10) + `"}`, 413, "at most 64 KB"},
+	{"graphql body at limit", "POST", "/api/v1/graphql", "/api/v1/graphql", `{"query":"{ shippers { totalCount } }` + strings.Repeat(" ", 64<<10-len(`{"query":"{ shippers { totalCount } }"}`)) + `"}`, 200, `"totalCount":1`},
+	{"graphql get too large", "GET", "/api/v1/graphql", "/api/v1/graphql?query=%7Bshippers%7BtotalCount%7D%7D" + strings.Repeat("%20", 64<<10/3), "", 413, "at most 64 KB"},
+	{"graphql bad json", "POST", "/api/v1/graphql", "/api/v1/graphql", `{`, 400, ""},
+	{"graphql without query", "POST", "/api/v1/graphql", "/api/v1/graphql", `{"query":" "}`, 400, `"field":"query"`},
+	{"graphql get", "GET", "/api/v1/graphql", "/api/v1/graphql?query=%7Bterritories%7Bitems%7BterritoryDescription%20region%7BregionDescription%7D%7D%7D%7D", "", 200, `"regionDescription":"Eastern"`},
+	{"graphql get without query", "GET", "/api/v1/graphql", "/api/v1/graphql", "", 400, `"field":"query"`},
+	{"graphql get bad variables", "GET", "/api/v1/graphql", "/api/v1/graphql?query=%7Bshippers%7BtotalCount%7D%7D&variables=%5B", "", 400, `"field":"variables"`},
+	{"event websocket without upgrade", "GET", "/api/v1/events/ws", "/api/v1/events/ws", "", 426, "WebSocket upgrade"},
+	{"audit log id without entity", "GET", "/api/v1/audit", "/api/v1/audit?id=10248", "", 400, `{"field":"entity","message":"is required when id is given"}`},
+	{"delete order with details", "DELETE", "/api/v1/orders/:id", "/api/v1/orders/10248", "", 409, `"blockers":[{"entity":"order_details","count":1,"ids":["OrderID=10248,ProductID=1"]}]`},
+	{"delete order cascade", "DELETE", "/api/v1/orders/:id", "/api/v1/orders/10248?cascade=true", "", 200, ""},
+	{"delete order bad cascade", "DELETE", "/api/v1/orders/:id", "/api/v1/orders/10248?cascade=yes-please", "", 400, `"field":"cascade"`},
+	{"delete missing order", "DELETE", "/api/v1/orders/:id", "/api/v1/orders/1", "", 404, ""},
+	{"bulk orders", "POST", "/api/v1/orders/bulk", "/api/v1/orders/bulk", `[{"op":"patch","id":10248,"data":{"freight":40}},{"op":"patch","id":10248,"data":{"freight":-1}}]`, 400, `"index":1,"op":"patch","id":"10248","status":400`},
+	{"order details", "GET", "/api/v1/orders/:id/details", "/api/v1/orders/10248/details", "", 200, `"product_id":1`},
+	{"details of missing order", "GET", "/api/v1/orders/:id/details", "/api/v1/orders/1/details", "", 404, ""},
+
+	{"list regions", "GET", "/api/v1/regions", "/api/v1/regions", "", 200, `"region_description":"Eastern"`},
+	{"get region", "GET", "/api/v1/regions/:id", "/api/v1/regions/1", "", 200, ""},
+	{"get missing region", "GET", "/api/v1/regions/:id", "/api/v1/regions/9", "", 404, ""},
+	{"territory employees", "GET", "/api/v1/territories/:id/employees", "/api/v1/territories/01581/employees", "", 200, `"employee_id":1`},
+
+	{"top customers", "GET", "/api/v1/reports/top-customers", "/api/v1/reports/top-customers", "", 200, `"customer_id":"ALFKI"`},
+	{"top products", "GET", "/api/v1/reports/top-products", "/api/v1/reports/top-products", "", 200, ""},
+	{"sales by category", "GET", "/api/v1/reports/sales-by-category", "/api/v1/reports/sales-by-category", "", 200, ""},
+	{"sales by employee", "GET", "/api/v1/reports/sales-by-employee", "/api/v1/reports/sales-by-employee", "", 200, ""},
+	{"sales summary", "GET", "/api/v1/reports/sales-summary", "/api/v1/reports/sales-summary", "", 200, `"total_orders":1`},
+
+	{"list imports", "GET", "/api/v1/imports", "/api/v1/imports", "", 200, "[]"},
+	{"get missing import", "GET", "/api/v1/imports/:id", "/api/v1/imports/nope", "", 404, ""},
+	{"create import without file", "POST", "/api/v1/imports", "/api/v1/imports", `{"entity":"products"}`, 400, `"field":"file"`},
+	{"commit missing import", "POST", "/api/v1/imports/:id/commit", "/api/v1/imports/nope/commit", "", 404, ""},
+	{"monthly sales", "GET", "/api/v1/reports/monthly-sales", "/api/v1/reports/monthly-sales", "", 200, ""},
+	{"inventory status", "GET", "/api/v1/reports/inventory-status", "/api/v1/reports/inventory-status", "", 200, ""},
+	{"top suppliers", "GET", "/api/v1/reports/top-suppliers", "/api/v1/reports/top-suppliers", "", 200, ""},
+	{"customer growth", "GET", "/api/v1/reports/customer-growth", "/api/v1/reports/customer-growth", "", 200, ""},
+	{"order status summary", "GET", "/api/v1/reports/order-status-summary", "/api/v1/reports/order-status-summary", "", 200, ""},
+	{"region sales", "GET", "/api/v1/reports/region-sales", "/api/v1/reports/region-sales", "", 200, ""},
+	{"employee performance", "GET", "/api/v1/reports/employee-performance", "/api/v1/reports/employee-performance", "", 200, ""},
+	{"product profitability", "GET", "/api/v1/reports/product-profitability", "/api/v1/reports/product-profitability", "", 200, ""},
+	{"average order value", "GET", "/api/v1/reports/average-order-value", "/api/v1/reports/average-order-value", "", 200, `"average":168`},
+}
+
+func TestRoutes(t *testing.T) {
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := newEngine(seed())
+
+			var body io.Reader
+			if tc.body != "" {
+				body = strings.NewReader(tc.body)
+			}
+			req := httptest.NewRequest(tc.method, tc.path, body)
+			if tc.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			if rec.Code != tc.want {
+				t.Fatalf("%s %s: status = %d, want %d; body: %s", tc.method, tc.path, rec.Code, tc.want, rec.Body.String())
+			}
+			if tc.wantBody != "" && !strings.Contains(rec.Body.String(), tc.wantBody) {
+				t.Errorf("%s %s: body %s does not contain %s", tc.method, tc.path, rec.Body.String(), tc.wantBody)
+			}
+			if ct := rec.Header().Get("Content-Type"); tc.want >= 400 && !strings.HasPrefix(ct, "application/problem+json") {
+				t.Errorf("%s %s: Content-Type = %q, want application/problem+json", tc.method, tc.path, ct)
+			}
+		})
+	}
+}
+
+// TestRoutesCovered memastikan setiap route yang didaftarkan Register punya minimal satu case.
+func TestRoutesCovered(t *testing.T) {
+	covered := map[string]bool{}
+	for _, tc := range cases {
+		covered[tc.method+" "+tc.route] = true
+	}
+	for _, ri := range newEngine(seed()).Routes() {
+		if !covered[ri.Method+" "+ri.Path] {
+			t.Errorf("route %s %s has no test case", ri.Method, ri.Path)
+		}
+	}
+}
+
+func TestSoftDeleteAndRestore(t *testing.T) {
+	e := newEngine(seed())
+
+	if rec := do(e, "DELETE", "/api/v1/customers/ALFKI", ""); rec.Code != http.StatusOK {
+		t.Fatalf("delete: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	if rec := do(e, "GET", "/api/v1/customers", ""); strings.Contains(rec.Body.String(), "ALFKI") {
+		t.Fatalf("deleted customer still listed: %s", rec.Body.String())
+	}
+	if rec := do(e, "GET", "/api/v1/customers/ALFKI", ""); rec.Code != http.StatusNotFound {
+		t.Fatalf("get deleted: status = %d, want 404", rec.Code)
+	}
+	rec := do(e, "GET", "/api/v1/customers/ALFKI?include_deleted=true", "")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"deleted_at":`) {
+		t.Fatalf("include_deleted: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	if rec := do(e, "GET", "/api/v1/customers?include_deleted=true", ""); !strings.Contains(rec.Body.String(), "ALFKI") {
+		t.Fatalf("include_deleted list misses ALFKI: %s", rec.Body.String())
+	}
+
+	// Order lama tetap utuh, tapi order baru tidak boleh merujuk customer yang dihapus.
+	if rec := do(e, "GET", "/api/v1/orders/10248", ""); rec.Code != http.StatusOK {
+		t.Fatalf("historical order: status = %d", rec.Code)
+	}
+	if rec := do(e, "POST", "/api/v1/orders", `{"customer_id":"ALFKI","employee_id":1}`); rec.Code != http.StatusBadRequest {
+		t.Fatalf("order for deleted customer: status = %d, want 400; body: %s", rec.Code, rec.Body.String())
+	}
+	if rec := do(e, "PATCH", "/api/v1/customers/ALFKI", `{"city":"Berlin"}`); rec.Code != http.StatusNotFound {
+		t.Fatalf("patch deleted: status = %d, want 404", rec.Code)
+	}
+	if rec := do(e, "DELETE", "/api/v1/customers/ALFKI", ""); rec.Code != http.StatusNotFound {
+		t.Fatalf("delete twice: status = %d, want 404", rec.Code)
+	}
+
+	rec = do(e, "POST", "/api/v1/customers/ALFKI/restore", "")
+	if rec.Code != http.StatusOK || strings.Contains(rec.Body.String(), "deleted_at") {
+		t.Fatalf("restore: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	if rec := do(e, "GET", "/api/v1/customers/ALFKI", ""); rec.Code != http.StatusOK {
+		t.Fatalf("get restored: status = %d", rec.Code)
+	}
+	if rec := do(e, "POST", "/api/v1/customers/ALFKI/restore", ""); rec.Code != http.StatusConflict {
+		t.Fatalf("restore twice: status = %d, want 409", rec.Code)
+	}
+}
+
+func TestDeleteReassignsDependents(t *testing.T) {
+	s := seed()
+	s.Suppliers.Seed(models.Supplier{SupplierID: 2, CompanyName: "New Orleans Cajun Delights"})
+	s.Orders.Seed(models.Order{OrderID: 10249, CustomerID: strPtr("ANATR")})
+	e := newEngine(s)
+
+	if rec := do(e, "DELETE", "/api/v1/suppliers/1?reassign_to=2", ""); rec.Code != http.StatusOK {
+		t.Fatalf("reassign supplier: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	if rec := do(e, "GET", "/api/v1/products/1", ""); !strings.Contains(rec.Body.String(), `"supplier_id":2`) {
+		t.Fatalf("product not moved to supplier 2: %s", rec.Body.String())
+	}
+
+	// Order 10250 sudah punya baris untuk product 1, jadi tidak bisa menampung
+	// baris product 1 dari 10248.
+	s.Orders.Seed(models.Order{OrderID: 10250, CustomerID: strPtr("ANATR")})
+	s.Orders.SeedDetails(models.OrderDetail{OrderID: 10250, ProductID: 1, Quantity: 1})
+	rec := do(e, "DELETE", "/api/v1/orders/10248?reassign_to=10250", "")
+	if rec.Code != http.StatusConflict || !strings.Contains(rec.Body.String(), "order 10250 already has detail lines for product 1") {
+		t.Fatalf("reassign onto same product: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+
+	if rec := do(e, "DELETE", "/api/v1/orders/10248?reassign_to=10249", ""); rec.Code != http.StatusOK {
+		t.Fatalf("reassign order: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	rec = do(e, "GET", "/api/v1/orders/10249/details", "")
+	if !strings.Contains(rec.Body.String(), `"order_id":10249,"product_id":1`) {
+		t.Fatalf("details not moved to order 10249: %s", rec.Body.String())
+	}
+	rec = do(e, "GET", "/api/v1/orders/10249/history", "")
+	if !strings.Contains(rec.Body.String(), `"action":"detail_added"`) || !strings.Contains(rec.Body.String(), `"order_id":{"before":null,"after":10249}`) {
+		t.Fatalf("moved detail not in history of order 10249: %s", rec.Body.String())
+	}
+}
+
+func TestAuditLog(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret")
+	token, err := utils.GenerateJWT("nancy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := seed()
+	e := newEngineWith(s, testConfig{env: "production"})
+	auth := []string{"Authorization", "Bearer " + token, "X-Request-ID", "req-1"}
+
+	if rec := do(e, "PATCH", "/api/v1/products/1", `{"unit_price":19.5}`, auth...); rec.Code != http.StatusOK {
+		t.Fatalf("patch: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	if rec := do(e, "DELETE", "/api/v1/orders/10248?cascade=true", "", auth...); rec.Code != http.StatusOK {
+		t.Fatalf("delete: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+
+	rec := do(e, "GET", "/api/v1/products/1/history", "", auth...)
+	var history []models.AuditEntry
+	if err := json.Unmarshal(rec.Body.Bytes(), &history); err != nil || len(history) != 1 {
+		t.Fatalf("history: %v; body: %s", err, rec.Body.String())
+	}
+	got := history[0]
+	if got.Action != "updated" || got.Actor != "nancy" || got.RequestID != "req-1" {
+		t.Errorf("entry = %+v", got)
+	}
+	if len(got.Changes) != 1 || got.Changes["unit_price"].Before != 18.0 || got.Changes["unit_price"].After != 19.5 {
+		t.Errorf("changes = %+v, want only unit_price 18 -> 19.5", got.Changes)
+	}
+
+	rec = do(e, "GET", "/api/v1/audit?entity=orders&id=10248", "", auth...)
+	if !strings.Contains(rec.Body.String(), `"action":"deleted"`) || !strings.Contains(rec.Body.String(), `"customer_id":{"before":"ALFKI","after":null}`) {
+		t.Errorf("order audit: %s", rec.Body.String())
+	}
+	// Baris detail yang ikut terhapus tercatat satu per satu.
+	if !strings.Contains(rec.Body.String(), `"action":"detail_removed"`) || !strings.Contains(rec.Body.String(), `"product_id":{"before":1,"after":null}`) {
+		t.Errorf("order detail audit: %s", rec.Body.String())
+	}
+
+	// Perubahan yang gagal tidak meninggalkan jejak.
+	do(e, "PATCH", "/api/v1/products/1", `{"unit_price":-1}`, auth...)
+	if entries, _ := s.Audit.ListAudit(context.Background(), repositories.AuditFilter{Limit: 10}); len(entries) != 3 {
+		t.Errorf("audit entries = %d, want 3", len(entries))
+	}
+}
+
+func TestEventStream(t *testing.T) {
+	e := newEngine(seed())
+	srv := httptest.NewServer(e)
+	defer srv.Close()
+
+	// Event 1 (product), 2 (customer), 3 (product) sudah ada sebelum client konek.
+	do(e, "PATCH", "/api/v1/products/1", `{"unit_price":19.5}`)
+	do(e, "PATCH", "/api/v1/customers/ALFKI", `{"city":"Bandung"}`)
+	do(e, "PATCH", "/api/v1/products/1", `{"unit_price":20}`)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, "GET", srv.URL+"/api/v1/events/stream?resources=products", nil)
+	req.Header.Set("Last-Event-ID", "1")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if ct := res.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+
+	// Resume: event 3 dikirim ulang, event customer tidak ikut.
+	lines := bufio.NewScanner(res.Body)
+	next := func() string {
+		for lines.Scan() {
+			if strings.HasPrefix(lines.Text(), "id: ") {
+				id := lines.Text()
+				lines.Scan()
+				lines.Scan()
+				return id + " " + lines.Text()
+			}
+		}
+		t.Fatalf("stream ended: %v", lines.Err())
+		return ""
+	}
+	if got := next(); !strings.HasPrefix(got, "id: 3 data: ") || !strings.Contains(got, `"type":"product.updated"`) {
+		t.Errorf("first event = %s", got)
+	}
+
+	// Perubahan baru langsung dipush.
+	do(e, "DELETE", "/api/v1/products/2", "")
+	if got := next(); !strings.HasPrefix(got, "id: 4 data: ") || !strings.Contains(got, `"action":"deleted"`) {
+		t.Errorf("live event = %s", got)
+	}
+
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/api/v1/events/ws?resources=customers&last_event_id=0"
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	var got models.ChangeEvent
+	if err := conn.ReadJSON(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got.EventID != 2 || got.Entity != "customers" || !strings.Contains(string(got.After), `"city":"Bandung"`) {
+		t.Errorf("websocket event = %+v", got)
+	}
+}
+
+// webhookEngine memasang routes di atas services milik test, supaya test bisa
+// menjalankan worker webhook seperti main.
+func webhookEngine(s *memory.Store, cfg testConfig) (*gin.Engine, *services.Services) {
+	repos := s.Repositories()
+	svc := services.New(repos, s.TxRunner(), nil)
+	e := server.NewEngine()
+	routes.Register(e, routes.Deps{Config: cfg, Repos: &repos, Tx: s.TxRunner(), Services: svc})
+	return e, svc
+}
+
+func TestWebhooks(t *testing.T) {
+	type hit struct {
+		event, timestamp, signature string
+		body                        []byte
+	}
+	hits := make(chan hit, 20)
+	var failures atomic.Int32
+	failures.Store(1) // percobaan pertama dijawab 503
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		hits <- hit{r.Header.Get("X-Webhook-Event"), r.Header.Get("X-Webhook-Timestamp"), r.Header.Get("X-Webhook-Signature"), body}
+		if failures.Add(-1) >= 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer receiver.Close()
+
+	s := seed()
+	e, svc := webhookEngine(s, testConfig{})
+	ctx, cancel := context.WithCancel(context.Background())
+	svc.Webhooks.Start(ctx, services.WebhookOptions{MaxAttempts: 2, BaseDelay: 10 * time.Millisecond, PollInterval: 10 * time.Millisecond, AllowPrivate: true})
+	defer svc.Webhooks.Wait()
+	defer cancel()
+
+	const secret = "0123456789abcdef"
+	rec := do(e, "POST", "/api/v1/webhooks", `{"url":"`+receiver.URL+`","events":["order.shipped","product.low_stock"],"secret":"`+secret+`"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	if rec := do(e, "GET", "/api/v1/webhooks/1", ""); strings.Contains(rec.Body.String(), secret) {
+		t.Errorf("GET leaks the secret: %s", rec.Body.String())
+	}
+
+	// Customer tidak di-subscribe; order yang dikirim menghasilkan order.shipped.
+	do(e, "PATCH", "/api/v1/customers/ALFKI", `{"city":"Berlin"}`)
+	if rec := do(e, "PATCH", "/api/v1/orders/10248", `{"shipped_date":"1996-07-16"}`); rec.Code != http.StatusOK {
+		t.Fatalf("ship order: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	d := waitDelivery(t, e, 1, models.DeliveryDelivered)
+	if d.EventType != "order.shipped" || d.Attempts != 2 || len(d.AttemptLog) != 2 ||
+		*d.AttemptLog[0].StatusCode != 503 || *d.AttemptLog[1].StatusCode != 200 {
+		t.Errorf("delivery = %+v", d)
+	}
+	h := <-hits
+	if h.event != "order.shipped" || !strings.Contains(string(h.body), `"entity_id":"10248"`) {
+		t.Errorf("hit = %s %s", h.event, h.body)
+	}
+	ts, _ := strconv.ParseInt(h.timestamp, 10, 64)
+	if h.signature != services.SignWebhook(secret, ts, h.body) {
+		t.Errorf("signature %q does not verify", h.signature)
+	}
+
+	// Produk tanpa ReorderLevel (0) tidak pernah dianggap stoknya menipis,
+	// juga saat stoknya habis.
+	do(e, "PATCH", "/api/v1/products/1", `{"units_in_stock":50,"reorder_level":0}`)
+	do(e, "PATCH", "/api/v1/products/1", `{"units_in_stock":0}`)
+	if rec := do(e, "GET", "/api/v1/webhook-deliveries", ""); strings.Contains(rec.Body.String(), "product.low_stock") {
+		t.Errorf("low_stock without reorder level: %s", rec.Body.String())
+	}
+
+	// Receiver mati: setelah MaxAttempts delivery masuk dead-letter, lalu bisa di-retry.
+	failures.Store(100)
+	do(e, "PATCH", "/api/v1/products/1", `{"units_in_stock":50,"reorder_level":10}`)
+	do(e, "PATCH", "/api/v1/products/1", `{"units_in_stock":5}`)
+	waitDelivery(t, e, 2, models.DeliveryDead)
+	if rec := do(e, "GET", "/api/v1/webhook-deliveries?status=dead", ""); !strings.Contains(rec.Body.String(), `"event_type":"product.low_stock"`) {
+		t.Errorf("dead letters: %s", rec.Body.String())
+	}
+	failures.Store(0)
+	if rec := do(e, "POST", "/api/v1/webhook-deliveries/2/retry", ""); rec.Code != http.StatusOK {
+		t.Fatalf("retry: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	waitDelivery(t, e, 2, models.DeliveryDelivered)
+	if rec := do(e, "POST", "/api/v1/webhook-deliveries/2/retry", ""); rec.Code != http.StatusConflict {
+		t.Errorf("retry delivered: status = %d, want 409", rec.Code)
+	}
+}
+
+// Client default menolak receiver di alamat internal, juga lewat hostname
+// yang di-resolve ke sana, supaya webhook tidak bisa dipakai untuk SSRF.
+func TestWebhookBlocksInternalTargets(t *testing.T) {
+	var hits atomic.Int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { hits.Add(1) }))
+	defer receiver.Close()
+
+	s := seed()
+	e, svc := webhookEngine(s, testConfig{})
+	ctx, cancel := context.WithCancel(context.Background())
+	svc.Webhooks.Start(ctx, services.WebhookOptions{MaxAttempts: 1, PollInterval: 10 * time.Millisecond})
+	defer svc.Webhooks.Wait()
+	defer cancel()
+
+	_, port, _ := net.SplitHostPort(strings.TrimPrefix(receiver.URL, "http://"))
+	for _, host := range []string{"127.0.0.1", "localhost"} {
+		if rec := do(e, "POST", "/api/v1/webhooks", `{"url":"http://`+host+`:`+port+`/hook","events":["customer.updated"]}`); rec.Code != http.StatusCreated {
+			t.Fatalf("create %s: status = %d; body: %s", host, rec.Code, rec.Body.String())
+		}
+	}
+	do(e, "PATCH", "/api/v1/customers/ALFKI", `{"city":"Berlin"}`)
+	for id := 1; id <= 2; id++ {
+		d := waitDelivery(t, e, id, models.DeliveryDead)
+		if !strings.Contains(d.LastError, "is not allowed") {
+			t.Errorf("delivery %d error = %q", id, d.LastError)
+		}
+	}
+	if n := hits.Load(); n != 0 {
+		t.Errorf("receiver hit %d times", n)
+	}
+}
+
+// Payload webhook dibentuk dengan role yang menyimpan webhook, bukan role
+// yang memicu event-nya.
+func TestWebhookPayloadVisibility(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret")
+	bearer := func(roles ...string) []string {
+		token, err := utils.GenerateJWT("nancy", roles...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return []string{"Authorization", "Bearer " + token}
+	}
+	plain, hr := bearer(), bearer("hr")
+
+	bodies := make(chan [2]string, 4)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies <- [2]string{r.URL.Path, string(body)}
+	}))
+	defer receiver.Close()
+
+	s := seed()
+	s.Employees.Seed(models.Employee{EmployeeID: 3, LastName: "Leverling", FirstName: "Janet",
+		BirthDate: "1963-08-30", HomePhone: "(206) 555-3412", Notes: "Janet has a BS degree in chemistry."})
+	e, svc := webhookEngine(s, testConfig{env: "production"})
+	ctx, cancel := context.WithCancel(context.Background())
+	svc.Webhooks.Start(ctx, services.WebhookOptions{MaxAttempts: 1, PollInterval: 10 * time.Millisecond, AllowPrivate: true})
+	defer svc.Webhooks.Wait()
+	defer cancel()
+
+	for _, sub := range []struct {
+		path string
+		auth []string
+	}{{"/plain", plain}, {"/hr", hr}} {
+		if rec := do(e, "POST", "/api/v1/webhooks", `{"url":"`+receiver.URL+sub.path+`","events":["*"]}`, sub.auth...); rec.Code != http.StatusCreated {
+			t.Fatalf("create %s: status = %d; body: %s", sub.path, rec.Code, rec.Body.String())
+		}
+	}
+	if rec := do(e, "PATCH", "/api/v1/employees/3", `{"home_phone":"(206) 555-0000"}`, hr...); rec.Code != http.StatusOK {
+		t.Fatalf("patch: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+
+	got := map[string]string{}
+	for len(got) < 2 {
+		select {
+		case b := <-bodies:
+			got[b[0]] = b[1]
+		case <-time.After(2 * time.Second):
+			t.Fatalf("deliveries received: %v", got)
+		}
+	}
+	if body := got["/plain"]; !strings.Contains(body, `"type":"employee.updated"`) || strings.Contains(body, "555-") ||
+		strings.Contains(body, "1963-08-30") || strings.Contains(body, "chemistry") || !strings.Contains(body, `"last_name":"Leverling"`) {
+		t.Errorf("payload for non-HR subscriber: %s", body)
+	}
+	if body := got["/hr"]; !strings.Contains(body, `"home_phone":"(206) 555-0000"`) || !strings.Contains(body, "1963-08-30") {
+		t.Errorf("payload for HR subscriber: %s", body)
+	}
+
+	// Mengubah webhook HR tanpa role HR ikut menurunkan payload-nya.
+	if rec := do(e, "PUT", "/api/v1/webhooks/2", `{"url":"`+receiver.URL+`/hr","events":["*"]}`, plain...); rec.Code != http.StatusOK {
+		t.Fatalf("update: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	do(e, "PATCH", "/api/v1/employees/3", `{"home_phone":"(206) 555-1111"}`, hr...)
+	for range 2 {
+		select {
+		case b := <-bodies:
+			if strings.Contains(b[1], "555-1111") {
+				t.Errorf("payload for %s after non-HR update: %s", b[0], b[1])
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("second delivery not received")
+		}
+	}
+}
+
+func waitDelivery(t *testing.T, e *gin.Engine, id int, status string) models.WebhookDelivery {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		var d models.WebhookDelivery
+		rec := do(e, "GET", "/api/v1/webhook-deliveries/"+strconv.Itoa(id), "")
+		if rec.Code == http.StatusOK {
+			if err := json.Unmarshal(rec.Body.Bytes(), &d); err != nil {
+				t.Fatalf("decoding delivery: %v (%s)", err, rec.Body)
+			}
+			if d.Status == status {
+				return d
+			}
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("delivery %d not %s: %s", id, status, rec.Body)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// countingOrders dan countingProducts menghitung query batch untuk memastikan
+// relasi GraphQL dimuat sekali per level, bukan sekali per baris (N+1).
+type countingOrders struct {
+	*memory.OrderRepository
+	byCustomer, details atomic.Int32
+}
+
+func (r *countingOrders) GetOrdersByCustomerIDs(ctx context.Context, ids []string) ([]models.Order, error) {
+	r.byCustomer.Add(1)
+	return r.OrderRepository.GetOrdersByCustomerIDs(ctx, ids)
+}
+
+func (r *countingOrders) GetOrderDetailsByOrderIDs(ctx context.Context, ids []int) ([]models.OrderDetail, error) {
+	r.details.Add(1)
+	return r.OrderRepository.GetOrderDetailsByOrderIDs(ctx, ids)
+}
+
+type countingProducts struct {
+	*memory.ProductRepository
+	byID atomic.Int32
+}
+
+func (r *countingProducts) GetProductsByIDs(ctx context.Context, ids []int) ([]models.Product, error) {
+	r.byID.Add(1)
+	return r.ProductRepository.GetProductsByIDs(ctx, ids)
+}
+
+func TestGraphQLBatchesRelations(t *testing.T) {
+	s := seed()
+	for i := 0; i < 5; i++ {
+		id := "CUST" + strconv.Itoa(i)
+		s.Customers.Seed(models.Customer{CustomerID: id, CompanyName: "Company " + id})
+		s.Orders.Seed(models.Order{OrderID: int64(20000 + i), CustomerID: strPtr(id)})
+		s.Orders.SeedDetails(
+			models.OrderDetail{OrderID: int64(20000 + i), ProductID: 1, Quantity: 1},
+			models.OrderDetail{OrderID: int64(20000 + i), ProductID: 2, Quantity: 2},
+		)
+	}
+	orders := &countingOrders{OrderRepository: s.Orders}
+	products := &countingProducts{ProductRepository: s.Products}
+	repos := s.Repositories()
+	repos.Orders = orders
+	repos.Products = products
+	e := server.NewEngine()
+	routes.Register(e, routes.Deps{Config: testConfig{}, Repos: &repos, Tx: s.TxRunner()})
+
+	query := `{"query":"{ customers(limit: 10) { totalCount hasNextPage items { customerId orders { orderId details { product { productName } } } } } }"}`
+	rec := do(e, "POST", "/api/v1/graphql", query)
+	var res struct {
+		Data struct {
+			Customers struct {
+				TotalCount  int
+				HasNextPage bool
+				Items       []struct {
+					CustomerID string
+					Orders     []struct {
+						Details []struct {
+							Product *struct{ ProductName string }
+						}
+					}
+				}
+			}
+		}
+		Errors []any
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil || rec.Code != 200 || len(res.Errors) > 0 {
+		t.Fatalf("graphql = %d %s", rec.Code, rec.Body.String())
+	}
+	page := res.Data.Customers
+	if page.TotalCount != 7 || page.HasNextPage || len(page.Items) != 7 {
+		t.Fatalf("page = %+v", page)
+	}
+	lines := 0
+	for _, c := range page.Items {
+		for _, o := range c.Orders {
+			for _, d := range o.Details {
+				if d.Product == nil {
+					t.Fatalf("customer %s: detail without product", c.CustomerID)
+				}
+				lines++
+			}
+		}
+	}
+	if lines != 11 {
+		t.Errorf("order lines = %d, want 11", lines)
+	}
+	if n := orders.byCustomer.Load(); n != 1 {
+		t.Errorf("orders by customer queried %d times, want 1", n)
+	}
+	if n := orders.details.Load(); n != 1 {
+		t.Errorf("order details queried %d times, want 1", n)
+	}
+	if n := products.byID.Load(); n != 1 {
+		t.Errorf("products queried %d times, want 1", n)
+	}
+
+	rec = do(e, "POST", "/api/v1/graphql", `{"query":"{ customers(limit: 3, offset: 3) { hasNextPage items { customerId } } }"}`)
+	if !strings.Contains(rec.Body.String(), `"hasNextPage":true`) || !strings.Contains(rec.Body.String(), `"customerId":"CUST1"`) {
+		t.Errorf("second page = %s", rec.Body.String())
+	}
+}
+
+func TestIncludeAndSparseFieldsets(t *testing.T) {
+	s := seed()
+	s.Orders.Seed(models.Order{OrderID: 10249, CustomerID: strPtr("ANATR"), EmployeeID: i64Ptr(2)})
+	s.Orders.SeedDetails(models.OrderDetail{OrderID: 10249, ProductID: 1, Quantity: 3}, models.OrderDetail{OrderID: 10249, ProductID: 2, Quantity: 4})
+	orders := &countingOrders{OrderRepository: s.Orders}
+	products := &countingProducts{ProductRepository: s.Products}
+	repos := s.Repositories()
+	repos.Orders = orders
+	repos.Products = products
+	e := server.NewEngine()
+	routes.Register(e, routes.Deps{Config: testConfig{}, Repos: &repos, Tx: s.TxRunner()})
+
+	rec := do(e, "GET", "/api/v1/orders/10248?include=customer,employee,shipper,details.product&fields[order]=order_id,order_date&fields[customer]=company_name&fields[employee]=last_name&fields[product]=product_name", "")
+	want := `{"customer":{"company_name":"Alfreds Futterkiste"},"details":[{"discount":0,"order_id":10248,"product":{"product_name":"Chai"},"product_id":1,"quantity":12,"unit_price":14}],` +
+		`"employee":{"last_name":"Davolio"},` +
+		`"order_date":null,"order_id":10248,"shipper":{"company_name":"Speedy Express","phone":"(503) 555-9831","shipper_id":1}}`
+	if rec.Code != 200 || rec.Body.String() != want {
+		t.Fatalf("order with includes = %d\n got %s\nwant %s", rec.Code, rec.Body.String(), want)
+	}
+	if tag := rec.Header().Get("ETag"); tag == "" || tag == do(e, "GET", "/api/v1/orders/10248", "").Header().Get("ETag") {
+		t.Errorf("compound ETag %q must differ from the resource ETag", tag)
+	}
+
+	// list: relasi dimuat sekali per level, bukan per order
+	orders.details.Store(0)
+	products.byID.Store(0)
+	rec = do(e, "GET", "/api/v1/orders/paginated?page_size=5&include=details.product&fields[order]=order_id&fields[order_detail]=quantity&fields[product]=product_name", "")
+	var page models.Paginated[struct {
+		OrderID int `json:"order_id"`
+		Details []struct {
+			Quantity int
+			Product  *struct {
+				ProductName string `json:"product_name"`
+			}
+		}
+	}]
+	if err := json.Unmarshal(rec.Body.Bytes(), &page); err != nil || page.TotalItems != 2 || len(page.Items) != 2 {
+		t.Fatalf("paginated = %d %s", rec.Code, rec.Body.String())
+	}
+	if d := page.Items[1].Details; len(d) != 2 || d[0].Product.ProductName != "Chai" || d[1].Product.ProductName != "Orphan" {
+		t.Errorf("order 10249 details = %+v", d)
+	}
+	if orders.details.Load() != 1 || products.byID.Load() != 1 {
+		t.Errorf("details queried %d times, products %d times; want 1 each", orders.details.Load(), products.byID.Load())
+	}
+
+	rec = do(e, "GET", "/api/v1/customers?include=orders&fields[customer]=customer_id&fields[order]=order_id", "")
+	if !strings.Contains(rec.Body.String(), `{"customer_id":"ALFKI","orders":[{"order_id":10248}]}`) {
+		t.Errorf("customers with orders = %s", rec.Body.String())
+	}
+	rec = do(e, "GET", "/api/v1/employees/1?include=manager,territories&fields[employee]=last_name", "")
+	if rec.Body.String() != `{"last_name":"Davolio","manager":null,"territories":[{"region_id":1,"territory_description":"Westboro","territory_id":"01581"}]}` {
+		t.Errorf("employee with territories = %s", rec.Body.String())
+	}
+
+	for _, path := range []string{
+		"/api/v1/orders/10248?include=invoice",
+		"/api/v1/orders/10248?include=details.supplier",
+		"/api/v1/orders/10248?fields[order]=order_id,total",
+		"/api/v1/orders/10248?fields[invoice]=id",
+		"/api/v1/shippers/1?include=orders",
+	} {
+		if rec := do(e, "GET", path, ""); rec.Code != 400 || rec.Header().Get("Content-Type") != "application/problem+json" {
+			t.Errorf("%s = %d %s", path, rec.Code, rec.Body.String())
+		}
+	}
+}
+
+// northwindSQL membuat potongan schema Northwind di SQLite in-memory untuk
+// endpoint yang menjalankan SQL langsung (OData), lengkap dengan data contoh.
+func northwindSQL(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+	stmts := []string{
+		`CREATE TABLE Customers (CustomerID TEXT PRIMARY KEY, CompanyName TEXT, ContactName TEXT, ContactTitle TEXT, Address TEXT, City TEXT, Region TEXT, PostalCode TEXT, Country TEXT, Phone TEXT, Fax TEXT)`,
+		`CREATE TABLE Employees (EmployeeID INTEGER PRIMARY KEY, LastName TEXT, FirstName TEXT, Title TEXT, TitleOfCourtesy TEXT, BirthDate DATETIME, HireDate DATETIME, Address TEXT, City TEXT, Region TEXT, PostalCode TEXT, Country TEXT, HomePhone TEXT, Extension TEXT, Photo BLOB, Notes TEXT, ReportsTo INTEGER, PhotoPath TEXT)`,
+		`CREATE TABLE Shippers (ShipperID INTEGER PRIMARY KEY, CompanyName TEXT, Phone TEXT)`,
+		`CREATE TABLE Categories (CategoryID INTEGER PRIMARY KEY, CategoryName TEXT, Description TEXT, Picture BLOB)`,
+		`CREATE TABLE Suppliers (SupplierID INTEGER PRIMARY KEY, CompanyName TEXT, ContactName TEXT, ContactTitle TEXT, Address TEXT, City TEXT, Region TEXT, PostalCode TEXT, Country TEXT, Phone TEXT, Fax TEXT, HomePage TEXT)`,
+		`CREATE TABLE Products (ProductID INTEGER PRIMARY KEY, ProductName TEXT, SupplierID INTEGER, CategoryID INTEGER, QuantityPerUnit TEXT, UnitPrice NUMERIC, UnitsInStock INTEGER, UnitsOnOrder INTEGER, ReorderLevel INTEGER, Discontinued TEXT)`,
+		`CREATE TABLE Orders (OrderID INTEGER PRIMARY KEY, CustomerID TEXT, EmployeeID INTEGER, OrderDate DATETIME, RequiredDate DATETIME, ShippedDate DATETIME, ShipVia INTEGER, Freight NUMERIC, ShipName TEXT, ShipAddress TEXT, ShipCity TEXT, ShipRegion TEXT, ShipPostalCode TEXT, ShipCountry TEXT)`,
+		`CREATE TABLE OrderDetails (OrderID INTEGER, ProductID INTEGER, UnitPrice NUMERIC, Quantity INTEGER, Discount REAL, PRIMARY KEY (OrderID, ProductID))`,
+		`CREATE TABLE Regions (RegionID INTEGER PRIMARY KEY, RegionDescription TEXT)`,
+		`CREATE TABLE Territories (TerritoryID TEXT PRIMARY KEY, TerritoryDescription TEXT, RegionID INTEGER)`,
+		`CREATE TABLE EmployeeTerritories (EmployeeID INTEGER, TerritoryID TEXT)`,
+	}
+	for _, stmt := range stmts {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+
+	stmts = []string{
+		`INSERT INTO Customers (CustomerID, CompanyName, Country) VALUES ('ALFKI', 'Alfreds Futterkiste', 'Germany'), ('ANATR', 'Ana Trujillo', 'Mexico'), ('OLDCO', 'Old Company', 'Germany')`,
+		`INSERT INTO Employees (EmployeeID, LastName, FirstName, HireDate, ReportsTo) VALUES (1, 'Davolio', 'Nancy', '1992-05-01 00:00:00.000', 2), (2, 'Fuller', 'Andrew', '1992-08-14', NULL)`,
+		`INSERT INTO Shippers (ShipperID, CompanyName) VALUES (1, 'Speedy Express')`,
+		`INSERT INTO Categories (CategoryID, CategoryName) VALUES (1, 'Beverages')`,
+		`INSERT INTO Suppliers (SupplierID, CompanyName) VALUES (1, 'Exotic Liquids')`,
+		`INSERT INTO Products (ProductID, ProductName, SupplierID, CategoryID, UnitPrice, UnitsInStock, Discontinued) VALUES (1, 'Chai', 1, 1, 18, 39, '0'), (2, 'Côte de Blaye', 1, 1, 263.5, 17, '1')`,
+		`INSERT INTO Orders (OrderID, CustomerID, EmployeeID, OrderDate, ShipVia, Freight) VALUES (10248, 'ALFKI', 1, '1996-07-04 00:00:00.000', 1, 32.38), (10249, 'ANATR', 2, '1997-01-10', 1, 11.61)`,
+		`INSERT INTO OrderDetails VALUES (10248, 1, 14, 12, 0), (10248, 2, 210.8, 5, 0.1), (10249, 1, 14.4, 9, 0)`,
+		`INSERT INTO Regions VALUES (1, 'Eastern')`,
+		`INSERT INTO Territories VALUES ('01581', 'Westboro          ', 1)`,
+		`INSERT INTO EmployeeTerritories VALUES (1, '01581')`,
+	}
+	for _, stmt := range stmts {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+	// Kolom DeletedAt, index pencarian, dsb. dibuat oleh migration sungguhan,
+	// setelah data contoh ada supaya index-nya ikut terisi.
+	if err := migrations.Apply(context.Background(), db); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`UPDATE Customers SET DeletedAt = '2024-01-01T00:00:00Z' WHERE CustomerID = 'OLDCO'`); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestOData(t *testing.T) {
+	e := server.NewEngine()
+	routes.Register(e, routes.Deps{DB: northwindSQL(t), Config: testConfig{}})
+
+	tests := []struct {
+		name, path string
+		want       int
+		wantBody   []string
+	}{
+		{"service document", "/api/v1/odata", 200, []string{`"@odata.context":"http://example.com/api/v1/odata/$metadata"`, `{"name":"Customers","kind":"EntitySet","url":"Customers"}`}},
+		{"metadata", "/api/v1/odata/$metadata", 200, []string{`<EntityType Name="Customer">`, `<NavigationProperty Name="Orders" Type="Collection(Northwind.Order)">`, `<EntitySet Name="OrderDetails" EntityType="Northwind.OrderDetail">`}},
+		{"filter select count", "/api/v1/odata/Customers?$filter=Country%20eq%20'Germany'&$select=CustomerID,CompanyName&$count=true", 200,
+			[]string{`"@odata.context":"http://example.com/api/v1/odata/$metadata#Customers(CustomerID,CompanyName)","@odata.count":1,"value":[{"CustomerID":"ALFKI","CompanyName":"Alfreds Futterkiste"}]`}},
+		{"include deleted", "/api/v1/odata/Customers?$filter=Country%20eq%20'Germany'&$select=CustomerID&include_deleted=true", 200, []string{`"OLDCO"`}},
+		{"entity with nested expand", "/api/v1/odata/Customers('ALFKI')?$select=CustomerID&$expand=Orders($select=OrderID,OrderDate;$expand=OrderDetails($filter=Quantity%20gt%2010;$select=Quantity))", 200,
+			[]string{`$metadata#Customers(CustomerID)/$entity","CustomerID":"ALFKI","Orders":[{"OrderID":10248,"OrderDate":"1996-07-04T00:00:00Z","OrderDetails":[{"Quantity":12}]}]}`}},
+		{"navigation path and date function", "/api/v1/odata/Orders?$filter=Customer/Country%20eq%20'Germany'%20and%20year(OrderDate)%20eq%201996&$select=OrderID", 200, []string{`"value":[{"OrderID":10248}]`}},
+		{"any lambda", "/api/v1/odata/Customers?$filter=Orders/any(o:o/Freight%20gt%2030)&$select=CustomerID", 200, []string{`"value":[{"CustomerID":"ALFKI"}]`}},
+		{"date literal", "/api/v1/odata/Orders?$filter=OrderDate%20ge%201997-01-01&$select=OrderID", 200, []string{`"value":[{"OrderID":10249}]`}},
+		{"orderby top", "/api/v1/odata/Products?$orderby=UnitPrice%20desc&$top=1&$select=ProductName,UnitPrice,Discontinued", 200, []string{`"value":[{"ProductName":"Côte de Blaye","UnitPrice":263.5,"Discontinued":true}]`}},
+		{"expand single with count", "/api/v1/odata/Products?$select=ProductID&$expand=Category($select=CategoryName),OrderDetails($count=true;$top=1;$select=OrderID)&$top=1", 200,
+			[]string{`{"ProductID":1,"Category":{"CategoryName":"Beverages"},"OrderDetails@odata.count":2,"OrderDetails":[{"OrderID":10248}]}`}},
+		{"many to many", "/api/v1/odata/Employees(1)?$select=LastName,HireDate&$expand=Territories,Manager($select=LastName)", 200,
+			[]string{`"HireDate":"1992-05-01T00:00:00Z"`, `"Territories":[{"TerritoryID":"01581","TerritoryDescription":"Westboro","RegionID":1}]`, `"Manager":{"LastName":"Fuller"}`}},
+		{"composite key", "/api/v1/odata/OrderDetails(OrderID=10248,ProductID=2)", 200, []string{`"Quantity":5,"Discount":0.1`}},
+		{"count segment", "/api/v1/odata/Orders/$count?$filter=Freight%20lt%2020", 200, []string{`1`}},
+		{"unknown property", "/api/v1/odata/Customers?$filter=Foo%20eq%201", 400, []string{`"target":"$filter"`, `unknown property \"Foo\" on Customer`}},
+		{"type mismatch", "/api/v1/odata/Orders?$filter=Freight%20eq%20'x'", 400, []string{`cannot compare number with string`}},
+		{"unsupported option", "/api/v1/odata/Customers?$search=foo", 400, []string{`"target":"$search"`}},
+		{"unknown entity", "/api/v1/odata/Customers('NOPE1')", 404, []string{`"code":"NotFound"`}},
+		{"unknown set", "/api/v1/odata/Invoices", 404, []string{`"error"`}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := do(e, "GET", tc.path, "")
+			if rec.Code != tc.want {
+				t.Fatalf("status = %d, want %d; body: %s", rec.Code, tc.want, rec.Body.String())
+			}
+			for _, want := range tc.wantBody {
+				if !strings.Contains(rec.Body.String(), want) {
+					t.Errorf("body %s does not contain %s", rec.Body.String(), want)
+				}
+			}
+			if rec.Header().Get("OData-Version") != "4.0" {
+				t.Errorf("OData-Version = %q", rec.Header().Get("OData-Version"))
+			}
+		})
+	}
+}
+
+func TestODataServerDrivenPaging(t *testing.T) {
+	db := northwindSQL(t)
+	if _, err := db.Exec(`WITH RECURSIVE n(i) AS (SELECT 2 UNION ALL SELECT i + 1 FROM n WHERE i < 1005)
+		INSERT INTO Regions SELECT i, 'Region ' || i FROM n`); err != nil {
+		t.Fatal(err)
+	}
+	e := server.NewEngine()
+	routes.Register(e, routes.Deps{DB: db, Config: testConfig{}})
+
+	var page struct {
+		Value    []map[string]any `json:"value"`
+		NextLink string           `json:"@odata.nextLink"`
+	}
+	rec := do(e, "GET", "/api/v1/odata/Regions?$select=RegionID&$top=1003", "")
+	if err := json.Unmarshal(rec.Body.Bytes(), &page); err != nil || len(page.Value) != 1000 || page.NextLink == "" {
+		t.Fatalf("first page: %d rows, nextLink %q (%v)", len(page.Value), page.NextLink, err)
+	}
+	next, _ := strings.CutPrefix(page.NextLink, "http://example.com")
+	page.Value, page.NextLink = nil, ""
+	rec = do(e, "GET", next, "")
+	if err := json.Unmarshal(rec.Body.Bytes(), &page); err != nil || len(page.Value) != 3 || page.NextLink != "" {
+		t.Fatalf("second page (%s): %s", next, rec.Body.String())
+	}
+	if page.Value[0]["RegionID"] != float64(1001) {
+		t.Errorf("second page starts at %v, want 1001", page.Value[0]["RegionID"])
+	}
+}
+
+// Index memory memakai repositories.Mark; markup di data juga di-escape di sana.
+func TestSearchEscapesMarkup(t *testing.T) {
+	s := seed()
+	_ = s.Search.IndexDocuments(context.Background(),
+		models.SearchDocument{Entity: "products", EntityID: "9", Title: "<script>alert(1)</script> Tea", Body: "10 boxes & more"})
+	e := newEngine(s)
+	rec := do(e, "GET", "/api/v1/search?q=tea+boxes", "")
+	var res models.SearchResult
+	_ = json.Unmarshal(rec.Body.Bytes(), &res)
+	if len(res.Hits) != 1 || res.Hits[0].Title != "&lt;script&gt;alert(1)&lt;/script&gt; <mark>Tea</mark>" ||
+		res.Hits[0].Snippet != "10 <mark>boxes</mark> &amp; more" {
+		t.Fatalf("hits = %+v (%s)", res.Hits, rec.Body.String())
+	}
+}
+
+func TestSearchSQL(t *testing.T) {
+	e := server.NewEngine()
+	routes.Register(e, routes.Deps{DB: northwindSQL(t), Config: testConfig{}})
+
+	search := func(path string) models.SearchResult {
+		t.Helper()
+		rec := do(e, "GET", path, "")
+		var res models.SearchResult
+		if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil || rec.Code != http.StatusOK {
+			t.Fatalf("%s: status = %d; body: %s", path, rec.Code, rec.Body.String())
+		}
+		return res
+	}
+
+	// Tanpa aksen dan sebagai awalan: "cote bla" menemukan "Côte de Blaye".
+	res := search("/api/v1/search?q=cote+bla")
+	if len(res.Hits) != 1 || res.Hits[0].ID != "2" || res.Hits[0].Title != "<mark>Côte</mark> de <mark>Blaye</mark>" {
+		t.Fatalf("cote bla: %+v", res)
+	}
+	if res := search("/api/v1/search?q=chia"); !res.Fuzzy || len(res.Hits) != 1 || res.Hits[0].ID != "1" {
+		t.Fatalf("typo chia: %+v", res)
+	}
+
+	// Markup di data di-escape; hanya <mark> yang berupa HTML.
+	if rec := do(e, "POST", "/api/v1/products", `{"product_name":"<img src=x onerror=alert(1)> Tea","supplier_id":1,"category_id":1}`); rec.Code != http.StatusCreated {
+		t.Fatalf("create: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	if res := search("/api/v1/search?q=onerror"); len(res.Hits) != 1 || res.Hits[0].Title != "&lt;img src=x <mark>onerror</mark>=alert(1)&gt; Tea" {
+		t.Fatalf("markup in title: %+v", res)
+	}
+
+	// Index ikut diperbarui saat data ditulis.
+	rec := do(e, "POST", "/api/v1/products", `{"product_name":"Chang","supplier_id":1,"category_id":1,"unit_price":19}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	res = search("/api/v1/search?q=chang&type=products")
+	if len(res.Hits) != 1 {
+		t.Fatalf("new product not indexed: %+v", res)
+	}
+	if rec := do(e, "GET", "/api/v1/products?q=chang", ""); !strings.Contains(rec.Body.String(), `"product_name":"Chang"`) {
+		t.Fatalf("list ?q=: %s", rec.Body.String())
+	}
+	if rec := do(e, "DELETE", "/api/v1/products/"+res.Hits[0].ID, ""); rec.Code != http.StatusOK {
+		t.Fatalf("delete: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	if res := search("/api/v1/search?q=chang"); len(res.Hits) != 0 {
+		t.Fatalf("deleted product still indexed: %+v", res)
+	}
+}
+
+// Filter dan paginasi list GraphQL dijalankan di query SQL.
+func TestGraphQLSQL(t *testing.T) {
+	db := northwindSQL(t)
+	// Fixture tidak mengisi kolom stok yang wajib ada saat product di-scan.
+	if _, err := db.Exec(`UPDATE Products SET UnitsOnOrder = 0, ReorderLevel = 0`); err != nil {
+		t.Fatal(err)
+	}
+	e := server.NewEngine()
+	routes.Register(e, routes.Deps{DB: db, Config: testConfig{}})
+
+	// OLDCO (Germany) sudah dihapus, jadi tidak ikut difilter maupun dihitung.
+	tests := []struct{ query, want string }{
+		{`{ customers(limit: 1) { totalCount hasNextPage items { customerId } } }`, `{"customers":{"hasNextPage":true,"items":[{"customerId":"ALFKI"}],"totalCount":2}}`},
+		{`{ customers(limit: 1, offset: 1) { totalCount hasNextPage items { customerId } } }`, `{"customers":{"hasNextPage":false,"items":[{"customerId":"ANATR"}],"totalCount":2}}`},
+		{`{ customers(country: \"germany\") { totalCount items { customerId } } }`, `{"customers":{"items":[{"customerId":"ALFKI"}],"totalCount":1}}`},
+		{`{ customers(search: \"FUT\") { totalCount items { customerId } } }`, `{"customers":{"items":[{"customerId":"ALFKI"}],"totalCount":1}}`},
+		{`{ customers(search: \"%\") { totalCount items { customerId } } }`, `{"customers":{"items":[],"totalCount":0}}`},
+		{`{ products(discontinued: true) { totalCount items { productId } } }`, `{"products":{"items":[{"productId":2}],"totalCount":1}}`},
+		{`{ products(categoryId: 1, search: \"cha\") { totalCount items { productId } } }`, `{"products":{"items":[{"productId":1}],"totalCount":1}}`},
+		{`{ orders(shipped: false, employeeId: 2) { totalCount items { orderId } } }`, `{"orders":{"items":[{"orderId":10249}],"totalCount":1}}`},
+		{`{ orders(customerId: \"ALFKI\", shipperId: 1) { totalCount items { orderId } } }`, `{"orders":{"items":[{"orderId":10248}],"totalCount":1}}`},
+	}
+	for _, tc := range tests {
+		rec := do(e, "POST", "/api/v1/graphql", `{"query":"`+tc.query+`"}`)
+		if want := `{"data":` + tc.want + `}`; rec.Code != http.StatusOK || rec.Body.String() != want {
+			t.Errorf("%s: status = %d; body: %s\nwant %s", tc.query, rec.Code, rec.Body.String(), want)
+		}
+	}
+}
+
+// Nama file export berasal dari ID di path dan tidak boleh keluar dari
+// tanda kutip header Content-Disposition.
+func TestExportFilename(t *testing.T) {
+	s := seed()
+	s.Customers.Seed(models.Customer{CustomerID: `AB"; x=\..`, CompanyName: "Odd Id"})
+	e := newEngine(s)
+	for format, want := range map[string]string{"json": `attachment; filename="customer-AB___x__...json"`, "zip": `attachment; filename="customer-AB___x__...zip"`} {
+		rec := do(e, "GET", "/api/v1/customers/"+url.PathEscape(`AB"; x=\..`)+"/data-export?format="+format, "")
+		if got := rec.Header().Get("Content-Disposition"); rec.Code != http.StatusOK || got != want {
+			t.Errorf("%s: status = %d, Content-Disposition = %q, want %q", format, rec.Code, got, want)
+		}
+	}
+}
+
+// Erasure tidak bisa dibatalkan, jadi hanya admin yang boleh menjalankannya.
+func TestEraseRequiresAdmin(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret")
+	bearer := func(roles ...string) []string {
+		token, err := utils.GenerateJWT("nancy", roles...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return []string{"Authorization", "Bearer " + token}
+	}
+	e := newEngineWith(seed(), testConfig{env: "production"})
+
+	for _, path := range []string{"/api/v1/customers/ALFKI/erase", "/api/v1/employees/1/erase"} {
+		rec := do(e, "POST", path, "", bearer(visibility.RoleHR, visibility.RoleSales)...)
+		if rec.Code != http.StatusForbidden || !strings.Contains(rec.Body.String(), "requires the admin role") {
+			t.Errorf("%s as hr/sales: status = %d; body: %s", path, rec.Code, rec.Body.String())
+		}
+		if rec := do(e, "POST", path, "", bearer(visibility.RoleAdmin)...); rec.Code != http.StatusOK {
+			t.Errorf("%s as admin: status = %d; body: %s", path, rec.Code, rec.Body.String())
+		}
+	}
+	if rec := do(e, "GET", "/api/v1/customers/ALFKI", "", bearer(visibility.RoleAdmin)...); !strings.Contains(rec.Body.String(), `"contact_name":"anon-`) {
+		t.Errorf("customer not erased by admin: %s", rec.Body.String())
+	}
+}
+
+func TestPersonalDataSQL(t *testing.T) {
+	db := northwindSQL(t)
+	e := server.NewEngine()
+	routes.Register(e, routes.Deps{DB: db, Config: testConfig{}})
+
+	if rec := do(e, "POST", "/api/v1/webhooks", `{"url":"https://partner.example.com/hook","events":["*"]}`); rec.Code != http.StatusCreated {
+		t.Fatalf("create webhook: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	if rec := do(e, "PATCH", "/api/v1/customers/ALFKI", `{"contact_name":"Maria Anders","phone":"030-0074321"}`); rec.Code != http.StatusOK {
+		t.Fatalf("patch customer: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	if _, err := db.Exec(`UPDATE Orders SET ShipName = 'Maria Anders', ShipAddress = 'Obere Str. 57' WHERE OrderID = 10248`); err != nil {
+		t.Fatal(err)
+	}
+	// Response yang disimpan untuk Idempotency-Key juga memuat data pribadi.
+	if rec := do(e, "POST", "/api/v1/orders", `{"customer_id":"ALFKI","ship_name":"Maria Anders","ship_address":"Obere Str. 57"}`, "Idempotency-Key", "alfki-order"); rec.Code != http.StatusCreated {
+		t.Fatalf("create order: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	if rec := do(e, "POST", "/api/v1/orders", `{"customer_id":"ANATR","ship_name":"Ana Trujillo"}`, "Idempotency-Key", "anatr-order"); rec.Code != http.StatusCreated {
+		t.Fatalf("create other order: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	// File import dibuang begitu job selesai.
+	rec := upload(e, map[string]string{"entity": "customers"}, "Company Name,Contact Name,Country\nBlauer See Delikatessen,Hanna Moos,Germany\n")
+	var job models.ImportJob
+	_ = json.Unmarshal(rec.Body.Bytes(), &job)
+	waitImport(t, e, job.JobID, models.ImportValidated)
+	do(e, "POST", "/api/v1/imports/"+job.JobID+"/commit", "")
+	if job = waitImport(t, e, job.JobID, models.ImportCompleted, models.ImportFailed); job.Status != models.ImportCompleted {
+		t.Fatalf("import: %+v", job)
+	}
+	var size int
+	if err := db.QueryRow(`SELECT length(Data) FROM ImportJobs WHERE JobID = ?`, job.JobID).Scan(&size); err != nil || size != 0 {
+		t.Errorf("import file after completion: %d bytes (%v)", size, err)
+	}
+
+	rec = do(e, "GET", "/api/v1/customers/ALFKI/data-export?format=zip", "")
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "application/zip" {
+		t.Fatalf("export: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	zr, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	if got := strings.Join(names, ","); got != "customer.json,exported_at.json,history.json,orders.json" {
+		t.Fatalf("zip files = %s", got)
+	}
+
+	rec = do(e, "POST", "/api/v1/customers/ALFKI/erase", "")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"order_ids":[10248,`) {
+		t.Fatalf("erase: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	rec = do(e, "GET", "/api/v1/orders/10248", "")
+	if strings.Contains(rec.Body.String(), "Obere") || !strings.Contains(rec.Body.String(), `"freight":32.38`) {
+		t.Fatalf("order after erase: %s", rec.Body.String())
+	}
+	var events string
+	if err := db.QueryRow(`SELECT group_concat(COALESCE(Before, '') || COALESCE(After, '')) FROM EventLog`).Scan(&events); err != nil {
+		t.Fatal(err)
+	}
+	history := map[string]string{"event log": events}
+	for _, path := range []string{"/api/v1/audit", "/api/v1/webhook-deliveries"} {
+		rec := do(e, "GET", path, "")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: status = %d; body: %s", path, rec.Code, rec.Body.String())
+		}
+		history[path] = rec.Body.String()
+	}
+	var keys, responses string
+	if err := db.QueryRow(`SELECT group_concat(IdempotencyKey), group_concat(ResponseBody) FROM IdempotencyKeys`).Scan(&keys, &responses); err != nil {
+		t.Fatal(err)
+	}
+	if keys != "anatr-order" || strings.Contains(responses, "Maria Anders") {
+		t.Errorf("idempotency keys after erase = %s: %s", keys, responses)
+	}
+	for name, body := range history {
+		for _, secret := range []string{"Maria Anders", "Obere Str. 57", "030-0074321"} {
+			if strings.Contains(body, secret) {
+				t.Errorf("%s still contains %q", name, secret)
+			}
+		}
+		if !strings.Contains(body, "[erased]") {
+			t.Errorf("%s: nothing redacted: %s", name, body)
+		}
+	}
+}
+
+func TestFieldVisibility(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret")
+	bearer := func(roles ...string) []string {
+		token, err := utils.GenerateJWT("nancy", roles...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return []string{"Authorization", "Bearer " + token}
+	}
+	plain, hr, sales := bearer(), bearer("hr"), bearer("sales")
+
+	s := seed()
+	s.Employees.Seed(models.Employee{EmployeeID: 3, LastName: "Leverling", FirstName: "Janet",
+		BirthDate: "1963-08-30", HomePhone: "(206) 555-3412", Notes: "Janet has a BS degree in chemistry."})
+	s.Customers.Seed(models.Customer{CustomerID: "ALFKI", CompanyName: "Alfreds Futterkiste", ContactName: "Maria Anders", Country: "Germany"})
+	_ = s.Search.IndexDocuments(context.Background(),
+		models.SearchDocument{Entity: "customers", EntityID: "ALFKI", Title: "Alfreds Futterkiste", Body: "Maria Anders Germany"})
+	e := newEngineWith(s, testConfig{env: "production"})
+
+	body := do(e, "GET", "/api/v1/employees/3", "", plain...).Body.String()
+	if !strings.Contains(body, `"birth_date":null`) || !strings.Contains(body, `"home_phone":null`) ||
+		strings.Contains(body, `"notes"`) || !strings.Contains(body, `"last_name":"Leverling"`) {
+		t.Errorf("employee for non-HR: %s", body)
+	}
+	if body := do(e, "GET", "/api/v1/employees", "", plain...).Body.String(); strings.Contains(body, "1963-08-30") {
+		t.Errorf("employee list for non-HR: %s", body)
+	}
+	if body := do(e, "GET", "/api/v1/employees/3", "", hr...).Body.String(); !strings.Contains(body, `"birth_date":"1963-08-30"`) || !strings.Contains(body, `"notes":"Janet`) {
+		t.Errorf("employee for HR: %s", body)
+	}
+
+	// PUT balik representasi yang disensor tidak menghapus field tersembunyi.
+	if rec := do(e, "PUT", "/api/v1/employees/3", strings.Replace(body, "Janet", "Jan", 1), plain...); rec.Code != http.StatusOK {
+		t.Fatalf("put: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	if emp, _ := s.Employees.GetEmployeeByID(context.Background(), 3); emp.FirstName != "Jan" || emp.BirthDate != "1963-08-30" || emp.Notes == "" {
+		t.Errorf("after put = %+v", emp)
+	}
+
+	// Riwayat juga disensor.
+	if rec := do(e, "PATCH", "/api/v1/employees/3", `{"home_phone":"(206) 555-0000"}`, hr...); rec.Code != http.StatusOK {
+		t.Fatalf("patch: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	if body := do(e, "GET", "/api/v1/employees/3/history", "", plain...).Body.String(); strings.Contains(body, "555-") || !strings.Contains(body, `"home_phone":{"before":null,"after":null}`) {
+		t.Errorf("history for non-HR: %s", body)
+	}
+
+	if body := do(e, "GET", "/api/v1/customers/ALFKI", "", hr...).Body.String(); !strings.Contains(body, `"contact_name":null`) {
+		t.Errorf("customer for non-sales: %s", body)
+	}
+	if body := do(e, "GET", "/api/v1/customers/ALFKI", "", sales...).Body.String(); !strings.Contains(body, `"contact_name":"Maria Anders"`) {
+		t.Errorf("customer for sales: %s", body)
+	}
+	if body := do(e, "GET", "/api/v1/search?q=maria", "", plain...).Body.String(); strings.Contains(body, "ALFKI") {
+		t.Errorf("search by hidden contact name: %s", body)
+	}
+	if body := do(e, "GET", "/api/v1/search?q=maria", "", sales...).Body.String(); !strings.Contains(body, `\u003cmark\u003eMaria`) {
+		t.Errorf("search for sales: %s", body)
+	}
+
+	db := northwindSQL(t)
+	if _, err := db.Exec(`UPDATE Employees SET BirthDate = '1948-12-08' WHERE EmployeeID = 1`); err != nil {
+		t.Fatal(err)
+	}
+	o := server.NewEngine()
+	routes.Register(o, routes.Deps{DB: db, Config: testConfig{env: "production"}})
+	if body := do(o, "GET", "/api/v1/odata/Employees(1)?$select=LastName,BirthDate", "", plain...).Body.String(); !strings.Contains(body, `"LastName":"Davolio","BirthDate":null`) {
+		t.Errorf("odata for non-HR: %s", body)
+	}
+	if rec := do(o, "GET", "/api/v1/odata/Employees?$filter=year(BirthDate)%20eq%201948", "", plain...); rec.Code != http.StatusBadRequest {
+		t.Errorf("odata filter on hidden property: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	if body := do(o, "GET", "/api/v1/odata/Employees?$filter=year(BirthDate)%20eq%201948&$select=BirthDate", "", hr...).Body.String(); !strings.Contains(body, `"BirthDate":"1948-12-08T00:00:00Z"`) {
+		t.Errorf("odata for HR: %s", body)
+	}
+}
+
+// putImage mengunggah data sebagai field multipart "file".
+func putImage(e *gin.Engine, path string, data []byte, headers ...string) *httptest.ResponseRecorder {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	part, _ := w.CreateFormFile("file", "image")
+	_, _ = part.Write(data)
+	_ = w.Close()
+
+	req := httptest.NewRequest("PUT", path, &body)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func testImage(t *testing.T, w, h int, encode func(io.Writer, image.Image) error) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := encode(&buf, image.NewRGBA(image.Rect(0, 0, w, h))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestImagesSQL(t *testing.T) {
+	db := northwindSQL(t)
+	e := server.NewEngine()
+	routes.Register(e, routes.Deps{DB: db, Config: testConfig{}})
+
+	// Gambar category Northwind asli: BMP di dalam header OLE 78 byte.
+	raw := testImage(t, 40, 20, bmp.Encode)
+	ole := append(append([]byte{0x15, 0x1c}, make([]byte, images.OLEHeaderSize-2)...), raw...)
+	if _, err := db.Exec(`UPDATE Categories SET Picture = ? WHERE CategoryID = 1`, ole); err != nil {
+		t.Fatal(err)
+	}
+	rec := do(e, "GET", "/api/v1/categories/1/picture", "")
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "image/bmp" || !bytes.Equal(rec.Body.Bytes(), raw) {
+		t.Fatalf("picture: status = %d, type %q, %d bytes", rec.Code, rec.Header().Get("Content-Type"), rec.Body.Len())
+	}
+	if again := do(e, "GET", "/api/v1/categories/1/picture", "", "If-None-Match", rec.Header().Get("ETag")); again.Code != http.StatusNotModified {
+		t.Errorf("conditional picture: status = %d", again.Code)
+	}
+	rec = do(e, "GET", "/api/v1/categories/1/picture?size=16", "")
+	if cfg, err := png.DecodeConfig(rec.Body); err != nil || cfg.Width != 16 || cfg.Height != 8 || rec.Header().Get("Content-Type") != "image/png" {
+		t.Errorf("thumbnail: %+v, %v", cfg, err)
+	}
+
+	// PUT JSON tidak lagi menghapus gambar, dan list tidak memuatnya.
+	if rec := do(e, "PUT", "/api/v1/categories/1", `{"category_name":"Beverages"}`); rec.Code != http.StatusOK {
+		t.Fatalf("put category: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	if body := do(e, "GET", "/api/v1/categories", "").Body.String(); strings.Contains(body, "picture") {
+		t.Errorf("categories list contains picture: %s", body)
+	}
+	if rec := do(e, "GET", "/api/v1/categories/1/picture", ""); rec.Code != http.StatusOK {
+		t.Errorf("picture after put: status = %d", rec.Code)
+	}
+
+	photo := testImage(t, 30, 60, png.Encode)
+	rec = putImage(e, "/api/v1/employees/1/photo", photo)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"content_type":"image/png","size":`+strconv.Itoa(len(photo))+`,"width":30,"height":60`) {
+		t.Fatalf("upload photo: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	rec = do(e, "GET", "/api/v1/employees/1/photo", "")
+	if !bytes.Equal(rec.Body.Bytes(), photo) || rec.Header().Get("X-Content-Type-Options") != "nosniff" {
+		t.Errorf("photo: %d bytes, headers %v", rec.Body.Len(), rec.Header())
+	}
+	tag := rec.Header().Get("ETag")
+	if rec := putImage(e, "/api/v1/employees/1/photo", []byte("GIF89a not really"), "If-Match", tag); rec.Code != http.StatusBadRequest {
+		t.Errorf("upload non-image: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	if rec := putImage(e, "/api/v1/employees/1/photo", photo, "If-Match", `"stale"`); rec.Code != http.StatusPreconditionFailed {
+		t.Errorf("upload with stale If-Match: status = %d", rec.Code)
+	}
+	if rec := putImage(e, "/api/v1/employees/1/photo", make([]byte, images.MaxSize+1)); rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("upload too large: status = %d", rec.Code)
+	}
+	if body := do(e, "GET", "/api/v1/employees/1/history", "").Body.String(); !strings.Contains(body, `"action":"photo_updated"`) ||
+		!strings.Contains(body, `"width":{"before":null,"after":30}`) {
+		t.Errorf("photo history: %s", body)
+	}
+
+	if rec := do(e, "DELETE", "/api/v1/employees/1/photo", "", "If-Match", tag); rec.Code != http.StatusOK {
+		t.Fatalf("delete photo: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	if rec := do(e, "GET", "/api/v1/employees/1/photo", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("photo after delete: status = %d", rec.Code)
+	}
+}
+
+func TestReportError(t *testing.T) {
+	s := seed()
+	s.Reports.Err = http.ErrHandlerTimeout
+	rec := httptest.NewRecorder()
+	newEngine(s).ServeHTTP(rec, httptest.NewRequest("GET", "/api/v1/reports/top-products", nil))
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", rec.Code)
+	}
+	if strings.Contains(rec.Body.String(), "timeout") {
+		t.Fatalf("internal error leaked to client: %s", rec.Body.String())
+	}
+}
+
+func TestPatchWritesOnlySuppliedFields(t *testing.T) {
+	s := seed()
+	e := newEngine(s)
+
+	req := httptest.NewRequest("PATCH", "/api/v1/orders/10248", strings.NewReader(
+		`[{"op":"test","path":"/customer_id","value":"ALFKI"},{"op":"replace","path":"/freight","value":7.5}]`))
+	req.Header.Set("Content-Type", "application/json-patch+json")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	o, _ := s.Orders.GetOrderByID(req.Context(), 10248)
+	if o.Freight == nil || *o.Freight != 7.5 || o.CustomerID == nil || *o.CustomerID != "ALFKI" || o.ShipVia == nil {
+		t.Fatalf("order after patch = %+v", o)
+	}
+
+	req = httptest.NewRequest("PATCH", "/api/v1/orders/10248", strings.NewReader(
+		`[{"op":"test","path":"/customer_id","value":"ANATR"},{"op":"replace","path":"/freight","value":1}]`))
+	req.Header.Set("Content-Type", "application/json-patch+json")
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("failed test op: status = %d, want 409", rec.Code)
+	}
+
+	req = httptest.NewRequest("PATCH", "/api/v1/orders/10248", strings.NewReader(`freight=1`))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnsupportedMediaType {
+		t.Fatalf("form body: status = %d, want 415", rec.Code)
+	}
+}
+
+func TestETagOptimisticConcurrency(t *testing.T) {
+	e := newEngine(seed())
+
+	get := do(e, "GET", "/api/v1/orders/10248", "")
+	tag := get.Header().Get("ETag")
+	if get.Code != http.StatusOK || tag == "" {
+		t.Fatalf("GET: status = %d, ETag = %q", get.Code, tag)
+	}
+	if rec := do(e, "GET", "/api/v1/orders/10248", "", "If-None-Match", tag); rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+		t.Fatalf("If-None-Match: status = %d, body = %q", rec.Code, rec.Body.String())
+	}
+
+	// Clerk pertama berhasil, clerk kedua memakai ETag lama dan ditolak.
+	if rec := do(e, "PUT", "/api/v1/orders/10248", `{"customer_id":"ALFKI","freight":10}`, "If-Match", tag); rec.Code != http.StatusOK {
+		t.Fatalf("first PUT: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+	rec := do(e, "PUT", "/api/v1/orders/10248", `{"customer_id":"ANATR","freight":20}`, "If-Match", tag)
+	if rec.Code != http.StatusPreconditionFailed {
+		t.Fatalf("stale PUT: status = %d, want 412", rec.Code)
+	}
+	if rec := do(e, "DELETE", "/api/v1/orders/10248", "", "If-Match", tag); rec.Code != http.StatusPreconditionFailed {
+		t.Fatalf("stale DELETE: status = %d, want 412", rec.Code)
+	}
+
+	fresh := do(e, "GET", "/api/v1/orders/10248", "").Header().Get("ETag")
+	if fresh == tag {
+		t.Fatal("ETag did not change after update")
+	}
+	patch := do(e, "PATCH", "/api/v1/orders/10248", `{"freight":30}`, "If-Match", fresh)
+	if patch.Code != http.StatusOK || patch.Header().Get("ETag") == fresh {
+		t.Fatalf("PATCH with fresh ETag: status = %d, ETag = %q", patch.Code, patch.Header().Get("ETag"))
+	}
+	if got := do(e, "GET", "/api/v1/orders/10248", "").Header().Get("ETag"); got != patch.Header().Get("ETag") {
+		t.Fatalf("PATCH ETag %q does not match GET ETag %q", patch.Header().Get("ETag"), got)
+	}
+
+	list := do(e, "GET", "/api/v1/orders", "")
+	if rec := do(e, "GET", "/api/v1/orders", "", "If-None-Match", list.Header().Get("ETag")); rec.Code != http.StatusNotModified {
+		t.Fatalf("list If-None-Match: status = %d, want 304", rec.Code)
+	}
+}
+
+func TestIfMatchRequired(t *testing.T) {
+	e := newEngineWith(seed(), testConfig{requireIfMatch: true})
+
+	if rec := do(e, "PATCH", "/api/v1/shippers/1", `{"phone":"1"}`); rec.Code != http.StatusPreconditionRequired {
+		t.Fatalf("PATCH without If-Match: status = %d, want 428", rec.Code)
+	}
+	if rec := do(e, "POST", "/api/v1/shippers", `{"company_name":"United Package"}`); rec.Code != http.StatusCreated {
+		t.Fatalf("POST must not need If-Match: status = %d", rec.Code)
+	}
+	tag := do(e, "GET", "/api/v1/shippers/1", "").Header().Get("ETag")
+	if rec := do(e, "DELETE", "/api/v1/shippers/1", "", "If-Match", tag); rec.Code != http.StatusOK {
+		t.Fatalf("DELETE with If-Match: status = %d; body: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestProblemInstanceIsRequestID(t *testing.T) {
+	req := httptest.NewRequest("GET", "/api/v1/orders/1", nil)
+	req.Header.Set("X-Request-ID", "req-123")
+	rec := httptest.NewRecorder()
+	newEngine(seed()).ServeHTTP(rec, req)
+
+	var p models.Problem
+	if err := json.Unmarshal(rec.Body.Bytes(), &p); err != nil {
+		t.Fatal(err)
+	}
+	if p.Status != http.StatusNotFound || p.Type != "/problems/not-found" || p.Instance != "req-123" {
+		t.Fatalf("unexpected problem: %+v", p)
+	}
+	if got := rec.Header().Get("X-Request-ID"); got != "req-123" {
+		t.Fatalf("X-Request-ID = %q, want req-123", got)
+	}
+}
+
+func TestIdempotencyKeyReplaysCreate(t *testing.T) {
+	e := newEngine(seed())
+	const body = `{"customer_id":"ALFKI","freight":1.5}`
+
+	first := do(e, "POST", "/api/v1/orders", body, "Idempotency-Key", "order-1")
+	if first.Code != http.StatusCreated {
+		t.Fatalf("first POST: status = %d, body = %s", first.Code, first.Body)
+	}
+	second := do(e, "POST", "/api/v1/orders", body, "Idempotency-Key", "order-1")
+	if second.Code != http.StatusCreated || second.Body.String() != first.Body.String() {
+		t.Fatalf("retry: status = %d, body = %s, want replay of %s", second.Code, second.Body, first.Body)
+	}
+	if second.Header().Get("Idempotent-Replayed") != "true" {
+		t.Fatal("retry is missing Idempotent-Replayed header")
+	}
+
+	var orders []models.Order
+	list := do(e, "GET", "/api/v1/orders", "")
+	if err := json.Unmarshal(list.Body.Bytes(), &orders); err != nil {
+		t.Fatal(err)
+	}
+	if len(orders) != 2 {
+		t.Fatalf("got %d orders, want the seeded one plus a single new one", len(orders))
+	}
+
+	other := do(e, "POST", "/api/v1/orders", `{"customer_id":"ALFKI","freight":9}`, "Idempotency-Key", "order-1")
+	if other.Code != http.StatusConflict {
+		t.Fatalf("reused key with another body: status = %d, want 409", other.Code)
+	}
+}
+
+func TestIdempotencyKeyFailedRequestNotStored(t *testing.T) {
+	e := newEngine(seed())
+
+	if rec := do(e, "POST", "/api/v1/shippers", `{"company_name":""}`, "Idempotency-Key", "s-1"); rec.Code != http.StatusBadRequest {
+		t.Fatalf("invalid POST: status = %d, want 400", rec.Code)
+	}
+	rec := do(e, "POST", "/api/v1/shippers", `{"company_name":""}`, "Idempotency-Key", "s-1")
+	if rec.Code != http.StatusBadRequest || rec.Header().Get("Idempotent-Replayed") != "" {
+		t.Fatalf("retry of failed POST: status = %d, replayed = %q; want it processed again", rec.Code, rec.Header().Get("Idempotent-Replayed"))
+	}
+	if rec := do(e, "POST", "/api/v1/shippers", `{"company_name":"United Package"}`, "Idempotency-Key", strings.Repeat("k", 256)); rec.Code != http.StatusBadRequest {
+		t.Fatalf("oversized key: status = %d, want 400", rec.Code)
+	}
+}
+
+func TestBulkNDJSON(t *testing.T) {
+	e := newEngine(seed())
+	body := `{"op":"create","data":{"product_name":"Chang","unit_price":19}}
+
+{"op":"patch","id":1,"data":{"unit_price":20}}
+`
+	rec := do(e, "POST", "/api/v1/products/bulk", body, "Content-Type", "application/x-ndjson")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+	}
+	var resp models.BulkResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Succeeded != 2 || len(resp.Results) != 2 || resp.Results[1].ID != "1" {
+		t.Fatalf("unexpected results: %+v", resp)
+	}
+	if rec := do(e, "GET", "/api/v1/products/1", ""); !strings.Contains(rec.Body.String(), `"unit_price":20`) {
+		t.Fatalf("patch from NDJSON not applied: %s", rec.Body)
+	}
+
+	rec = do(e, "POST", "/api/v1/products/bulk", "{\"op\":\"delete\",\"id\":1}\nnot json\n", "Content-Type", "application/x-ndjson")
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), `"field":"line 2"`) {
+		t.Fatalf("malformed line: status = %d, body = %s", rec.Code, rec.Body)
+	}
+	if rec := do(e, "POST", "/api/v1/products/bulk", `[]`, "Content-Type", "text/csv"); rec.Code != http.StatusUnsupportedMediaType {
+		t.Fatalf("csv body: status = %d, want 415", rec.Code)
+	}
+
+	// Baris setelah operasi ke-1000 tidak di-parse lagi, meskipun bukan JSON.
+	many := strings.Repeat("{\"op\":\"delete\",\"id\":1}\n", 1000) + "not json\n"
+	rec = do(e, "POST", "/api/v1/products/bulk", many, "Content-Type", "application/x-ndjson")
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "at most 1000 operations") {
+		t.Fatalf("too many lines: status = %d, body = %s", rec.Code, rec.Body)
+	}
+	rec = do(e, "POST", "/api/v1/products/bulk", `[{"op":"create","data":{"product_name":"`+strings.Repeat("x", 10<<20)+`"}}]`)
+	if rec.Code != http.StatusRequestEntityTooLarge || !strings.Contains(rec.Body.String(), "at most 10 MB") {
+		t.Fatalf("large body: status = %d, body = %.200s", rec.Code, rec.Body)
+	}
+}
+
+// upload mengirim CSV sebagai multipart form ke /imports.
+func upload(e *gin.Engine, fields map[string]string, csv string) *httptest.ResponseRecorder {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	for k, v := range fields {
+		_ = w.WriteField(k, v)
+	}
+	part, _ := w.CreateFormFile("file", "import.csv")
+	_, _ = io.WriteString(part, csv)
+	_ = w.Close()
+
+	req := httptest.NewRequest("POST", "/api/v1/imports", &body)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+// waitImport menunggu job import selesai diproses di background.
+func waitImport(t *testing.T, e *gin.Engine, id string, statuses ...string) models.ImportJob {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		var job models.ImportJob
+		rec := do(e, "GET", "/api/v1/imports/"+id, "")
+		if err := json.Unmarshal(rec.Body.Bytes(), &job); err != nil {
+			t.Fatalf("decoding job: %v (%s)", err, rec.Body)
+		}
+		for _, s := range statuses {
+			if job.Status == s {
+				return job
+			}
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("job %s stuck in %q", id, job.Status)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestImportCSV(t *testing.T) {
+	e := newEngine(seed())
+	csv := "Product Name,Unit Price,Discontinued,supplier\n" +
+		"Chang,19,no,1\n" +
+		",10,no,\n" +
+		"Aniseed Syrup,ten,yes,42\n" +
+		"Ikura,31,0,\n"
+
+	rec := upload(e, map[string]string{"entity": "products"}, csv)
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("upload: status = %d, body = %s", rec.Code, rec.Body)
+	}
+	var job models.ImportJob
+	_ = json.Unmarshal(rec.Body.Bytes(), &job)
+	if rec.Header().Get("Location") != "/api/v1/imports/"+job.JobID {
+		t.Fatalf("Location = %q", rec.Header().Get("Location"))
+	}
+	if _, ok := job.Mapping["supplier"]; ok || job.Mapping["Unit Price"] != "unit_price" {
+		t.Fatalf("auto mapping = %v", job.Mapping)
+	}
+
+	job = waitImport(t, e, job.JobID, models.ImportValidated)
+	if job.TotalRows != 4 || job.ValidRows != 2 || job.InvalidRows != 2 {
+		t.Fatalf("dry run counts: %+v", job)
+	}
+	want := []models.ImportRowError{
+		{Row: 3, Field: "product_name", Message: "is required"},
+		{Row: 4, Field: "unit_price", Message: "must be a number"},
+	}
+	if len(job.Errors) != len(want) || job.Errors[0] != want[0] || job.Errors[1] != want[1] {
+		t.Fatalf("dry run errors = %+v, want %+v", job.Errors, want)
+	}
+	if rec := do(e, "GET", "/api/v1/products/3", ""); rec.Code != http.StatusNotFound {
+		t.Fatal("dry run must not insert rows")
+	}
+
+	if rec := do(e, "POST", "/api/v1/imports/"+job.JobID+"/commit", ""); rec.Code != http.StatusConflict {
+		t.Fatalf("commit with invalid rows: status = %d, want 409", rec.Code)
+	}
+	if rec := do(e, "POST", "/api/v1/imports/"+job.JobID+"/commit?skip_invalid=true", ""); rec.Code != http.StatusAccepted {
+		t.Fatalf("commit: status = %d, body = %s", rec.Code, rec.Body)
+	}
+	job = waitImport(t, e, job.JobID, models.ImportCompleted, models.ImportFailed)
+	if job.Status != models.ImportCompleted || job.ImportedRows != 2 {
+		t.Fatalf("commit result: %+v", job)
+	}
+	if rec := do(e, "GET", "/api/v1/products", ""); !strings.Contains(rec.Body.String(), `"product_name":"Ikura"`) {
+		t.Fatalf("imported product missing: %s", rec.Body)
+	}
+	if rec := do(e, "POST", "/api/v1/imports/"+job.JobID+"/commit", ""); rec.Code != http.StatusConflict {
+		t.Fatalf("second commit: status = %d, want 409", rec.Code)
+	}
+}
+
+// Job yang ditinggal proses sebelumnya ditandai failed saat start, dan job
+// yang berjalan saat shutdown ditunggu sampai statusnya tersimpan.
+func TestImportRecovery(t *testing.T) {
+	s := seed()
+	now := time.Now().UTC()
+	for id, status := range map[string]string{"stuck-validating": models.ImportValidating, "stuck-committing": models.ImportCommitting, "done": models.ImportValidated} {
+		_ = s.Imports.CreateImportJob(context.Background(), &models.ImportJob{JobID: id, Entity: "products", Status: status,
+			Mapping: map[string]string{"name": "product_name"}, CreatedAt: now, UpdatedAt: now, Data: []byte("name\nChai\n")})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	e := server.NewEngine()
+	repos := s.Repositories()
+	svc := services.New(repos, s.TxRunner(), nil)
+	routes.Register(e, routes.Deps{Config: testConfig{}, Repos: &repos, Tx: s.TxRunner(), Services: svc, Background: ctx})
+
+	for _, id := range []string{"stuck-validating", "stuck-committing"} {
+		if job, _ := s.Imports.GetImportJob(context.Background(), id); job.Status != models.ImportFailed || !strings.Contains(job.Message, "restart") {
+			t.Errorf("%s after start: %s %q", id, job.Status, job.Message)
+		}
+	}
+	if job, _ := s.Imports.GetImportJob(context.Background(), "done"); job.Status != models.ImportValidated {
+		t.Errorf("validated job after start: %s", job.Status)
+	}
+
+	rec := upload(e, map[string]string{"entity": "products"}, "Product Name\nChang\n")
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("upload: status = %d, body = %s", rec.Code, rec.Body)
+	}
+	var job models.ImportJob
+	_ = json.Unmarshal(rec.Body.Bytes(), &job)
+	cancel()
+	svc.Imports.Wait()
+	if job, _ = s.Imports.GetImportJob(context.Background(), job.JobID); job.Status != models.ImportValidated && job.Status != models.ImportFailed {
+		t.Errorf("job after shutdown: %s", job.Status)
+	}
+}
+
+func TestImportMappingErrors(t *testing.T) {
+	e := newEngine(seed())
+
+	rec := upload(e, map[string]string{"entity": "suppliers", "mapping": `{"Name":"company_name","Town":"city","Web":"website"}`}, "Name,Web\nAcme,acme.example\n")
+	if rec.Code != http.StatusBadRequest ||
+		!strings.Contains(rec.Body.String(), `{"field":"mapping.Town","message":"is not a column in the CSV header"},{"field":"mapping.Web","message":"is not a known field: website"}`) {
+		t.Fatalf("bad mapping: status = %d, body = %s", rec.Code, rec.Body)
+	}
+	if rec := upload(e, map[string]string{"entity": "orders"}, "a\n1\n"); rec.Code != http.StatusBadRequest {
+		t.Fatalf("unsupported entity: status = %d", rec.Code)
+	}
+	if rec := upload(e, map[string]string{"entity": "customers"}, "foo,bar\n1,2\n"); rec.Code != http.StatusBadRequest {
+		t.Fatalf("no mapped columns: status = %d", rec.Code)
+	}
+
+	rec = upload(e, map[string]string{"entity": "customers", "mapping": `{"Firma":"company_name","Land":"country"}`}, "Firma,Land\nAlfreds,Germany\nBogus,Atlantis\n")
+	var job models.ImportJob
+	_ = json.Unmarshal(rec.Body.Bytes(), &job)
+	job = waitImport(t, e, job.JobID, models.ImportValidated)
+	if job.ValidRows != 1 || len(job.Errors) != 1 || job.Errors[0].Field != "country" || job.Errors[0].Row != 3 {
+		t.Fatalf("customer dry run: %+v", job)
+	}
+}
@@ -0,0 +1,11 @@
+package routes
+
+import (
+	"northwind-api/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterSearchRoutes(rg *gin.RouterGroup, h *handlers.SearchHandler) {
+	rg.GET("/search", h.Search)
+}
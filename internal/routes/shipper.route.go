@@ -13,6 +13,8 @@ func RegisterShipperRoutes(rg *gin.RouterGroup, h *handlers.ShipperHandler) {
 		shippers.GET("/:id", h.GetOne)
 		shippers.POST("", h.Create)
 		shippers.PUT("/:id", h.Update)
+		shippers.PATCH("/:id", h.Patch)
 		shippers.DELETE("/:id", h.Delete)
+		shippers.POST("/:id/restore", h.Restore)
 	}
 }
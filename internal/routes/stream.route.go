@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"northwind-api/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterStreamRoutes memasang stream perubahan data via SSE dan WebSocket.
+func RegisterStreamRoutes(rg *gin.RouterGroup, h *handlers.StreamHandler) {
+	events := rg.Group("/events")
+	{
+		events.GET("/stream", h.SSE)
+		events.GET("/ws", h.WebSocket)
+	}
+}
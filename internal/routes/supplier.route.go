@@ -13,6 +13,8 @@ func RegisterSupplierRoutes(rg *gin.RouterGroup, h *handlers.SupplierHandler) {
 		categories.GET("/:id", h.GetOne)
 		categories.POST("", h.Create)
 		categories.PUT("/:id", h.Update)
+		categories.PATCH("/:id", h.Patch)
 		categories.DELETE("/:id", h.Delete)
+		categories.POST("/:id/restore", h.Restore)
 	}
 }
@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"northwind-api/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterWebhookRoutes(rg *gin.RouterGroup, h *handlers.WebhookHandler) {
+	webhooks := rg.Group("/webhooks")
+	{
+		webhooks.GET("", h.GetAll)
+		webhooks.GET("/:id", h.GetOne)
+		webhooks.POST("", h.Create)
+		webhooks.PUT("/:id", h.Update)
+		webhooks.DELETE("/:id", h.Delete)
+	}
+	deliveries := rg.Group("/webhook-deliveries")
+	{
+		deliveries.GET("", h.Deliveries)
+		deliveries.GET("/:id", h.GetDelivery)
+		deliveries.POST("/:id/retry", h.Retry)
+	}
+}
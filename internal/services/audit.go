@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"northwind-api/internal/models"
+	"northwind-api/internal/repositories"
+	"northwind-api/internal/requestctx"
+)
+
+// AuditEntities adalah nama resource yang perubahannya tercatat di audit log.
+var AuditEntities = []string{"customers", "employees", "shippers", "products", "categories", "suppliers", "orders"}
+
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+)
+
+type AuditService struct {
+	base
+}
+
+// List mengembalikan audit log terbaru lebih dulu. entity wajib diisi kalau id diisi.
+func (s *AuditService) List(ctx context.Context, entity, id string, limit int) ([]models.AuditEntry, error) {
+	var c checks
+	if entity != "" && !slices.Contains(AuditEntities, entity) {
+		c.fail("entity", "must be one of the audited resources")
+	}
+	if id != "" && entity == "" {
+		c.fail("entity", "is required when id is given")
+	}
+	if limit < 0 || limit > maxAuditLimit {
+		c.fail("limit", "must be between 1 and 1000")
+	}
+	if err := c.result(); err != nil {
+		return nil, err
+	}
+	if limit == 0 {
+		limit = defaultAuditLimit
+	}
+	store := s.read(ctx).Audit
+	if store == nil {
+		return []models.AuditEntry{}, nil
+	}
+	return store.ListAudit(ctx, repositories.AuditFilter{Entity: entity, EntityID: id, Limit: limit})
+}
+
+// recordAudit menulis satu entry per event ke audit log di transaksi yang sedang berjalan.
+func recordAudit(ctx context.Context, r repositories.Repositories, events []Event) error {
+	if r.Audit == nil || len(events) == 0 {
+		return nil
+	}
+	actor, requestID := requestctx.Actor(ctx), requestctx.ID(ctx)
+	entries := make([]models.AuditEntry, 0, len(events))
+	for _, e := range events {
+		changes, err := diff(e.Before, e.After)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, models.AuditEntry{
+			Entity:     e.Entity,
+			EntityID:   e.EntityID,
+			Action:     e.Action,
+			Actor:      actor,
+			RequestID:  requestID,
+			Changes:    changes,
+			OccurredAt: e.OccurredAt,
+		})
+	}
+	return r.Audit.RecordAudit(ctx, entries...)
+}
+
+// diff membandingkan representasi JSON before dan after per field top-level.
+// Field yang nilainya sama tidak dicatat.
+func diff(before, after any) (map[string]models.AuditChange, error) {
+	b, err := fieldsOf(before)
+	if err != nil {
+		return nil, err
+	}
+	a, err := fieldsOf(after)
+	if err != nil {
+		return nil, err
+	}
+	changes := map[string]models.AuditChange{}
+	for k, v := range b {
+		if w, ok := a[k]; !ok || !reflect.DeepEqual(v, w) {
+			changes[k] = models.AuditChange{Before: v, After: a[k]}
+		}
+	}
+	for k, w := range a {
+		if _, ok := b[k]; !ok {
+			changes[k] = models.AuditChange{After: w}
+		}
+	}
+	return changes, nil
+}
+
+func fieldsOf(v any) (map[string]any, error) {
+	if v == nil {
+		return nil, nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding audit snapshot: %w", err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/etag"
+	"northwind-api/internal/models"
+	"northwind-api/internal/patch"
+	"northwind-api/internal/repositories"
+	"northwind-api/internal/validation"
+)
+
+// BulkMode menentukan perilaku batch kalau ada operasi yang gagal.
+type BulkMode string
+
+const (
+	// BulkAtomic menjalankan semua operasi dalam satu transaksi; satu gagal, semua batal.
+	BulkAtomic BulkMode = "atomic"
+	// BulkBestEffort menjalankan tiap operasi dalam transaksinya sendiri.
+	BulkBestEffort BulkMode = "best_effort"
+)
+
+const (
+	BulkCreate = "create"
+	BulkUpdate = "update"
+	BulkPatch  = "patch"
+	BulkDelete = "delete"
+)
+
+// MaxBulkOperations membatasi jumlah operasi per request.
+const MaxBulkOperations = 1000
+
+// ErrRolledBack menandai operasi yang sebenarnya berhasil tapi ikut dibatalkan
+// karena operasi lain di batch atomic gagal.
+var ErrRolledBack = errors.New("not applied: batch was rolled back")
+
+// BulkResult adalah hasil satu operasi batch.
+type BulkResult struct {
+	Index int
+	Op    string
+	ID    string
+	Err   error
+}
+
+// ParseBulkMode membaca ?mode=; kosong berarti atomic.
+func ParseBulkMode(s string) (BulkMode, error) {
+	switch BulkMode(s) {
+	case "", BulkAtomic:
+		return BulkAtomic, nil
+	case BulkBestEffort:
+		return BulkBestEffort, nil
+	}
+	return "", apperr.Validation("mode", "must be one of: %s, %s", BulkAtomic, BulkBestEffort)
+}
+
+// bulkFunc menjalankan satu operasi dan mengembalikan ID resource yang disentuh.
+type bulkFunc func(ctx context.Context, op models.BulkOperation) (string, error)
+
+// bulk menjalankan ops sesuai mode. Error per operasi masuk ke hasilnya;
+// error yang dikembalikan hanya untuk kegagalan batch itu sendiri (mis. commit).
+func (b base) bulk(ctx context.Context, mode BulkMode, ops []models.BulkOperation, fn bulkFunc) ([]BulkResult, error) {
+	if len(ops) == 0 {
+		return nil, apperr.Validation("", "at least one operation is required")
+	}
+	if len(ops) > MaxBulkOperations {
+		return nil, apperr.Validation("", "at most %d operations are allowed per request", MaxBulkOperations)
+	}
+
+	results := make([]BulkResult, len(ops))
+	run := func(ctx context.Context) (failed bool) {
+		for i, op := range ops {
+			opCtx := ctx
+			if op.IfMatch != "" {
+				opCtx = etag.WithIfMatch(ctx, op.IfMatch)
+			}
+			var id string
+			var err error
+			switch op.Op {
+			case BulkCreate, BulkUpdate, BulkPatch, BulkDelete:
+				id, err = fn(opCtx, op)
+			default:
+				err = apperr.Validation("op", "must be one of: %s, %s, %s, %s", BulkCreate, BulkUpdate, BulkPatch, BulkDelete)
+			}
+			results[i] = BulkResult{Index: i, Op: op.Op, ID: id, Err: err}
+			failed = failed || err != nil
+		}
+		return failed
+	}
+
+	if mode == BulkBestEffort {
+		run(ctx)
+		return results, nil
+	}
+
+	errFailed := errors.New("bulk operation failed")
+	err := b.write(ctx, func(ctx context.Context, _ repositories.Repositories) error {
+		if run(ctx) {
+			return errFailed
+		}
+		return nil
+	})
+	switch {
+	case errors.Is(err, errFailed):
+		for i := range results {
+			if results[i].Err == nil {
+				results[i].Err = ErrRolledBack
+				if results[i].Op == BulkCreate {
+					results[i].ID = ""
+				}
+			}
+		}
+	case err != nil:
+		return nil, err
+	}
+	return results, nil
+}
+
+// bulkIntID membaca id numerik operasi.
+func bulkIntID(op models.BulkOperation) (int, error) {
+	var id int
+	if err := json.Unmarshal(op.ID, &id); err != nil || id <= 0 {
+		return 0, apperr.Validation("id", "must be a positive integer")
+	}
+	return id, nil
+}
+
+func bulkStringID(op models.BulkOperation) (string, error) {
+	var id string
+	if err := json.Unmarshal(op.ID, &id); err != nil || id == "" {
+		return "", apperr.Validation("id", "is required")
+	}
+	return id, nil
+}
+
+// bulkData men-decode dan memvalidasi data create/update ke v.
+func bulkData(op models.BulkOperation, v any) error {
+	if len(op.Data) == 0 || string(op.Data) == "null" {
+		return apperr.Validation("data", "is required")
+	}
+	return validation.DecodeJSON(op.Data, v)
+}
+
+// bulkPatch memperlakukan data operasi patch sebagai JSON Merge Patch.
+func bulkPatch(op models.BulkOperation) (patch.Patch, error) {
+	if len(op.Data) == 0 || string(op.Data) == "null" {
+		return patch.Patch{}, apperr.Validation("data", "is required")
+	}
+	return patch.Patch{Kind: patch.MergePatch, Body: op.Data}, nil
+}
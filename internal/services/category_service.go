@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/etag"
+	"northwind-api/internal/models"
+	"northwind-api/internal/patch"
+	"northwind-api/internal/repositories"
+	"northwind-api/internal/validation"
+	"strconv"
+)
+
+type CategoryService struct {
+	base
+}
+
+func (s *CategoryService) List(ctx context.Context) ([]models.Category, error) {
+	return s.read(ctx).Categories.GetAllCategories(ctx)
+}
+
+func (s *CategoryService) Get(ctx context.Context, id int) (models.Category, error) {
+	return s.read(ctx).Categories.GetCategoryByID(ctx, id)
+}
+
+func (s *CategoryService) Create(ctx context.Context, c *models.Category) error {
+	c.DeletedAt = nil
+	if err := validateCategory(c); err != nil {
+		return err
+	}
+	return s.write(ctx, func(ctx context.Context, r repositories.Repositories) error {
+		id, err := r.Categories.CreateCategory(ctx, c)
+		if err != nil {
+			return err
+		}
+		c.CategoryID = id
+		emit(ctx, newEvent("category", "categories", strconv.FormatInt(id, 10), ActionCreated, nil, *c))
+		return nil
+	})
+}
+
+func (s *CategoryService) Update(ctx context.Context, c *models.Category) error {
+	c.DeletedAt = nil
+	if err := validateCategory(c); err != nil {
+		return err
+	}
+	return s.write(ctx, func(ctx context.Context, r repositories.Repositories) error {
+		before, err := r.Categories.GetCategoryByID(ctx, int(c.CategoryID))
+		if err != nil {
+			return err
+		}
+		if err := etag.Check(ctx, before); err != nil {
+			return err
+		}
+		if err := r.Categories.UpdateCategory(ctx, c); err != nil {
+			return err
+		}
+		emit(ctx, newEvent("category", "categories", strconv.FormatInt(c.CategoryID, 10), ActionUpdated, before, *c))
+		return nil
+	})
+}
+
+// Patch menerapkan merge patch / JSON Patch ke category dan hanya menulis kolom yang disentuh.
+func (s *CategoryService) Patch(ctx context.Context, id int, p patch.Patch) (models.Category, error) {
+	var after models.Category
+	err := s.write(ctx, func(ctx context.Context, r repositories.Repositories) error {
+		before, err := r.Categories.GetCategoryByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := etag.Check(ctx, before); err != nil {
+			return err
+		}
+		fields, err := p.Apply(before, &after, "category_id", "deleted_at")
+		if err != nil {
+			return err
+		}
+		after.CategoryID = int64(id)
+		if err := validateCategory(&after); err != nil {
+			return err
+		}
+		if len(fields) == 0 {
+			return nil
+		}
+		if err := r.Categories.PatchCategory(ctx, id, repositories.Changes(patch.Columns(&after, fields))); err != nil {
+			return err
+		}
+		emit(ctx, newEvent("category", "categories", strconv.Itoa(id), ActionUpdated, before, after))
+		return nil
+	})
+	return after, err
+}
+
+// Delete men-soft delete category. Product live di category ini menghalangi
+// delete kecuali opts meminta cascade atau reassign.
+func (s *CategoryService) Delete(ctx context.Context, id int, opts DeleteOptions) error {
+	if err := opts.check(id); err != nil {
+		return err
+	}
+	return s.write(ctx, func(ctx context.Context, r repositories.Repositories) error {
+		before, err := r.Categories.GetCategoryByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := etag.Check(ctx, before); err != nil {
+			return err
+		}
+		if opts.ReassignTo != 0 {
+			_, err := r.Categories.GetCategoryByID(ctx, opts.ReassignTo)
+			var c checks
+			c.ref("reassign_to", "category", err)
+			if err := c.result(); err != nil {
+				return err
+			}
+		}
+		products, err := r.Products.GetProductIDsByCategoryID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := resolveProducts(ctx, r, "category", id, opts, products, "category_id"); err != nil {
+			return err
+		}
+		if err := r.Categories.DeleteCategory(ctx, id); err != nil {
+			return err
+		}
+		emit(ctx, newEvent("category", "categories", strconv.Itoa(id), ActionDeleted, before, nil))
+		return nil
+	})
+}
+
+// Restore mengembalikan category yang sudah di-soft delete.
+func (s *CategoryService) Restore(ctx context.Context, id int) (models.Category, error) {
+	var after models.Category
+	err := s.write(ctx, func(ctx context.Context, r repositories.Repositories) error {
+		before, err := r.Categories.GetCategoryByID(repositories.WithDeleted(ctx), id)
+		if err != nil {
+			return err
+		}
+		if before.DeletedAt == nil {
+			return apperr.Conflict("category is not deleted")
+		}
+		if err := r.Categories.RestoreCategory(ctx, id); err != nil {
+			return err
+		}
+		after = before
+		after.DeletedAt = nil
+		emit(ctx, newEvent("category", "categories", strconv.Itoa(id), ActionRestored, before, after))
+		return nil
+	})
+	return after, err
+}
+
+func validateCategory(c *models.Category) error {
+	return validation.Struct(c)
+}
@@ -0,0 +1,255 @@
+package services
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/etag"
+	"northwind-api/internal/models"
+	"northwind-api/internal/patch"
+	"northwind-api/internal/repositories"
+)
+
+// ActionMerged dipakai untuk customer duplikat yang digabung ke customer lain.
+// Customer-nya di-soft delete, jadi masih bisa di-restore.
+const ActionMerged = "merged"
+
+const DefaultDuplicateScore = 0.8
+
+// Bobot sinyal untuk skor duplikat. Sinyal yang kosong di salah satu
+// customer tidak ikut dihitung, jadi skor tetap 0..1.
+var duplicateWeights = map[string]float64{
+	"company_name": 0.6,
+	"phone":        0.2,
+	"address":      0.2,
+}
+
+// legalForms adalah kata bentuk badan usaha yang diabaikan saat
+// membandingkan nama perusahaan ("Alfreds Futterkiste GmbH" = "Alfreds Futterkiste").
+var legalForms = map[string]bool{
+	"ab": true, "ag": true, "as": true, "bv": true, "cia": true, "co": true, "corp": true,
+	"gmbh": true, "inc": true, "kg": true, "llc": true, "ltd": true, "ltda": true, "nv": true,
+	"oy": true, "plc": true, "sa": true, "sarl": true, "spa": true, "srl": true,
+}
+
+// streetWords menyeragamkan singkatan alamat yang umum di data Northwind.
+var streetWords = map[string]string{
+	"str": "strasse", "st": "street", "ave": "avenue", "av": "avenue", "rd": "road",
+	"blvd": "boulevard", "dr": "drive", "ln": "lane", "sq": "square", "pl": "place",
+}
+
+// mergedCustomer adalah snapshot "after" event merged: customer duplikat yang
+// sudah dihapus beserta tujuan penggabungannya, supaya tercatat di audit log.
+type mergedCustomer struct {
+	models.Customer
+	MergedInto string `json:"merged_into"`
+}
+
+// Duplicates mencari pasangan customer live yang kemungkinan perusahaan yang
+// sama: nama mirip (tanpa aksen dan bentuk badan usaha), nomor telepon yang
+// sama setelah dibuang selain angka, dan alamat yang sama setelah singkatan
+// diseragamkan. Hasilnya terurut dari skor tertinggi.
+func (s *CustomerService) Duplicates(ctx context.Context, minScore float64) ([]models.CustomerDuplicate, error) {
+	switch {
+	case minScore == 0:
+		minScore = DefaultDuplicateScore
+	case minScore < 0 || minScore > 1:
+		return nil, apperr.Validation("min_score", "must be between 0 and 1")
+	}
+	customers, err := s.read(ctx).Customers.GetAllCustomers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]duplicateKey, len(customers))
+	for i, c := range customers {
+		keys[i] = newDuplicateKey(c)
+	}
+
+	dups := []models.CustomerDuplicate{}
+	for i := range customers {
+		for j := i + 1; j < len(customers); j++ {
+			score, matches := keys[i].compare(keys[j])
+			if score >= minScore {
+				dups = append(dups, models.CustomerDuplicate{
+					Customers: []models.Customer{customers[i], customers[j]},
+					Score:     score,
+					Matches:   matches,
+				})
+			}
+		}
+	}
+	sort.SliceStable(dups, func(i, j int) bool { return dups[i].Score > dups[j].Score })
+	return dups, nil
+}
+
+type duplicateKey struct {
+	name, phone, address string
+}
+
+func newDuplicateKey(c models.Customer) duplicateKey {
+	var k duplicateKey
+	k.name = strings.Join(slices.DeleteFunc(normalWords(c.CompanyName), func(w string) bool { return legalForms[w] }), " ")
+	k.phone = strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, c.Phone)
+	// Awalan 0 (kode area nasional) dibuang supaya cocok dengan format +kode negara.
+	k.phone = strings.TrimLeft(k.phone, "0")
+	if len(k.phone) < 6 {
+		k.phone = ""
+	}
+	if c.Address != "" {
+		words := normalWords(c.Address)
+		for i, w := range words {
+			if full, ok := streetWords[w]; ok {
+				words[i] = full
+			}
+		}
+		words = append(words, normalWords(c.PostalCode+" "+c.City)...)
+		k.address = strings.Join(words, " ")
+	}
+	return k
+}
+
+// compare mengembalikan skor 0..1 dan kemiripan per sinyal.
+func (a duplicateKey) compare(b duplicateKey) (float64, map[string]float64) {
+	matches := map[string]float64{"company_name": similarity(a.name, b.name)}
+	if a.phone != "" && b.phone != "" {
+		// Nomor yang sama dengan/tanpa kode negara dianggap sama.
+		if strings.HasSuffix(a.phone, b.phone) || strings.HasSuffix(b.phone, a.phone) {
+			matches["phone"] = 1
+		} else {
+			matches["phone"] = 0
+		}
+	}
+	if a.address != "" && b.address != "" {
+		matches["address"] = similarity(a.address, b.address)
+	}
+	var score, total float64
+	for signal, m := range matches {
+		score += duplicateWeights[signal] * m
+		total += duplicateWeights[signal]
+	}
+	return score / total, matches
+}
+
+// similarity = 1 - editDistance / panjang string terpanjang.
+func similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	n := max(len(ra), len(rb))
+	if n == 0 {
+		return 0
+	}
+	return 1 - float64(editDistance(ra, rb))/float64(n)
+}
+
+// normalWords memecah teks seperti index pencarian, dengan ß menjadi ss.
+func normalWords(s string) []string {
+	return repositories.SearchWords(strings.ReplaceAll(s, "ß", "ss"))
+}
+
+// Merge menggabungkan customer duplikat ke customer id dalam satu transaksi:
+// semua order duplikat dipindah ke id, field kosong id diisi dari duplikat
+// kalau FillBlanks, lalu duplikat di-soft delete dengan event "merged".
+// Setiap langkah tercatat di audit log.
+func (s *CustomerService) Merge(ctx context.Context, id string, req models.CustomerMergeRequest) (models.CustomerMergeResult, error) {
+	res := models.CustomerMergeResult{MergedIDs: req.DuplicateIDs, MovedOrderIDs: []int64{}}
+	var c checks
+	for i, dupID := range req.DuplicateIDs {
+		switch {
+		case dupID == id:
+			c.fail("duplicate_ids", "must not contain the surviving customer")
+		case slices.Contains(req.DuplicateIDs[:i], dupID):
+			c.fail("duplicate_ids", "must not contain "+dupID+" twice")
+		}
+	}
+	if err := c.result(); err != nil {
+		return res, err
+	}
+
+	err := s.write(ctx, func(ctx context.Context, r repositories.Repositories) error {
+		survivor, err := r.Customers.GetCustomerByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := etag.Check(ctx, survivor); err != nil {
+			return err
+		}
+		var c checks
+		dups := make([]models.Customer, 0, len(req.DuplicateIDs))
+		for _, dupID := range req.DuplicateIDs {
+			dup, err := r.Customers.GetCustomerByID(ctx, dupID)
+			c.ref("duplicate_ids", "customer "+dupID, err)
+			dups = append(dups, dup)
+		}
+		if err := c.result(); err != nil {
+			return err
+		}
+
+		orders, err := r.Orders.GetOrdersByCustomerIDs(ctx, req.DuplicateIDs)
+		if err != nil {
+			return err
+		}
+		for _, before := range orders {
+			after := before
+			after.CustomerID = &id
+			if err := r.Orders.PatchOrder(ctx, int(before.OrderID), repositories.Changes(patch.Columns(&after, []string{"customer_id"}))); err != nil {
+				return err
+			}
+			emit(ctx, newEvent("order", "orders", strconv.FormatInt(before.OrderID, 10), ActionUpdated, before, after))
+			res.MovedOrderIDs = append(res.MovedOrderIDs, before.OrderID)
+		}
+
+		res.Customer = survivor
+		if req.FillBlanks {
+			var fields []string
+			for _, dup := range dups {
+				fields = append(fields, fillBlanks(&res.Customer, dup)...)
+			}
+			if len(fields) > 0 {
+				if err := r.Customers.PatchCustomer(ctx, id, repositories.Changes(patch.Columns(&res.Customer, fields))); err != nil {
+					return err
+				}
+				emit(ctx, newEvent("customer", "customers", id, ActionUpdated, survivor, res.Customer))
+			}
+		}
+
+		now := time.Now().UTC()
+		for _, dup := range dups {
+			if err := r.Customers.DeleteCustomer(ctx, dup.CustomerID); err != nil {
+				return err
+			}
+			after := dup
+			after.DeletedAt = &now
+			emit(ctx, newEvent("customer", "customers", dup.CustomerID, ActionMerged, dup, mergedCustomer{Customer: after, MergedInto: id}))
+		}
+		return nil
+	})
+	return res, err
+}
+
+// fillBlanks mengisi field string dst yang kosong dari src dan mengembalikan
+// nama field JSON yang berubah.
+func fillBlanks(dst *models.Customer, src models.Customer) []string {
+	var fields []string
+	dv, sv := reflect.ValueOf(dst).Elem(), reflect.ValueOf(src)
+	for i := 0; i < dv.NumField(); i++ {
+		f := dv.Field(i)
+		if f.Kind() != reflect.String || f.String() != "" || sv.Field(i).String() == "" {
+			continue
+		}
+		f.SetString(sv.Field(i).String())
+		name, _, _ := strings.Cut(dv.Type().Field(i).Tag.Get("json"), ",")
+		fields = append(fields, name)
+	}
+	return fields
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/models"
+	"northwind-api/internal/repositories"
+	"northwind-api/internal/utils"
+)
+
+// CustomerIDStrategy menentukan cara CustomerID customer baru dibuat.
+type CustomerIDStrategy string
+
+const (
+	// CustomerIDClassic membuat kode 5 huruf besar dari CompanyName seperti
+	// data Northwind asli ("Ana Trujillo" -> "ANATR"). Default.
+	CustomerIDClassic CustomerIDStrategy = "classic"
+	// CustomerIDUUID membuat UUID acak.
+	CustomerIDUUID CustomerIDStrategy = "uuid"
+	// CustomerIDClient memakai customer_id dari client: wajib, 5 huruf
+	// besar/angka, dan belum dipakai customer lain (termasuk yang dihapus).
+	CustomerIDClient CustomerIDStrategy = "client"
+)
+
+var CustomerIDStrategies = []CustomerIDStrategy{CustomerIDClassic, CustomerIDUUID, CustomerIDClient}
+
+// ParseCustomerIDStrategy membaca nilai config; string kosong berarti classic.
+func ParseCustomerIDStrategy(s string) (CustomerIDStrategy, error) {
+	if s == "" {
+		return CustomerIDClassic, nil
+	}
+	for _, st := range CustomerIDStrategies {
+		if string(st) == strings.ToLower(s) {
+			return st, nil
+		}
+	}
+	return "", fmt.Errorf("unknown customer ID strategy %q; expected classic, uuid or client", s)
+}
+
+// customerID menentukan CustomerID untuk customer baru sesuai strategy.
+// Untuk classic dan uuid, customer_id dari client diabaikan.
+func (s *CustomerService) customerID(ctx context.Context, r repositories.Repositories, c *models.Customer) (string, error) {
+	switch s.IDStrategy {
+	case CustomerIDUUID:
+		return utils.GenerateCustomerID(), nil
+	case CustomerIDClient:
+		if err := checkClientCustomerID(c.CustomerID); err != nil {
+			return "", err
+		}
+		taken, err := customerIDTaken(ctx, r, c.CustomerID)
+		if err != nil {
+			return "", err
+		}
+		if taken {
+			return "", apperr.Conflict("customer %s already exists", c.CustomerID)
+		}
+		return c.CustomerID, nil
+	default:
+		for _, id := range classicCustomerIDs(c.CompanyName) {
+			taken, err := customerIDTaken(ctx, r, id)
+			if err != nil || !taken {
+				return id, err
+			}
+		}
+		return "", apperr.Conflict("no free customer ID left for %q", c.CompanyName)
+	}
+}
+
+func checkClientCustomerID(id string) error {
+	if id == "" {
+		return apperr.Validation("customer_id", "is required")
+	}
+	if len(id) != 5 || strings.IndexFunc(id, func(r rune) bool { return !(r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') }) >= 0 {
+		return apperr.Validation("customer_id", "must be 5 uppercase letters or digits")
+	}
+	return nil
+}
+
+// customerIDTaken juga menghitung customer yang di-soft delete, karena
+// barisnya masih memakai primary key tersebut.
+func customerIDTaken(ctx context.Context, r repositories.Repositories, id string) (bool, error) {
+	_, err := r.Customers.GetCustomerByID(repositories.WithDeleted(ctx), id)
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, apperr.ErrNotFound):
+		return false, nil
+	}
+	return false, err
+}
+
+// classicCustomerIDs mengembalikan kandidat kode berurutan dari yang paling
+// mirip konvensi Northwind: 3 huruf kata pertama + 2 huruf kata kedua
+// ("Alfreds Futterkiste" -> ALFFU), 4 + 1 huruf (ALFRF), 3 + 1 + 1 huruf untuk
+// nama tiga kata, lalu 5 huruf pertama nama. Nama yang terlalu pendek diisi X.
+// Sesudah itu huruf terakhir kandidat pertama diganti A-Z, lalu dua huruf
+// terakhirnya diganti 01-99.
+func classicCustomerIDs(companyName string) []string {
+	var words []string
+	for _, w := range normalWords(companyName) {
+		// Huruf di luar ASCII yang tidak punya bentuk tanpa aksen (mis. ø) dibuang.
+		w = strings.Map(func(r rune) rune {
+			if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+				return unicode.ToUpper(r)
+			}
+			return -1
+		}, w)
+		if w != "" {
+			words = append(words, w)
+		}
+	}
+	pad := func(s string) string {
+		return (s + "XXXXX")[:5]
+	}
+
+	var ids []string
+	add := func(id string) {
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	if len(words) >= 2 {
+		add(pad(prefix(words[0], 3) + prefix(words[1], 2)))
+		if len(words[0]) >= 4 {
+			add(pad(prefix(words[0], 4) + prefix(words[1], 1)))
+		}
+	}
+	if len(words) >= 3 {
+		add(pad(prefix(words[0], 3) + prefix(words[1], 1) + prefix(words[2], 1)))
+	}
+	add(pad(strings.Join(words, "")))
+	base := ids[0]
+	for c := 'A'; c <= 'Z'; c++ {
+		add(base[:4] + string(c))
+	}
+	for n := 1; n <= 99; n++ {
+		add(fmt.Sprintf("%s%02d", base[:3], n))
+	}
+	return ids
+}
+
+func prefix(s string, n int) string {
+	return s[:min(len(s), n)]
+}
@@ -0,0 +1,210 @@
+package services
+
+import (
+	"context"
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/etag"
+	"northwind-api/internal/models"
+	"northwind-api/internal/patch"
+	"northwind-api/internal/repositories"
+	"northwind-api/internal/validation"
+	"northwind-api/internal/visibility"
+)
+
+type CustomerService struct {
+	base
+	// IDStrategy menentukan CustomerID customer baru; kosong berarti classic.
+	IDStrategy CustomerIDStrategy
+}
+
+func (s *CustomerService) List(ctx context.Context) ([]models.Customer, error) {
+	return s.read(ctx).Customers.GetAllCustomers(ctx)
+}
+
+// Search mengembalikan customers yang cocok dengan q (lihat SearchService),
+// terurut dari yang paling relevan.
+func (s *CustomerService) Search(ctx context.Context, q string) ([]models.Customer, error) {
+	r := s.read(ctx)
+	ids, err := searchIDs(ctx, r, "customers", q)
+	if err != nil || len(ids) == 0 {
+		return []models.Customer{}, err
+	}
+	rows, err := r.Customers.GetCustomersByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	return rankOrder(ids, rows, func(c models.Customer) string { return c.CustomerID }), nil
+}
+
+func (s *CustomerService) Get(ctx context.Context, id string) (models.Customer, error) {
+	return s.read(ctx).Customers.GetCustomerByID(ctx, id)
+}
+
+// Create menentukan CustomerID sesuai IDStrategy lalu menyimpan customer baru.
+func (s *CustomerService) Create(ctx context.Context, c *models.Customer) error {
+	c.DeletedAt = nil
+	if err := validateCustomer(c); err != nil {
+		return err
+	}
+	return s.write(ctx, func(ctx context.Context, r repositories.Repositories) error {
+		id, err := s.customerID(ctx, r, c)
+		if err != nil {
+			return err
+		}
+		c.CustomerID = id
+		if _, err := r.Customers.CreateCustomer(ctx, c); err != nil {
+			return err
+		}
+		emit(ctx, newEvent("customer", "customers", c.CustomerID, ActionCreated, nil, *c))
+		return nil
+	})
+}
+
+func (s *CustomerService) Update(ctx context.Context, c *models.Customer) error {
+	c.DeletedAt = nil
+	if err := validateCustomer(c); err != nil {
+		return err
+	}
+	return s.write(ctx, func(ctx context.Context, r repositories.Repositories) error {
+		before, err := r.Customers.GetCustomerByID(ctx, c.CustomerID)
+		if err != nil {
+			return err
+		}
+		if err := etag.Check(ctx, before); err != nil {
+			return err
+		}
+		// Field yang disembunyikan dari caller tidak bisa diubahnya lewat PUT.
+		visibility.Keep(ctx, c, before)
+		if err := r.Customers.UpdateCustomer(ctx, c); err != nil {
+			return err
+		}
+		emit(ctx, newEvent("customer", "customers", c.CustomerID, ActionUpdated, before, *c))
+		return nil
+	})
+}
+
+// Patch menerapkan merge patch / JSON Patch ke customer dan hanya menulis kolom yang disentuh.
+func (s *CustomerService) Patch(ctx context.Context, id string, p patch.Patch) (models.Customer, error) {
+	var after models.Customer
+	err := s.write(ctx, func(ctx context.Context, r repositories.Repositories) error {
+		before, err := r.Customers.GetCustomerByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := etag.Check(ctx, before); err != nil {
+			return err
+		}
+		// Patch diterapkan ke resource seperti yang dilihat caller, supaya
+		// operasi test JSON Patch tidak membocorkan field yang disembunyikan.
+		view := before
+		visibility.Redact(ctx, &view)
+		fields, err := p.Apply(view, &after, "customer_id", "deleted_at")
+		if err != nil {
+			return err
+		}
+		visibility.Keep(ctx, &after, before)
+		after.CustomerID = id
+		if err := validateCustomer(&after); err != nil {
+			return err
+		}
+		if len(fields) == 0 {
+			return nil
+		}
+		if err := r.Customers.PatchCustomer(ctx, id, repositories.Changes(patch.Columns(&after, fields))); err != nil {
+			return err
+		}
+		emit(ctx, newEvent("customer", "customers", id, ActionUpdated, before, after))
+		return nil
+	})
+	return after, err
+}
+
+func (s *CustomerService) Delete(ctx context.Context, id string) error {
+	return s.write(ctx, func(ctx context.Context, r repositories.Repositories) error {
+		before, err := r.Customers.GetCustomerByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := etag.Check(ctx, before); err != nil {
+			return err
+		}
+		if err := r.Customers.DeleteCustomer(ctx, id); err != nil {
+			return err
+		}
+		emit(ctx, newEvent("customer", "customers", id, ActionDeleted, before, nil))
+		return nil
+	})
+}
+
+// Restore mengembalikan customer yang sudah di-soft delete.
+func (s *CustomerService) Restore(ctx context.Context, id string) (models.Customer, error) {
+	var after models.Customer
+	err := s.write(ctx, func(ctx context.Context, r repositories.Repositories) error {
+		before, err := r.Customers.GetCustomerByID(repositories.WithDeleted(ctx), id)
+		if err != nil {
+			return err
+		}
+		if before.DeletedAt == nil {
+			return apperr.Conflict("customer is not deleted")
+		}
+		if err := r.Customers.RestoreCustomer(ctx, id); err != nil {
+			return err
+		}
+		after = before
+		after.DeletedAt = nil
+		emit(ctx, newEvent("customer", "customers", id, ActionRestored, before, after))
+		return nil
+	})
+	return after, err
+}
+
+func validateCustomer(c *models.Customer) error {
+	return validation.Struct(c)
+}
+
+// Bulk menjalankan create/update/patch/delete customer secara batch.
+func (s *CustomerService) Bulk(ctx context.Context, mode BulkMode, ops []models.BulkOperation) ([]BulkResult, error) {
+	return s.bulk(ctx, mode, ops, func(ctx context.Context, op models.BulkOperation) (string, error) {
+		if op.Op == BulkCreate {
+			var c models.Customer
+			if err := bulkData(op, &c); err != nil {
+				return "", err
+			}
+			err := s.Create(ctx, &c)
+			return c.CustomerID, err
+		}
+		id, err := bulkStringID(op)
+		if err != nil {
+			return "", err
+		}
+		switch op.Op {
+		case BulkUpdate:
+			var c models.Customer
+			if err := bulkData(op, &c); err != nil {
+				return id, err
+			}
+			c.CustomerID = id
+			err = s.Update(ctx, &c)
+		case BulkPatch:
+			var p patch.Patch
+			if p, err = bulkPatch(op); err == nil {
+				_, err = s.Patch(ctx, id, p)
+			}
+		case BulkDelete:
+			err = s.Delete(ctx, id)
+		}
+		return id, err
+	})
+}
+
+// Check menjalankan validasi Create tanpa menyimpan; dipakai dry-run import.
+func (s *CustomerService) Check(ctx context.Context, c *models.Customer) error {
+	if err := validateCustomer(c); err != nil {
+		return err
+	}
+	if s.IDStrategy != CustomerIDClient {
+		return nil
+	}
+	_, err := s.customerID(ctx, s.read(ctx), c)
+	return err
+}
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/models"
+	"northwind-api/internal/patch"
+	"northwind-api/internal/repositories"
+)
+
+// DeleteOptions menentukan nasib data yang masih merujuk resource yang dihapus.
+// Tanpa opsi, delete ditolak dengan 409 berisi daftar blocker.
+type DeleteOptions struct {
+	// Cascade ikut menghapus data yang merujuk.
+	Cascade bool
+	// ReassignTo memindahkan rujukan ke resource lain dengan ID ini.
+	ReassignTo int
+}
+
+func (o DeleteOptions) check(id int) error {
+	switch {
+	case o.Cascade && o.ReassignTo != 0:
+		return apperr.Validation("reassign_to", "cannot be combined with cascade")
+	case o.ReassignTo == id:
+		return apperr.Validation("reassign_to", "must differ from the resource being deleted")
+	}
+	return nil
+}
+
+// maxBlockerIDs membatasi jumlah ID contoh per blocker di response 409.
+const maxBlockerIDs = 20
+
+func blocker(entity string, ids []int) apperr.Blocker {
+	b := apperr.Blocker{Entity: entity, Count: len(ids)}
+	for _, id := range ids[:min(len(ids), maxBlockerIDs)] {
+		b.IDs = append(b.IDs, strconv.Itoa(id))
+	}
+	return b
+}
+
+func hasDependents(kind string, id int, blockers ...apperr.Blocker) error {
+	return apperr.Conflict("%s %d still has dependent records; retry with ?cascade=true or ?reassign_to=ID", kind, id).
+		WithBlockers(blockers...)
+}
+
+// resolveProducts menangani product live yang masih merujuk supplier/category
+// yang mau dihapus: cascade ikut men-soft delete, reassign mengisi field dengan
+// opts.ReassignTo. Target reassign harus sudah divalidasi pemanggil.
+func resolveProducts(ctx context.Context, r repositories.Repositories, kind string, id int, opts DeleteOptions, ids []int, field string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if !opts.Cascade && opts.ReassignTo == 0 {
+		return hasDependents(kind, id, blocker("products", ids))
+	}
+	for _, pid := range ids {
+		before, err := r.Products.GetProductByID(ctx, pid)
+		if err != nil {
+			return err
+		}
+		key := strconv.Itoa(pid)
+		if opts.Cascade {
+			if err := r.Products.DeleteProduct(ctx, pid); err != nil {
+				return err
+			}
+			emit(ctx, newEvent("product", "products", key, ActionDeleted, before, nil))
+			continue
+		}
+		after := before
+		to := opts.ReassignTo
+		if field == "supplier_id" {
+			after.SupplierID = &to
+		} else {
+			after.CategoryID = &to
+		}
+		if err := r.Products.PatchProduct(ctx, pid, repositories.Changes(patch.Columns(&after, []string{field}))); err != nil {
+			return err
+		}
+		emit(ctx, newEvent("product", "products", key, ActionUpdated, before, after))
+	}
+	return nil
+}
+
+// ActionDetailRemoved dan ActionDetailAdded dipakai per baris detail yang
+// ikut dihapus (cascade) atau dipindah (reassign) saat order dihapus. Event-nya
+// milik order yang kehilangan/mendapat baris tersebut, dengan before/after
+// berupa models.OrderDetail.
+const (
+	ActionDetailRemoved = "detail_removed"
+	ActionDetailAdded   = "detail_added"
+)
+
+// resolveOrderDetails menangani baris detail order yang mau dihapus. Target
+// reassign yang sudah punya baris untuk product yang sama ditolak 409, karena
+// (OrderID, ProductID) adalah primary key detail.
+func resolveOrderDetails(ctx context.Context, r repositories.Repositories, id int, opts DeleteOptions, details []models.OrderDetail) error {
+	if len(details) == 0 {
+		return nil
+	}
+	key := strconv.Itoa(id)
+	switch {
+	case opts.Cascade:
+		if err := r.Orders.DeleteOrderDetails(ctx, id); err != nil {
+			return err
+		}
+		for _, d := range details {
+			emit(ctx, newEvent("order", "orders", key, ActionDetailRemoved, d, nil))
+		}
+		return nil
+	case opts.ReassignTo != 0:
+		existing, err := r.Orders.GetOrderDetailsByOrderID(ctx, opts.ReassignTo)
+		if err != nil {
+			return err
+		}
+		var clash []string
+		for _, d := range details {
+			if slices.ContainsFunc(existing, func(e models.OrderDetail) bool { return e.ProductID == d.ProductID }) {
+				clash = append(clash, strconv.FormatInt(d.ProductID, 10))
+			}
+		}
+		if len(clash) > 0 {
+			return apperr.Conflict("order %d already has detail lines for product %s; merge them first or reassign to another order",
+				opts.ReassignTo, strings.Join(clash, ", "))
+		}
+		if err := r.Orders.MoveOrderDetails(ctx, id, opts.ReassignTo); err != nil {
+			return err
+		}
+		to := strconv.Itoa(opts.ReassignTo)
+		for _, d := range details {
+			moved := d
+			moved.OrderID = int64(opts.ReassignTo)
+			emit(ctx, newEvent("order", "orders", key, ActionDetailRemoved, d, nil))
+			emit(ctx, newEvent("order", "orders", to, ActionDetailAdded, nil, moved))
+		}
+		return nil
+	}
+	b := apperr.Blocker{Entity: "order_details", Count: len(details)}
+	for _, d := range details[:min(len(details), maxBlockerIDs)] {
+		b.IDs = append(b.IDs, fmt.Sprintf("OrderID=%d,ProductID=%d", d.OrderID, d.ProductID))
+	}
+	return hasDependents("order", id, b)
+}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/etag"
+	"northwind-api/internal/models"
+	"northwind-api/internal/patch"
+	"northwind-api/internal/repositories"
+	"northwind-api/internal/validation"
+	"northwind-api/internal/visibility"
+	"strconv"
+)
+
+type EmployeeService struct {
+	base
+}
+
+func (s *EmployeeService) List(ctx context.Context) ([]models.Employee, error) {
+	return s.read(ctx).Employees.GetAllEmployees(ctx)
+}
+
+func (s *EmployeeService) Get(ctx context.Context, id int) (models.Employee, error) {
+	return s.read(ctx).Employees.GetEmployeeByID(ctx, id)
+}
+
+func (s *EmployeeService) Create(ctx context.Context, emp *models.Employee) error {
+	emp.DeletedAt = nil
+	if err := validateEmployee(emp); err != nil {
+		return err
+	}
+	return s.write(ctx, func(ctx context.Context, r repositories.Repositories) error {
+		if err := checkEmployeeRefs(ctx, r, emp); err != nil {
+			return err
+		}
+		id, err := r.Employees.CreateEmployee(ctx, *emp)
+		if err != nil {
+			return err
+		}
+		emp.EmployeeID = int(id)
+		emit(ctx, newEvent("employee", "employees", strconv.Itoa(emp.EmployeeID), ActionCreated, nil, *emp))
+		return nil
+	})
+}
+
+func (s *EmployeeService) Update(ctx context.Context, emp *models.Employee) error {
+	emp.DeletedAt = nil
+	if err := validateEmployee(emp); err != nil {
+		return err
+	}
+	return s.write(ctx, func(ctx context.Context, r repositories.Repositories) error {
+		before, err := r.Employees.GetEmployeeByID(ctx, emp.EmployeeID)
+		if err != nil {
+			return err
+		}
+		if err := etag.Check(ctx, before); err != nil {
+			return err
+		}
+		if err := checkEmployeeRefs(ctx, r, emp); err != nil {
+			return err
+		}
+		// Field yang disembunyikan dari caller tidak bisa diubahnya lewat PUT.
+		visibility.Keep(ctx, emp, before)
+		if err := r.Employees.UpdateEmployee(ctx, emp); err != nil {
+			return err
+		}
+		emit(ctx, newEvent("employee", "employees", strconv.Itoa(emp.EmployeeID), ActionUpdated, before, *emp))
+		return nil
+	})
+}
+
+// Patch menerapkan merge patch / JSON Patch ke employee dan hanya menulis kolom yang disentuh.
+func (s *EmployeeService) Patch(ctx context.Context, id int, p patch.Patch) (models.Employee, error) {
+	var after models.Employee
+	err := s.write(ctx, func(ctx context.Context, r repositories.Repositories) error {
+		before, err := r.Employees.GetEmployeeByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := etag.Check(ctx, before); err != nil {
+			return err
+		}
+		// Patch diterapkan ke resource seperti yang dilihat caller, supaya
+		// operasi test JSON Patch tidak membocorkan field yang disembunyikan.
+		view := before
+		visibility.Redact(ctx, &view)
+		fields, err := p.Apply(view, &after, "employee_id", "deleted_at")
+		if err != nil {
+			return err
+		}
+		visibility.Keep(ctx, &after, before)
+		after.EmployeeID = id
+		if err := validateEmployee(&after); err != nil {
+			return err
+		}
+		if err := checkEmployeeRefs(ctx, r, &after); err != nil {
+			return err
+		}
+		if len(fields) == 0 {
+			return nil
+		}
+		if err := r.Employees.PatchEmployee(ctx, id, repositories.Changes(patch.Columns(&after, fields))); err != nil {
+			return err
+		}
+		emit(ctx, newEvent("employee", "employees", strconv.Itoa(id), ActionUpdated, before, after))
+		return nil
+	})
+	return after, err
+}
+
+func (s *EmployeeService) Delete(ctx context.Context, id int) error {
+	return s.write(ctx, func(ctx context.Context, r repositories.Repositories) error {
+		before, err := r.Employees.GetEmployeeByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := etag.Check(ctx, before); err != nil {
+			return err
+		}
+		if err := r.Employees.DeleteEmployee(ctx, id); err != nil {
+			return err
+		}
+		emit(ctx, newEvent("employee", "employees", strconv.Itoa(id), ActionDeleted, before, nil))
+		return nil
+	})
+}
+
+// Restore mengembalikan employee yang sudah di-soft delete.
+func (s *EmployeeService) Restore(ctx context.Context, id int) (models.Employee, error) {
+	var after models.Employee
+	err := s.write(ctx, func(ctx context.Context, r repositories.Repositories) error {
+		before, err := r.Employees.GetEmployeeByID(repositories.WithDeleted(ctx), id)
+		if err != nil {
+			return err
+		}
+		if before.DeletedAt == nil {
+			return apperr.Conflict("employee is not deleted")
+		}
+		if err := r.Employees.RestoreEmployee(ctx, id); err != nil {
+			return err
+		}
+		after = before
+		after.DeletedAt = nil
+		emit(ctx, newEvent("employee", "employees", strconv.Itoa(id), ActionRestored, before, after))
+		return nil
+	})
+	return after, err
+}
+
+func validateEmployee(emp *models.Employee) error {
+	if err := validation.Struct(emp); err != nil {
+		return err
+	}
+	var c checks
+	if emp.BirthDate != "" && emp.HireDate != "" {
+		birth, _ := validation.ParseDate(emp.BirthDate)
+		hire, _ := validation.ParseDate(emp.HireDate)
+		if hire.Before(birth) {
+			c.fail("hire_date", "must not be before birth_date")
+		}
+	}
+	return c.result()
+}
+
+// checkEmployeeRefs memastikan atasan (reports_to) ada dan bukan dirinya sendiri.
+func checkEmployeeRefs(ctx context.Context, r repositories.Repositories, emp *models.Employee) error {
+	var c checks
+	if emp.ReportsTo != nil {
+		if emp.EmployeeID != 0 && *emp.ReportsTo == emp.EmployeeID {
+			c.fail("reports_to", "must not reference the employee itself")
+		} else {
+			_, err := r.Employees.GetEmployeeByID(ctx, *emp.ReportsTo)
+			c.ref("reports_to", "employee", err)
+		}
+	}
+	return c.result()
+}
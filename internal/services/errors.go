@@ -0,0 +1,39 @@
+package services
+
+import (
+	"errors"
+	"northwind-api/internal/apperr"
+)
+
+// checks mengumpulkan field error dari aturan yang butuh database atau
+// lebih dari satu field, supaya semuanya dilaporkan sekaligus.
+type checks struct {
+	fields []apperr.FieldError
+	err    error
+}
+
+func (c *checks) fail(field, message string) {
+	c.fields = append(c.fields, apperr.FieldError{Field: field, Message: message})
+}
+
+// ref mencatat hasil lookup foreign key: NotFound menjadi field error,
+// error lain (mis. database) menghentikan validasi.
+func (c *checks) ref(field, entity string, err error) {
+	switch {
+	case err == nil || c.err != nil:
+	case errors.Is(err, apperr.ErrNotFound):
+		c.fail(field, "references unknown "+entity)
+	default:
+		c.err = err
+	}
+}
+
+func (c *checks) result() error {
+	if c.err != nil {
+		return c.err
+	}
+	if len(c.fields) > 0 {
+		return apperr.Invalid(c.fields...)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	ActionCreated  = "created"
+	ActionUpdated  = "updated"
+	ActionDeleted  = "deleted"
+	ActionRestored = "restored"
+)
+
+// Event adalah domain event yang di-emit setelah perubahan data berhasil di-commit.
+type Event struct {
+	Type       string    `json:"type"`   // mis. "order.created"
+	Entity     string    `json:"entity"` // nama resource, mis. "orders"
+	EntityID   string    `json:"entity_id"`
+	Action     string    `json:"action"` // created | updated | deleted | restored | merged
+	Before     any       `json:"before,omitempty"`
+	After      any       `json:"after,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+func (e Event) stamp() Event {
+	if e.OccurredAt.IsZero() {
+		e.OccurredAt = time.Now().UTC()
+	}
+	return e
+}
+
+// newEvent membentuk event standar untuk satu entity, mis. ("order", "orders", ...).
+func newEvent(kind, entity, id, action string, before, after any) Event {
+	return Event{
+		Type:     kind + "." + action,
+		Entity:   entity,
+		EntityID: id,
+		Action:   action,
+		Before:   before,
+		After:    after,
+	}
+}
+
+type EventHandler func(ctx context.Context, e Event)
+
+// Dispatcher adalah event bus in-process yang sederhana dan sinkron.
+type Dispatcher struct {
+	mu       sync.RWMutex
+	handlers []EventHandler
+}
+
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{}
+}
+
+func (d *Dispatcher) Subscribe(h EventHandler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.handlers = append(d.handlers, h)
+}
+
+func (d *Dispatcher) Publish(ctx context.Context, events ...Event) {
+	d.mu.RLock()
+	handlers := append([]EventHandler(nil), d.handlers...)
+	d.mu.RUnlock()
+
+	for _, e := range events {
+		for _, h := range handlers {
+			h(ctx, e)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/etag"
+	"northwind-api/internal/images"
+	"northwind-api/internal/models"
+	"northwind-api/internal/repositories"
+	"runtime"
+	"strconv"
+)
+
+// ActionPhotoUpdated dan ActionPictureUpdated dipakai saat foto employee atau
+// gambar category diganti atau dihapus. Before/after event-nya adalah
+// models.ImageInfo (nil kalau tidak ada gambar), bukan resource-nya.
+const (
+	ActionPhotoUpdated   = "photo_updated"
+	ActionPictureUpdated = "picture_updated"
+)
+
+// thumbnails dibagi semua gambar: sekitar 32 MB thumbnail, dibuat paling
+// banyak satu per CPU bersamaan.
+var thumbnails = images.NewThumbnailCache(32<<20, runtime.GOMAXPROCS(0))
+
+// imageField adalah kolom gambar sebuah resource (Employees.Photo,
+// Categories.Picture) beserta fungsi repository untuk membaca dan menggantinya.
+type imageField struct {
+	kind, entity, action string
+	missing              string // pesan 404 kalau gambarnya kosong
+	get                  func(ctx context.Context, r repositories.Repositories, id int) ([]byte, error)
+	set                  func(ctx context.Context, r repositories.Repositories, id int, data []byte) error
+}
+
+var (
+	employeePhoto = imageField{
+		kind: "employee", entity: "employees", action: ActionPhotoUpdated, missing: "employee has no photo",
+		get: func(ctx context.Context, r repositories.Repositories, id int) ([]byte, error) {
+			return r.Employees.GetEmployeePhoto(ctx, id)
+		},
+		set: func(ctx context.Context, r repositories.Repositories, id int, data []byte) error {
+			return r.Employees.SetEmployeePhoto(ctx, id, data)
+		},
+	}
+	categoryPicture = imageField{
+		kind: "category", entity: "categories", action: ActionPictureUpdated, missing: "category has no picture",
+		get: func(ctx context.Context, r repositories.Repositories, id int) ([]byte, error) {
+			return r.Categories.GetCategoryPicture(ctx, id)
+		},
+		set: func(ctx context.Context, r repositories.Repositories, id int, data []byte) error {
+			return r.Categories.SetCategoryPicture(ctx, id, data)
+		},
+	}
+)
+
+// Photo mengembalikan foto employee, atau thumbnail-nya kalau size > 0.
+func (s *EmployeeService) Photo(ctx context.Context, id, size int) (models.Image, error) {
+	return employeePhoto.load(ctx, s.base, id, size)
+}
+
+// SetPhoto mengganti foto employee; data nil menghapusnya.
+func (s *EmployeeService) SetPhoto(ctx context.Context, id int, data []byte) (*models.ImageInfo, error) {
+	return employeePhoto.store(ctx, s.base, id, data)
+}
+
+// Picture mengembalikan gambar category, atau thumbnail-nya kalau size > 0.
+func (s *CategoryService) Picture(ctx context.Context, id, size int) (models.Image, error) {
+	return categoryPicture.load(ctx, s.base, id, size)
+}
+
+// SetPicture mengganti gambar category; data nil menghapusnya.
+func (s *CategoryService) SetPicture(ctx context.Context, id int, data []byte) (*models.ImageInfo, error) {
+	return categoryPicture.store(ctx, s.base, id, data)
+}
+
+// load membaca gambar tersimpan untuk dikirim: header OLE data Northwind
+// asli dibuang dan tipe dideteksi dari isinya. Thumbnail diambil dari cache.
+func (f imageField) load(ctx context.Context, b base, id, size int) (models.Image, error) {
+	if err := checkThumbnail(size); err != nil {
+		return models.Image{}, err
+	}
+	data, err := f.get(ctx, b.read(ctx), id)
+	if err != nil {
+		return models.Image{}, err
+	}
+	data = images.StripOLE(data)
+	if len(data) == 0 {
+		return models.Image{}, apperr.NotFound("%s", f.missing)
+	}
+	if size == 0 {
+		return models.Image{Data: data, ContentType: http.DetectContentType(data)}, nil
+	}
+	thumb, contentType, err := thumbnails.Thumbnail(ctx, data, size)
+	if err != nil {
+		return models.Image{}, err
+	}
+	return models.Image{Data: thumb, ContentType: contentType}, nil
+}
+
+// store mengganti gambar; data nil menghapusnya. Gambar baru divalidasi
+// sebelum transaksi dimulai dan ImageInfo-nya dipakai lagi untuk event.
+func (f imageField) store(ctx context.Context, b base, id int, data []byte) (*models.ImageInfo, error) {
+	info, data, err := inspect(data)
+	if err != nil {
+		return nil, err
+	}
+	var after any
+	if info != nil {
+		after = *info
+	}
+	err = b.write(ctx, func(ctx context.Context, r repositories.Repositories) error {
+		before, err := f.get(ctx, r, id)
+		if err != nil {
+			return err
+		}
+		// If-Match dibandingkan dengan ETag GET gambar; gambar pertama
+		// tidak punya versi untuk dibandingkan.
+		if before != nil {
+			if err := etag.CheckBytes(ctx, images.StripOLE(before)); err != nil {
+				return err
+			}
+		}
+		if data == nil && before == nil {
+			return apperr.NotFound("%s", f.missing)
+		}
+		if err := f.set(ctx, r, id, data); err != nil {
+			return err
+		}
+		emit(ctx, newEvent(f.kind, f.entity, strconv.Itoa(id), f.action, describe(before), after))
+		return nil
+	})
+	return info, err
+}
+
+func checkThumbnail(size int) error {
+	if size != 0 && (size < images.MinThumbnail || size > images.MaxThumbnail) {
+		return apperr.Validation("size", "must be between %d and %d", images.MinThumbnail, images.MaxThumbnail)
+	}
+	return nil
+}
+
+// inspect memvalidasi gambar yang diunggah. Header OLE dibuang supaya yang
+// tersimpan selalu file gambar biasa. data nil (hapus) dilewatkan.
+func inspect(data []byte) (*models.ImageInfo, []byte, error) {
+	if data == nil {
+		return nil, nil, nil
+	}
+	data = images.StripOLE(data)
+	info, err := images.Inspect(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &info, data, nil
+}
+
+// describe mengembalikan ImageInfo gambar lama untuk event, atau nil kalau
+// kosong. Hanya header-nya yang dibaca, jadi gambar yang rusak pun tetap
+// dicatat tipe dan ukurannya.
+func describe(data []byte) any {
+	data = images.StripOLE(data)
+	if len(data) == 0 {
+		return nil
+	}
+	return images.Describe(data)
+}
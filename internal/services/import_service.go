@@ -0,0 +1,528 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/models"
+	"northwind-api/internal/repositories"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// maxImportErrors membatasi jumlah error baris yang disimpan di laporan job.
+const maxImportErrors = 1000
+
+// Message job yang berhenti di tengah jalan; transaksi commit-nya rollback,
+// jadi tidak ada baris yang tersimpan.
+const (
+	importRestarted   = "interrupted by a server restart; upload the file again"
+	importInterrupted = "interrupted by a server shutdown; upload the file again"
+)
+
+// ImportService menjalankan import CSV di background: upload -> dry-run
+// (validasi per baris) -> commit. Baris disimpan lewat Create milik service
+// resource, jadi aturan validasi, referensi dan event-nya sama dengan API biasa.
+type ImportService struct {
+	base
+	targets map[string]importTarget
+	// stop membatalkan job yang sedang berjalan saat aplikasi berhenti; nil
+	// berarti job berjalan sampai selesai.
+	stop context.Context
+	jobs sync.WaitGroup
+}
+
+// importTarget menghubungkan satu entity dengan service-nya.
+type importTarget struct {
+	model   reflect.Type
+	idField string
+	// clientID true kalau idField boleh diisi dari CSV.
+	clientID func() bool
+	check    func(ctx context.Context, v any) error
+	create   func(ctx context.Context, v any) error
+}
+
+func newImportService(b base, products *ProductService, customers *CustomerService, suppliers *SupplierService) *ImportService {
+	return &ImportService{base: b, targets: map[string]importTarget{
+		"products": {
+			model:   reflect.TypeOf(models.Product{}),
+			idField: "product_id",
+			check:   func(ctx context.Context, v any) error { return products.Check(ctx, v.(*models.Product)) },
+			create:  func(ctx context.Context, v any) error { return products.Create(ctx, v.(*models.Product)) },
+		},
+		"customers": {
+			model:    reflect.TypeOf(models.Customer{}),
+			idField:  "customer_id",
+			clientID: func() bool { return customers.IDStrategy == CustomerIDClient },
+			check:    func(ctx context.Context, v any) error { return customers.Check(ctx, v.(*models.Customer)) },
+			create:   func(ctx context.Context, v any) error { return customers.Create(ctx, v.(*models.Customer)) },
+		},
+		"suppliers": {
+			model:   reflect.TypeOf(models.Supplier{}),
+			idField: "supplier_id",
+			check:   func(ctx context.Context, v any) error { return suppliers.Check(ctx, v.(*models.Supplier)) },
+			create:  func(ctx context.Context, v any) error { return suppliers.Create(ctx, v.(*models.Supplier)) },
+		},
+	}}
+}
+
+// Recover dipanggil sekali saat start, sebelum request pertama. Job yang
+// masih validating/committing ditinggal proses sebelumnya, jadi ditandai
+// failed. Job berikutnya dibatalkan saat ctx selesai; Wait menunggunya.
+func (s *ImportService) Recover(ctx context.Context) {
+	n, err := s.repos.Imports.FailRunningImportJobs(ctx, importRestarted)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to recover interrupted import jobs")
+	} else if n > 0 {
+		log.Warn().Int("jobs", n).Msg("import jobs interrupted by a restart marked failed")
+	}
+	s.stop = ctx
+}
+
+// Wait menunggu job yang sedang berjalan selesai atau berhenti.
+func (s *ImportService) Wait() {
+	s.jobs.Wait()
+}
+
+func (s *ImportService) List(ctx context.Context) ([]models.ImportJob, error) {
+	return s.repos.Imports.ListImportJobs(ctx)
+}
+
+func (s *ImportService) Get(ctx context.Context, id string) (models.ImportJob, error) {
+	job, err := s.repos.Imports.GetImportJob(ctx, id)
+	job.Data = nil
+	return job, err
+}
+
+// Start menyimpan file sebagai job baru lalu menjalankan dry-run di background.
+// Header dan mapping dicek langsung supaya kesalahan upload dijawab 400.
+func (s *ImportService) Start(ctx context.Context, entity, fileName string, data []byte, mapping map[string]string) (models.ImportJob, error) {
+	target, ok := s.targets[entity]
+	if !ok {
+		return models.ImportJob{}, apperr.Validation("entity", "must be one of: customers, products, suppliers")
+	}
+	header, err := csvHeader(data)
+	if err != nil {
+		return models.ImportJob{}, err
+	}
+	if mapping, err = target.resolveMapping(header, mapping); err != nil {
+		return models.ImportJob{}, err
+	}
+
+	now := time.Now().UTC()
+	job := models.ImportJob{
+		JobID:     uuid.NewString(),
+		Entity:    entity,
+		Status:    models.ImportValidating,
+		FileName:  fileName,
+		Mapping:   mapping,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Data:      data,
+	}
+	if err := s.repos.Imports.CreateImportJob(ctx, &job); err != nil {
+		return models.ImportJob{}, err
+	}
+	s.background(ctx, job, s.dryRun)
+	job.Data = nil
+	return job, nil
+}
+
+// Commit menyimpan semua baris job yang sudah lolos dry-run dalam satu
+// transaksi. Kalau ada baris tidak valid, commit ditolak kecuali skipInvalid.
+func (s *ImportService) Commit(ctx context.Context, id string, skipInvalid bool) (models.ImportJob, error) {
+	job, err := s.repos.Imports.GetImportJob(ctx, id)
+	if err != nil {
+		return job, err
+	}
+	if job.Status != models.ImportValidated {
+		return models.ImportJob{}, apperr.Conflict("import job is %s; only validated jobs can be committed", job.Status)
+	}
+	if job.InvalidRows > 0 && !skipInvalid {
+		return models.ImportJob{}, apperr.Conflict("%d rows are invalid; fix the file or commit with skip_invalid=true", job.InvalidRows)
+	}
+	if err := s.repos.Imports.TransitionImportJob(ctx, id, models.ImportValidated, models.ImportCommitting); err != nil {
+		return models.ImportJob{}, err
+	}
+	job.Status = models.ImportCommitting
+	s.background(ctx, job, func(ctx context.Context, job *models.ImportJob) error {
+		return s.commit(ctx, job, skipInvalid)
+	})
+	job.Data = nil
+	return job, nil
+}
+
+// background menjalankan fn di goroutine terpisah dari request dan
+// menyimpan hasilnya; error yang bukan kesalahan data membuat job failed.
+// Nilai ctx request (actor, roles) tetap dipakai, tapi job dibatalkan saat
+// aplikasi berhenti, bukan saat request selesai.
+func (s *ImportService) background(ctx context.Context, job models.ImportJob, fn func(ctx context.Context, job *models.ImportJob) error) {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	stop := func() bool { return false }
+	if s.stop != nil {
+		stop = context.AfterFunc(s.stop, cancel)
+	}
+	s.jobs.Add(1)
+	go func() {
+		defer s.jobs.Done()
+		defer cancel()
+		defer stop()
+		// Hasil job tetap disimpan walaupun ctx-nya sudah dibatalkan.
+		saveCtx := context.WithoutCancel(ctx)
+		defer func() {
+			if r := recover(); r != nil {
+				log.Error().Interface("panic", r).Str("job_id", job.JobID).Msg("import job panicked")
+				job.Status, job.Message = models.ImportFailed, "internal error"
+				s.save(saveCtx, &job)
+			}
+		}()
+		if err := fn(ctx, &job); err != nil {
+			if ctx.Err() != nil {
+				log.Warn().Str("job_id", job.JobID).Msg("import job interrupted by shutdown")
+				job.Status, job.Message = models.ImportFailed, importInterrupted
+			} else {
+				log.Error().Err(err).Str("job_id", job.JobID).Msg("import job failed")
+				job.Status, job.Message = models.ImportFailed, "internal error"
+			}
+		}
+		s.save(saveCtx, &job)
+	}()
+}
+
+func (s *ImportService) save(ctx context.Context, job *models.ImportJob) {
+	job.UpdatedAt = time.Now().UTC()
+	if err := s.repos.Imports.UpdateImportJob(ctx, job); err != nil {
+		log.Error().Err(err).Str("job_id", job.JobID).Msg("failed to save import job")
+	}
+}
+
+// dryRun memvalidasi semua baris tanpa menyimpan apa pun.
+func (s *ImportService) dryRun(ctx context.Context, job *models.ImportJob) error {
+	target := s.targets[job.Entity]
+	rows, err := target.rows(job.Data, job.Mapping)
+	if err != nil {
+		return rowsError(job, err)
+	}
+	var report importReport
+	for _, row := range rows {
+		if row.invalid(&report) {
+			continue
+		}
+		if err := target.check(ctx, row.model); err != nil {
+			if !report.add(row.line, err) {
+				return err
+			}
+			continue
+		}
+		report.valid++
+	}
+	report.apply(job, len(rows))
+	job.Status = models.ImportValidated
+	return nil
+}
+
+// commit menyimpan baris dalam satu transaksi. Tanpa skipInvalid, satu baris
+// gagal membatalkan semuanya dan job menjadi failed.
+func (s *ImportService) commit(ctx context.Context, job *models.ImportJob, skipInvalid bool) error {
+	target := s.targets[job.Entity]
+	rows, err := target.rows(job.Data, job.Mapping)
+	if err != nil {
+		return rowsError(job, err)
+	}
+
+	var report importReport
+	errRejected := errors.New("import rejected")
+	err = s.write(ctx, func(ctx context.Context, _ repositories.Repositories) error {
+		for _, row := range rows {
+			if row.invalid(&report) {
+				continue
+			}
+			if err := target.create(ctx, row.model); err != nil {
+				if !report.add(row.line, err) {
+					return err
+				}
+				continue
+			}
+			report.valid++
+		}
+		if report.invalid > 0 && !skipInvalid {
+			return errRejected
+		}
+		return nil
+	})
+	report.apply(job, len(rows))
+	switch {
+	case errors.Is(err, errRejected):
+		job.Status = models.ImportFailed
+		job.Message = "some rows failed during commit; no rows were imported"
+		return nil
+	case err != nil:
+		return err
+	}
+	job.ImportedRows = report.valid
+	job.Status = models.ImportCompleted
+	return nil
+}
+
+// rowsError membuat job failed kalau file tidak bisa dibaca lagi sebagai CSV.
+func rowsError(job *models.ImportJob, err error) error {
+	if e, ok := apperr.As(err); ok {
+		job.Status, job.Message = models.ImportFailed, e.Message
+		return nil
+	}
+	return err
+}
+
+// importReport mengumpulkan hasil validasi per baris.
+type importReport struct {
+	valid, invalid int
+	errors         []models.ImportRowError
+}
+
+// add mencatat error baris. Error yang bukan apperr (mis. database) tidak
+// dianggap kesalahan data; hasilnya false dan job harus dihentikan.
+func (r *importReport) add(line int, err error) bool {
+	e, ok := apperr.As(err)
+	if !ok {
+		return false
+	}
+	r.invalid++
+	fields := e.FieldErrors()
+	if len(fields) == 0 {
+		fields = []apperr.FieldError{{Message: e.Message}}
+	}
+	for _, f := range fields {
+		if len(r.errors) < maxImportErrors {
+			r.errors = append(r.errors, models.ImportRowError{Row: line, Field: f.Field, Message: f.Message})
+		}
+	}
+	return true
+}
+
+func (r *importReport) apply(job *models.ImportJob, total int) {
+	job.TotalRows, job.ValidRows, job.InvalidRows, job.Errors = total, r.valid, r.invalid, r.errors
+}
+
+// importRow adalah satu baris CSV yang sudah dipetakan ke model.
+type importRow struct {
+	line   int
+	model  any
+	errors []apperr.FieldError
+}
+
+// invalid mencatat error konversi baris ke report.
+func (row importRow) invalid(r *importReport) bool {
+	if len(row.errors) == 0 {
+		return false
+	}
+	r.add(row.line, apperr.Invalid(row.errors...))
+	return true
+}
+
+func csvReader(data []byte) *csv.Reader {
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\ufeff"))))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	return r
+}
+
+func csvHeader(data []byte) ([]string, error) {
+	header, err := csvReader(data).Read()
+	if err == io.EOF {
+		return nil, apperr.Validation("file", "CSV file is empty")
+	}
+	if err != nil {
+		return nil, apperr.Validation("file", "is not a valid CSV file").Wrap(err)
+	}
+	for i := range header {
+		header[i] = strings.TrimSpace(header[i])
+	}
+	return header, nil
+}
+
+// resolveMapping memeriksa mapping kolom CSV -> field. Tanpa mapping, kolom
+// dicocokkan otomatis dengan nama field JSON atau nama kolom database
+// (tanpa membedakan huruf besar, spasi dan underscore).
+func (t importTarget) resolveMapping(header []string, requested map[string]string) (map[string]string, error) {
+	fields := t.fields()
+	mapping := map[string]string{}
+	var invalid []apperr.FieldError
+
+	if len(requested) == 0 {
+		byName := map[string]string{}
+		for name, sf := range fields {
+			byName[normalizeColumn(name)] = name
+			if col, _, _ := strings.Cut(sf.Tag.Get("db"), ","); col != "" {
+				byName[normalizeColumn(col)] = name
+			}
+		}
+		for _, col := range header {
+			if name, ok := byName[normalizeColumn(col)]; ok {
+				mapping[col] = name
+			}
+		}
+	} else {
+		columns := map[string]bool{}
+		for _, col := range header {
+			columns[col] = true
+		}
+		cols := make([]string, 0, len(requested))
+		for col := range requested {
+			cols = append(cols, col)
+		}
+		sort.Strings(cols)
+		for _, col := range cols {
+			name := requested[col]
+			switch _, known := fields[name]; {
+			case !columns[col]:
+				invalid = append(invalid, apperr.FieldError{Field: "mapping." + col, Message: "is not a column in the CSV header"})
+			case !known:
+				invalid = append(invalid, apperr.FieldError{Field: "mapping." + col, Message: "is not a known field: " + name})
+			default:
+				mapping[col] = name
+			}
+		}
+	}
+	if len(invalid) > 0 {
+		return nil, apperr.Invalid(invalid...)
+	}
+
+	seen := map[string]string{}
+	for _, col := range header {
+		name, ok := mapping[col]
+		if !ok {
+			continue
+		}
+		if other, dup := seen[name]; dup {
+			return nil, apperr.Validation("mapping", "columns %q and %q both map to %s", other, col, name)
+		}
+		seen[name] = col
+	}
+	if len(mapping) == 0 {
+		return nil, apperr.Validation("mapping", "no CSV column maps to a known field")
+	}
+	return mapping, nil
+}
+
+// fields mengembalikan field model yang boleh diisi dari CSV, per nama JSON.
+func (t importTarget) fields() map[string]reflect.StructField {
+	fields := map[string]reflect.StructField{}
+	for i := 0; i < t.model.NumField(); i++ {
+		sf := t.model.Field(i)
+		name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if name == "" || name == "-" || name == "deleted_at" {
+			continue
+		}
+		if name == t.idField && (t.clientID == nil || !t.clientID()) {
+			continue
+		}
+		fields[name] = sf
+	}
+	return fields
+}
+
+// rows membaca semua baris data dan mengubahnya ke model sesuai mapping.
+func (t importTarget) rows(data []byte, mapping map[string]string) ([]importRow, error) {
+	r := csvReader(data)
+	header, err := r.Read()
+	if err != nil {
+		return nil, apperr.Validation("file", "is not a valid CSV file").Wrap(err)
+	}
+	index := map[string]int{}
+	for i, col := range header {
+		index[strings.TrimSpace(col)] = i
+	}
+	fields := t.fields()
+
+	var rows []importRow
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, apperr.Validation("file", "is not a valid CSV file").Wrap(err)
+		}
+		line, _ := r.FieldPos(0)
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+
+		row := importRow{line: line}
+		doc := map[string]any{}
+		for col, name := range mapping {
+			i, ok := index[col]
+			if !ok || i >= len(record) || strings.TrimSpace(record[i]) == "" {
+				continue
+			}
+			v, msg := cellValue(fields[name].Type, strings.TrimSpace(record[i]))
+			if msg != "" {
+				row.errors = append(row.errors, apperr.FieldError{Field: name, Message: msg})
+				continue
+			}
+			doc[name] = v
+		}
+		sort.Slice(row.errors, func(i, j int) bool { return row.errors[i].Field < row.errors[j].Field })
+
+		model := reflect.New(t.model).Interface()
+		raw, _ := json.Marshal(doc)
+		if err := json.Unmarshal(raw, model); err != nil {
+			return nil, err
+		}
+		row.model = model
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// cellValue mengubah isi sel ke tipe field; pesan error memakai kalimat
+// yang sama dengan error tipe JSON di API.
+func cellValue(t reflect.Type, raw string) (any, string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		switch strings.ToLower(raw) {
+		case "1", "true", "yes", "y":
+			return true, ""
+		case "0", "false", "no", "n":
+			return false, ""
+		}
+		return nil, "must be a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, "must be an integer"
+		}
+		return n, ""
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, "must be a number"
+		}
+		return f, ""
+	}
+	return raw, ""
+}
+
+func normalizeColumn(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '_' || r == '-' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(strings.TrimSpace(s)))
+}
@@ -0,0 +1,352 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/models"
+	"northwind-api/internal/repositories"
+	"northwind-api/internal/visibility"
+)
+
+// maxIncludeBatch membatasi jumlah kunci per query IN saat memuat relasi.
+const maxIncludeBatch = 500
+
+// loadFunc memuat baris relasi untuk kunci induk keys dan mengelompokkannya
+// per kunci induk. Kunci dibandingkan sebagai string supaya id int dan string
+// ditangani dengan cara yang sama.
+type loadFunc func(ctx context.Context, r repositories.Repositories, keys []string) (map[string][]any, error)
+
+type relation struct {
+	typ   string // tipe resource target
+	many  bool
+	local string // field JSON di induk yang berisi kunci
+	load  loadFunc
+}
+
+type resource struct {
+	model     reflect.Type
+	relations map[string]relation
+}
+
+// resources adalah graf tipe resource yang bisa di-include beserta relasinya.
+var resources = map[string]resource{
+	"order": {model: reflect.TypeFor[models.Order](), relations: map[string]relation{
+		"customer": {typ: "customer", local: "customer_id", load: byString(func(ctx context.Context, r repositories.Repositories, ids []string) ([]models.Customer, error) {
+			return r.Customers.GetCustomersByIDs(ctx, ids)
+		}, func(c models.Customer) string { return c.CustomerID })},
+		"employee": {typ: "employee", local: "employee_id", load: byInt(func(ctx context.Context, r repositories.Repositories, ids []int) ([]models.Employee, error) {
+			return r.Employees.GetEmployeesByIDs(ctx, ids)
+		}, func(e models.Employee) int { return e.EmployeeID })},
+		"shipper": {typ: "shipper", local: "ship_via", load: byInt(func(ctx context.Context, r repositories.Repositories, ids []int) ([]models.Shipper, error) {
+			return r.Shippers.GetShippersByIDs(ctx, ids)
+		}, func(s models.Shipper) int { return s.ShipperID })},
+		"details": {typ: "order_detail", many: true, local: "order_id", load: byInt(func(ctx context.Context, r repositories.Repositories, ids []int) ([]models.OrderDetail, error) {
+			return r.Orders.GetOrderDetailsByOrderIDs(ctx, ids)
+		}, func(d models.OrderDetail) int { return int(d.OrderID) })},
+	}},
+	"order_detail": {model: reflect.TypeFor[models.OrderDetail](), relations: map[string]relation{
+		"product": {typ: "product", local: "product_id", load: byInt(func(ctx context.Context, r repositories.Repositories, ids []int) ([]models.Product, error) {
+			return r.Products.GetProductsByIDs(ctx, ids)
+		}, func(p models.Product) int { return p.ProductID })},
+	}},
+	"customer": {model: reflect.TypeFor[models.Customer](), relations: map[string]relation{
+		"orders": {typ: "order", many: true, local: "customer_id", load: byString(func(ctx context.Context, r repositories.Repositories, ids []string) ([]models.Order, error) {
+			return r.Orders.GetOrdersByCustomerIDs(ctx, ids)
+		}, func(o models.Order) string { return *o.CustomerID })},
+	}},
+	"employee": {model: reflect.TypeFor[models.Employee](), relations: map[string]relation{
+		"manager": {typ: "employee", local: "reports_to", load: byInt(func(ctx context.Context, r repositories.Repositories, ids []int) ([]models.Employee, error) {
+			return r.Employees.GetEmployeesByIDs(ctx, ids)
+		}, func(e models.Employee) int { return e.EmployeeID })},
+		"territories": {typ: "territory", many: true, local: "employee_id", load: loadTerritories},
+	}},
+	"product": {model: reflect.TypeFor[models.Product](), relations: map[string]relation{
+		"category": {typ: "category", local: "category_id", load: byInt(func(ctx context.Context, r repositories.Repositories, ids []int) ([]models.Category, error) {
+			return r.Categories.GetCategoriesByIDs(ctx, ids)
+		}, func(c models.Category) int { return int(c.CategoryID) })},
+		"supplier": {typ: "supplier", local: "supplier_id", load: byInt(func(ctx context.Context, r repositories.Repositories, ids []int) ([]models.Supplier, error) {
+			return r.Suppliers.GetSuppliersByIDs(ctx, ids)
+		}, func(s models.Supplier) int { return int(s.SupplierID) })},
+	}},
+	"category": {model: reflect.TypeFor[models.Category](), relations: map[string]relation{
+		"products": {typ: "product", many: true, local: "category_id", load: byInt(func(ctx context.Context, r repositories.Repositories, ids []int) ([]models.Product, error) {
+			return r.Products.GetProductsByCategoryIDs(ctx, ids)
+		}, func(p models.Product) int { return *p.CategoryID })},
+	}},
+	"supplier": {model: reflect.TypeFor[models.Supplier](), relations: map[string]relation{
+		"products": {typ: "product", many: true, local: "supplier_id", load: byInt(func(ctx context.Context, r repositories.Repositories, ids []int) ([]models.Product, error) {
+			return r.Products.GetProductsBySupplierIDs(ctx, ids)
+		}, func(p models.Product) int { return *p.SupplierID })},
+	}},
+	"shipper":   {model: reflect.TypeFor[models.Shipper]()},
+	"territory": {model: reflect.TypeFor[models.Territory]()},
+}
+
+func byInt[V any](fetch func(context.Context, repositories.Repositories, []int) ([]V, error), key func(V) int) loadFunc {
+	return func(ctx context.Context, r repositories.Repositories, keys []string) (map[string][]any, error) {
+		ids := intIDs(keys)
+		rows, err := fetch(ctx, r, ids)
+		return groupRows(rows, func(v V) string { return strconv.Itoa(key(v)) }), err
+	}
+}
+
+func byString[V any](fetch func(context.Context, repositories.Repositories, []string) ([]V, error), key func(V) string) loadFunc {
+	return func(ctx context.Context, r repositories.Repositories, keys []string) (map[string][]any, error) {
+		rows, err := fetch(ctx, r, keys)
+		return groupRows(rows, key), err
+	}
+}
+
+func groupRows[V any](rows []V, key func(V) string) map[string][]any {
+	out := map[string][]any{}
+	for _, row := range rows {
+		k := key(row)
+		out[k] = append(out[k], row)
+	}
+	return out
+}
+
+// loadTerritories memuat territory per employee lewat EmployeeTerritories.
+func loadTerritories(ctx context.Context, r repositories.Repositories, keys []string) (map[string][]any, error) {
+	ids := intIDs(keys)
+	links, err := r.Regions.GetEmployeeTerritoriesByEmployeeIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	var territoryIDs []string
+	for _, l := range links {
+		if !slices.Contains(territoryIDs, l.TerritoryID) {
+			territoryIDs = append(territoryIDs, l.TerritoryID)
+		}
+	}
+	territories, err := r.Regions.GetTerritoriesByIDs(ctx, territoryIDs)
+	if err != nil {
+		return nil, err
+	}
+	byID := map[string]models.Territory{}
+	for _, t := range territories {
+		byID[t.TerritoryID] = t
+	}
+	out := map[string][]any{}
+	for _, l := range links {
+		if t, ok := byID[l.TerritoryID]; ok {
+			k := strconv.Itoa(l.EmployeeID)
+			out[k] = append(out[k], t)
+		}
+	}
+	return out, nil
+}
+
+// Inclusion adalah hasil parse ?include= dan ?fields[type]= untuk satu tipe
+// resource. Nilai nil berarti response dikirim apa adanya.
+type Inclusion struct {
+	typ    string
+	tree   includeNode
+	fields map[string][]string
+}
+
+// includeNode adalah relasi yang diminta di satu level, mis. untuk
+// include=details.product node order berisi details -> {product}.
+type includeNode map[string]includeNode
+
+// IncludeService menempelkan relasi ke response (compound document) dan
+// memangkas field sesuai sparse fieldset. Relasi dimuat per level dengan
+// satu query IN per relasi, bukan per baris.
+type IncludeService struct {
+	base
+}
+
+// Parse memvalidasi include (dipisah koma, relasi bersarang dengan titik)
+// dan fields (tipe -> daftar field dipisah koma) terhadap tipe resource typ.
+func (s *IncludeService) Parse(typ, include string, fields map[string]string) (*Inclusion, error) {
+	if include == "" && len(fields) == 0 {
+		return nil, nil
+	}
+	in := &Inclusion{typ: typ, tree: includeNode{}, fields: map[string][]string{}}
+	for _, path := range strings.Split(include, ",") {
+		if path = strings.TrimSpace(path); path == "" {
+			continue
+		}
+		node, t := in.tree, typ
+		for _, name := range strings.Split(path, ".") {
+			rel, ok := resources[t].relations[name]
+			if !ok {
+				return nil, apperr.Validation("include", "unknown relationship %q on %s; available: %s", name, t, strings.Join(relationNames(t), ", "))
+			}
+			if node[name] == nil {
+				node[name] = includeNode{}
+			}
+			node, t = node[name], rel.typ
+		}
+	}
+	for t, list := range fields {
+		res, ok := resources[t]
+		if !ok {
+			return nil, apperr.Validation("fields["+t+"]", "unknown resource type %q", t)
+		}
+		known := jsonFields(res.model)
+		names := []string{}
+		for _, f := range strings.Split(list, ",") {
+			if f = strings.TrimSpace(f); f == "" {
+				continue
+			}
+			if !slices.Contains(known, f) {
+				return nil, apperr.Validation("fields["+t+"]", "unknown field %q on %s", f, t)
+			}
+			names = append(names, f)
+		}
+		in.fields[t] = names
+	}
+	return in, nil
+}
+
+func relationNames(typ string) []string {
+	var names []string
+	for name := range resources[typ].relations {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	if len(names) == 0 {
+		return []string{"none"}
+	}
+	return names
+}
+
+// jsonFields mengembalikan nama field JSON dari struct model.
+func jsonFields(t reflect.Type) []string {
+	var out []string
+	for i := range t.NumField() {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+// One menerapkan in ke satu resource.
+func (s *IncludeService) One(ctx context.Context, in *Inclusion, v any) (map[string]any, error) {
+	items, err := s.Many(ctx, in, []any{v})
+	if err != nil {
+		return nil, err
+	}
+	return items[0], nil
+}
+
+// Many menerapkan in ke slice resource (mis. []models.Order).
+func (s *IncludeService) Many(ctx context.Context, in *Inclusion, list any) ([]map[string]any, error) {
+	items, err := toMaps(ctx, list)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.embed(ctx, s.read(ctx), in, in.typ, in.tree, items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// embed memuat relasi node untuk semua items sekaligus, turun ke relasi
+// bersarang, lalu memangkas field items sesuai fields[typ].
+func (s *IncludeService) embed(ctx context.Context, r repositories.Repositories, in *Inclusion, typ string, node includeNode, items []map[string]any) error {
+	names := make([]string, 0, len(node))
+	for name := range node {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		rel := resources[typ].relations[name]
+		var keys []string
+		seen := map[string]bool{}
+		for _, item := range items {
+			if k, ok := keyOf(item[rel.local]); ok && !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+
+		groups := map[string][]map[string]any{}
+		var children []map[string]any
+		for start := 0; start < len(keys); start += maxIncludeBatch {
+			rows, err := rel.load(ctx, r, keys[start:min(start+maxIncludeBatch, len(keys))])
+			if err != nil {
+				return err
+			}
+			for k, group := range rows {
+				converted, err := toMaps(ctx, group)
+				if err != nil {
+					return err
+				}
+				groups[k] = converted
+				children = append(children, converted...)
+			}
+		}
+		if err := s.embed(ctx, r, in, rel.typ, node[name], children); err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			k, _ := keyOf(item[rel.local])
+			group := groups[k]
+			switch {
+			case rel.many && group == nil:
+				item[name] = []map[string]any{}
+			case rel.many:
+				item[name] = group
+			case len(group) > 0:
+				item[name] = group[0]
+			default:
+				item[name] = nil
+			}
+		}
+	}
+
+	if keep, ok := in.fields[typ]; ok {
+		for _, item := range items {
+			for f := range item {
+				if !slices.Contains(keep, f) && node[f] == nil {
+					delete(item, f)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// keyOf mengubah nilai kunci hasil decode JSON menjadi string; false kalau kosong.
+func keyOf(v any) (string, bool) {
+	switch v := v.(type) {
+	case nil:
+		return "", false
+	case string:
+		return v, v != ""
+	default:
+		return fmt.Sprint(v), true
+	}
+}
+
+// toMaps mengubah slice model menjadi map lewat JSON, dengan angka tetap
+// json.Number supaya id dan harga tidak berubah presisinya. Field yang tidak
+// boleh dilihat caller sudah disembunyikan di sini, karena setelah menjadi
+// map tipe modelnya tidak diketahui lagi.
+func toMaps(ctx context.Context, list any) ([]map[string]any, error) {
+	b, err := visibility.Marshal(ctx, list)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var out []map[string]any
+	if err := dec.Decode(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
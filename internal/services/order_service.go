@@ -0,0 +1,222 @@
+package services
+
+import (
+	"context"
+	"northwind-api/internal/etag"
+	"northwind-api/internal/models"
+	"northwind-api/internal/patch"
+	"northwind-api/internal/repositories"
+	"northwind-api/internal/validation"
+	"strconv"
+)
+
+type OrderService struct {
+	base
+}
+
+func (s *OrderService) List(ctx context.Context) ([]models.Order, error) {
+	return s.read(ctx).Orders.GetAllOrders(ctx)
+}
+
+func (s *OrderService) Page(ctx context.Context, page, pageSize int) (*models.Paginated[models.Order], error) {
+	return s.read(ctx).Orders.GetOrdersPage(ctx, page, pageSize)
+}
+
+func (s *OrderService) Get(ctx context.Context, id int) (models.Order, error) {
+	return s.read(ctx).Orders.GetOrderByID(ctx, id)
+}
+
+// Details mengembalikan baris OrderDetails; NotFound kalau order-nya tidak ada.
+func (s *OrderService) Details(ctx context.Context, orderID int) ([]models.OrderDetail, error) {
+	r := s.read(ctx)
+	if _, err := r.Orders.GetOrderByID(ctx, orderID); err != nil {
+		return nil, err
+	}
+	return r.Orders.GetOrderDetailsByOrderID(ctx, orderID)
+}
+
+func (s *OrderService) Create(ctx context.Context, o *models.Order) error {
+	if err := validateOrder(o); err != nil {
+		return err
+	}
+	return s.write(ctx, func(ctx context.Context, r repositories.Repositories) error {
+		if err := checkOrderRefs(ctx, r, o); err != nil {
+			return err
+		}
+		id, err := r.Orders.CreateOrder(ctx, o)
+		if err != nil {
+			return err
+		}
+		o.OrderID = id
+		emit(ctx, newEvent("order", "orders", strconv.FormatInt(id, 10), ActionCreated, nil, *o))
+		return nil
+	})
+}
+
+func (s *OrderService) Update(ctx context.Context, o *models.Order) error {
+	if err := validateOrder(o); err != nil {
+		return err
+	}
+	return s.write(ctx, func(ctx context.Context, r repositories.Repositories) error {
+		before, err := r.Orders.GetOrderByID(ctx, int(o.OrderID))
+		if err != nil {
+			return err
+		}
+		if err := etag.Check(ctx, before); err != nil {
+			return err
+		}
+		if err := checkOrderRefs(ctx, r, o); err != nil {
+			return err
+		}
+		if err := r.Orders.UpdateOrder(ctx, o); err != nil {
+			return err
+		}
+		emit(ctx, newEvent("order", "orders", strconv.FormatInt(o.OrderID, 10), ActionUpdated, before, *o))
+		return nil
+	})
+}
+
+// Patch menerapkan merge patch / JSON Patch ke order dan hanya menulis kolom yang disentuh.
+func (s *OrderService) Patch(ctx context.Context, id int, p patch.Patch) (models.Order, error) {
+	var after models.Order
+	err := s.write(ctx, func(ctx context.Context, r repositories.Repositories) error {
+		before, err := r.Orders.GetOrderByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := etag.Check(ctx, before); err != nil {
+			return err
+		}
+		fields, err := p.Apply(before, &after, "order_id")
+		if err != nil {
+			return err
+		}
+		after.OrderID = int64(id)
+		if err := validateOrder(&after); err != nil {
+			return err
+		}
+		if err := checkOrderRefs(ctx, r, &after); err != nil {
+			return err
+		}
+		if len(fields) == 0 {
+			return nil
+		}
+		if err := r.Orders.PatchOrder(ctx, id, repositories.Changes(patch.Columns(&after, fields))); err != nil {
+			return err
+		}
+		emit(ctx, newEvent("order", "orders", strconv.Itoa(id), ActionUpdated, before, after))
+		return nil
+	})
+	return after, err
+}
+
+// Delete menghapus order. Baris detail menghalangi delete kecuali opts meminta
+// cascade (ikut dihapus) atau reassign (dipindah ke order lain).
+func (s *OrderService) Delete(ctx context.Context, id int, opts DeleteOptions) error {
+	if err := opts.check(id); err != nil {
+		return err
+	}
+	return s.write(ctx, func(ctx context.Context, r repositories.Repositories) error {
+		before, err := r.Orders.GetOrderByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := etag.Check(ctx, before); err != nil {
+			return err
+		}
+		if opts.ReassignTo != 0 {
+			_, err := r.Orders.GetOrderByID(ctx, opts.ReassignTo)
+			var c checks
+			c.ref("reassign_to", "order", err)
+			if err := c.result(); err != nil {
+				return err
+			}
+		}
+		details, err := r.Orders.GetOrderDetailsByOrderID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := resolveOrderDetails(ctx, r, id, opts, details); err != nil {
+			return err
+		}
+		if err := r.Orders.DeleteOrder(ctx, id); err != nil {
+			return err
+		}
+		emit(ctx, newEvent("order", "orders", strconv.Itoa(id), ActionDeleted, before, nil))
+		return nil
+	})
+}
+
+func validateOrder(o *models.Order) error {
+	if err := validation.Struct(o); err != nil {
+		return err
+	}
+	var c checks
+	if o.OrderDate != nil {
+		ordered, _ := validation.ParseDate(*o.OrderDate)
+		if o.RequiredDate != nil {
+			if t, _ := validation.ParseDate(*o.RequiredDate); t.Before(ordered) {
+				c.fail("required_date", "must not be before order_date")
+			}
+		}
+		if o.ShippedDate != nil {
+			if t, _ := validation.ParseDate(*o.ShippedDate); t.Before(ordered) {
+				c.fail("shipped_date", "must not be before order_date")
+			}
+		}
+	}
+	return c.result()
+}
+
+// checkOrderRefs memastikan customer, employee dan shipper (ship_via) yang dirujuk ada.
+func checkOrderRefs(ctx context.Context, r repositories.Repositories, o *models.Order) error {
+	var c checks
+	if o.CustomerID != nil {
+		_, err := r.Customers.GetCustomerByID(ctx, *o.CustomerID)
+		c.ref("customer_id", "customer", err)
+	}
+	if o.EmployeeID != nil {
+		_, err := r.Employees.GetEmployeeByID(ctx, int(*o.EmployeeID))
+		c.ref("employee_id", "employee", err)
+	}
+	if o.ShipVia != nil {
+		_, err := r.Shippers.GetShipperByID(ctx, int(*o.ShipVia))
+		c.ref("ship_via", "shipper", err)
+	}
+	return c.result()
+}
+
+// Bulk menjalankan create/update/patch/delete order secara batch.
+func (s *OrderService) Bulk(ctx context.Context, mode BulkMode, ops []models.BulkOperation) ([]BulkResult, error) {
+	return s.bulk(ctx, mode, ops, func(ctx context.Context, op models.BulkOperation) (string, error) {
+		if op.Op == BulkCreate {
+			var o models.Order
+			if err := bulkData(op, &o); err != nil {
+				return "", err
+			}
+			err := s.Create(ctx, &o)
+			return strconv.FormatInt(o.OrderID, 10), err
+		}
+		id, err := bulkIntID(op)
+		if err != nil {
+			return "", err
+		}
+		switch op.Op {
+		case BulkUpdate:
+			var o models.Order
+			if err := bulkData(op, &o); err != nil {
+				return strconv.Itoa(id), err
+			}
+			o.OrderID = int64(id)
+			err = s.Update(ctx, &o)
+		case BulkPatch:
+			var p patch.Patch
+			if p, err = bulkPatch(op); err == nil {
+				_, err = s.Patch(ctx, id, p)
+			}
+		case BulkDelete:
+			err = s.Delete(ctx, id, DeleteOptions{})
+		}
+		return strconv.Itoa(id), err
+	})
+}
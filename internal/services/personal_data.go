@@ -0,0 +1,280 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/models"
+	"northwind-api/internal/patch"
+	"northwind-api/internal/repositories"
+	"northwind-api/internal/visibility"
+)
+
+// ActionErased dipakai untuk customer, employee dan order yang data
+// pribadinya dipseudonimkan atas permintaan subject-nya.
+const ActionErased = "erased"
+
+// personalFields adalah field JSON berisi data pribadi per resource. Nilainya
+// dikosongkan saat erasure dan diganti repositories.Erased di riwayat.
+// Kota, region, negara dan angka order sengaja dipertahankan untuk laporan.
+var personalFields = map[string][]string{
+	"customers": {"contact_name", "contact_title", "address", "postal_code", "phone", "fax"},
+	"orders":    {"ship_name", "ship_address", "ship_postal_code"},
+	"employees": {"last_name", "first_name", "title_of_courtesy", "birth_date", "address", "postal_code",
+		"home_phone", "extension", "photo", "notes", "photo_path"},
+}
+
+// idFields adalah field ID resource di response JSON, dipakai untuk mencari
+// response Idempotency-Key yang memuat subject-nya.
+var idFields = map[string]string{"customers": "customer_id", "orders": "order_id", "employees": "employee_id"}
+
+// canErase membatasi erasure ke role admin karena tidak bisa dibatalkan.
+// Seperti kebijakan field, context tanpa identitas (auth mati) tidak dibatasi.
+func canErase(ctx context.Context) error {
+	if !visibility.Allowed(ctx, visibility.RoleAdmin) {
+		return apperr.Forbidden("erasing personal data requires the %s role", visibility.RoleAdmin)
+	}
+	return nil
+}
+
+// ExportData mengumpulkan customer (juga yang sudah dihapus), order beserta
+// detailnya, dan riwayat audit customer dan order tersebut.
+func (s *CustomerService) ExportData(ctx context.Context, id string) (models.CustomerDataExport, error) {
+	out := models.CustomerDataExport{Orders: []models.OrderWithDetails{}}
+	r := s.read(ctx)
+	customer, err := r.Customers.GetCustomerByID(repositories.WithDeleted(ctx), id)
+	if err != nil {
+		return out, err
+	}
+	out.Customer = customer
+
+	orders, err := r.Orders.GetOrdersByCustomerIDs(ctx, []string{id})
+	if err != nil {
+		return out, err
+	}
+	orderIDs := make([]int, len(orders))
+	for i, o := range orders {
+		orderIDs[i] = int(o.OrderID)
+	}
+	details, err := r.Orders.GetOrderDetailsByOrderIDs(ctx, orderIDs)
+	if err != nil {
+		return out, err
+	}
+	for _, o := range orders {
+		od := models.OrderWithDetails{Order: o, Details: []models.OrderDetail{}}
+		for _, d := range details {
+			if d.OrderID == o.OrderID {
+				od.Details = append(od.Details, d)
+			}
+		}
+		out.Orders = append(out.Orders, od)
+	}
+
+	keys := []auditKey{{"customers", id}}
+	for _, o := range orders {
+		keys = append(keys, auditKey{"orders", strconv.FormatInt(o.OrderID, 10)})
+	}
+	if out.History, err = history(ctx, r, keys); err != nil {
+		return out, err
+	}
+	out.ExportedAt = time.Now().UTC()
+	return out, nil
+}
+
+// ExportData mengumpulkan employee (juga yang sudah dihapus), territory-nya
+// dan riwayat auditnya.
+func (s *EmployeeService) ExportData(ctx context.Context, id int) (models.EmployeeDataExport, error) {
+	out := models.EmployeeDataExport{Territories: []models.Territory{}}
+	r := s.read(ctx)
+	emp, err := r.Employees.GetEmployeeByID(repositories.WithDeleted(ctx), id)
+	if err != nil {
+		return out, err
+	}
+	out.Employee = emp
+
+	territories, err := loadTerritories(ctx, r, []string{strconv.Itoa(id)})
+	if err != nil {
+		return out, err
+	}
+	for _, t := range territories[strconv.Itoa(id)] {
+		out.Territories = append(out.Territories, t.(models.Territory))
+	}
+	if out.History, err = history(ctx, r, []auditKey{{"employees", strconv.Itoa(id)}}); err != nil {
+		return out, err
+	}
+	out.ExportedAt = time.Now().UTC()
+	return out, nil
+}
+
+// Erase mempseudonimkan customer dan ship address order-ordernya dalam satu
+// transaksi: contact_name dan ship_name diganti pseudonym acak, field pribadi
+// lain dikosongkan, lalu nilai lamanya dihapus dari audit log, event log dan
+// payload webhook. Customer yang sudah dihapus juga bisa di-erase. Hanya
+// admin yang boleh (lihat canErase).
+func (s *CustomerService) Erase(ctx context.Context, id string) (models.ErasureResult, error) {
+	res := models.ErasureResult{Entity: "customers", EntityID: id, Fields: personalFields["customers"], OrderIDs: []int64{}}
+	if err := canErase(ctx); err != nil {
+		return res, err
+	}
+	pseudonym, err := newPseudonym()
+	if err != nil {
+		return res, err
+	}
+	res.Pseudonym = pseudonym
+	err = s.write(ctx, func(ctx context.Context, r repositories.Repositories) error {
+		ctx = repositories.WithDeleted(ctx)
+		customer, err := r.Customers.GetCustomerByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		customer.ContactName = pseudonym
+		customer.ContactTitle, customer.Address, customer.PostalCode, customer.Phone, customer.Fax = "", "", "", "", ""
+		if err := r.Customers.PatchCustomer(ctx, id, repositories.Changes(patch.Columns(&customer, res.Fields))); err != nil {
+			return err
+		}
+
+		orders, err := r.Orders.GetOrdersByCustomerIDs(ctx, []string{id})
+		if err != nil {
+			return err
+		}
+		keys := []auditKey{{"customers", id}}
+		for i := range orders {
+			o := &orders[i]
+			o.ShipName, o.ShipAddress, o.ShipPostalCode = &pseudonym, nil, nil
+			if err := r.Orders.PatchOrder(ctx, int(o.OrderID), repositories.Changes(patch.Columns(o, personalFields["orders"]))); err != nil {
+				return err
+			}
+			keys = append(keys, auditKey{"orders", strconv.FormatInt(o.OrderID, 10)})
+			res.OrderIDs = append(res.OrderIDs, o.OrderID)
+		}
+		if err := redactHistory(ctx, r, keys); err != nil {
+			return err
+		}
+
+		// Event erased sengaja tanpa before supaya nilai lama tidak tercatat lagi.
+		emit(ctx, newEvent("customer", "customers", id, ActionErased, nil, customer))
+		for _, o := range orders {
+			emit(ctx, newEvent("order", "orders", strconv.FormatInt(o.OrderID, 10), ActionErased, nil, o))
+		}
+		return nil
+	})
+	return res, err
+}
+
+// Erase mempseudonimkan employee: last_name diganti pseudonym acak,
+// first_name menjadi "anon", field pribadi lain dikosongkan, lalu nilai
+// lamanya dihapus dari audit log, event log dan payload webhook. Employee
+// yang sudah dihapus juga bisa di-erase. Hanya admin yang boleh (lihat canErase).
+func (s *EmployeeService) Erase(ctx context.Context, id int) (models.ErasureResult, error) {
+	res := models.ErasureResult{Entity: "employees", EntityID: strconv.Itoa(id), Fields: personalFields["employees"], OrderIDs: []int64{}}
+	if err := canErase(ctx); err != nil {
+		return res, err
+	}
+	pseudonym, err := newPseudonym()
+	if err != nil {
+		return res, err
+	}
+	res.Pseudonym = pseudonym
+	err = s.write(ctx, func(ctx context.Context, r repositories.Repositories) error {
+		ctx = repositories.WithDeleted(ctx)
+		emp, err := r.Employees.GetEmployeeByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		emp.LastName, emp.FirstName = pseudonym, "anon"
+		emp.TitleOfCourtesy, emp.BirthDate, emp.Address, emp.PostalCode = "", "", "", ""
+		emp.HomePhone, emp.Extension, emp.Photo, emp.Notes, emp.PhotoPath = "", "", nil, "", ""
+		if err := r.Employees.PatchEmployee(ctx, id, repositories.Changes(patch.Columns(&emp, res.Fields))); err != nil {
+			return err
+		}
+		if err := r.Employees.SetEmployeePhoto(ctx, id, nil); err != nil {
+			return err
+		}
+		if err := redactHistory(ctx, r, []auditKey{{"employees", res.EntityID}}); err != nil {
+			return err
+		}
+		emit(ctx, newEvent("employee", "employees", res.EntityID, ActionErased, nil, emp))
+		return nil
+	})
+	return res, err
+}
+
+type auditKey struct {
+	entity, id string
+}
+
+// history menggabungkan audit log beberapa entity, terbaru lebih dulu.
+func history(ctx context.Context, r repositories.Repositories, keys []auditKey) ([]models.AuditEntry, error) {
+	out := []models.AuditEntry{}
+	if r.Audit == nil {
+		return out, nil
+	}
+	for _, k := range keys {
+		entries, err := r.Audit.ListAudit(ctx, repositories.AuditFilter{Entity: k.entity, EntityID: k.id})
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, entries...)
+	}
+	slices.SortStableFunc(out, func(a, b models.AuditEntry) int {
+		return -a.OccurredAt.Compare(b.OccurredAt)
+	})
+	return out, nil
+}
+
+// redactHistory mengganti nilai field pribadi di audit log, event log dan
+// payload webhook (termasuk yang belum terkirim) dengan repositories.Erased,
+// dan menghapus response Idempotency-Key yang memuat subject-nya.
+func redactHistory(ctx context.Context, r repositories.Repositories, keys []auditKey) error {
+	for _, k := range keys {
+		fields := personalFields[k.entity]
+		if r.Idempotency != nil {
+			if err := r.Idempotency.PurgeIdempotencyResponses(ctx, mentions(k)...); err != nil {
+				return err
+			}
+		}
+		if r.Audit != nil {
+			if err := r.Audit.RedactAudit(ctx, k.entity, k.id, fields); err != nil {
+				return err
+			}
+		}
+		if r.EventLog != nil {
+			if err := r.EventLog.RedactEvents(ctx, k.entity, k.id, fields); err != nil {
+				return err
+			}
+		}
+		if r.Webhooks != nil {
+			if err := r.Webhooks.RedactDeliveries(ctx, k.entity, k.id, fields); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// mentions mengembalikan potongan JSON yang muncul di response yang memuat
+// resource k, mis. "customer_id":"ALFKI" atau "order_id":10248 (diikuti ,
+// atau } supaya 10248 tidak cocok dengan 102480).
+func mentions(k auditKey) []string {
+	field := `"` + idFields[k.entity] + `":`
+	if k.entity == "customers" {
+		id, _ := json.Marshal(k.id)
+		return []string{field + string(id)}
+	}
+	return []string{field + k.id + ",", field + k.id + "}"}
+}
+
+func newPseudonym() (string, error) {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("error generating pseudonym: %w", err)
+	}
+	return "anon-" + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,225 @@
+package services
+
+import (
+	"context"
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/etag"
+	"northwind-api/internal/models"
+	"northwind-api/internal/patch"
+	"northwind-api/internal/repositories"
+	"northwind-api/internal/validation"
+	"strconv"
+)
+
+type ProductService struct {
+	base
+}
+
+func (s *ProductService) List(ctx context.Context) ([]models.Product, error) {
+	return s.read(ctx).Products.GetAllProducts(ctx)
+}
+
+// Search mengembalikan products yang cocok dengan q (lihat SearchService),
+// terurut dari yang paling relevan.
+func (s *ProductService) Search(ctx context.Context, q string) ([]models.Product, error) {
+	r := s.read(ctx)
+	ids, err := searchIDs(ctx, r, "products", q)
+	if err != nil || len(ids) == 0 {
+		return []models.Product{}, err
+	}
+	nums := intIDs(ids)
+	rows, err := r.Products.GetProductsByIDs(ctx, nums)
+	if err != nil {
+		return nil, err
+	}
+	return rankOrder(ids, rows, func(p models.Product) string { return strconv.Itoa(p.ProductID) }), nil
+}
+
+func (s *ProductService) Get(ctx context.Context, id int) (models.Product, error) {
+	return s.read(ctx).Products.GetProductByID(ctx, id)
+}
+
+func (s *ProductService) Supplier(ctx context.Context, productID int) (models.ProductSupplier, error) {
+	return s.read(ctx).Products.GetSupplierByProductID(ctx, productID)
+}
+
+func (s *ProductService) Category(ctx context.Context, productID int) (models.ProductCategory, error) {
+	return s.read(ctx).Products.GetCategoryByProductID(ctx, productID)
+}
+
+func (s *ProductService) Create(ctx context.Context, p *models.Product) error {
+	p.DeletedAt = nil
+	if err := validateProduct(p); err != nil {
+		return err
+	}
+	return s.write(ctx, func(ctx context.Context, r repositories.Repositories) error {
+		if err := checkProductRefs(ctx, r, p); err != nil {
+			return err
+		}
+		id, err := r.Products.CreateProduct(ctx, *p)
+		if err != nil {
+			return err
+		}
+		p.ProductID = int(id)
+		emit(ctx, newEvent("product", "products", strconv.Itoa(p.ProductID), ActionCreated, nil, *p))
+		return nil
+	})
+}
+
+func (s *ProductService) Update(ctx context.Context, p *models.Product) error {
+	p.DeletedAt = nil
+	if err := validateProduct(p); err != nil {
+		return err
+	}
+	return s.write(ctx, func(ctx context.Context, r repositories.Repositories) error {
+		before, err := r.Products.GetProductByID(ctx, p.ProductID)
+		if err != nil {
+			return err
+		}
+		if err := etag.Check(ctx, before); err != nil {
+			return err
+		}
+		if err := checkProductRefs(ctx, r, p); err != nil {
+			return err
+		}
+		if err := r.Products.UpdateProduct(ctx, p); err != nil {
+			return err
+		}
+		emit(ctx, newEvent("product", "products", strconv.Itoa(p.ProductID), ActionUpdated, before, *p))
+		return nil
+	})
+}
+
+// Patch menerapkan merge patch / JSON Patch ke product dan hanya menulis kolom yang disentuh.
+func (s *ProductService) Patch(ctx context.Context, id int, p patch.Patch) (models.Product, error) {
+	var after models.Product
+	err := s.write(ctx, func(ctx context.Context, r repositories.Repositories) error {
+		before, err := r.Products.GetProductByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := etag.Check(ctx, before); err != nil {
+			return err
+		}
+		fields, err := p.Apply(before, &after, "product_id", "deleted_at")
+		if err != nil {
+			return err
+		}
+		after.ProductID = id
+		if err := validateProduct(&after); err != nil {
+			return err
+		}
+		if err := checkProductRefs(ctx, r, &after); err != nil {
+			return err
+		}
+		if len(fields) == 0 {
+			return nil
+		}
+		if err := r.Products.PatchProduct(ctx, id, repositories.Changes(patch.Columns(&after, fields))); err != nil {
+			return err
+		}
+		emit(ctx, newEvent("product", "products", strconv.Itoa(id), ActionUpdated, before, after))
+		return nil
+	})
+	return after, err
+}
+
+func (s *ProductService) Delete(ctx context.Context, id int) error {
+	return s.write(ctx, func(ctx context.Context, r repositories.Repositories) error {
+		before, err := r.Products.GetProductByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := etag.Check(ctx, before); err != nil {
+			return err
+		}
+		if err := r.Products.DeleteProduct(ctx, id); err != nil {
+			return err
+		}
+		emit(ctx, newEvent("product", "products", strconv.Itoa(id), ActionDeleted, before, nil))
+		return nil
+	})
+}
+
+// Restore mengembalikan product yang sudah di-soft delete.
+func (s *ProductService) Restore(ctx context.Context, id int) (models.Product, error) {
+	var after models.Product
+	err := s.write(ctx, func(ctx context.Context, r repositories.Repositories) error {
+		before, err := r.Products.GetProductByID(repositories.WithDeleted(ctx), id)
+		if err != nil {
+			return err
+		}
+		if before.DeletedAt == nil {
+			return apperr.Conflict("product is not deleted")
+		}
+		if err := r.Products.RestoreProduct(ctx, id); err != nil {
+			return err
+		}
+		after = before
+		after.DeletedAt = nil
+		emit(ctx, newEvent("product", "products", strconv.Itoa(id), ActionRestored, before, after))
+		return nil
+	})
+	return after, err
+}
+
+func validateProduct(p *models.Product) error {
+	return validation.Struct(p)
+}
+
+// checkProductRefs memastikan supplier dan category yang dirujuk ada.
+func checkProductRefs(ctx context.Context, r repositories.Repositories, p *models.Product) error {
+	var c checks
+	if p.SupplierID != nil {
+		_, err := r.Suppliers.GetSupplierByID(ctx, *p.SupplierID)
+		c.ref("supplier_id", "supplier", err)
+	}
+	if p.CategoryID != nil {
+		_, err := r.Categories.GetCategoryByID(ctx, *p.CategoryID)
+		c.ref("category_id", "category", err)
+	}
+	return c.result()
+}
+
+// Bulk menjalankan create/update/patch/delete product secara batch.
+func (s *ProductService) Bulk(ctx context.Context, mode BulkMode, ops []models.BulkOperation) ([]BulkResult, error) {
+	return s.bulk(ctx, mode, ops, func(ctx context.Context, op models.BulkOperation) (string, error) {
+		if op.Op == BulkCreate {
+			var p models.Product
+			if err := bulkData(op, &p); err != nil {
+				return "", err
+			}
+			err := s.Create(ctx, &p)
+			return strconv.Itoa(p.ProductID), err
+		}
+		id, err := bulkIntID(op)
+		if err != nil {
+			return "", err
+		}
+		switch op.Op {
+		case BulkUpdate:
+			var p models.Product
+			if err := bulkData(op, &p); err != nil {
+				return strconv.Itoa(id), err
+			}
+			p.ProductID = id
+			err = s.Update(ctx, &p)
+		case BulkPatch:
+			var p patch.Patch
+			if p, err = bulkPatch(op); err == nil {
+				_, err = s.Patch(ctx, id, p)
+			}
+		case BulkDelete:
+			err = s.Delete(ctx, id)
+		}
+		return strconv.Itoa(id), err
+	})
+}
+
+// Check menjalankan validasi Create (termasuk referensi) tanpa menyimpan; dipakai dry-run import.
+func (s *ProductService) Check(ctx context.Context, p *models.Product) error {
+	if err := validateProduct(p); err != nil {
+		return err
+	}
+	return checkProductRefs(ctx, s.read(ctx), p)
+}
@@ -0,0 +1,324 @@
+package services
+
+import (
+	"context"
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/models"
+	"northwind-api/internal/repositories"
+	"northwind-api/internal/visibility"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+
+	maxSearchWords = 10
+	// maxSimilarTerms membatasi ejaan alternatif per kata saat pencarian fuzzy.
+	maxSimilarTerms = 10
+)
+
+// SearchTypes adalah resource yang masuk index pencarian.
+var SearchTypes = []string{"customers", "products", "suppliers"}
+
+// SearchService menjawab GET /search lintas resource.
+type SearchService struct {
+	base
+}
+
+// Enabled false kalau repositories tidak punya index pencarian.
+func (s *SearchService) Enabled() bool {
+	return s.repos.Search != nil
+}
+
+// Search mencari q di types (kosong berarti semua). Setiap kata dicocokkan
+// sebagai awalan; kalau tidak ada hasil sama sekali, kata yang ejaannya mirip
+// (typo satu-dua huruf) ikut dicari dan Fuzzy bernilai true.
+func (s *SearchService) Search(ctx context.Context, q string, types []string, limit int) (models.SearchResult, error) {
+	for _, t := range types {
+		if !slices.Contains(SearchTypes, t) {
+			return models.SearchResult{}, apperr.Validation("type", "unknown type %q; expected one of %s", t, strings.Join(SearchTypes, ", "))
+		}
+	}
+	switch {
+	case limit == 0:
+		limit = DefaultSearchLimit
+	case limit < 0 || limit > MaxSearchLimit:
+		return models.SearchResult{}, apperr.Validation("limit", "must be between 1 and %d", MaxSearchLimit)
+	}
+	return search(ctx, s.read(ctx), q, types, limit)
+}
+
+func search(ctx context.Context, r repositories.Repositories, q string, types []string, limit int) (models.SearchResult, error) {
+	res := models.SearchResult{Query: q, Hits: []models.SearchHit{}}
+	if r.Search == nil {
+		return res, apperr.Validation("q", "search is not enabled")
+	}
+	words := repositories.SearchWords(q)
+	if len(words) == 0 {
+		return res, apperr.Validation("q", "must contain at least one letter or digit")
+	}
+	if len(words) > maxSearchWords {
+		return res, apperr.Validation("q", "must not contain more than %d words", maxSearchWords)
+	}
+
+	query := repositories.SearchQuery{Entities: types, Limit: limit}
+	for _, w := range words {
+		query.Terms = append(query.Terms, []repositories.SearchTerm{{Word: w, Prefix: true}})
+	}
+	hits, err := r.Search.Search(ctx, query)
+	if err == nil {
+		hits, err = visibleHits(ctx, r, query.Terms, hits)
+	}
+	if err != nil || len(hits) > 0 {
+		res.Hits = hits
+		return res, err
+	}
+
+	shortest := len([]rune(words[0]))
+	for _, w := range words {
+		shortest = min(shortest, len([]rune(w)))
+	}
+	vocab, err := r.Search.SearchTerms(ctx, max(shortest-2, 1))
+	if err != nil {
+		return res, err
+	}
+	expanded := false
+	for i, w := range words {
+		for _, alt := range similarTerms(w, vocab) {
+			query.Terms[i] = append(query.Terms[i], repositories.SearchTerm{Word: alt})
+			expanded = true
+		}
+	}
+	if !expanded {
+		return res, nil
+	}
+	if hits, err = r.Search.Search(ctx, query); err != nil {
+		return res, err
+	}
+	if res.Hits, err = visibleHits(ctx, r, query.Terms, hits); err != nil {
+		return res, err
+	}
+	res.Fuzzy = true
+	return res, nil
+}
+
+// visibleHits mencocokkan ulang hit customer dengan field yang boleh dilihat
+// ctx. Index berisi data kontak, jadi tanpa ini caller bisa menemukan
+// customer (dan melihat snippet) lewat nama kontak yang disembunyikan.
+func visibleHits(ctx context.Context, r repositories.Repositories, terms [][]repositories.SearchTerm, hits []models.SearchHit) ([]models.SearchHit, error) {
+	if len(visibility.Hidden(ctx, reflect.TypeFor[models.Customer]())) == 0 {
+		return hits, nil
+	}
+	var ids []string
+	for _, h := range hits {
+		if h.Type == "customers" {
+			ids = append(ids, h.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return hits, nil
+	}
+	customers, err := r.Customers.GetCustomersByIDs(repositories.WithDeleted(ctx), ids)
+	if err != nil {
+		return nil, err
+	}
+	docs := make(map[string]models.SearchDocument, len(customers))
+	for _, c := range customers {
+		visibility.Redact(ctx, &c)
+		docs[c.CustomerID], _ = searchDocument("customers", c.CustomerID, c)
+	}
+	out := hits[:0]
+	for _, h := range hits {
+		if h.Type == "customers" {
+			doc, ok := docs[h.ID]
+			if !ok {
+				continue
+			}
+			title, inTitle := repositories.Mark(doc.Title, terms)
+			body, inBody := repositories.Mark(doc.Body, terms)
+			matched := true
+			for i := range terms {
+				matched = matched && (inTitle[i] || inBody[i])
+			}
+			if !matched {
+				continue
+			}
+			h.Title, h.Snippet = title, body
+		}
+		out = append(out, h)
+	}
+	return out, nil
+}
+
+// searchIDs mengembalikan id entity yang cocok dengan q, dari yang paling
+// relevan, untuk ?q= di list endpoint.
+func searchIDs(ctx context.Context, r repositories.Repositories, entity, q string) ([]string, error) {
+	res, err := search(ctx, r, q, []string{entity}, MaxSearchLimit)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(res.Hits))
+	for i, h := range res.Hits {
+		ids[i] = h.ID
+	}
+	return ids, nil
+}
+
+// rankOrder mengurutkan rows sesuai urutan ids (hasil ranking pencarian).
+func rankOrder[T any](ids []string, rows []T, key func(T) string) []T {
+	pos := make(map[string]int, len(ids))
+	for i, id := range ids {
+		pos[id] = i
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return pos[key(rows[i])] < pos[key(rows[j])] })
+	return rows
+}
+
+// intIDs mengubah id string menjadi int; yang bukan angka dilewati.
+func intIDs(ids []string) []int {
+	out := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if n, err := strconv.Atoi(id); err == nil {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+// similarTerms memilih kata di vocab yang berjarak edit kecil dari w: 1 untuk
+// kata 4-7 huruf, 2 untuk yang lebih panjang. Kata yang lebih panjang juga
+// dibandingkan awalannya, jadi "chocolat" menemukan "chocolade".
+func similarTerms(w string, vocab []string) []string {
+	word := []rune(w)
+	if len(word) < 4 {
+		return nil
+	}
+	maxDist := 1
+	if len(word) >= 8 {
+		maxDist = 2
+	}
+	type candidate struct {
+		term string
+		dist int
+	}
+	var found []candidate
+	for _, term := range vocab {
+		t := []rune(term)
+		if term == w || len(t) < len(word)-maxDist {
+			continue
+		}
+		d := editDistance(word, t)
+		if d > maxDist && len(t) > len(word) {
+			d = editDistance(word, t[:len(word)])
+		}
+		if d <= maxDist {
+			found = append(found, candidate{term, d})
+		}
+	}
+	sort.Slice(found, func(i, j int) bool {
+		if found[i].dist != found[j].dist {
+			return found[i].dist < found[j].dist
+		}
+		return found[i].term < found[j].term
+	})
+	out := make([]string, 0, min(len(found), maxSimilarTerms))
+	for _, c := range found[:min(len(found), maxSimilarTerms)] {
+		out = append(out, c.term)
+	}
+	return out
+}
+
+// editDistance menghitung jarak Damerau-Levenshtein (optimal string
+// alignment): sisip, hapus, ganti, atau tukar dua huruf bersebelahan.
+func editDistance(a, b []rune) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
+
+// indexSearch memperbarui index pencarian untuk event customers, products dan
+// suppliers di transaksi yang sama. Entity yang dihapus atau digabung ke
+// entity lain dikeluarkan dari index.
+func indexSearch(ctx context.Context, r repositories.Repositories, events []Event) error {
+	if r.Search == nil {
+		return nil
+	}
+	for _, e := range events {
+		if !slices.Contains(SearchTypes, e.Entity) {
+			continue
+		}
+		if e.Action == ActionDeleted || e.Action == ActionMerged || e.Action == ActionErased && erasedDeleted(e.After) {
+			if err := r.Search.RemoveDocument(ctx, e.Entity, e.EntityID); err != nil {
+				return err
+			}
+			continue
+		}
+		if doc, ok := searchDocument(e.Entity, e.EntityID, e.After); ok {
+			if err := r.Search.IndexDocuments(ctx, doc); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// erasedDeleted: customer yang di-erase setelah dihapus tidak boleh masuk
+// index lagi.
+func erasedDeleted(v any) bool {
+	c, ok := v.(models.Customer)
+	return ok && c.DeletedAt != nil
+}
+
+// searchDocument membentuk isi index; kolomnya sama dengan migration 0007.
+func searchDocument(entity, id string, v any) (models.SearchDocument, bool) {
+	doc := models.SearchDocument{Entity: entity, EntityID: id}
+	switch v := v.(type) {
+	case models.Customer:
+		doc.Title = v.CompanyName
+		doc.Body = joinWords(v.ContactName, v.ContactTitle, v.Address, v.City, v.Region, v.PostalCode, v.Country)
+	case models.Product:
+		doc.Title = v.ProductName
+		doc.Body = joinWords(deref(v.QuantityPerUnit))
+	case models.Supplier:
+		doc.Title = v.CompanyName
+		doc.Body = joinWords(deref(v.ContactName), deref(v.ContactTitle), deref(v.Address), deref(v.City),
+			deref(v.Region), deref(v.PostalCode), deref(v.Country))
+	default:
+		return doc, false
+	}
+	return doc, true
+}
+
+func joinWords(parts ...string) string {
+	return strings.Join(slices.DeleteFunc(parts, func(s string) bool { return s == "" }), " ")
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
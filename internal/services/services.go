@@ -0,0 +1,117 @@
+// Package services berisi business logic di antara handlers dan repositories:
+// validasi, transaksi lintas repository, domain error dan domain event.
+// Package ini tidak bergantung pada gin supaya bisa dipakai ulang dari CLI.
+package services
+
+import (
+	"context"
+	"northwind-api/internal/repositories"
+)
+
+type Services struct {
+	Customers  *CustomerService
+	Employees  *EmployeeService
+	Shippers   *ShipperService
+	Products   *ProductService
+	Categories *CategoryService
+	Suppliers  *SupplierService
+	Orders     *OrderService
+	Imports    *ImportService
+	Audit      *AuditService
+	Stream     *StreamService
+	Webhooks   *WebhookService
+	Includes   *IncludeService
+	Search     *SearchService
+	Events     *Dispatcher
+
+	base base
+}
+
+// New membangun semua service di atas repos yang sama. events boleh nil.
+func New(repos repositories.Repositories, tx repositories.TxRunner, events *Dispatcher) *Services {
+	if events == nil {
+		events = NewDispatcher()
+	}
+	b := base{repos: repos, tx: tx, events: events}
+	s := &Services{
+		Customers:  &CustomerService{base: b},
+		Employees:  &EmployeeService{base: b},
+		Shippers:   &ShipperService{base: b},
+		Products:   &ProductService{base: b},
+		Categories: &CategoryService{base: b},
+		Suppliers:  &SupplierService{base: b},
+		Orders:     &OrderService{base: b},
+		Audit:      &AuditService{base: b},
+		Includes:   &IncludeService{base: b},
+		Search:     &SearchService{base: b},
+		Events:     events,
+		base:       b,
+	}
+	s.Stream = newStreamService(b)
+	s.Webhooks = newWebhookService(b)
+	s.Imports = newImportService(b, s.Products, s.Customers, s.Suppliers)
+	return s
+}
+
+// InTx menjalankan beberapa operasi service di dalam satu transaksi.
+// Event dari semua operasi baru dipublish setelah commit.
+func (s *Services) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return s.base.write(ctx, func(ctx context.Context, _ repositories.Repositories) error {
+		return fn(ctx)
+	})
+}
+
+// base dibagi oleh semua service.
+type base struct {
+	repos  repositories.Repositories
+	tx     repositories.TxRunner
+	events *Dispatcher
+}
+
+// read mengembalikan repositories yang tepat untuk query baca: milik transaksi
+// aktif kalau ada, supaya bacaan di dalam transaksi melihat perubahannya sendiri.
+func (b base) read(ctx context.Context) repositories.Repositories {
+	return repositories.Scoped(ctx, b.repos)
+}
+
+type pendingKey struct{}
+
+// write menjalankan fn di dalam transaksi. Event yang di-emit selama fn
+// berjalan dicatat ke audit log, event log, outbox webhook dan index
+// pencarian di transaksi yang sama, lalu baru dipublish setelah transaksi
+// terluar berhasil commit.
+func (b base) write(ctx context.Context, fn func(ctx context.Context, r repositories.Repositories) error) error {
+	if _, nested := ctx.Value(pendingKey{}).(*[]Event); nested {
+		return b.tx.WithTx(ctx, fn)
+	}
+
+	var pending []Event
+	ctx = context.WithValue(ctx, pendingKey{}, &pending)
+	err := b.tx.WithTx(ctx, func(ctx context.Context, r repositories.Repositories) error {
+		if err := fn(ctx, r); err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, r, pending); err != nil {
+			return err
+		}
+		if err := recordEvents(ctx, r, pending); err != nil {
+			return err
+		}
+		if err := enqueueWebhooks(ctx, r, pending); err != nil {
+			return err
+		}
+		return indexSearch(ctx, r, pending)
+	})
+	if err != nil {
+		return err
+	}
+	b.events.Publish(ctx, pending...)
+	return nil
+}
+
+// emit mencatat event untuk dipublish setelah commit.
+func emit(ctx context.Context, e Event) {
+	if pending, ok := ctx.Value(pendingKey{}).(*[]Event); ok {
+		*pending = append(*pending, e.stamp())
+	}
+}
@@ -0,0 +1,287 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/models"
+	"northwind-api/internal/repositories"
+	"northwind-api/internal/repositories/memory"
+	"northwind-api/internal/services"
+)
+
+func newServices(t *testing.T) (*services.Services, *memory.Store, *[]services.Event) {
+	t.Helper()
+	store := memory.NewStore()
+	var got []services.Event
+	d := services.NewDispatcher()
+	d.Subscribe(func(ctx context.Context, e services.Event) { got = append(got, e) })
+	return services.New(store.Repositories(), store.TxRunner(), d), store, &got
+}
+
+func TestCustomerCreateGeneratesIDAndPublishesEvent(t *testing.T) {
+	svc, store, events := newServices(t)
+
+	c := models.Customer{CompanyName: "Around the Horn"}
+	if err := svc.Customers.Create(context.Background(), &c); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if c.CustomerID == "" {
+		t.Fatal("CustomerID was not generated")
+	}
+	if _, err := store.Customers.GetCustomerByID(context.Background(), c.CustomerID); err != nil {
+		t.Fatalf("customer not stored: %v", err)
+	}
+	if len(*events) != 1 || (*events)[0].Type != "customer.created" || (*events)[0].EntityID != c.CustomerID {
+		t.Fatalf("events = %+v", *events)
+	}
+}
+
+func TestValidationErrorStopsWrite(t *testing.T) {
+	svc, store, events := newServices(t)
+
+	err := svc.Products.Create(context.Background(), &models.Product{UnitPrice: 10})
+	verr, ok := apperr.As(err)
+	if !ok || !errors.Is(err, apperr.ErrValidation) || len(verr.FieldErrors()) != 1 || verr.FieldErrors()[0].Field != "product_name" {
+		t.Fatalf("err = %v, want validation error on product_name", err)
+	}
+	if all, _ := store.Products.GetAllProducts(context.Background()); len(all) != 0 {
+		t.Fatalf("product stored despite validation error: %+v", all)
+	}
+	if len(*events) != 0 {
+		t.Fatalf("events published for failed write: %+v", *events)
+	}
+}
+
+func TestReferentialChecks(t *testing.T) {
+	svc, store, _ := newServices(t)
+	store.Customers.Seed(models.Customer{CustomerID: "ALFKI", CompanyName: "Alfreds Futterkiste"})
+
+	customer, employee, shipVia := "NOONE", int64(7), int64(3)
+	err := svc.Orders.Create(context.Background(), &models.Order{CustomerID: &customer, EmployeeID: &employee, ShipVia: &shipVia})
+	verr, ok := apperr.As(err)
+	if !ok || !errors.Is(err, apperr.ErrValidation) {
+		t.Fatalf("err = %v, want validation error", err)
+	}
+	var got []string
+	for _, f := range verr.FieldErrors() {
+		got = append(got, f.Field)
+	}
+	if strings.Join(got, ",") != "customer_id,employee_id,ship_via" {
+		t.Fatalf("fields = %v", got)
+	}
+
+	customer = "ALFKI"
+	if err := svc.Orders.Create(context.Background(), &models.Order{CustomerID: &customer}); err != nil {
+		t.Fatalf("order with known customer: %v", err)
+	}
+
+	supplier := 9
+	err = svc.Products.Create(context.Background(), &models.Product{ProductName: "Tea", SupplierID: &supplier})
+	if verr, ok := apperr.As(err); !ok || verr.FieldErrors()[0].Field != "supplier_id" {
+		t.Fatalf("err = %v, want unknown supplier_id", err)
+	}
+}
+
+func TestOrderDatesMustFollowOrderDate(t *testing.T) {
+	svc, _, _ := newServices(t)
+	ordered, shipped := "1996-07-04", "1996-07-01 00:00:00"
+	err := svc.Orders.Create(context.Background(), &models.Order{OrderDate: &ordered, ShippedDate: &shipped})
+	if verr, ok := apperr.As(err); !ok || verr.FieldErrors()[0].Field != "shipped_date" {
+		t.Fatalf("err = %v, want shipped_date error", err)
+	}
+}
+
+func TestEventsPublishedOnlyAfterOutermostTx(t *testing.T) {
+	svc, store, events := newServices(t)
+	store.Shippers.Seed(models.Shipper{ShipperID: 1, CompanyName: "Speedy Express"})
+
+	err := svc.InTx(context.Background(), func(ctx context.Context) error {
+		if err := svc.Shippers.Update(ctx, &models.Shipper{ShipperID: 1, CompanyName: "Speedy"}); err != nil {
+			return err
+		}
+		if len(*events) != 0 {
+			t.Errorf("event published before outer tx finished")
+		}
+		return errors.New("abort")
+	})
+	if err == nil {
+		t.Fatal("expected error from outer tx")
+	}
+	if len(*events) != 0 {
+		t.Fatalf("events published for failed tx: %+v", *events)
+	}
+}
+
+func TestCustomerDuplicatesAndMerge(t *testing.T) {
+	svc, store, events := newServices(t)
+	ctx := context.Background()
+	store.Customers.Seed(
+		models.Customer{CustomerID: "ALFKI", CompanyName: "Alfreds Futterkiste", Address: "Obere Str. 57", City: "Berlin", PostalCode: "12209", Phone: "030-0074321"},
+		models.Customer{CustomerID: "3f1c0a52-5d1e-4c43-9a52-0c5e2a7d9b11", CompanyName: "Alfreds Futterkiste GmbH", ContactTitle: "Sales Representative",
+			Address: "Obere Straße 57", City: "Berlin", PostalCode: "12209", Phone: "+49 30 0074321"},
+		models.Customer{CustomerID: "ANATR", CompanyName: "Ana Trujillo Emparedados y helados", Phone: "(5) 555-4729"},
+	)
+	dupID := "3f1c0a52-5d1e-4c43-9a52-0c5e2a7d9b11"
+	store.Orders.Seed(models.Order{OrderID: 10643, CustomerID: &dupID})
+
+	dups, err := svc.Customers.Duplicates(ctx, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dups) != 1 || dups[0].Score < 0.99 || dups[0].Matches["phone"] != 1 {
+		t.Fatalf("duplicates = %+v", dups)
+	}
+
+	res, err := svc.Customers.Merge(ctx, "ALFKI", models.CustomerMergeRequest{DuplicateIDs: []string{dupID}, FillBlanks: true})
+	if err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+	if len(res.MovedOrderIDs) != 1 || res.Customer.ContactTitle != "Sales Representative" || res.Customer.Phone != "030-0074321" {
+		t.Fatalf("result = %+v", res)
+	}
+	if o, _ := store.Orders.GetOrderByID(ctx, 10643); o.CustomerID == nil || *o.CustomerID != "ALFKI" {
+		t.Fatalf("order not moved: %+v", o)
+	}
+	if _, err := store.Customers.GetCustomerByID(ctx, dupID); !errors.Is(err, apperr.ErrNotFound) {
+		t.Fatalf("duplicate still live: %v", err)
+	}
+	var types []string
+	for _, e := range *events {
+		types = append(types, e.Type)
+	}
+	if got := strings.Join(types, ","); got != "order.updated,customer.updated,customer.merged" {
+		t.Fatalf("events = %s", got)
+	}
+	entries, _ := store.Audit.ListAudit(ctx, repositories.AuditFilter{Entity: "customers", EntityID: dupID, Limit: 10})
+	if len(entries) != 1 || entries[0].Action != "merged" || entries[0].Changes["merged_into"].After != "ALFKI" {
+		t.Fatalf("audit = %+v", entries)
+	}
+
+	_, err = svc.Customers.Merge(ctx, "ALFKI", models.CustomerMergeRequest{DuplicateIDs: []string{"ANATR", "NOPE1"}})
+	if !errors.Is(err, apperr.ErrValidation) {
+		t.Fatalf("merge unknown duplicate: err = %v", err)
+	}
+	if _, err := store.Customers.GetCustomerByID(ctx, "ANATR"); err != nil {
+		t.Fatalf("failed merge deleted ANATR: %v", err)
+	}
+}
+
+func TestCustomerIDStrategies(t *testing.T) {
+	ctx := context.Background()
+	deleted := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	create := func(strategy services.CustomerIDStrategy, c models.Customer) (string, error) {
+		svc, store, _ := newServices(t)
+		store.Customers.Seed(
+			models.Customer{CustomerID: "ANATR", CompanyName: "Ana Trujillo Emparedados y helados"},
+			models.Customer{CustomerID: "OLDCO", CompanyName: "Old Company", DeletedAt: &deleted},
+		)
+		svc.Customers.IDStrategy = strategy
+		err := svc.Customers.Create(ctx, &c)
+		return c.CustomerID, err
+	}
+
+	tests := []struct {
+		name     string
+		strategy services.CustomerIDStrategy
+		customer models.Customer
+		want     string
+		wantErr  error
+	}{
+		{"classic", "", models.Customer{CompanyName: "Alfreds Futterkiste"}, "ALFFU", nil},
+		{"classic accents", services.CustomerIDClassic, models.Customer{CompanyName: "Königlich Essen"}, "KONES", nil},
+		{"classic collision", services.CustomerIDClassic, models.Customer{CompanyName: "Ana Trujillo Helados"}, "ANATH", nil},
+		{"classic ignores client id", services.CustomerIDClassic, models.Customer{CustomerID: "ZZZZZ", CompanyName: "Old Company"}, "OLDCA", nil},
+		{"client", services.CustomerIDClient, models.Customer{CustomerID: "ALFKI", CompanyName: "Alfreds Futterkiste"}, "ALFKI", nil},
+		{"client missing id", services.CustomerIDClient, models.Customer{CompanyName: "Alfreds Futterkiste"}, "", apperr.ErrValidation},
+		{"client lowercase id", services.CustomerIDClient, models.Customer{CustomerID: "alfki", CompanyName: "Alfreds Futterkiste"}, "", apperr.ErrValidation},
+		{"client id of deleted customer", services.CustomerIDClient, models.Customer{CustomerID: "OLDCO", CompanyName: "Old Company"}, "", apperr.ErrConflict},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			id, err := create(tc.strategy, tc.customer)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil || id != tc.want {
+				t.Fatalf("id = %q, err = %v; want %q", id, err, tc.want)
+			}
+		})
+	}
+
+	id, err := create(services.CustomerIDUUID, models.Customer{CompanyName: "Alfreds Futterkiste"})
+	if err != nil || len(id) != 36 {
+		t.Fatalf("uuid: id = %q, err = %v", id, err)
+	}
+	if _, err := services.ParseCustomerIDStrategy("sequential"); err == nil {
+		t.Fatal("unknown strategy accepted")
+	}
+}
+
+func TestCustomerEraseRedactsHistory(t *testing.T) {
+	svc, store, events := newServices(t)
+	ctx := context.Background()
+	if err := store.Webhooks.CreateWebhook(ctx, &models.Webhook{URL: "https://example.com/hook", Events: []string{"*"}, Active: true}); err != nil {
+		t.Fatal(err)
+	}
+	c := models.Customer{CompanyName: "Alfreds Futterkiste", ContactName: "Maria Anders", Address: "Obere Str. 57", City: "Berlin", Phone: "030-0074321"}
+	if err := svc.Customers.Create(ctx, &c); err != nil {
+		t.Fatal(err)
+	}
+	shipName, shipAddress, freight := "Maria Anders", "Obere Str. 57", 29.46
+	o := models.Order{CustomerID: &c.CustomerID, ShipName: &shipName, ShipAddress: &shipAddress, Freight: &freight}
+	if err := svc.Orders.Create(ctx, &o); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.Customers.Delete(ctx, c.CustomerID); err != nil {
+		t.Fatal(err)
+	}
+
+	export, err := svc.Customers.ExportData(ctx, c.CustomerID)
+	if err != nil {
+		t.Fatalf("ExportData: %v", err)
+	}
+	if export.Customer.Phone != c.Phone || len(export.Orders) != 1 || len(export.History) != 3 {
+		t.Fatalf("export = %+v", export)
+	}
+
+	res, err := svc.Customers.Erase(ctx, c.CustomerID)
+	if err != nil {
+		t.Fatalf("Erase: %v", err)
+	}
+	got, _ := store.Customers.GetCustomerByID(repositories.WithDeleted(ctx), c.CustomerID)
+	if got.ContactName != res.Pseudonym || !strings.HasPrefix(got.ContactName, "anon-") || got.Phone != "" || got.City != "Berlin" || got.DeletedAt == nil {
+		t.Fatalf("customer = %+v", got)
+	}
+	order, _ := store.Orders.GetOrderByID(ctx, int(o.OrderID))
+	if *order.ShipName != res.Pseudonym || order.ShipAddress != nil || *order.Freight != freight {
+		t.Fatalf("order = %+v", order)
+	}
+	if last := (*events)[len(*events)-1]; last.Type != "order.erased" || last.Before != nil {
+		t.Fatalf("last event = %+v", last)
+	}
+
+	audit, _ := store.Audit.ListAudit(ctx, repositories.AuditFilter{})
+	changes, _ := store.EventLog.ListEvents(ctx, repositories.EventFilter{Limit: 100})
+	deliveries, _ := store.Webhooks.ListDeliveries(ctx, repositories.DeliveryFilter{Limit: 100})
+	if len(changes) == 0 || len(deliveries) == 0 {
+		t.Fatalf("events = %d, deliveries = %d", len(changes), len(deliveries))
+	}
+	raw, _ := json.Marshal([]any{audit, changes, deliveries})
+	for _, secret := range []string{"Maria Anders", "Obere Str. 57", "030-0074321"} {
+		if strings.Contains(string(raw), secret) {
+			t.Errorf("%q still in history", secret)
+		}
+	}
+	if !strings.Contains(string(raw), repositories.Erased) || !strings.Contains(string(raw), "Alfreds Futterkiste") {
+		t.Errorf("history lost more than the personal fields: %s", raw)
+	}
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/etag"
+	"northwind-api/internal/models"
+	"northwind-api/internal/patch"
+	"northwind-api/internal/repositories"
+	"northwind-api/internal/validation"
+	"strconv"
+)
+
+type ShipperService struct {
+	base
+}
+
+func (s *ShipperService) List(ctx context.Context) ([]models.Shipper, error) {
+	return s.read(ctx).Shippers.GetAllShippers(ctx)
+}
+
+func (s *ShipperService) Get(ctx context.Context, id int) (models.Shipper, error) {
+	return s.read(ctx).Shippers.GetShipperByID(ctx, id)
+}
+
+func (s *ShipperService) Create(ctx context.Context, shipper *models.Shipper) error {
+	shipper.DeletedAt = nil
+	if err := validateShipper(shipper); err != nil {
+		return err
+	}
+	return s.write(ctx, func(ctx context.Context, r repositories.Repositories) error {
+		id, err := r.Shippers.CreateShipper(ctx, *shipper)
+		if err != nil {
+			return err
+		}
+		shipper.ShipperID = int(id)
+		emit(ctx, newEvent("shipper", "shippers", strconv.Itoa(shipper.ShipperID), ActionCreated, nil, *shipper))
+		return nil
+	})
+}
+
+func (s *ShipperService) Update(ctx context.Context, shipper *models.Shipper) error {
+	shipper.DeletedAt = nil
+	if err := validateShipper(shipper); err != nil {
+		return err
+	}
+	return s.write(ctx, func(ctx context.Context, r repositories.Repositories) error {
+		before, err := r.Shippers.GetShipperByID(ctx, shipper.ShipperID)
+		if err != nil {
+			return err
+		}
+		if err := etag.Check(ctx, before); err != nil {
+			return err
+		}
+		if err := r.Shippers.UpdateShipper(ctx, shipper); err != nil {
+			return err
+		}
+		emit(ctx, newEvent("shipper", "shippers", strconv.Itoa(shipper.ShipperID), ActionUpdated, before, *shipper))
+		return nil
+	})
+}
+
+// Patch menerapkan merge patch / JSON Patch ke shipper dan hanya menulis kolom yang disentuh.
+func (s *ShipperService) Patch(ctx context.Context, id int, p patch.Patch) (models.Shipper, error) {
+	var after models.Shipper
+	err := s.write(ctx, func(ctx context.Context, r repositories.Repositories) error {
+		before, err := r.Shippers.GetShipperByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := etag.Check(ctx, before); err != nil {
+			return err
+		}
+		fields, err := p.Apply(before, &after, "shipper_id", "deleted_at")
+		if err != nil {
+			return err
+		}
+		after.ShipperID = id
+		if err := validateShipper(&after); err != nil {
+			return err
+		}
+		if len(fields) == 0 {
+			return nil
+		}
+		if err := r.Shippers.PatchShipper(ctx, id, repositories.Changes(patch.Columns(&after, fields))); err != nil {
+			return err
+		}
+		emit(ctx, newEvent("shipper", "shippers", strconv.Itoa(id), ActionUpdated, before, after))
+		return nil
+	})
+	return after, err
+}
+
+func (s *ShipperService) Delete(ctx context.Context, id int) error {
+	return s.write(ctx, func(ctx context.Context, r repositories.Repositories) error {
+		before, err := r.Shippers.GetShipperByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := etag.Check(ctx, before); err != nil {
+			return err
+		}
+		if err := r.Shippers.DeleteShipper(ctx, id); err != nil {
+			return err
+		}
+		emit(ctx, newEvent("shipper", "shippers", strconv.Itoa(id), ActionDeleted, before, nil))
+		return nil
+	})
+}
+
+// Restore mengembalikan shipper yang sudah di-soft delete.
+func (s *ShipperService) Restore(ctx context.Context, id int) (models.Shipper, error) {
+	var after models.Shipper
+	err := s.write(ctx, func(ctx context.Context, r repositories.Repositories) error {
+		before, err := r.Shippers.GetShipperByID(repositories.WithDeleted(ctx), id)
+		if err != nil {
+			return err
+		}
+		if before.DeletedAt == nil {
+			return apperr.Conflict("shipper is not deleted")
+		}
+		if err := r.Shippers.RestoreShipper(ctx, id); err != nil {
+			return err
+		}
+		after = before
+		after.DeletedAt = nil
+		emit(ctx, newEvent("shipper", "shippers", strconv.Itoa(id), ActionRestored, before, after))
+		return nil
+	})
+	return after, err
+}
+
+func validateShipper(s *models.Shipper) error {
+	return validation.Struct(s)
+}
@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"northwind-api/internal/models"
+	"northwind-api/internal/repositories"
+)
+
+// streamBatch membatasi jumlah event yang dibaca dari event log per putaran.
+const streamBatch = 100
+
+// StreamService membagikan event log ke client stream (SSE/WebSocket).
+// Subscriber dibangunkan oleh dispatcher setelah commit; sumber datanya tetap
+// event log, jadi client yang resume dengan Last-Event-ID tidak kehilangan event.
+type StreamService struct {
+	base
+
+	mu   sync.Mutex
+	subs map[*Subscription]struct{}
+}
+
+func newStreamService(b base) *StreamService {
+	s := &StreamService{base: b, subs: map[*Subscription]struct{}{}}
+	b.events.Subscribe(s.notify)
+	return s
+}
+
+// Enabled false kalau repositories tidak punya event log.
+func (s *StreamService) Enabled() bool {
+	return s.repos.EventLog != nil
+}
+
+// Subscribe membuka subscription untuk resources (kosong = semua resource yang
+// diaudit). lastEventID kosong berarti mulai dari event berikutnya; selain itu
+// event setelah ID tersebut dikirim ulang lebih dulu. Subscription wajib di-Close.
+func (s *StreamService) Subscribe(ctx context.Context, resources []string, lastEventID string) (*Subscription, error) {
+	var c checks
+	for _, r := range resources {
+		if !slices.Contains(AuditEntities, r) {
+			c.fail("resources", "must be a comma-separated list of: "+strings.Join(AuditEntities, ", "))
+			break
+		}
+	}
+	var after int64
+	if lastEventID != "" {
+		id, err := strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil || id < 0 {
+			c.fail("last_event_id", "must be a non-negative event ID")
+		}
+		after = id
+	}
+	if err := c.result(); err != nil {
+		return nil, err
+	}
+	if !s.Enabled() {
+		return nil, fmt.Errorf("event log is not configured")
+	}
+	if lastEventID == "" {
+		latest, err := s.repos.EventLog.LatestEventID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		after = latest
+	}
+
+	sub := &Subscription{
+		store:    s.repos.EventLog,
+		entities: resources,
+		lastID:   after,
+		wake:     make(chan struct{}, 1),
+	}
+	s.mu.Lock()
+	s.subs[sub] = struct{}{}
+	s.mu.Unlock()
+	sub.close = func() {
+		s.mu.Lock()
+		delete(s.subs, sub)
+		s.mu.Unlock()
+	}
+	return sub, nil
+}
+
+// notify membangunkan subscriber yang tertarik pada resource event ini.
+func (s *StreamService) notify(_ context.Context, e Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for sub := range s.subs {
+		if sub.wants(e.Entity) {
+			select {
+			case sub.wake <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+// Subscription adalah posisi baca satu client di event log.
+type Subscription struct {
+	store    repositories.EventLogStore
+	entities []string
+	lastID   int64
+	wake     chan struct{}
+	close    func()
+}
+
+func (sub *Subscription) wants(entity string) bool {
+	return len(sub.entities) == 0 || slices.Contains(sub.entities, entity)
+}
+
+// Next mengembalikan event berikutnya. Kalau belum ada, menunggu sampai ada
+// perubahan, ctx selesai, atau wait habis; hasil kosong tanpa error berarti
+// waktunya kirim heartbeat. Event yang ditulis proses lain ikut terbaca saat wait habis.
+func (sub *Subscription) Next(ctx context.Context, wait time.Duration) ([]models.ChangeEvent, error) {
+	events, err := sub.fetch(ctx)
+	if err != nil || len(events) > 0 {
+		return events, err
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-sub.wake:
+	case <-timer.C:
+	}
+	return sub.fetch(ctx)
+}
+
+func (sub *Subscription) fetch(ctx context.Context) ([]models.ChangeEvent, error) {
+	events, err := sub.store.ListEvents(ctx, repositories.EventFilter{
+		AfterID:  sub.lastID,
+		Entities: sub.entities,
+		Limit:    streamBatch,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if n := len(events); n > 0 {
+		sub.lastID = events[n-1].EventID
+	}
+	return events, nil
+}
+
+func (sub *Subscription) Close() {
+	sub.close()
+}
+
+// recordEvents menulis event ke event log di transaksi yang sedang berjalan.
+func recordEvents(ctx context.Context, r repositories.Repositories, events []Event) error {
+	if r.EventLog == nil || len(events) == 0 {
+		return nil
+	}
+	entries := make([]models.ChangeEvent, 0, len(events))
+	for _, e := range events {
+		before, err := snapshot(e.Before)
+		if err != nil {
+			return err
+		}
+		after, err := snapshot(e.After)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, models.ChangeEvent{
+			Type:       e.Type,
+			Entity:     e.Entity,
+			EntityID:   e.EntityID,
+			Action:     e.Action,
+			Before:     before,
+			After:      after,
+			OccurredAt: e.OccurredAt,
+		})
+	}
+	return r.EventLog.AppendEvents(ctx, entries...)
+}
+
+func snapshot(v any) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding event snapshot: %w", err)
+	}
+	return raw, nil
+}
@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/etag"
+	"northwind-api/internal/models"
+	"northwind-api/internal/patch"
+	"northwind-api/internal/repositories"
+	"northwind-api/internal/validation"
+	"strconv"
+)
+
+type SupplierService struct {
+	base
+}
+
+func (s *SupplierService) List(ctx context.Context) ([]models.Supplier, error) {
+	return s.read(ctx).Suppliers.GetAllSuppliers(ctx)
+}
+
+// Search mengembalikan suppliers yang cocok dengan q (lihat SearchService),
+// terurut dari yang paling relevan.
+func (s *SupplierService) Search(ctx context.Context, q string) ([]models.Supplier, error) {
+	r := s.read(ctx)
+	ids, err := searchIDs(ctx, r, "suppliers", q)
+	if err != nil || len(ids) == 0 {
+		return []models.Supplier{}, err
+	}
+	nums := intIDs(ids)
+	rows, err := r.Suppliers.GetSuppliersByIDs(ctx, nums)
+	if err != nil {
+		return nil, err
+	}
+	return rankOrder(ids, rows, func(sup models.Supplier) string { return strconv.FormatInt(sup.SupplierID, 10) }), nil
+}
+
+func (s *SupplierService) Get(ctx context.Context, id int) (models.Supplier, error) {
+	return s.read(ctx).Suppliers.GetSupplierByID(ctx, id)
+}
+
+func (s *SupplierService) Create(ctx context.Context, sup *models.Supplier) error {
+	sup.DeletedAt = nil
+	if err := validateSupplier(sup); err != nil {
+		return err
+	}
+	return s.write(ctx, func(ctx context.Context, r repositories.Repositories) error {
+		if err := r.Suppliers.CreateSupplier(ctx, sup); err != nil {
+			return err
+		}
+		emit(ctx, newEvent("supplier", "suppliers", strconv.FormatInt(sup.SupplierID, 10), ActionCreated, nil, *sup))
+		return nil
+	})
+}
+
+func (s *SupplierService) Update(ctx context.Context, sup *models.Supplier) error {
+	sup.DeletedAt = nil
+	if err := validateSupplier(sup); err != nil {
+		return err
+	}
+	return s.write(ctx, func(ctx context.Context, r repositories.Repositories) error {
+		before, err := r.Suppliers.GetSupplierByID(ctx, int(sup.SupplierID))
+		if err != nil {
+			return err
+		}
+		if err := etag.Check(ctx, before); err != nil {
+			return err
+		}
+		if err := r.Suppliers.UpdateSupplier(ctx, sup); err != nil {
+			return err
+		}
+		emit(ctx, newEvent("supplier", "suppliers", strconv.FormatInt(sup.SupplierID, 10), ActionUpdated, before, *sup))
+		return nil
+	})
+}
+
+// Patch menerapkan merge patch / JSON Patch ke supplier dan hanya menulis kolom yang disentuh.
+func (s *SupplierService) Patch(ctx context.Context, id int, p patch.Patch) (models.Supplier, error) {
+	var after models.Supplier
+	err := s.write(ctx, func(ctx context.Context, r repositories.Repositories) error {
+		before, err := r.Suppliers.GetSupplierByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := etag.Check(ctx, before); err != nil {
+			return err
+		}
+		fields, err := p.Apply(before, &after, "supplier_id", "deleted_at")
+		if err != nil {
+			return err
+		}
+		after.SupplierID = int64(id)
+		if err := validateSupplier(&after); err != nil {
+			return err
+		}
+		if len(fields) == 0 {
+			return nil
+		}
+		if err := r.Suppliers.PatchSupplier(ctx, id, repositories.Changes(patch.Columns(&after, fields))); err != nil {
+			return err
+		}
+		emit(ctx, newEvent("supplier", "suppliers", strconv.Itoa(id), ActionUpdated, before, after))
+		return nil
+	})
+	return after, err
+}
+
+// Delete men-soft delete supplier. Product live dari supplier ini menghalangi
+// delete kecuali opts meminta cascade atau reassign.
+func (s *SupplierService) Delete(ctx context.Context, id int, opts DeleteOptions) error {
+	if err := opts.check(id); err != nil {
+		return err
+	}
+	return s.write(ctx, func(ctx context.Context, r repositories.Repositories) error {
+		before, err := r.Suppliers.GetSupplierByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := etag.Check(ctx, before); err != nil {
+			return err
+		}
+		if opts.ReassignTo != 0 {
+			_, err := r.Suppliers.GetSupplierByID(ctx, opts.ReassignTo)
+			var c checks
+			c.ref("reassign_to", "supplier", err)
+			if err := c.result(); err != nil {
+				return err
+			}
+		}
+		products, err := r.Products.GetProductIDsBySupplierID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := resolveProducts(ctx, r, "supplier", id, opts, products, "supplier_id"); err != nil {
+			return err
+		}
+		if err := r.Suppliers.DeleteSupplier(ctx, id); err != nil {
+			return err
+		}
+		emit(ctx, newEvent("supplier", "suppliers", strconv.Itoa(id), ActionDeleted, before, nil))
+		return nil
+	})
+}
+
+// Restore mengembalikan supplier yang sudah di-soft delete.
+func (s *SupplierService) Restore(ctx context.Context, id int) (models.Supplier, error) {
+	var after models.Supplier
+	err := s.write(ctx, func(ctx context.Context, r repositories.Repositories) error {
+		before, err := r.Suppliers.GetSupplierByID(repositories.WithDeleted(ctx), id)
+		if err != nil {
+			return err
+		}
+		if before.DeletedAt == nil {
+			return apperr.Conflict("supplier is not deleted")
+		}
+		if err := r.Suppliers.RestoreSupplier(ctx, id); err != nil {
+			return err
+		}
+		after = before
+		after.DeletedAt = nil
+		emit(ctx, newEvent("supplier", "suppliers", strconv.Itoa(id), ActionRestored, before, after))
+		return nil
+	})
+	return after, err
+}
+
+func validateSupplier(s *models.Supplier) error {
+	return validation.Struct(s)
+}
+
+// Check menjalankan validasi Create tanpa menyimpan; dipakai dry-run import.
+func (s *SupplierService) Check(ctx context.Context, sup *models.Supplier) error {
+	return validateSupplier(sup)
+}
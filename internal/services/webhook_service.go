@@ -0,0 +1,565 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"northwind-api/internal/etag"
+	"northwind-api/internal/models"
+	"northwind-api/internal/repositories"
+	"northwind-api/internal/requestctx"
+	"northwind-api/internal/visibility"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Event turunan yang hanya dikirim lewat webhook, di samping "<kind>.<action>".
+const (
+	EventOrderShipped    = "order.shipped"     // ShippedDate order baru terisi
+	EventProductLowStock = "product.low_stock" // UnitsInStock turun sampai ReorderLevel
+)
+
+// WebhookEvents adalah tipe event yang bisa di-subscribe; "*" berarti semua.
+var WebhookEvents = webhookEventTypes()
+
+func webhookEventTypes() []string {
+	var types []string
+	for _, kind := range []string{"customer", "employee", "shipper", "product", "category", "supplier", "order"} {
+		for _, action := range []string{ActionCreated, ActionUpdated, ActionDeleted, ActionRestored} {
+			types = append(types, kind+"."+action)
+		}
+	}
+	return append(types, "customer."+ActionMerged, "customer."+ActionErased, "employee."+ActionErased, "order."+ActionErased,
+		"order."+ActionDetailRemoved, "order."+ActionDetailAdded,
+		"employee."+ActionPhotoUpdated, "category."+ActionPictureUpdated,
+		EventOrderShipped, EventProductLowStock)
+}
+
+const (
+	webhookBatch         = 50
+	defaultDeliveryLimit = 100
+	maxDeliveryLimit     = 1000
+	maxWebhookResponse   = 64 << 10
+)
+
+// WebhookOptions mengatur worker pengiriman. Field nol memakai default.
+type WebhookOptions struct {
+	// MaxAttempts sebelum delivery masuk dead-letter (default 10).
+	MaxAttempts int
+	// BaseDelay jeda retry pertama; retry ke-n menunggu BaseDelay * 2^(n-1) (default 30s).
+	BaseDelay time.Duration
+	// MaxDelay batas atas jeda retry (default 1h).
+	MaxDelay time.Duration
+	// PollInterval jeda cek outbox kalau tidak ada event baru (default 5s).
+	PollInterval time.Duration
+	// Client untuk request ke receiver (default timeout 10s, redirect tidak
+	// diikuti, alamat internal ditolak; lihat guardDial).
+	Client *http.Client
+	// AllowPrivate mengizinkan client default mengirim ke alamat loopback dan
+	// jaringan privat, mis. receiver lokal saat development atau test.
+	AllowPrivate bool
+}
+
+func (o WebhookOptions) withDefaults() WebhookOptions {
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = 10
+	}
+	if o.BaseDelay <= 0 {
+		o.BaseDelay = 30 * time.Second
+	}
+	if o.MaxDelay <= 0 {
+		o.MaxDelay = time.Hour
+	}
+	if o.PollInterval <= 0 {
+		o.PollInterval = 5 * time.Second
+	}
+	if o.Client == nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		if !o.AllowPrivate {
+			// Tanpa proxy supaya yang dicek guardDial adalah alamat receiver sendiri.
+			transport.Proxy = nil
+			transport.DialContext = (&net.Dialer{Timeout: 10 * time.Second, Control: guardDial}).DialContext
+		}
+		o.Client = &http.Client{
+			Transport: transport,
+			Timeout:   10 * time.Second,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		}
+	}
+	return o
+}
+
+// blockedNets adalah rentang internal di luar yang sudah dikenali netip.Addr
+// (loopback, privat, link-local, dsb.).
+var blockedNets = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"), // shared address space (CGNAT)
+}
+
+// guardDial menolak koneksi webhook ke alamat loopback, link-local, privat dan
+// sejenisnya. Dipanggil net.Dialer setelah hostname di-resolve, jadi hostname
+// yang mengarah (atau berganti lewat DNS rebinding) ke alamat internal ikut
+// tertolak, bukan hanya URL berisi IP.
+func guardDial(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	ip = ip.Unmap()
+	blocked := ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() ||
+		slices.ContainsFunc(blockedNets, func(p netip.Prefix) bool { return p.Contains(ip) })
+	if blocked {
+		return fmt.Errorf("webhook target %s is not allowed: loopback, link-local and private addresses are blocked", ip)
+	}
+	return nil
+}
+
+// backoff mengembalikan jeda sebelum percobaan berikutnya setelah attempts kali gagal.
+func (o WebhookOptions) backoff(attempts int) time.Duration {
+	d := o.BaseDelay
+	for i := 1; i < attempts && d < o.MaxDelay; i++ {
+		d *= 2
+	}
+	return min(d, o.MaxDelay)
+}
+
+// WebhookService mengelola subscription webhook dan mengirim isi outbox.
+type WebhookService struct {
+	base
+	wake   chan struct{}
+	worker sync.WaitGroup
+}
+
+func newWebhookService(b base) *WebhookService {
+	s := &WebhookService{base: b, wake: make(chan struct{}, 1)}
+	b.events.Subscribe(func(context.Context, Event) { s.kick() })
+	return s
+}
+
+// Enabled false kalau repositories tidak punya store webhook.
+func (s *WebhookService) Enabled() bool {
+	return s.repos.Webhooks != nil
+}
+
+// kick membangunkan worker tanpa menunggu PollInterval.
+func (s *WebhookService) kick() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *WebhookService) List(ctx context.Context) ([]models.Webhook, error) {
+	webhooks, err := s.repos.Webhooks.ListWebhooks(ctx)
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+	return webhooks, err
+}
+
+func (s *WebhookService) Get(ctx context.Context, id int) (models.Webhook, error) {
+	w, err := s.repos.Webhooks.GetWebhook(ctx, id)
+	w.Secret = ""
+	return w, err
+}
+
+// Create menyimpan subscription baru. Response-nya satu-satunya tempat secret
+// terlihat; kalau tidak dikirim client, secret dibuatkan.
+func (s *WebhookService) Create(ctx context.Context, in models.WebhookInput) (models.Webhook, error) {
+	if err := validateWebhook(in); err != nil {
+		return models.Webhook{}, err
+	}
+	now := time.Now().UTC()
+	w := models.Webhook{
+		URL:       in.URL,
+		Events:    slices.Compact(slices.Sorted(slices.Values(in.Events))),
+		Secret:    in.Secret,
+		Active:    in.Active == nil || *in.Active,
+		Roles:     callerRoles(ctx),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if w.Secret == "" {
+		secret, err := newWebhookSecret()
+		if err != nil {
+			return models.Webhook{}, err
+		}
+		w.Secret = secret
+	}
+	if err := s.repos.Webhooks.CreateWebhook(ctx, &w); err != nil {
+		return models.Webhook{}, err
+	}
+	return w, nil
+}
+
+// Update mengganti URL, events dan status aktif. Secret kosong berarti tetap.
+func (s *WebhookService) Update(ctx context.Context, id int, in models.WebhookInput) (models.Webhook, error) {
+	if err := validateWebhook(in); err != nil {
+		return models.Webhook{}, err
+	}
+	w, err := s.repos.Webhooks.GetWebhook(ctx, id)
+	if err != nil {
+		return w, err
+	}
+	if err := checkWebhookVersion(ctx, w); err != nil {
+		return models.Webhook{}, err
+	}
+	w.URL = in.URL
+	w.Events = slices.Compact(slices.Sorted(slices.Values(in.Events)))
+	if in.Secret != "" {
+		w.Secret = in.Secret
+	}
+	if in.Active != nil {
+		w.Active = *in.Active
+	}
+	// Yang mengubah URL/events ikut menentukan apa yang boleh dikirim.
+	w.Roles = callerRoles(ctx)
+	w.UpdatedAt = time.Now().UTC()
+	if err := s.repos.Webhooks.UpdateWebhook(ctx, &w); err != nil {
+		return models.Webhook{}, err
+	}
+	s.kick()
+	w.Secret = ""
+	return w, nil
+}
+
+// Delete menghapus subscription beserta delivery dan log-nya.
+func (s *WebhookService) Delete(ctx context.Context, id int) error {
+	w, err := s.repos.Webhooks.GetWebhook(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := checkWebhookVersion(ctx, w); err != nil {
+		return err
+	}
+	return s.repos.Webhooks.DeleteWebhook(ctx, id)
+}
+
+// checkWebhookVersion mencocokkan If-Match dengan ETag yang dikirim GET,
+// yang dihitung tanpa secret.
+func checkWebhookVersion(ctx context.Context, w models.Webhook) error {
+	w.Secret = ""
+	return etag.Check(ctx, w)
+}
+
+// Deliveries mengembalikan delivery log terbaru lebih dulu; status "dead"
+// adalah dead-letter list.
+func (s *WebhookService) Deliveries(ctx context.Context, webhookID int, status string, limit int) ([]models.WebhookDelivery, error) {
+	var c checks
+	if status != "" && !slices.Contains([]string{models.DeliveryPending, models.DeliveryDelivered, models.DeliveryDead}, status) {
+		c.fail("status", "must be one of: pending, delivered, dead")
+	}
+	if limit < 0 || limit > maxDeliveryLimit {
+		c.fail("limit", "must be between 1 and 1000")
+	}
+	if webhookID != 0 {
+		_, err := s.repos.Webhooks.GetWebhook(ctx, webhookID)
+		c.ref("webhook_id", "webhook", err)
+	}
+	if err := c.result(); err != nil {
+		return nil, err
+	}
+	if limit == 0 {
+		limit = defaultDeliveryLimit
+	}
+	return s.repos.Webhooks.ListDeliveries(ctx, repositories.DeliveryFilter{WebhookID: webhookID, Status: status, Limit: limit})
+}
+
+// Delivery mengembalikan satu delivery beserta log semua percobaannya.
+func (s *WebhookService) Delivery(ctx context.Context, id int64) (models.WebhookDelivery, error) {
+	return s.repos.Webhooks.GetDelivery(ctx, id)
+}
+
+// Retry mengeluarkan delivery dari dead-letter list dan menjadwalkannya ulang
+// dengan jatah percobaan baru.
+func (s *WebhookService) Retry(ctx context.Context, id int64) (models.WebhookDelivery, error) {
+	if _, err := s.repos.Webhooks.GetDelivery(ctx, id); err != nil {
+		return models.WebhookDelivery{}, err
+	}
+	if err := s.repos.Webhooks.RequeueDelivery(ctx, id, time.Now().UTC()); err != nil {
+		return models.WebhookDelivery{}, err
+	}
+	s.kick()
+	return s.repos.Webhooks.GetDelivery(ctx, id)
+}
+
+func validateWebhook(in models.WebhookInput) error {
+	var c checks
+	if u, err := url.Parse(in.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		c.fail("url", "must be an absolute http or https URL")
+	}
+	for _, e := range in.Events {
+		if e != "*" && !slices.Contains(WebhookEvents, e) {
+			c.fail("events", fmt.Sprintf("unknown event type %q", e))
+		}
+	}
+	return c.result()
+}
+
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("error generating webhook secret: %w", err)
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+// SignWebhook menghitung nilai header X-Webhook-Signature: HMAC-SHA256 dari
+// "<timestamp>.<body>" dengan secret webhook, hex, diawali "sha256=".
+// Receiver menghitung ulang nilai ini untuk memverifikasi pengirim.
+func SignWebhook(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Start menjalankan Run di goroutine; Wait menunggunya selesai setelah ctx
+// dibatalkan, supaya percobaan yang sedang berjalan tercatat sebelum database
+// ditutup.
+func (s *WebhookService) Start(ctx context.Context, opts WebhookOptions) {
+	s.worker.Add(1)
+	go func() {
+		defer s.worker.Done()
+		s.Run(ctx, opts)
+	}()
+}
+
+func (s *WebhookService) Wait() {
+	s.worker.Wait()
+}
+
+// Run mengirim isi outbox sampai ctx selesai. Dibangunkan setiap ada event
+// yang di-commit, dan tetap polling supaya retry terjadwal dan delivery yang
+// tertinggal dari proses sebelumnya ikut terkirim.
+func (s *WebhookService) Run(ctx context.Context, opts WebhookOptions) {
+	opts = opts.withDefaults()
+	ticker := time.NewTicker(opts.PollInterval)
+	defer ticker.Stop()
+	for {
+		s.deliverDue(ctx, opts)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+	}
+}
+
+func (s *WebhookService) deliverDue(ctx context.Context, opts WebhookOptions) {
+	for ctx.Err() == nil {
+		due, err := s.repos.Webhooks.DueDeliveries(ctx, time.Now(), webhookBatch)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to load due webhook deliveries")
+			return
+		}
+		for _, d := range due {
+			s.deliver(ctx, opts, d)
+		}
+		if len(due) < webhookBatch {
+			return
+		}
+	}
+}
+
+// deliver melakukan satu percobaan kirim lalu mencatat hasilnya: delivered,
+// dijadwalkan ulang dengan exponential backoff, atau dead.
+func (s *WebhookService) deliver(ctx context.Context, opts WebhookOptions, d models.WebhookDelivery) {
+	w, err := s.repos.Webhooks.GetWebhook(ctx, d.WebhookID)
+	if err != nil {
+		log.Error().Err(err).Int64("delivery_id", d.DeliveryID).Msg("failed to load webhook")
+		return
+	}
+
+	start := time.Now()
+	status, err := post(ctx, opts.Client, w, d)
+	if ctx.Err() != nil {
+		// Shutdown di tengah request: biarkan pending, dikirim ulang setelah restart.
+		return
+	}
+	a := models.WebhookAttempt{AttemptedAt: start.UTC(), DurationMs: time.Since(start).Milliseconds()}
+	d.Attempts++
+	d.LastStatusCode = nil
+	if status != 0 {
+		a.StatusCode, d.LastStatusCode = &status, &status
+	}
+	now := time.Now().UTC()
+	switch {
+	case err == nil:
+		d.Status, d.NextAttemptAt, d.DeliveredAt, d.LastError = models.DeliveryDelivered, nil, &now, ""
+	case d.Attempts >= opts.MaxAttempts:
+		a.Error, d.LastError = err.Error(), err.Error()
+		d.Status, d.NextAttemptAt = models.DeliveryDead, nil
+		log.Warn().Err(err).Int64("delivery_id", d.DeliveryID).Int("webhook_id", w.WebhookID).Msg("webhook delivery moved to dead-letter")
+	default:
+		a.Error, d.LastError = err.Error(), err.Error()
+		next := now.Add(opts.backoff(d.Attempts))
+		d.NextAttemptAt = &next
+	}
+
+	if err := s.tx.WithTx(ctx, func(ctx context.Context, r repositories.Repositories) error {
+		return r.Webhooks.RecordAttempt(ctx, d, a)
+	}); err != nil {
+		log.Error().Err(err).Int64("delivery_id", d.DeliveryID).Msg("failed to record webhook attempt")
+	}
+}
+
+// post mengirim payload ke receiver. Status 2xx berarti sukses; selain itu
+// (termasuk redirect) dihitung gagal.
+func post(ctx context.Context, client *http.Client, w models.Webhook, d models.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, err
+	}
+	ts := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "northwind-api-webhooks")
+	req.Header.Set("X-Webhook-ID", strconv.FormatInt(d.DeliveryID, 10))
+	req.Header.Set("X-Webhook-Event", d.EventType)
+	req.Header.Set("X-Webhook-Timestamp", strconv.FormatInt(ts, 10))
+	req.Header.Set("X-Webhook-Signature", SignWebhook(w.Secret, ts, d.Payload))
+
+	res, err := client.Do(req)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return 0, err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, maxWebhookResponse))
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return res.StatusCode, fmt.Errorf("receiver returned %d", res.StatusCode)
+	}
+	return res.StatusCode, nil
+}
+
+// enqueueWebhooks menulis satu delivery per (event, webhook yang subscribe)
+// ke outbox di transaksi yang sedang berjalan.
+func enqueueWebhooks(ctx context.Context, r repositories.Repositories, events []Event) error {
+	if r.Webhooks == nil || len(events) == 0 {
+		return nil
+	}
+	webhooks, err := r.Webhooks.ListWebhooks(ctx)
+	if err != nil || len(webhooks) == 0 {
+		return err
+	}
+	now := time.Now().UTC()
+	var deliveries []models.WebhookDelivery
+	for _, e := range events {
+		for _, typ := range webhookTypes(e) {
+			ev := e
+			ev.Type = typ
+			// Payload dibentuk dengan role subscriber-nya; subscriber dengan
+			// role yang sama memakai payload yang sama.
+			payloads := map[string][]byte{}
+			for _, w := range webhooks {
+				if !w.Active || !(slices.Contains(w.Events, typ) || slices.Contains(w.Events, "*")) {
+					continue
+				}
+				key := rolesKey(w.Roles)
+				payload, ok := payloads[key]
+				if !ok {
+					if payload, err = visibility.Marshal(subscriberContext(w), ev); err != nil {
+						return fmt.Errorf("error encoding webhook payload: %w", err)
+					}
+					payloads[key] = payload
+				}
+				deliveries = append(deliveries, models.WebhookDelivery{
+					WebhookID:     w.WebhookID,
+					EventType:     typ,
+					Payload:       payload,
+					Status:        models.DeliveryPending,
+					NextAttemptAt: &now,
+					CreatedAt:     now,
+				})
+			}
+		}
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return r.Webhooks.EnqueueDeliveries(ctx, deliveries...)
+}
+
+// callerRoles mengembalikan role caller untuk disimpan di webhook; nil kalau
+// ctx tidak membawa identitas.
+func callerRoles(ctx context.Context) []string {
+	roles, ok := requestctx.Roles(ctx)
+	if !ok {
+		return nil
+	}
+	roles = slices.Clone(roles)
+	slices.Sort(roles)
+	return roles
+}
+
+// subscriberContext adalah ctx dengan role webhook, dipakai untuk memutuskan
+// field mana yang ikut di payload-nya.
+func subscriberContext(w models.Webhook) context.Context {
+	if w.Roles == nil {
+		return context.Background()
+	}
+	return requestctx.WithRoles(context.Background(), w.Roles)
+}
+
+func rolesKey(roles []string) string {
+	if roles == nil {
+		return "*"
+	}
+	return "[" + strings.Join(roles, ",") + "]"
+}
+
+// webhookTypes mengembalikan tipe event e beserta event turunannya.
+func webhookTypes(e Event) []string {
+	types := []string{e.Type}
+	switch after := e.After.(type) {
+	case models.Order:
+		before, ok := e.Before.(models.Order)
+		if shipped(after) && (!ok || !shipped(before)) {
+			types = append(types, EventOrderShipped)
+		}
+	case models.Product:
+		before, ok := e.Before.(models.Product)
+		if lowStock(after) && (!ok || !lowStock(before)) {
+			types = append(types, EventProductLowStock)
+		}
+	}
+	return types
+}
+
+func shipped(o models.Order) bool {
+	return o.ShippedDate != nil && strings.TrimSpace(*o.ShippedDate) != ""
+}
+
+// lowStock: stok sudah di bawah atau sama dengan ReorderLevel untuk produk yang
+// masih dijual. ReorderLevel 0 berarti produk tidak dipantau stoknya.
+func lowStock(p models.Product) bool {
+	return !p.Discontinued && p.DeletedAt == nil && p.ReorderLevel > 0 && p.UnitsInStock <= p.ReorderLevel
+}
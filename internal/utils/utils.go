@@ -2,7 +2,9 @@ package utils
 
 import (
 	"fmt"
-	"net/http"
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/requestctx"
+	"strings"
 	"time"
 
 	"os"
@@ -17,12 +19,16 @@ import (
 	"encoding/base64"
 )
 
-// GenerateJWT generates a JWT token for a username
-func GenerateJWT(username string) (string, error) {
+// GenerateJWT generates a JWT token for a username, with optional roles
+// (see visibility.RoleHR etc.).
+func GenerateJWT(username string, roles ...string) (string, error) {
 	claims := jwt.MapClaims{
 		"username": username,
 		"exp":      time.Now().Add(time.Hour * 24).Unix(),
 	}
+	if len(roles) > 0 {
+		claims["roles"] = roles
+	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString([]byte(getJWTSecret()))
 }
@@ -30,35 +36,62 @@ func GenerateJWT(username string) (string, error) {
 // AuthMiddlewareJWT checks for a valid JWT token in the Authorization header.
 func AuthMiddlewareJWT() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		var tokenString string
-
-		// Accept both "Bearer <token>" and "<token>"
-		if len(authHeader) > 7 && authHeader[:7] == "Bearer " {
-			tokenString = authHeader[7:]
-		} else {
-			tokenString = authHeader
+		actor, roles, err := Authenticate(c.GetHeader("Authorization"))
+		if err != nil {
+			_ = c.Error(err)
+			c.Abort()
+			return
 		}
+		ctx := requestctx.WithRoles(requestctx.WithActor(c.Request.Context(), actor), roles)
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
 
-		if tokenString == "" {
-			c.Error(fmt.Errorf("missing token"))
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing token"})
-			return
+// Authenticate memvalidasi nilai header Authorization ("Bearer <token>" atau
+// "<token>") dan mengembalikan actor untuk audit log serta role caller (claim
+// "roles" berupa array, atau "role" berupa string). Dipakai bersama oleh
+// middleware HTTP dan interceptor gRPC.
+func Authenticate(authHeader string) (actor string, roles []string, err error) {
+	tokenString := authHeader
+	// Accept both "Bearer <token>" and "<token>"
+	if len(authHeader) > 7 && authHeader[:7] == "Bearer " {
+		tokenString = authHeader[7:]
+	}
+	if tokenString == "" {
+		return "", nil, apperr.Unauthorized("missing token")
+	}
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (any, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method")
+		}
+		return []byte(getJWTSecret()), nil
+	})
+	if err != nil || !token.Valid {
+		log.Error().Err(err).Msg("Invalid token")
+		return "", nil, apperr.Unauthorized("invalid token")
+	}
+	// actor untuk audit log: username dari GenerateJWT, atau sub dari issuer lain
+	if claims, ok := token.Claims.(jwt.MapClaims); ok {
+		actor, _ = claims["username"].(string)
+		if actor == "" {
+			actor, _ = claims.GetSubject()
 		}
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (any, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method")
+		switch v := claims["roles"].(type) {
+		case []any:
+			for _, r := range v {
+				if r, ok := r.(string); ok {
+					roles = append(roles, r)
+				}
 			}
-			return []byte(getJWTSecret()), nil
-		})
-		if err != nil || !token.Valid {
-			log.Error().Err(err).Msg("Invalid token")
-			c.Error(fmt.Errorf("invalid token"))
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
-			return
+		case string:
+			roles = strings.Fields(v)
+		}
+		if role, ok := claims["role"].(string); ok && role != "" {
+			roles = append(roles, role)
 		}
-		c.Next()
 	}
+	return actor, roles, nil
 }
 
 func ParseInt(s string) int {
@@ -0,0 +1,30 @@
+package validation
+
+import (
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// countryNames berisi nama negara yang dipakai di data Northwind (termasuk
+// singkatan lama seperti "USA" dan "UK"), supaya data existing tetap valid.
+var countryNames = map[string]bool{
+	"argentina": true, "australia": true, "austria": true, "belgium": true,
+	"brazil": true, "canada": true, "denmark": true, "finland": true,
+	"france": true, "germany": true, "ireland": true, "italy": true,
+	"japan": true, "mexico": true, "netherlands": true, "norway": true,
+	"poland": true, "portugal": true, "singapore": true, "spain": true,
+	"sweden": true, "switzerland": true, "uk": true, "usa": true,
+	"venezuela": true,
+}
+
+// codes hanya dipakai untuk tabel kode ISO bawaan validator.
+var codes = validator.New()
+
+// IsCountry menerima kode ISO 3166-1 alpha-2/alpha-3 atau nama negara yang dikenal.
+func IsCountry(s string) bool {
+	if countryNames[strings.ToLower(strings.TrimSpace(s))] {
+		return true
+	}
+	return codes.Var(s, "iso3166_1_alpha2|iso3166_1_alpha3") == nil
+}
@@ -0,0 +1,51 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+
+	"northwind-api/internal/apperr"
+)
+
+// DecodeJSON mem-parse data ke v lalu menjalankan aturan binding, dengan
+// error yang sama seperti binding body request di handlers.
+func DecodeJSON(data []byte, v any) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return JSONError(err)
+	}
+	return Struct(v)
+}
+
+// JSONError mengubah error decoding JSON menjadi validation error; salah tipe
+// dilaporkan per field.
+func JSONError(err error) *apperr.Error {
+	if e, ok := apperr.As(err); ok {
+		return e
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return apperr.Validation(typeErr.Field, "must be %s", jsonKind(typeErr.Type.Kind())).Wrap(err)
+	}
+	return apperr.Validation("", "malformed JSON body").Wrap(err)
+}
+
+func jsonKind(k reflect.Kind) string {
+	switch k {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	case reflect.Struct, reflect.Map:
+		return "an object"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	}
+	return "a valid " + k.String()
+}
@@ -0,0 +1,164 @@
+// Package validation menjalankan aturan deklaratif (tag `binding`) pada model
+// dan mengubah hasilnya menjadi apperr validation error. Dipakai oleh binding
+// gin di handlers dan oleh services, jadi semua jalur tulis memakai aturan yang sama.
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"northwind-api/internal/apperr"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.SetTagName("binding")
+	v.RegisterTagNameFunc(jsonFieldName)
+	_ = v.RegisterValidation("notblank", func(fl validator.FieldLevel) bool {
+		return strings.TrimSpace(fl.Field().String()) != ""
+	})
+	_ = v.RegisterValidation("isodate", func(fl validator.FieldLevel) bool {
+		_, err := ParseDate(fl.Field().String())
+		return err == nil
+	})
+	_ = v.RegisterValidation("country", func(fl validator.FieldLevel) bool {
+		return IsCountry(fl.Field().String())
+	})
+	return v
+}
+
+// Engine mengembalikan validator yang dipakai, untuk adapter binding gin.
+func Engine() *validator.Validate {
+	return validate
+}
+
+func jsonFieldName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return f.Name
+	}
+	return name
+}
+
+// Struct memvalidasi struct, pointer ke struct, atau slice of struct. Hasilnya
+// nil atau *apperr.Error berisi semua field yang tidak valid.
+func Struct(obj any) error {
+	fields, err := collect(reflect.ValueOf(obj), "")
+	if err != nil {
+		return err
+	}
+	if len(fields) > 0 {
+		return apperr.Invalid(fields...)
+	}
+	return nil
+}
+
+func collect(v reflect.Value, prefix string) ([]apperr.FieldError, error) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		var fields []apperr.FieldError
+		for i := 0; i < v.Len(); i++ {
+			fs, err := collect(v.Index(i), fmt.Sprintf("%s[%d].", prefix, i))
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, fs...)
+		}
+		return fields, nil
+	case reflect.Struct:
+		err := validate.Struct(v.Interface())
+		var verrs validator.ValidationErrors
+		if err == nil {
+			return nil, nil
+		}
+		if !errors.As(err, &verrs) {
+			return nil, err
+		}
+		fields := make([]apperr.FieldError, 0, len(verrs))
+		for _, fe := range verrs {
+			fields = append(fields, apperr.FieldError{Field: prefix + fieldPath(fe), Message: message(fe)})
+		}
+		return fields, nil
+	}
+	return nil, nil
+}
+
+// fieldPath membuang nama struct root dari namespace, mis. "Order.freight" -> "freight".
+func fieldPath(fe validator.FieldError) string {
+	if _, rest, ok := strings.Cut(fe.Namespace(), "."); ok {
+		return rest
+	}
+	return fe.Field()
+}
+
+func message(fe validator.FieldError) string {
+	isString := fe.Kind() == reflect.String
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "notblank":
+		return "must not be blank"
+	case "min", "gte":
+		if isString {
+			return fmt.Sprintf("must be at least %s characters", fe.Param())
+		}
+		return "must be at least " + fe.Param()
+	case "max", "lte":
+		if isString {
+			return fmt.Sprintf("must be at most %s characters", fe.Param())
+		}
+		return "must be at most " + fe.Param()
+	case "gt":
+		return "must be greater than " + fe.Param()
+	case "lt":
+		return "must be less than " + fe.Param()
+	case "len":
+		return fmt.Sprintf("must be exactly %s characters", fe.Param())
+	case "oneof":
+		return "must be one of: " + strings.ReplaceAll(fe.Param(), " ", ", ")
+	case "alphanum":
+		return "must contain only letters and digits"
+	case "url":
+		return "must be a valid URL"
+	case "isodate":
+		return "must be an ISO 8601 date (YYYY-MM-DD)"
+	case "country":
+		return "must be an ISO 3166-1 country code or a known country name"
+	}
+	return "failed " + fe.Tag() + " validation"
+}
+
+// dateLayouts adalah format tanggal yang diterima; data Northwind lama
+// menyimpan tanggal dengan atau tanpa komponen waktu.
+var dateLayouts = []string{
+	"2006-01-02",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04:05.000",
+	time.RFC3339,
+}
+
+// ParseDate mem-parse tanggal ISO 8601 dalam salah satu dateLayouts.
+func ParseDate(s string) (time.Time, error) {
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", s)
+}
@@ -0,0 +1,56 @@
+package validation_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"northwind-api/internal/apperr"
+	"northwind-api/internal/models"
+	"northwind-api/internal/validation"
+)
+
+func strPtr(s string) *string { return &s }
+
+func TestStruct(t *testing.T) {
+	tests := []struct {
+		name   string
+		obj    any
+		fields []string
+	}{
+		{"valid customer", &models.Customer{CompanyName: "Alfreds", Country: "Germany"}, nil},
+		{"blank company", models.Customer{CompanyName: "   "}, []string{"company_name"}},
+		{"too long city", models.Customer{CompanyName: "A", City: "Llanfairpwllgwyngyll"}, []string{"city"}},
+		{"iso3 country", models.Customer{CompanyName: "A", Country: "DEU"}, nil},
+		{"unknown country", models.Customer{CompanyName: "A", Country: "Narnia"}, []string{"country"}},
+		{"bad customer id", models.Order{CustomerID: strPtr(strings.Repeat("A", 37))}, []string{"customer_id"}},
+		{"datetime accepted", models.Order{OrderDate: strPtr("1996-07-04 00:00:00.000")}, nil},
+		{"bad date", models.Order{OrderDate: strPtr("04/07/1996")}, []string{"order_date"}},
+		{"discount out of range", models.OrderDetail{UnitPrice: 1, Quantity: 1, Discount: 1.5}, []string{"discount"}},
+		{"slice indexes", []models.Shipper{{CompanyName: "Ok"}, {}}, []string{"[1].company_name"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validation.Struct(tc.obj)
+			if len(tc.fields) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			e, ok := apperr.As(err)
+			if !ok || !errors.Is(err, apperr.ErrValidation) {
+				t.Fatalf("err = %v, want validation error", err)
+			}
+			got := e.FieldErrors()
+			if len(got) != len(tc.fields) {
+				t.Fatalf("fields = %+v, want %v", got, tc.fields)
+			}
+			for i, f := range tc.fields {
+				if got[i].Field != f {
+					t.Errorf("field[%d] = %q, want %q", i, got[i].Field, f)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package visibility
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"northwind-api/internal/models"
+)
+
+var (
+	marshalerType = reflect.TypeFor[json.Marshaler]()
+	auditType     = reflect.TypeFor[models.AuditEntry]()
+	eventType     = reflect.TypeFor[models.ChangeEvent]()
+	deliveryType  = reflect.TypeFor[models.WebhookDelivery]()
+)
+
+// Marshal seperti json.Marshal, tapi field yang tidak boleh dilihat ctx
+// menjadi null atau dibuang, di mana pun letaknya: resource itu sendiri,
+// relasi yang di-embed, export, audit log, event dan payload webhook.
+// Urutan field lainnya tidak berubah.
+func Marshal(ctx context.Context, v any) ([]byte, error) {
+	raw, err := json.Marshal(v)
+	if err != nil || !restricted(ctx) {
+		return raw, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	tree, err := decode(dec)
+	if err != nil {
+		return nil, err
+	}
+	apply(ctx, reflect.ValueOf(v), tree)
+	return json.Marshal(tree)
+}
+
+// restricted: ada resource yang field-nya tersembunyi bagi ctx.
+func restricted(ctx context.Context) bool {
+	for _, t := range resources {
+		if len(Hidden(ctx, t)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// apply berjalan di v dan JSON-nya (node) bersamaan, lalu menerapkan
+// kebijakan setiap struct ke object JSON-nya.
+func apply(ctx context.Context, v reflect.Value, node any) {
+	if !v.IsValid() || node == nil {
+		return
+	}
+	if k := v.Kind(); k == reflect.Pointer || k == reflect.Interface {
+		if !v.IsNil() {
+			apply(ctx, v.Elem(), node)
+		}
+		return
+	}
+	t := v.Type()
+	switch t {
+	case auditType:
+		// Diff audit: changes.<field> = {before, after}.
+		if changes, ok := member(node, "changes").(*object); ok {
+			for _, r := range Hidden(ctx, Resource(v.FieldByName("Entity").String())) {
+				if r.Omit {
+					changes.remove(r.Field)
+				} else if c, ok := changes.get(r.Field).(*object); ok {
+					c.null("before")
+					c.null("after")
+				}
+			}
+		}
+		return
+	case eventType:
+		hideSnapshot(ctx, v.FieldByName("Entity").String(), node)
+		return
+	case deliveryType:
+		payload := member(node, "payload")
+		if entity, ok := member(payload, "entity").(string); ok {
+			hideSnapshot(ctx, entity, payload)
+		}
+		return
+	}
+	if t.Implements(marshalerType) || reflect.PointerTo(t).Implements(marshalerType) {
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		obj, ok := node.(*object)
+		if !ok {
+			return
+		}
+		for i := range t.NumField() {
+			f := t.Field(i)
+			name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			switch {
+			case name == "-" || !f.IsExported() && !f.Anonymous:
+			case f.Anonymous && name == "":
+				// Field embedded tanpa nama JSON digabung ke object induknya.
+				apply(ctx, v.Field(i), obj)
+			case name == "":
+				apply(ctx, v.Field(i), obj.get(f.Name))
+			default:
+				apply(ctx, v.Field(i), obj.get(name))
+			}
+		}
+		hide(Hidden(ctx, t), obj)
+	case reflect.Slice, reflect.Array:
+		arr, ok := node.([]any)
+		if !ok || t.Elem().Kind() == reflect.Uint8 {
+			return
+		}
+		for i := range min(v.Len(), len(arr)) {
+			apply(ctx, v.Index(i), arr[i])
+		}
+	case reflect.Map:
+		obj, ok := node.(*object)
+		if !ok {
+			return
+		}
+		iter := v.MapRange()
+		for iter.Next() {
+			apply(ctx, iter.Value(), obj.get(fmt.Sprint(iter.Key().Interface())))
+		}
+	}
+}
+
+// hideSnapshot menerapkan kebijakan resource entity ke before/after event.
+func hideSnapshot(ctx context.Context, entity string, node any) {
+	hidden := Hidden(ctx, Resource(entity))
+	for _, key := range []string{"before", "after"} {
+		if obj, ok := member(node, key).(*object); ok {
+			hide(hidden, obj)
+		}
+	}
+}
+
+func hide(rules []Rule, obj *object) {
+	for _, r := range rules {
+		if r.Omit {
+			obj.remove(r.Field)
+		} else {
+			obj.null(r.Field)
+		}
+	}
+}
+
+func member(node any, key string) any {
+	if obj, ok := node.(*object); ok {
+		return obj.get(key)
+	}
+	return nil
+}
+
+// object adalah object JSON yang mempertahankan urutan field.
+type object struct {
+	keys   []string
+	values []any
+}
+
+func (o *object) get(key string) any {
+	for i, k := range o.keys {
+		if k == key {
+			return o.values[i]
+		}
+	}
+	return nil
+}
+
+// null mengganti nilai key yang ada dengan null.
+func (o *object) null(key string) {
+	for i, k := range o.keys {
+		if k == key {
+			o.values[i] = nil
+		}
+	}
+}
+
+func (o *object) remove(key string) {
+	for i, k := range o.keys {
+		if k == key {
+			o.keys = append(o.keys[:i], o.keys[i+1:]...)
+			o.values = append(o.values[:i], o.values[i+1:]...)
+			return
+		}
+	}
+}
+
+func (o *object) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, k := range o.keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, _ := json.Marshal(k)
+		value, err := json.Marshal(o.values[i])
+		if err != nil {
+			return nil, err
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// decode membaca satu nilai JSON; object menjadi *object, array []any,
+// angka json.Number.
+func decode(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		obj := &object{}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decode(dec)
+			if err != nil {
+				return nil, err
+			}
+			obj.keys = append(obj.keys, key.(string))
+			obj.values = append(obj.values, value)
+		}
+		_, err := dec.Token()
+		return obj, err
+	case json.Delim('['):
+		arr := []any{}
+		for dec.More() {
+			value, err := decode(dec)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, value)
+		}
+		_, err := dec.Token()
+		return arr, err
+	}
+	return tok, nil
+}
@@ -0,0 +1,133 @@
+// Package visibility menerapkan kebijakan field per role: field model yang
+// diberi tag `visible:"<role>"` hanya terlihat oleh caller dengan role
+// tersebut (atau admin). Untuk caller lain nilainya null, atau dibuang dari
+// response kalau tag-nya `visible:"<role>,omit"`.
+//
+// Semua output (REST, GraphQL, OData, gRPC) membaca kebijakan dari sini,
+// jadi menambah field sensitif cukup dengan menambah tag di model.
+package visibility
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"northwind-api/internal/models"
+	"northwind-api/internal/requestctx"
+)
+
+const (
+	// RoleAdmin melihat semua field.
+	RoleAdmin = "admin"
+	// RoleHR melihat data pribadi employee.
+	RoleHR = "hr"
+	// RoleSales melihat data kontak customer.
+	RoleSales = "sales"
+)
+
+// Rule adalah kebijakan satu field model.
+type Rule struct {
+	// Field adalah nama field JSON, Column nama kolom database (tag db).
+	Field, Column string
+	// Role yang boleh melihat field ini.
+	Role string
+	// Omit membuang field dari response alih-alih mengirim null.
+	Omit bool
+
+	index []int
+}
+
+// resources memetakan nama resource (seperti di audit log dan event) ke
+// model yang punya kebijakan, untuk snapshot yang tidak bertipe.
+var resources = map[string]reflect.Type{
+	"customers": reflect.TypeFor[models.Customer](),
+	"employees": reflect.TypeFor[models.Employee](),
+}
+
+var cache sync.Map // reflect.Type -> []Rule
+
+// Rules mengembalikan kebijakan field struct t (kosong kalau tidak ada).
+func Rules(t reflect.Type) []Rule {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+	if rules, ok := cache.Load(t); ok {
+		return rules.([]Rule)
+	}
+	var rules []Rule
+	for _, f := range reflect.VisibleFields(t) {
+		tag, ok := f.Tag.Lookup("visible")
+		if !ok || !f.IsExported() {
+			continue
+		}
+		role, opt, _ := strings.Cut(tag, ",")
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "" {
+			name = f.Name
+		}
+		col, _, _ := strings.Cut(f.Tag.Get("db"), ",")
+		rules = append(rules, Rule{Field: name, Column: col, Role: role, Omit: opt == "omit", index: f.Index})
+	}
+	cache.Store(t, rules)
+	return rules
+}
+
+// Allowed melaporkan apakah ctx boleh melihat field dengan role tersebut.
+// Context tanpa identitas (auth mati di non-production, job internal) tidak
+// dibatasi.
+func Allowed(ctx context.Context, role string) bool {
+	roles, ok := requestctx.Roles(ctx)
+	return !ok || slices.Contains(roles, role) || slices.Contains(roles, RoleAdmin)
+}
+
+// Hidden mengembalikan kebijakan field t yang tidak boleh dilihat ctx.
+func Hidden(ctx context.Context, t reflect.Type) []Rule {
+	var hidden []Rule
+	for _, r := range Rules(t) {
+		if !Allowed(ctx, r.Role) {
+			hidden = append(hidden, r)
+		}
+	}
+	return hidden
+}
+
+// Resource mengembalikan model untuk nama resource, atau nil kalau resource
+// tersebut tidak punya kebijakan.
+func Resource(name string) reflect.Type {
+	return resources[name]
+}
+
+// Visible melaporkan apakah ctx boleh melihat field JSON field di model T.
+func Visible[T any](ctx context.Context, field string) bool {
+	for _, r := range Hidden(ctx, reflect.TypeFor[T]()) {
+		if r.Field == field {
+			return false
+		}
+	}
+	return true
+}
+
+// Redact mengosongkan field v yang tidak boleh dilihat ctx. Dipakai untuk
+// output yang tidak mengenal null, mis. protobuf.
+func Redact[T any](ctx context.Context, v *T) {
+	rv := reflect.ValueOf(v).Elem()
+	for _, r := range Hidden(ctx, rv.Type()) {
+		f := rv.FieldByIndex(r.index)
+		f.Set(reflect.Zero(f.Type()))
+	}
+}
+
+// Keep menyalin field yang tidak boleh dilihat ctx dari before ke v. Dipakai
+// saat update: caller yang menerima null untuk field tersebut tidak boleh
+// menghapus nilainya dengan mengirim balik resource yang sama.
+func Keep[T any](ctx context.Context, v *T, before T) {
+	rv, bv := reflect.ValueOf(v).Elem(), reflect.ValueOf(before)
+	for _, r := range Hidden(ctx, rv.Type()) {
+		rv.FieldByIndex(r.index).Set(bv.FieldByIndex(r.index))
+	}
+}
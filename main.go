@@ -7,6 +7,7 @@ package main
 
 import (
 	"context"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -16,9 +17,13 @@ import (
 	_ "northwind-api/docs"
 
 	"northwind-api/internal/config"
+	"northwind-api/internal/grpcserver"
 	"northwind-api/internal/logging"
+	"northwind-api/internal/migrations"
+	"northwind-api/internal/repositories"
 	"northwind-api/internal/routes"
 	"northwind-api/internal/server"
+	"northwind-api/internal/services"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
@@ -40,6 +45,10 @@ func main() {
 		}
 	}()
 
+	if err := migrations.Apply(context.Background(), db); err != nil {
+		log.Fatal().Err(err).Msg("failed to apply migrations")
+	}
+
 	logFile, err := logging.InitLogger("app.log")
 	if err != nil {
 		log.Fatal().Err(err).Msg("failed to init logger")
@@ -59,12 +68,45 @@ func main() {
 	// Healthcheck
 	engine.GET("/healthz", func(c *gin.Context) { c.String(http.StatusOK, "ok") })
 
+	// Dibatalkan saat SIGINT/SIGTERM; menghentikan worker background
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// Repositories & services dipakai bersama oleh HTTP dan gRPC
+	repos := repositories.NewSQLRepositories(db)
+	tx := &repositories.SQLTxRunner{DB: db}
+	svc := services.New(repos, tx, nil)
+	if svc.Customers.IDStrategy, err = services.ParseCustomerIDStrategy(cfg.CustomerIDStrategy); err != nil {
+		log.Fatal().Err(err).Msg("invalid CUSTOMER_ID_STRATEGY")
+	}
+
 	// Register versioned routes (+ swagger non-prod, + auth gate inside)
 	routes.Register(engine, routes.Deps{
-		DB:     db,
-		Config: cfg,
+		DB:         db,
+		Config:     cfg,
+		Repos:      &repos,
+		Tx:         tx,
+		Services:   svc,
+		Background: ctx,
 	})
 
+	// Worker webhook mengirim isi outbox sampai ctx dibatalkan
+	if svc.Webhooks.Enabled() {
+		svc.Webhooks.Start(ctx, services.WebhookOptions{})
+	}
+
+	// gRPC server di port terpisah; auth sama dengan HTTP (JWT hanya di production)
+	grpcSrv := grpcserver.New(grpcserver.Deps{
+		Services:        svc,
+		Reports:         repos.Reports,
+		RequireAuth:     cfg.Env() == "production",
+		IfMatchRequired: cfg.IfMatchRequired(),
+	})
+	grpcLis, err := net.Listen("tcp", ":"+cfg.GRPCPort)
+	if err != nil {
+		log.Fatal().Err(err).Msg("grpc listen")
+	}
+
 	// 4) HTTP server
 	addr := ":" + cfg.Port
 	srv := &http.Server{
@@ -84,9 +126,14 @@ func main() {
 		}
 	}()
 
+	log.Info().Msgf("gRPC server running at %s", grpcLis.Addr())
+	go func() {
+		if err := grpcSrv.Serve(grpcLis); err != nil {
+			log.Fatal().Err(err).Msg("grpc serve")
+		}
+	}()
+
 	// Wait for interrupt
-	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
-	defer stop()
 	<-ctx.Done()
 
 	log.Info().Msg("Shutting down server...")
@@ -98,5 +145,10 @@ func main() {
 		log.Error().Err(err).Msg("graceful shutdown failed; forcing close")
 		_ = srv.Close()
 	}
+	grpcSrv.Shutdown(shutdownCtx)
+	// Job import dan worker webhook sudah dibatalkan lewat ctx; tunggu
+	// statusnya tersimpan sebelum database ditutup.
+	svc.Imports.Wait()
+	svc.Webhooks.Wait()
 	log.Info().Msg("Server exited")
 }